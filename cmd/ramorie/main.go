@@ -74,11 +74,18 @@ func main() {
 			help.SetTier(commands.NewActivityCommand(), "common"),
 			help.SetTier(commands.NewSubtaskCommand(), "common"),
 			help.SetTier(commands.NewContextCommand(), "common"),
+			help.SetTier(commands.NewImportCommand(), "common"),
+			help.SetTier(commands.NewExportCommand(), "common"),
+			help.SetTier(commands.NewUndoCommand(), "common"),
+			help.SetTier(commands.NewHistoryCommand(), "common"),
+			help.SetTier(commands.NewReviewCommand(), "common"),
+			help.SetTier(commands.NewSearchesCommand(), "common"),
 
 			// 🟢 ADMIN — setup.
 			help.SetTier(commands.NewSetupCommand(), "admin"),
 			help.SetTier(commands.NewSetupHooksCommand(), "admin"),
 			help.SetTier(commands.NewDoctorCommand(), "admin"),
+			help.SetTier(commands.NewIndexCommand(), "admin"),
 			help.SetTier(commands.NewUnlockCommand(), "admin"),
 			help.SetTier(commands.NewLockCommand(), "admin"),
 			help.SetTier(commands.NewConfigCommand(), "admin"),
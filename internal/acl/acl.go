@@ -0,0 +1,253 @@
+// Package acl manages who can see and edit a memory.
+//
+// A memory carries a visibility (private, project or organization) plus
+// explicit reader and writer lists of user IDs. `ramorie memory share` and
+// the MCP memory tool's share action both go through Apply and Check here,
+// so the CLI and agents refuse the same unsafe changes: a memory encrypted
+// with someone's personal key can never be shared (nobody else can decrypt
+// it), and one encrypted with an organization key only inside that
+// organization.
+package acl
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/kutbudev/ramorie-cli/internal/api"
+	"github.com/kutbudev/ramorie-cli/internal/models"
+)
+
+// Memory visibilities.
+const (
+	Private      = "private"
+	Project      = "project"
+	Organization = "organization"
+)
+
+// Visibilities lists the visibilities that can be set.
+var Visibilities = []string{Private, Project, Organization}
+
+// Encryption scopes reported in State.Encryption.
+const (
+	EncryptionNone         = "none"
+	EncryptionPersonal     = "personal"
+	EncryptionOrganization = "organization"
+)
+
+// State is a memory's sharing state.
+type State struct {
+	MemoryID   string   `json:"memory_id"`
+	Visibility string   `json:"visibility"` // "" = the backend default
+	Readers    []string `json:"readers"`
+	Writers    []string `json:"writers"`
+	Encryption string   `json:"encryption"` // none, personal or organization
+	OrgID      string   `json:"organization_id,omitempty"`
+}
+
+// Change is a requested share. Readers, Writers and Revoke hold user IDs.
+type Change struct {
+	Visibility string
+	Readers    []string
+	Writers    []string
+	Revoke     []string
+}
+
+// Empty reports whether the change asks for nothing.
+func (c Change) Empty() bool {
+	return c.Visibility == "" && len(c.Readers) == 0 && len(c.Writers) == 0 && len(c.Revoke) == 0
+}
+
+// widens reports whether the change could expose the memory to more people.
+func (c Change) widens() bool {
+	return len(c.Readers) > 0 || len(c.Writers) > 0 || c.Visibility == Project || c.Visibility == Organization
+}
+
+// ParseVisibility normalizes a visibility; "" stays "".
+func ParseVisibility(s string) (string, error) {
+	v := strings.ToLower(strings.TrimSpace(s))
+	if v == "" || contains(Visibilities, v) {
+		return v, nil
+	}
+	return "", fmt.Errorf("invalid visibility %q (use %s)", s, strings.Join(Visibilities, ", "))
+}
+
+// Of reads a memory's sharing state.
+func Of(m *models.Memory) State {
+	s := State{
+		MemoryID:   m.ID.String(),
+		Visibility: m.Visibility,
+		Readers:    append([]string{}, m.Readers...),
+		Writers:    append([]string{}, m.Writers...),
+		Encryption: EncryptionNone,
+	}
+	if m.IsEncrypted {
+		s.Encryption = EncryptionPersonal
+		if m.EncryptionScope == "organization" {
+			s.Encryption = EncryptionOrganization
+			s.OrgID = m.EncryptionOrgID
+		}
+	}
+	return s
+}
+
+// Check refuses changes that would expose a memory to people who cannot
+// read it or that do not fit its project. projectOrg is the organization of
+// the memory's project ("" for a personal project). Narrowing access is
+// always allowed.
+func Check(m *models.Memory, projectOrg string, c Change) error {
+	if c.Visibility == Organization && projectOrg == "" {
+		return fmt.Errorf("the memory's project belongs to no organization; use project or private")
+	}
+	if !m.IsEncrypted || !c.widens() {
+		return nil
+	}
+	id := shortID(m.ID.String())
+	if m.EncryptionScope != "organization" {
+		return fmt.Errorf("memory %s is encrypted with a personal key, so nobody else can decrypt it — "+
+			"sharing it is refused. Re-create it in an organization project (encrypted with the organization key) to share it", id)
+	}
+	if projectOrg != "" && !strings.EqualFold(m.EncryptionOrgID, projectOrg) {
+		return fmt.Errorf("memory %s is encrypted with another organization's key than its project's — sharing it is refused", id)
+	}
+	return nil
+}
+
+// Apply computes the sharing state after a change. Revoking removes a user
+// from both lists; granting write does not imply read.
+func Apply(s State, c Change) State {
+	next := s
+	if c.Visibility != "" {
+		next.Visibility = c.Visibility
+	}
+	next.Readers = without(union(s.Readers, c.Readers), c.Revoke)
+	next.Writers = without(union(s.Writers, c.Writers), c.Revoke)
+	return next
+}
+
+// Updates is the memory update payload for the fields that differ between
+// two states.
+func Updates(before, after State) map[string]interface{} {
+	updates := map[string]interface{}{}
+	if after.Visibility != before.Visibility {
+		updates["visibility"] = after.Visibility
+	}
+	if strings.Join(after.Readers, ",") != strings.Join(before.Readers, ",") {
+		updates["readers"] = after.Readers
+	}
+	if strings.Join(after.Writers, ",") != strings.Join(before.Writers, ",") {
+		updates["writers"] = after.Writers
+	}
+	return updates
+}
+
+// Fields lists the keys of an Updates payload in a stable order, for the
+// undo journal.
+func Fields(updates map[string]interface{}) []string {
+	var fields []string
+	for _, f := range []string{"visibility", "readers", "writers"} {
+		if _, ok := updates[f]; ok {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// ResolveUsers turns emails and user IDs into user IDs. Emails are looked
+// up among the organization's members. When orgID is set, user IDs must
+// belong to members too: access is only ever granted inside the
+// organization that owns the project, so an empty member list for a set
+// orgID (it could not be loaded) refuses every user.
+func ResolveUsers(refs []string, orgID string, members []api.OrganizationMember) ([]string, error) {
+	var out []string
+	for _, ref := range refs {
+		ref = strings.TrimSpace(ref)
+		if ref == "" {
+			continue
+		}
+		if orgID != "" && len(members) == 0 {
+			return nil, fmt.Errorf("cannot check %s: the members of the project's organization could not be loaded", ref)
+		}
+		if strings.Contains(ref, "@") {
+			if len(members) == 0 {
+				return nil, fmt.Errorf("cannot look up %s: the memory's project has no organization to search — pass the user ID instead", ref)
+			}
+			found := ""
+			for _, mb := range members {
+				if strings.EqualFold(mb.Email, ref) {
+					found = mb.UserID
+					break
+				}
+			}
+			if found == "" {
+				return nil, fmt.Errorf("%s is not a member of the project's organization", ref)
+			}
+			out = append(out, found)
+			continue
+		}
+		if _, err := uuid.Parse(ref); err != nil || len(ref) != 36 {
+			return nil, fmt.Errorf("%q is neither an email nor a user ID", ref)
+		}
+		if orgID != "" && !isMember(members, ref) {
+			return nil, fmt.Errorf("user %s is not a member of the project's organization", shortID(ref))
+		}
+		out = append(out, ref)
+	}
+	return out, nil
+}
+
+// UserName names a user by email where the member list knows them, else by
+// short ID.
+func UserName(id string, members []api.OrganizationMember) string {
+	for _, mb := range members {
+		if strings.EqualFold(mb.UserID, id) && mb.Email != "" {
+			return mb.Email
+		}
+	}
+	return shortID(id)
+}
+
+func isMember(members []api.OrganizationMember, userID string) bool {
+	for _, mb := range members {
+		if strings.EqualFold(mb.UserID, userID) {
+			return true
+		}
+	}
+	return false
+}
+
+func union(list, add []string) []string {
+	out := append([]string{}, list...)
+	for _, id := range add {
+		if !contains(out, id) {
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+func without(list, remove []string) []string {
+	out := []string{}
+	for _, id := range list {
+		if !contains(remove, id) {
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func shortID(id string) string {
+	if len(id) > 8 {
+		return id[:8]
+	}
+	return id
+}
@@ -0,0 +1,103 @@
+package acl
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kutbudev/ramorie-cli/internal/api"
+	"github.com/kutbudev/ramorie-cli/internal/models"
+)
+
+const (
+	alice = "11111111-1111-1111-1111-111111111111"
+	bob   = "22222222-2222-2222-2222-222222222222"
+	org   = "33333333-3333-3333-3333-333333333333"
+)
+
+var members = []api.OrganizationMember{
+	{UserID: alice, Email: "alice@x.com"},
+	{UserID: bob, Email: "bob@x.com"},
+}
+
+func TestParseVisibility(t *testing.T) {
+	if v, err := ParseVisibility(" Organization "); err != nil || v != Organization {
+		t.Errorf("ParseVisibility = %q, %v", v, err)
+	}
+	if v, err := ParseVisibility(""); err != nil || v != "" {
+		t.Errorf("empty visibility = %q, %v", v, err)
+	}
+	if _, err := ParseVisibility("public"); err == nil {
+		t.Error("public should be refused")
+	}
+}
+
+func TestResolveUsers(t *testing.T) {
+	got, err := ResolveUsers([]string{"ALICE@x.com", bob}, org, members)
+	if err != nil || !reflect.DeepEqual(got, []string{alice, bob}) {
+		t.Fatalf("ResolveUsers = %v, %v", got, err)
+	}
+	if _, err := ResolveUsers([]string{"carol@x.com"}, org, members); err == nil {
+		t.Error("unknown email should fail")
+	}
+	if _, err := ResolveUsers([]string{"44444444-4444-4444-4444-444444444444"}, org, members); err == nil {
+		t.Error("user outside the organization should fail")
+	}
+	if _, err := ResolveUsers([]string{"alice@x.com"}, "", nil); err == nil {
+		t.Error("email without an organization should fail")
+	}
+	if _, err := ResolveUsers([]string{"alice"}, org, members); err == nil {
+		t.Error("neither email nor ID should fail")
+	}
+	if _, err := ResolveUsers([]string{bob}, org, nil); err == nil {
+		t.Error("an organization whose members could not be loaded should refuse access")
+	}
+	if got, err := ResolveUsers([]string{bob}, "", nil); err != nil || !reflect.DeepEqual(got, []string{bob}) {
+		t.Errorf("ID without an organization = %v, %v", got, err)
+	}
+}
+
+func TestApplyAndUpdates(t *testing.T) {
+	before := State{Visibility: Private, Readers: []string{alice}, Writers: []string{alice}}
+	after := Apply(before, Change{Visibility: Project, Readers: []string{bob, alice}, Revoke: []string{alice}})
+	if after.Visibility != Project || !reflect.DeepEqual(after.Readers, []string{bob}) || len(after.Writers) != 0 {
+		t.Fatalf("Apply = %+v", after)
+	}
+	u := Updates(before, after)
+	if u["visibility"] != Project || !reflect.DeepEqual(u["readers"], []string{bob}) || u["writers"] == nil {
+		t.Errorf("Updates = %v", u)
+	}
+	if f := Fields(u); !reflect.DeepEqual(f, []string{"visibility", "readers", "writers"}) {
+		t.Errorf("Fields = %v", f)
+	}
+	if u := Updates(before, Apply(before, Change{Readers: []string{alice}})); len(u) != 0 {
+		t.Errorf("no-op change produced updates %v", u)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	plain := &models.Memory{ID: uuid.New()}
+	if err := Check(plain, "", Change{Visibility: Organization}); err == nil {
+		t.Error("organization visibility without an organization should fail")
+	}
+	if err := Check(plain, org, Change{Readers: []string{bob}}); err != nil {
+		t.Errorf("plain memory: %v", err)
+	}
+
+	personal := &models.Memory{ID: uuid.New(), IsEncrypted: true}
+	if err := Check(personal, org, Change{Readers: []string{bob}}); err == nil || !strings.Contains(err.Error(), "personal key") {
+		t.Errorf("personal-key memory share = %v", err)
+	}
+	if err := Check(personal, org, Change{Visibility: Private, Revoke: []string{bob}}); err != nil {
+		t.Errorf("narrowing must be allowed: %v", err)
+	}
+
+	orgEnc := &models.Memory{ID: uuid.New(), IsEncrypted: true, EncryptionScope: "organization", EncryptionOrgID: org}
+	if err := Check(orgEnc, org, Change{Visibility: Organization}); err != nil {
+		t.Errorf("org-key memory in its organization: %v", err)
+	}
+	if err := Check(orgEnc, "55555555-5555-5555-5555-555555555555", Change{Visibility: Project}); err == nil {
+		t.Error("org-key memory in another organization's project should fail")
+	}
+}
@@ -0,0 +1,56 @@
+// Package activetask starts, stops and completes tasks while keeping this
+// machine's active task (config.ActiveTaskID) current.
+//
+// The server tracks the active task too but offers no way to read it back,
+// so the CLI, git hooks, MCP server and TUI all go through these helpers:
+// switching branches then stops whatever was started last, no matter where
+// it was started from. The remembered ID is always the task's full UUID.
+package activetask
+
+import (
+	"github.com/google/uuid"
+	"github.com/kutbudev/ramorie-cli/internal/api"
+	"github.com/kutbudev/ramorie-cli/internal/config"
+)
+
+// Start starts a task and remembers it as the active task, which new
+// memories link to. taskID may be a short prefix; it is resolved to the
+// full UUID before it is remembered.
+func Start(client *api.Client, taskID string) error {
+	if err := client.StartTask(taskID); err != nil {
+		return err
+	}
+	config.RememberActiveTask(fullID(client, taskID))
+	return nil
+}
+
+// Stop stops a task and forgets it if it was the active task.
+func Stop(client *api.Client, taskID string) error {
+	if err := client.StopTask(taskID); err != nil {
+		return err
+	}
+	config.ForgetActiveTask(taskID)
+	return nil
+}
+
+// Complete completes a task and forgets it if it was the active task.
+func Complete(client *api.Client, taskID string) error {
+	if err := client.CompleteTask(taskID); err != nil {
+		return err
+	}
+	config.ForgetActiveTask(taskID)
+	return nil
+}
+
+// fullID returns taskID's full UUID, looking short IDs up on the server.
+// It falls back to taskID when the lookup fails.
+func fullID(client *api.Client, taskID string) string {
+	if _, err := uuid.Parse(taskID); err == nil {
+		return taskID
+	}
+	t, err := client.GetTask(taskID)
+	if err != nil {
+		return taskID
+	}
+	return t.ID.String()
+}
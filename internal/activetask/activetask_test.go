@@ -0,0 +1,44 @@
+package activetask
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kutbudev/ramorie-cli/internal/api"
+	"github.com/kutbudev/ramorie-cli/internal/config"
+)
+
+func TestStartRemembersFullUUID(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	id := uuid.New()
+	short := id.String()[:8]
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/tasks/" + short + "/start", "/tasks/" + short + "/stop":
+			_, _ = w.Write([]byte(`{}`))
+		case "/tasks/" + short:
+			_, _ = w.Write([]byte(`{"id":"` + id.String() + `","title":"t","status":"IN_PROGRESS"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+	client := &api.Client{BaseURL: ts.URL, APIKey: "k", HTTPClient: ts.Client()}
+
+	if err := Start(client, short); err != nil {
+		t.Fatal(err)
+	}
+	if got := config.LoadActiveTask(); got != id.String() {
+		t.Fatalf("active task = %q, want full UUID %q", got, id)
+	}
+	if err := Stop(client, short); err != nil {
+		t.Fatal(err)
+	}
+	if got := config.LoadActiveTask(); got != "" {
+		t.Fatalf("active task after stop = %q, want empty", got)
+	}
+}
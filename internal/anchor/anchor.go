@@ -0,0 +1,703 @@
+// Package anchor links memories to regions of code and detects when that
+// code changes.
+//
+// `remember --anchor internal/api/client.go:120-160` (or the MCP remember
+// tool's anchors) records the file's path relative to its git repository,
+// the repository's origin remote, the line range, the git blob hash of the
+// file at that moment and the symbol the range sits in. The blob is written
+// to the local object database so it can be diffed later even when the file
+// was never committed.
+//
+// Check diffs the stored blob against the file on disk. Hunks above the
+// range shift it (the anchor moved); hunks that touch it mean the code the
+// memory describes changed, and find results and hook injections mark the
+// memory possibly stale. A hash of the anchored lines is kept as a fallback
+// for when the stored blob is not in the object database: it was
+// garbage-collected, or the anchor was made on another machine.
+//
+// Anchors are saved on the memory itself (its "anchors" field), so they
+// survive a lost home directory and `memory anchors --sync` can index them
+// elsewhere. The repository root recorded with them is a path on the machine
+// that made them; where it does not exist, the checkout the command runs in
+// is used when its origin remote matches. Encrypted memories keep their
+// anchors only on this machine, since the field is not encrypted. A copy in
+// ~/.ramorie/anchors.json (mode 0600), keyed by memory ID, indexes them for
+// Drift and ForFile; Import rebuilds it from the memories.
+package anchor
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kutbudev/ramorie-cli/internal/models"
+	"github.com/kutbudev/ramorie-cli/internal/statefile"
+)
+
+// States of an anchor.
+const (
+	Fresh   = "fresh"   // the anchored lines are unchanged and in place
+	Moved   = "moved"   // unchanged, but edits above shifted them
+	Drifted = "drifted" // the anchored lines changed
+	Missing = "missing" // the file is gone
+	Unknown = "unknown" // the repository is not on this machine, or git failed
+)
+
+// Anchor is a memory's link to a region of code.
+type Anchor struct {
+	Repo    string    `json:"repo"`             // absolute repository root on the machine that made it
+	Remote  string    `json:"remote,omitempty"` // normalized origin remote, to find the repository elsewhere
+	Path    string    `json:"path"`             // slash-separated, relative to Repo
+	Start   int       `json:"start,omitempty"`  // first line, 1-based; 0 anchors the whole file
+	End     int       `json:"end,omitempty"`    // last line, inclusive
+	Blob    string    `json:"blob"`             // git blob hash of the file when anchored
+	Region  string    `json:"region_hash"`      // sha256 of the anchored lines
+	Symbol  string    `json:"symbol,omitempty"`
+	Created time.Time `json:"created"`
+}
+
+// Lines renders the line range: "120-160", "120", or "" for a whole file.
+func (a Anchor) Lines() string {
+	return lineRange(a.Start, a.End)
+}
+
+// String renders the anchor as it is written on the command line.
+func (a Anchor) String() string {
+	s := a.Path
+	if l := a.Lines(); l != "" {
+		s += ":" + l
+	}
+	if a.Symbol != "" {
+		s += "#" + a.Symbol
+	}
+	return s
+}
+
+func lineRange(start, end int) string {
+	switch {
+	case start == 0:
+		return ""
+	case end == start:
+		return strconv.Itoa(start)
+	}
+	return fmt.Sprintf("%d-%d", start, end)
+}
+
+var specRe = regexp.MustCompile(`^(.+?):(\d+)(?:-(\d+))?$`)
+
+// ParseSpec splits "path[:start[-end]][#Symbol]". A bare start line anchors
+// that one line; no range anchors the whole file.
+func ParseSpec(spec string) (path string, start, end int, symbol string, err error) {
+	spec = strings.TrimSpace(spec)
+	if i := strings.LastIndex(spec, "#"); i >= 0 {
+		spec, symbol = spec[:i], strings.TrimSpace(spec[i+1:])
+	}
+	path = spec
+	if m := specRe.FindStringSubmatch(spec); m != nil {
+		path = m[1]
+		start, _ = strconv.Atoi(m[2])
+		end = start
+		if m[3] != "" {
+			end, _ = strconv.Atoi(m[3])
+		}
+		if start < 1 || end < start {
+			return "", 0, 0, "", fmt.Errorf("invalid line range in anchor %q", spec)
+		}
+	}
+	if path == "" {
+		return "", 0, 0, "", fmt.Errorf("anchor %q has no file path", spec)
+	}
+	return path, start, end, symbol, nil
+}
+
+// Resolve turns a spec into an anchor. Relative paths are taken from dir.
+// The file must be inside a git repository and the range inside the file.
+func Resolve(spec, dir string) (Anchor, error) {
+	path, start, end, symbol, err := ParseSpec(spec)
+	if err != nil {
+		return Anchor{}, err
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	abs, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return Anchor{}, fmt.Errorf("anchor %s: %w", spec, err)
+	}
+	if info, err := os.Stat(abs); err != nil {
+		return Anchor{}, fmt.Errorf("anchor %s: %w", spec, err)
+	} else if info.IsDir() {
+		return Anchor{}, fmt.Errorf("anchor %s: is a directory", spec)
+	}
+	repo, err := git(filepath.Dir(abs), "rev-parse", "--show-toplevel")
+	if err != nil {
+		return Anchor{}, fmt.Errorf("anchor %s: not inside a git repository", spec)
+	}
+	if r, err := filepath.EvalSymlinks(repo); err == nil {
+		repo = r
+	}
+	rel, err := filepath.Rel(repo, abs)
+	if err != nil {
+		return Anchor{}, fmt.Errorf("anchor %s: %w", spec, err)
+	}
+
+	data, err := os.ReadFile(abs)
+	if err != nil {
+		return Anchor{}, fmt.Errorf("anchor %s: %w", spec, err)
+	}
+	lines := splitLines(data)
+	if end > len(lines) {
+		return Anchor{}, fmt.Errorf("anchor %s: file has only %d lines", spec, len(lines))
+	}
+	// Writing the blob keeps it diffable after the file changes, committed
+	// or not.
+	blob, err := git(repo, "hash-object", "-w", "--", abs)
+	if err != nil {
+		return Anchor{}, fmt.Errorf("anchor %s: git hash-object: %w", spec, err)
+	}
+	if symbol == "" {
+		symbol = DetectSymbol(lines, start, end)
+	}
+	return Anchor{
+		Repo:    repo,
+		Remote:  originRemote(repo),
+		Path:    filepath.ToSlash(rel),
+		Start:   start,
+		End:     end,
+		Blob:    blob,
+		Region:  regionHash(lines, start, end),
+		Symbol:  symbol,
+		Created: time.Now().UTC(),
+	}, nil
+}
+
+// ResolveAll resolves several specs, failing on the first bad one.
+func ResolveAll(specs []string, dir string) ([]Anchor, error) {
+	var out []Anchor
+	for _, spec := range specs {
+		if strings.TrimSpace(spec) == "" {
+			continue
+		}
+		a, err := Resolve(spec, dir)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, a)
+	}
+	return out, nil
+}
+
+// Status is the result of checking an anchor.
+type Status struct {
+	State  string `json:"state"`
+	Start  int    `json:"start,omitempty"` // where the range is now (a best guess once drifted)
+	End    int    `json:"end,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// Stale reports whether the memory should be marked possibly stale.
+func (s Status) Stale() bool {
+	return s.State == Drifted || s.State == Missing
+}
+
+// Root returns where the anchor's repository is on this machine: the
+// recorded root if it exists, else the checkout of the working directory
+// when its origin remote is the anchor's.
+func (a Anchor) Root() (string, bool) {
+	if _, err := os.Stat(a.Repo); err == nil {
+		return a.Repo, true
+	}
+	if a.Remote == "" {
+		return "", false
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", false
+	}
+	repo, err := git(wd, "rev-parse", "--show-toplevel")
+	if err != nil || originRemote(repo) != a.Remote {
+		return "", false
+	}
+	return repo, true
+}
+
+// originRemote returns the repository's origin remote in NormalizeRemote
+// form, or "" when it has none.
+func originRemote(repo string) string {
+	url, err := git(repo, "config", "--get", "remote.origin.url")
+	if err != nil {
+		return ""
+	}
+	return NormalizeRemote(url)
+}
+
+// NormalizeRemote reduces the ssh and https forms of a remote URL to
+// "host/owner/repo", so clones made either way match.
+func NormalizeRemote(url string) string {
+	url = strings.TrimSpace(url)
+	if url == "" {
+		return ""
+	}
+	if i := strings.Index(url, "://"); i >= 0 {
+		url = url[i+3:]
+	} else if i := strings.Index(url, ":"); i >= 0 && !strings.Contains(url[:i], "/") {
+		// scp-like syntax: user@host:owner/repo
+		url = url[:i] + "/" + url[i+1:]
+	}
+	if i := strings.Index(url, "@"); i >= 0 && i < strings.Index(url+"/", "/") {
+		url = url[i+1:]
+	}
+	url = strings.TrimSuffix(strings.TrimSuffix(url, "/"), ".git")
+	return strings.ToLower(url)
+}
+
+// Check compares an anchor with the file on disk.
+func Check(a Anchor) Status {
+	root, ok := a.Root()
+	if !ok {
+		return Status{State: Unknown, Reason: "repository not found on this machine"}
+	}
+	a.Repo = root
+	abs := filepath.Join(root, filepath.FromSlash(a.Path))
+	data, err := os.ReadFile(abs)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Status{State: Missing, Reason: a.Path + " was deleted or renamed"}
+		}
+		return Status{State: Unknown, Reason: err.Error()}
+	}
+	lines := splitLines(data)
+	keep := Status{State: Fresh, Start: a.Start, End: a.End}
+
+	cur, err := git(a.Repo, "hash-object", "--", abs)
+	if err != nil {
+		return checkByContent(a, lines)
+	}
+	if cur == a.Blob {
+		return keep
+	}
+	if a.Start == 0 {
+		return Status{State: Drifted, Reason: a.Path + " changed since it was anchored"}
+	}
+
+	hunks, err := diffBlob(a.Repo, a.Blob, abs)
+	if err != nil {
+		return checkByContent(a, lines)
+	}
+	start, end, touched := Shift(hunks, a.Start, a.End)
+	if end > len(lines) {
+		end = len(lines)
+	}
+	if start > end {
+		start = end
+	}
+	switch {
+	case touched:
+		return Status{State: Drifted, Start: start, End: end,
+			Reason: fmt.Sprintf("%s:%s changed since it was anchored", a.Path, a.Lines())}
+	case start != a.Start:
+		return Status{State: Moved, Start: start, End: end,
+			Reason: "now at lines " + lineRange(start, end)}
+	}
+	return keep
+}
+
+// checkByContent looks for the anchored lines anywhere in the file. It is
+// used when git fails or the stored blob is no longer in the repository.
+func checkByContent(a Anchor, lines []string) Status {
+	if a.Start == 0 {
+		if regionHash(lines, 0, 0) == a.Region {
+			return Status{State: Fresh}
+		}
+		return Status{State: Drifted, Reason: a.Path + " changed since it was anchored"}
+	}
+	n := a.End - a.Start + 1
+	for i := 0; i+n <= len(lines); i++ {
+		if regionHash(lines, i+1, i+n) != a.Region {
+			continue
+		}
+		if i+1 == a.Start {
+			return Status{State: Fresh, Start: a.Start, End: a.End}
+		}
+		return Status{State: Moved, Start: i + 1, End: i + n, Reason: "now at lines " + lineRange(i+1, i+n)}
+	}
+	return Status{State: Drifted, Start: a.Start, End: a.End,
+		Reason: fmt.Sprintf("%s:%s changed since it was anchored", a.Path, a.Lines())}
+}
+
+// Hunk is one "@@ -a,b +c,d @@" header of a zero-context diff.
+type Hunk struct {
+	OldStart, OldLines, NewStart, NewLines int
+}
+
+var hunkRe = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+// ParseHunks reads the hunk headers of `git diff -U0` output.
+func ParseHunks(diff []byte) []Hunk {
+	var out []Hunk
+	sc := bufio.NewScanner(bytes.NewReader(diff))
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	for sc.Scan() {
+		m := hunkRe.FindStringSubmatch(sc.Text())
+		if m == nil {
+			continue
+		}
+		num := func(s string) int {
+			if s == "" {
+				return 1
+			}
+			n, _ := strconv.Atoi(s)
+			return n
+		}
+		out = append(out, Hunk{num(m[1]), num(m[2]), num(m[3]), num(m[4])})
+	}
+	return out
+}
+
+// Shift maps the old range start..end through the hunks. touched is set
+// when a hunk changes lines inside the range or inserts between them.
+func Shift(hunks []Hunk, start, end int) (newStart, newEnd int, touched bool) {
+	before, within := 0, 0
+	for _, h := range hunks {
+		delta := h.NewLines - h.OldLines
+		if h.OldLines == 0 {
+			// Pure insertion after line OldStart.
+			switch {
+			case h.OldStart < start:
+				before += delta
+			case h.OldStart < end:
+				touched = true
+				within += delta
+			}
+			continue
+		}
+		oldEnd := h.OldStart + h.OldLines - 1
+		switch {
+		case oldEnd < start:
+			before += delta
+		case h.OldStart <= end:
+			touched = true
+			within += delta
+		}
+	}
+	return start + before, end + before + within, touched
+}
+
+// diffBlob diffs a stored blob against a file on disk.
+func diffBlob(repo, blob, path string) ([]Hunk, error) {
+	old, err := gitBytes(repo, "cat-file", "blob", blob)
+	if err != nil {
+		return nil, err
+	}
+	tmp, err := os.CreateTemp("", "ramorie-anchor-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(old); err != nil {
+		tmp.Close()
+		return nil, err
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, err
+	}
+	// --no-index exits 1 when the files differ.
+	out, err := gitBytes(repo, "diff", "--no-index", "--no-color", "--no-ext-diff", "-U0", "--", tmp.Name(), path)
+	var exit *exec.ExitError
+	if err != nil && !(errors.As(err, &exit) && exit.ExitCode() == 1) {
+		return nil, err
+	}
+	return ParseHunks(out), nil
+}
+
+func git(dir string, args ...string) (string, error) {
+	out, err := gitBytes(dir, args...)
+	return strings.TrimSpace(string(out)), err
+}
+
+func gitBytes(dir string, args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", dir}, args...)...)
+	return cmd.Output()
+}
+
+func splitLines(data []byte) []string {
+	s := strings.TrimSuffix(string(data), "\n")
+	if s == "" {
+		return nil
+	}
+	lines := strings.Split(s, "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimSuffix(l, "\r")
+	}
+	return lines
+}
+
+func regionHash(lines []string, start, end int) string {
+	if start == 0 {
+		start, end = 1, len(lines)
+	}
+	if start < 1 || end > len(lines) || start > end {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(strings.Join(lines[start-1:end], "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// symbolRes match declarations in the languages agents most often anchor:
+// Go, Python, JavaScript/TypeScript and Rust.
+var symbolRes = []*regexp.Regexp{
+	regexp.MustCompile(`^func\s+(?:\([^)]*\)\s*)?([A-Za-z_]\w*)`),
+	regexp.MustCompile(`^type\s+([A-Za-z_]\w*)`),
+	regexp.MustCompile(`^\s*(?:async\s+)?def\s+([A-Za-z_]\w*)`),
+	regexp.MustCompile(`^\s*(?:export\s+)?(?:default\s+)?(?:abstract\s+)?class\s+([A-Za-z_$][\w$]*)`),
+	regexp.MustCompile(`^\s*(?:export\s+)?(?:default\s+)?(?:async\s+)?function\*?\s+([A-Za-z_$][\w$]*)`),
+	regexp.MustCompile(`^\s*(?:export\s+)?(?:const|let|var)\s+([A-Za-z_$][\w$]*)\s*=\s*(?:async\s*)?(?:\(|function)`),
+	regexp.MustCompile(`^\s*(?:pub(?:\([^)]*\))?\s+)?(?:async\s+)?(?:fn|struct|enum|trait)\s+([A-Za-z_]\w*)`),
+}
+
+// maxSymbolLookback bounds how far above the range DetectSymbol looks for
+// the enclosing declaration.
+const maxSymbolLookback = 200
+
+// DetectSymbol names the declaration a range belongs to: the first one
+// inside the range, else the nearest one above it.
+func DetectSymbol(lines []string, start, end int) string {
+	if start == 0 {
+		return ""
+	}
+	for i := start; i <= end && i <= len(lines); i++ {
+		if s := declaredSymbol(lines[i-1]); s != "" {
+			return s
+		}
+	}
+	for i := start - 1; i >= 1 && i >= start-maxSymbolLookback; i-- {
+		if s := declaredSymbol(lines[i-1]); s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+func declaredSymbol(line string) string {
+	for _, re := range symbolRes {
+		if m := re.FindStringSubmatch(line); m != nil {
+			return m[1]
+		}
+	}
+	return ""
+}
+
+// Store is the anchor file.
+type Store struct {
+	Path string
+}
+
+// Open returns the default store in ~/.ramorie/anchors.json.
+func Open() (*Store, error) {
+	path, err := statefile.Path("anchors.json")
+	if err != nil {
+		return nil, err
+	}
+	return &Store{Path: path}, nil
+}
+
+// Load returns every memory's anchors by memory ID.
+func (s *Store) Load() (map[string][]Anchor, error) {
+	out := map[string][]Anchor{}
+	data, err := os.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		return out, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("corrupt anchor file %s: %w", s.Path, err)
+	}
+	return out, nil
+}
+
+// Put replaces a memory's anchors. No anchors removes the memory.
+func (s *Store) Put(memoryID string, anchors []Anchor) error {
+	all, err := s.Load()
+	if err != nil {
+		return err
+	}
+	if len(anchors) == 0 {
+		if _, ok := all[memoryID]; !ok {
+			return nil
+		}
+		delete(all, memoryID)
+	} else {
+		all[memoryID] = anchors
+	}
+	return s.write(all)
+}
+
+func (s *Store) write(all map[string][]Anchor) error {
+	return statefile.WriteJSON(s.Path, all)
+}
+
+// Updater writes memory fields. memrev.Tracked satisfies it, so anchor
+// changes are kept in the memory's revision history like any other edit.
+type Updater interface {
+	UpdateMemory(id string, updates map[string]interface{}) (*models.Memory, error)
+}
+
+// Add appends anchors to a memory: on the memory and in the default store.
+func Add(u Updater, m *models.Memory, anchors []Anchor) error {
+	if len(anchors) == 0 {
+		return nil
+	}
+	s, err := Open()
+	if err != nil {
+		return err
+	}
+	all, err := s.Load()
+	if err != nil {
+		return err
+	}
+	return Set(u, m, append(all[m.ID.String()], anchors...))
+}
+
+// Set replaces a memory's anchors, on the memory first and then in the
+// default store. An encrypted memory's anchors go only to the store: the
+// anchors field is sent in plain text and would expose its paths and
+// symbols.
+func Set(u Updater, m *models.Memory, anchors []Anchor) error {
+	if anchors == nil {
+		anchors = []Anchor{}
+	}
+	memoryID := m.ID.String()
+	if !m.IsEncrypted {
+		if _, err := u.UpdateMemory(memoryID, map[string]interface{}{"anchors": anchors}); err != nil {
+			return fmt.Errorf("save anchors on memory %s: %w", memoryID, err)
+		}
+	}
+	s, err := Open()
+	if err != nil {
+		return err
+	}
+	return s.Put(memoryID, anchors)
+}
+
+// FromMemory decodes the anchors saved on a memory.
+func FromMemory(m models.Memory) ([]Anchor, error) {
+	if len(m.Anchors) == 0 || string(m.Anchors) == "null" {
+		return nil, nil
+	}
+	var anchors []Anchor
+	if err := json.Unmarshal(m.Anchors, &anchors); err != nil {
+		return nil, fmt.Errorf("memory %s: corrupt anchors: %w", m.ID, err)
+	}
+	return anchors, nil
+}
+
+// Import copies the anchors saved on memories into the default store and
+// returns how many memories had any. Store entries of other memories are
+// left alone.
+func Import(memories []models.Memory) (int, error) {
+	s, err := Open()
+	if err != nil {
+		return 0, err
+	}
+	all, err := s.Load()
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for _, m := range memories {
+		anchors, err := FromMemory(m)
+		if err != nil {
+			return n, err
+		}
+		if len(anchors) == 0 {
+			continue
+		}
+		all[m.ID.String()] = anchors
+		n++
+	}
+	if n == 0 {
+		return 0, nil
+	}
+	return n, s.write(all)
+}
+
+// Drift checks the anchors of the given memories in the default store and
+// returns the reason for each one whose anchored code changed. An unreadable
+// store reports no drift, since drift only annotates results.
+func Drift(memoryIDs []string) map[string]string {
+	out := map[string]string{}
+	s, err := Open()
+	if err != nil {
+		return out
+	}
+	all, err := s.Load()
+	if err != nil || len(all) == 0 {
+		return out
+	}
+	for _, id := range memoryIDs {
+		for _, a := range all[id] {
+			if st := Check(a); st.Stale() {
+				out[id] = "anchored code changed: " + st.Reason
+				break
+			}
+		}
+	}
+	return out
+}
+
+// ForFile returns the IDs of memories anchored to a file, from the default
+// store, most recently anchored first.
+func ForFile(path string) []string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil
+	}
+	if r, err := filepath.EvalSymlinks(abs); err == nil {
+		abs = r
+	}
+	s, err := Open()
+	if err != nil {
+		return nil
+	}
+	all, err := s.Load()
+	if err != nil {
+		return nil
+	}
+	type hit struct {
+		id      string
+		created time.Time
+	}
+	var hits []hit
+	for id, anchors := range all {
+		for _, a := range anchors {
+			root, ok := a.Root()
+			if ok && filepath.Join(root, filepath.FromSlash(a.Path)) == abs {
+				hits = append(hits, hit{id, a.Created})
+				break
+			}
+		}
+	}
+	sort.Slice(hits, func(i, j int) bool { return hits[i].created.After(hits[j].created) })
+	ids := make([]string, len(hits))
+	for i, h := range hits {
+		ids[i] = h.id
+	}
+	return ids
+}
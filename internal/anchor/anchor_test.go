@@ -0,0 +1,314 @@
+package anchor
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kutbudev/ramorie-cli/internal/models"
+)
+
+const sample = `package demo
+
+import "fmt"
+
+// Greet says hello.
+func Greet(name string) string {
+	msg := "hello " + name
+	return msg
+}
+
+func Other() {
+	fmt.Println("other")
+}
+`
+
+// newRepo creates a git repository holding demo.go.
+func newRepo(t *testing.T) (dir, file string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir = t.TempDir()
+	if out, err := exec.Command("git", "init", "-q", dir).CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v %s", err, out)
+	}
+	file = filepath.Join(dir, "demo.go")
+	write(t, file, sample)
+	return dir, file
+}
+
+func write(t *testing.T, path, body string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseSpec(t *testing.T) {
+	for _, tc := range []struct {
+		spec, path, symbol string
+		start, end         int
+	}{
+		{"a/b.go:120-160", "a/b.go", "", 120, 160},
+		{"a/b.go:7", "a/b.go", "", 7, 7},
+		{"a/b.go", "a/b.go", "", 0, 0},
+		{"a/b.go:3-9#Handle", "a/b.go", "Handle", 3, 9},
+		{`C:\src\b.go:3`, `C:\src\b.go`, "", 3, 3},
+	} {
+		path, start, end, symbol, err := ParseSpec(tc.spec)
+		if err != nil || path != tc.path || start != tc.start || end != tc.end || symbol != tc.symbol {
+			t.Errorf("ParseSpec(%q) = %q %d %d %q %v", tc.spec, path, start, end, symbol, err)
+		}
+	}
+	for _, bad := range []string{"a.go:9-3", "a.go:0", "#Sym"} {
+		if _, _, _, _, err := ParseSpec(bad); err == nil {
+			t.Errorf("ParseSpec(%q) should fail", bad)
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	dir, _ := newRepo(t)
+	a, err := Resolve("demo.go:7-8", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Path != "demo.go" || a.Start != 7 || a.End != 8 || a.Symbol != "Greet" || len(a.Blob) < 40 || a.Region == "" {
+		t.Errorf("anchor = %+v", a)
+	}
+	if a.String() != "demo.go:7-8#Greet" {
+		t.Errorf("String = %s", a.String())
+	}
+	if _, err := Resolve("demo.go:7-99", dir); err == nil || !strings.Contains(err.Error(), "only 13 lines") {
+		t.Errorf("out-of-range: %v", err)
+	}
+	if _, err := Resolve("nope.go", dir); err == nil {
+		t.Error("missing file should fail")
+	}
+	if _, err := Resolve("demo.go", t.TempDir()); err == nil {
+		t.Error("file outside a repo should fail")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	dir, file := newRepo(t)
+	a, err := Resolve("demo.go:6-9", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	whole, _ := Resolve("demo.go", dir)
+
+	if st := Check(a); st.State != Fresh {
+		t.Errorf("unchanged: %+v", st)
+	}
+
+	// Edits below the range leave it fresh.
+	write(t, file, sample+"\nfunc Third() {}\n")
+	if st := Check(a); st.State != Fresh {
+		t.Errorf("edit below: %+v", st)
+	}
+	if st := Check(whole); st.State != Drifted {
+		t.Errorf("whole-file anchor after edit: %+v", st)
+	}
+
+	// Lines added above move it.
+	write(t, file, strings.Replace(sample, "import \"fmt\"\n", "import \"fmt\"\n\nvar x = 1\nvar y = 2\n", 1))
+	if st := Check(a); st.State != Moved || st.Start != 9 || st.End != 12 {
+		t.Errorf("edit above: %+v", st)
+	}
+
+	// Changing a line inside drifts it.
+	write(t, file, strings.Replace(sample, `"hello "`, `"hi "`, 1))
+	if st := Check(a); st.State != Drifted || !st.Stale() || !strings.Contains(st.Reason, "demo.go:6-9") {
+		t.Errorf("edit inside: %+v", st)
+	}
+
+	// A stored blob that is gone falls back to the region hash.
+	gone := a
+	gone.Blob = strings.Repeat("0", 40)
+	write(t, file, "// header\n"+sample)
+	if st := Check(gone); st.State != Moved || st.Start != 7 {
+		t.Errorf("region fallback: %+v", st)
+	}
+
+	os.Remove(file)
+	if st := Check(a); st.State != Missing || !st.Stale() {
+		t.Errorf("deleted: %+v", st)
+	}
+	a.Repo = filepath.Join(dir, "elsewhere")
+	if st := Check(a); st.State != Unknown || st.Stale() {
+		t.Errorf("repo gone: %+v", st)
+	}
+}
+
+func TestShift(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		hunks      []Hunk
+		start, end int
+		touched    bool
+	}{
+		{"insert above", []Hunk{{2, 0, 3, 2}}, 12, 22, false},
+		{"delete above", []Hunk{{2, 3, 1, 0}}, 7, 17, false},
+		{"insert inside", []Hunk{{12, 0, 13, 1}}, 10, 21, true},
+		{"insert after last line", []Hunk{{20, 0, 21, 1}}, 10, 20, false},
+		{"change inside", []Hunk{{15, 1, 15, 1}}, 10, 20, true},
+		{"change below", []Hunk{{25, 1, 25, 4}}, 10, 20, false},
+	} {
+		start, end, touched := Shift(tc.hunks, 10, 20)
+		if start != tc.start || end != tc.end || touched != tc.touched {
+			t.Errorf("%s: %d-%d %v", tc.name, start, end, touched)
+		}
+	}
+	h := ParseHunks([]byte("diff --git a b\n@@ -3 +3,2 @@\n-x\n+y\n+z\n@@ -10,0 +11 @@\n+w\n"))
+	if len(h) != 2 || h[0] != (Hunk{3, 1, 3, 2}) || h[1] != (Hunk{10, 0, 11, 1}) {
+		t.Errorf("ParseHunks = %+v", h)
+	}
+}
+
+func TestDetectSymbol(t *testing.T) {
+	lines := []string{
+		"class Cart:",
+		"    def total(self):",
+		"        return sum(self.items)",
+		"export const add = async (a, b) => a + b",
+		"pub fn parse(input: &str) {",
+		"}",
+	}
+	for _, tc := range []struct {
+		start, end int
+		want       string
+	}{
+		{3, 3, "total"},
+		{1, 3, "Cart"},
+		{4, 4, "add"},
+		{6, 6, "parse"},
+		{0, 0, ""},
+	} {
+		if got := DetectSymbol(lines, tc.start, tc.end); got != tc.want {
+			t.Errorf("DetectSymbol(%d-%d) = %q, want %q", tc.start, tc.end, got, tc.want)
+		}
+	}
+}
+
+// memoryUpdates records the memory updates Add and Set make.
+func TestNormalizeRemote(t *testing.T) {
+	for _, url := range []string{
+		"git@github.com:kutbudev/ramorie-cli.git",
+		"https://github.com/kutbudev/ramorie-cli",
+		"https://user@GitHub.com/kutbudev/ramorie-cli.git/",
+		"ssh://git@github.com/kutbudev/ramorie-cli.git",
+	} {
+		if got := NormalizeRemote(url); got != "github.com/kutbudev/ramorie-cli" {
+			t.Errorf("NormalizeRemote(%q) = %q", url, got)
+		}
+	}
+}
+
+func TestRootFollowsRemote(t *testing.T) {
+	dir, _ := newRepo(t)
+	if out, err := exec.Command("git", "-C", dir, "remote", "add", "origin", "git@github.com:acme/app.git").CombinedOutput(); err != nil {
+		t.Fatalf("git remote add: %v %s", err, out)
+	}
+	a, err := Resolve("demo.go:6-9", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Remote != "github.com/acme/app" {
+		t.Errorf("Remote = %q", a.Remote)
+	}
+
+	// Made on another machine: the recorded root does not exist here, but
+	// the working directory is a checkout of the same remote.
+	a.Repo = "/home/someone-else/app"
+	a.Blob = strings.Repeat("0", 40)
+	t.Chdir(dir)
+	if root, ok := a.Root(); !ok || !sameDir(root, dir) {
+		t.Errorf("Root = %q, %v", root, ok)
+	}
+	if st := Check(a); st.State != Fresh {
+		t.Errorf("check in a clone: %+v", st)
+	}
+	a.Remote = "github.com/acme/other"
+	if st := Check(a); st.State != Unknown {
+		t.Errorf("other remote: %+v", st)
+	}
+}
+
+func sameDir(a, b string) bool {
+	ra, _ := filepath.EvalSymlinks(a)
+	rb, _ := filepath.EvalSymlinks(b)
+	return ra == rb
+}
+
+type memoryUpdates map[string]map[string]interface{}
+
+func (u memoryUpdates) UpdateMemory(id string, updates map[string]interface{}) (*models.Memory, error) {
+	u[id] = updates
+	return &models.Memory{}, nil
+}
+
+func TestStoreAndDrift(t *testing.T) {
+	dir, file := newRepo(t)
+	t.Setenv("HOME", t.TempDir())
+	a, _ := Resolve("demo.go:6-9", dir)
+	b, _ := Resolve("demo.go:11-13", dir)
+	u := memoryUpdates{}
+	m1 := &models.Memory{ID: uuid.New()}
+	m2 := &models.Memory{ID: uuid.New(), IsEncrypted: true}
+	if err := Add(u, m1, []Anchor{a}); err != nil {
+		t.Fatal(err)
+	}
+	if err := Add(u, m2, []Anchor{b}); err != nil {
+		t.Fatal(err)
+	}
+	if saved, _ := u[m1.ID.String()]["anchors"].([]Anchor); len(saved) != 1 || saved[0].Path != "demo.go" {
+		t.Errorf("anchors saved on m1 = %v", u[m1.ID.String()])
+	}
+	if _, ok := u[m2.ID.String()]; ok {
+		t.Errorf("anchors of an encrypted memory sent in plain text: %v", u[m2.ID.String()])
+	}
+	if ids := ForFile(file); len(ids) != 2 {
+		t.Errorf("ForFile = %v", ids)
+	}
+	write(t, file, strings.Replace(sample, `"other"`, `"changed"`, 1))
+	drift := Drift([]string{m1.ID.String(), m2.ID.String(), "m3"})
+	if len(drift) != 1 || !strings.Contains(drift[m2.ID.String()], "anchored code changed") {
+		t.Errorf("Drift = %v", drift)
+	}
+
+	s, _ := Open()
+	if err := s.Put(m2.ID.String(), nil); err != nil {
+		t.Fatal(err)
+	}
+	all, _ := s.Load()
+	if _, ok := all[m2.ID.String()]; ok || len(all) != 1 {
+		t.Errorf("after removing m2: %v", all)
+	}
+	if info, err := os.Stat(s.Path); err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf("store mode: %v %v", info.Mode(), err)
+	}
+}
+
+func TestImportFromMemories(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	id := uuid.New()
+	raw, _ := json.Marshal([]Anchor{{Repo: "/src/app", Path: "main.go", Start: 3, End: 9}})
+	n, err := Import([]models.Memory{{ID: id, Anchors: raw}, {ID: uuid.New()}})
+	if err != nil || n != 1 {
+		t.Fatalf("Import = %d, %v", n, err)
+	}
+	s, _ := Open()
+	all, _ := s.Load()
+	if got := all[id.String()]; len(got) != 1 || got[0].String() != "main.go:3-9" {
+		t.Errorf("indexed anchors = %v", all)
+	}
+	if _, err := FromMemory(models.Memory{ID: id, Anchors: json.RawMessage(`{"x":1}`)}); err == nil {
+		t.Error("corrupt anchors decoded")
+	}
+}
@@ -490,7 +490,7 @@ func (c *Client) CreateEncryptedTask(projectID, encryptedTitle, titleNonce, encr
 }
 
 // CreateEncryptedTaskWithMeta creates an encrypted task with agent metadata for MCP/CLI tracking
-func (c *Client) CreateEncryptedTaskWithMeta(projectID, encryptedTitle, titleNonce, priority string, meta *AgentMetadata) (*models.Task, error) {
+func (c *Client) CreateEncryptedTaskWithMeta(projectID, encryptedTitle, titleNonce, priority string, meta *AgentMetadata, tags ...string) (*models.Task, error) {
 	reqBody := map[string]interface{}{
 		"project_id":      projectID,
 		"encrypted_title": encryptedTitle,
@@ -499,6 +499,11 @@ func (c *Client) CreateEncryptedTaskWithMeta(projectID, encryptedTitle, titleNon
 		"priority":        priority,
 	}
 
+	// Add tags if provided
+	if len(tags) > 0 {
+		reqBody["tags"] = tags
+	}
+
 	// Add agent metadata if provided
 	if meta != nil {
 		if meta.AgentName != "" {
@@ -1307,6 +1312,15 @@ type FindItem struct {
 	StaleReason string  `json:"stale_reason,omitempty"`
 	Salience    float64 `json:"salience,omitempty"`
 	TrustReason string  `json:"trust_reason,omitempty"`
+
+	// ValidUntil is the memory's expiry (remember --expires), when the
+	// backend returns it. Hooks drop items past it.
+	ValidUntil *time.Time `json:"valid_until,omitempty"`
+
+	// LocalFallback marks hits served by the local lexical index because
+	// the backend was unreachable. Their Score is a BM25 score, not the
+	// backend's fused relevance.
+	LocalFallback bool `json:"local_fallback,omitempty"`
 }
 
 type FindMeta struct {
@@ -1372,6 +1386,13 @@ type FindDebug struct {
 // FindMemories calls POST /v1/memory/find. Pass ProjectHint to let the backend
 // auto-scope when no explicit project is set in the body.
 func (c *Client) FindMemories(opts FindMemoriesOptions) (*FindResponse, error) {
+	return c.FindMemoriesWithContext(context.Background(), opts)
+}
+
+// FindMemoriesWithContext is the context-aware variant of FindMemories.
+// Hooks bound it with a short deadline so a slow backend falls back to the
+// local index instead of holding up the prompt.
+func (c *Client) FindMemoriesWithContext(ctx context.Context, opts FindMemoriesOptions) (*FindResponse, error) {
 	body := map[string]interface{}{
 		"term": opts.Term,
 	}
@@ -1428,7 +1449,7 @@ func (c *Client) FindMemories(opts FindMemoriesOptions) (*FindResponse, error) {
 		extraHeaders["X-Project-Hint"] = opts.ProjectHint
 	}
 
-	respBody, err := c.makeRequestWithHeaders("POST", "/memory/find", body, extraHeaders)
+	respBody, err := c.makeRequestWithHeadersContext(ctx, "POST", "/memory/find", body, extraHeaders)
 	if err != nil {
 		return nil, err
 	}
@@ -1442,6 +1463,11 @@ func (c *Client) FindMemories(opts FindMemoriesOptions) (*FindResponse, error) {
 // makeRequestWithHeaders is makeRequest + custom headers. Kept separate so
 // existing call sites aren't touched.
 func (c *Client) makeRequestWithHeaders(method, endpoint string, body interface{}, headers map[string]string) ([]byte, error) {
+	return c.makeRequestWithHeadersContext(context.Background(), method, endpoint, body, headers)
+}
+
+// makeRequestWithHeadersContext is makeRequestWithHeaders bounded by ctx.
+func (c *Client) makeRequestWithHeadersContext(ctx context.Context, method, endpoint string, body interface{}, headers map[string]string) ([]byte, error) {
 	url := c.BaseURL + endpoint
 
 	var reqBody io.Reader
@@ -1453,7 +1479,7 @@ func (c *Client) makeRequestWithHeaders(method, endpoint string, body interface{
 		reqBody = bytes.NewBuffer(jsonBody)
 	}
 
-	req, err := http.NewRequest(method, url, reqBody)
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
 	if err != nil {
 		return nil, fmt.Errorf("create request: %w", err)
 	}
@@ -1661,6 +1687,34 @@ func (c *Client) GetMemory(id string) (*models.Memory, error) {
 	return &memory, nil
 }
 
+// MemoryRevision is one stored prior version of a memory.
+type MemoryRevision struct {
+	Revision  int           `json:"revision"`
+	CreatedAt time.Time     `json:"created_at"`
+	Source    string        `json:"source,omitempty"`
+	Memory    models.Memory `json:"memory"`
+}
+
+// ListMemoryRevisions fetches the backend's revision history of a memory,
+// oldest first. Backends without revision support answer 404.
+func (c *Client) ListMemoryRevisions(id string) ([]MemoryRevision, error) {
+	respBody, err := c.makeRequest("GET", "/memories/"+id+"/revisions", nil)
+	if err != nil {
+		return nil, err
+	}
+	var wrapped struct {
+		Revisions []MemoryRevision `json:"revisions"`
+	}
+	if err := json.Unmarshal(respBody, &wrapped); err == nil && wrapped.Revisions != nil {
+		return wrapped.Revisions, nil
+	}
+	var revs []MemoryRevision
+	if err := json.Unmarshal(respBody, &revs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal memory revisions: %w", err)
+	}
+	return revs, nil
+}
+
 // Job API methods
 
 // EnqueueJob enqueues a background job
@@ -1810,6 +1864,21 @@ func (c *Client) CreateSubtask(taskID, description string) (*models.Subtask, err
 	return &sub, nil
 }
 
+// CreateChildSubtask creates a subtask nested under parentSubtaskID.
+func (c *Client) CreateChildSubtask(taskID, parentSubtaskID, description string) (*models.Subtask, error) {
+	req := map[string]string{"description": description, "parent_subtask_id": parentSubtaskID}
+	endpoint := fmt.Sprintf("/tasks/%s/subtasks", taskID)
+	respBody, err := c.makeRequest("POST", endpoint, req)
+	if err != nil {
+		return nil, err
+	}
+	var sub models.Subtask
+	if err := json.Unmarshal(respBody, &sub); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal subtask: %w", err)
+	}
+	return &sub, nil
+}
+
 func (c *Client) ListSubtasks(taskID string) ([]models.Subtask, error) {
 	endpoint := fmt.Sprintf("/tasks/%s/subtasks", taskID)
 	respBody, err := c.makeRequest("GET", endpoint, nil)
@@ -2628,6 +2697,7 @@ type AgentEventFilter struct {
 	EventType  string
 	EntityType string
 	Limit      int
+	Cursor     string // NextCursor of the previous page
 }
 
 // GetAgentEvents retrieves agent activity events with optional filtering
@@ -2652,6 +2722,9 @@ func (c *Client) GetAgentEvents(filter AgentEventFilter) (*AgentEventListRespons
 	} else {
 		params.Add("limit", "20") // Default limit
 	}
+	if filter.Cursor != "" {
+		params.Add("cursor", filter.Cursor)
+	}
 
 	if encoded := params.Encode(); encoded != "" {
 		endpoint += "?" + encoded
@@ -2798,6 +2871,26 @@ func (c *Client) UpdateSubtask(subtaskID string, req UpdateSubtaskRequest) (*mod
 	return &subtask, nil
 }
 
+// MoveSubtask reparents a subtask. An empty parentSubtaskID moves it to the
+// top level (sent as JSON null).
+func (c *Client) MoveSubtask(subtaskID, parentSubtaskID string) (*models.Subtask, error) {
+	var parent interface{}
+	if parentSubtaskID != "" {
+		parent = parentSubtaskID
+	}
+	endpoint := fmt.Sprintf("/subtasks/%s", subtaskID)
+	respBody, err := c.makeRequest("PATCH", endpoint, map[string]interface{}{"parent_subtask_id": parent})
+	if err != nil {
+		return nil, err
+	}
+
+	var subtask models.Subtask
+	if err := json.Unmarshal(respBody, &subtask); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal subtask: %w", err)
+	}
+	return &subtask, nil
+}
+
 // DeleteSubtask deletes a subtask
 func (c *Client) DeleteSubtask(subtaskID string) error {
 	endpoint := fmt.Sprintf("/subtasks/%s", subtaskID)
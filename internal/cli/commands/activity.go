@@ -74,7 +74,21 @@ func NewActivityCommand() *cli.Command {
 					return err
 				}
 
-				// Burndown stays JSON — table layout is poor for time-series data.
+				// TTY: draw the series as a line chart. JSON (flag or pipe)
+				// and payloads we cannot chart keep the raw report.
+				if !wantJSON {
+					if labels, series, ok := burndownSeries(b); ok {
+						subtitle := fmt.Sprintf("last %dd · %s", days, interval)
+						if project != "" {
+							subtitle += " · project: " + project
+						}
+						fmt.Println(display.Header("📉 burndown", subtitle))
+						fmt.Println()
+						fmt.Println(display.LineChart(labels, series, display.ChartOptions{Height: 12}))
+						return nil
+					}
+				}
+
 				var out interface{}
 				if err := json.Unmarshal(b, &out); err != nil {
 					os.Stdout.Write(b)
@@ -200,3 +214,95 @@ func NewActivityCommand() *cli.Command {
 		},
 	}
 }
+
+// burndownLabelKeys are the fields tried, in order, for a point's x label.
+var burndownLabelKeys = []string{"date", "period", "day", "week", "label", "timestamp", "interval_start"}
+
+// burndownSeriesOrder puts the usual burndown lines first; other numeric
+// fields follow alphabetically.
+var burndownSeriesOrder = []string{"remaining", "ideal", "completed", "total", "created"}
+
+// burndownSeries extracts chartable series from a /reports/burndown payload.
+// It accepts a bare array of points or an object wrapping one (data, points,
+// …); every numeric field of the points becomes a series. ok is false when
+// nothing chartable is found, so the caller can fall back to JSON.
+func burndownSeries(body []byte) (labels []string, series []display.Series, ok bool) {
+	var raw interface{}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, nil, false
+	}
+	points := burndownPoints(raw)
+	if len(points) < 2 {
+		return nil, nil, false
+	}
+
+	numeric := map[string]bool{}
+	for _, p := range points {
+		for k, v := range p {
+			if _, isNum := v.(float64); isNum {
+				numeric[k] = true
+			}
+		}
+	}
+	keys := make([]string, 0, len(numeric))
+	for _, k := range burndownSeriesOrder {
+		if numeric[k] {
+			keys = append(keys, k)
+			delete(numeric, k)
+		}
+	}
+	rest := make([]string, 0, len(numeric))
+	for k := range numeric {
+		rest = append(rest, k)
+	}
+	slices.Sort(rest)
+	keys = append(keys, rest...)
+	if len(keys) == 0 {
+		return nil, nil, false
+	}
+
+	for _, p := range points {
+		label := ""
+		for _, k := range burndownLabelKeys {
+			if s, isStr := p[k].(string); isStr {
+				label = s
+				break
+			}
+		}
+		if len(label) > 10 && label[4] == '-' {
+			label = label[:10] // 2026-03-02T00:00:00Z → 2026-03-02
+		}
+		labels = append(labels, label)
+	}
+	for _, k := range keys {
+		values := make([]float64, len(points))
+		for i, p := range points {
+			values[i], _ = p[k].(float64)
+		}
+		series = append(series, display.Series{Name: k, Values: values})
+	}
+	return labels, series, true
+}
+
+// burndownPoints finds the array of point objects in a decoded payload.
+func burndownPoints(raw interface{}) []map[string]interface{} {
+	switch v := raw.(type) {
+	case []interface{}:
+		out := make([]map[string]interface{}, 0, len(v))
+		for _, item := range v {
+			m, isObj := item.(map[string]interface{})
+			if !isObj {
+				return nil
+			}
+			out = append(out, m)
+		}
+		return out
+	case map[string]interface{}:
+		for _, k := range []string{"data", "points", "series", "burndown", "items", "days"} {
+			if pts := burndownPoints(v[k]); len(pts) > 0 {
+				return pts
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package commands
+
+import "testing"
+
+func TestBurndownSeries_WrappedPoints(t *testing.T) {
+	body := []byte(`{"interval":"daily","data":[
+		{"date":"2026-03-01T00:00:00Z","remaining":10,"completed":0,"note":"x"},
+		{"date":"2026-03-02T00:00:00Z","remaining":6,"completed":4},
+		{"date":"2026-03-03T00:00:00Z","remaining":3,"completed":7}]}`)
+	labels, series, ok := burndownSeries(body)
+	if !ok {
+		t.Fatal("expected a chartable payload")
+	}
+	if len(labels) != 3 || labels[0] != "2026-03-01" {
+		t.Errorf("labels = %v", labels)
+	}
+	if len(series) != 2 || series[0].Name != "remaining" || series[1].Name != "completed" {
+		t.Fatalf("series = %+v", series)
+	}
+	if series[0].Values[2] != 3 || series[1].Values[0] != 0 {
+		t.Errorf("values = %+v", series)
+	}
+}
+
+func TestBurndownSeries_BareArrayAndFallback(t *testing.T) {
+	if _, series, ok := burndownSeries([]byte(`[{"period":"W1","open":4},{"period":"W2","open":2}]`)); !ok || series[0].Name != "open" {
+		t.Errorf("bare array not charted: %+v", series)
+	}
+	for _, body := range []string{`{"message":"no data"}`, `[{"date":"2026-03-01","remaining":1}]`, `not json`} {
+		if _, _, ok := burndownSeries([]byte(body)); ok {
+			t.Errorf("%s should fall back to JSON", body)
+		}
+	}
+}
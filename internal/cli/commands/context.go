@@ -3,9 +3,12 @@ package commands
 import (
 	"fmt"
 	"slices"
+	"strings"
 
 	"github.com/kutbudev/ramorie-cli/internal/api"
 	"github.com/kutbudev/ramorie-cli/internal/cli/display"
+	"github.com/kutbudev/ramorie-cli/internal/journal"
+	"github.com/kutbudev/ramorie-cli/internal/models"
 	"github.com/urfave/cli/v2"
 )
 
@@ -59,6 +62,7 @@ func contextCreateCmd() *cli.Command {
 				fmt.Printf("Error creating context: %v\n", err)
 				return err
 			}
+			journal.Record("context create", context.Name, journal.Create(journal.KindContext, context.ID.String()))
 
 			fmt.Printf("✅ Context '%s' created successfully!\n", context.Name)
 			return nil
@@ -136,14 +140,34 @@ func contextDeleteCmd() *cli.Command {
 			contextID := c.Args().First()
 
 			client := api.NewClient()
+			before := findContext(client, contextID)
 			err := client.DeleteContext(contextID)
 			if err != nil {
 				fmt.Printf("Error deleting context: %v\n", err)
 				return err
 			}
+			if before != nil {
+				journal.Record("context delete", before.Name,
+					journal.Delete(journal.KindContext, before.ID.String(), journal.ContextSnapshot(before)))
+			}
 
 			fmt.Printf("🗑️ Context %s deleted successfully.\n", contextID[:8])
 			return nil
 		},
 	}
 }
+
+// findContext looks a context up by ID or ID prefix for the undo journal;
+// there is no single-context endpoint. It returns nil when not found.
+func findContext(client *api.Client, id string) *models.Context {
+	contexts, err := client.ListContexts()
+	if err != nil {
+		return nil
+	}
+	for i := range contexts {
+		if strings.HasPrefix(contexts[i].ID.String(), id) {
+			return &contexts[i]
+		}
+	}
+	return nil
+}
@@ -1,13 +1,19 @@
 package commands
 
 import (
+	"bufio"
 	"encoding/json"
 	"fmt"
+	"io"
+	"os"
 	"slices"
+	"strings"
 
 	"github.com/kutbudev/ramorie-cli/internal/api"
 	"github.com/kutbudev/ramorie-cli/internal/cli/display"
+	"github.com/kutbudev/ramorie-cli/internal/journal"
 	"github.com/urfave/cli/v2"
+	"golang.org/x/term"
 )
 
 // contextPackListCmd lists all context packs.
@@ -113,6 +119,7 @@ func contextPackCreateCmd() *cli.Command {
 				fmt.Printf("Error creating context pack: %v\n", err)
 				return err
 			}
+			journal.Record("pack create", pack.Name, journal.Create(journal.KindPack, pack.ID))
 
 			fmt.Printf("✅ Context pack '%s' created successfully!\n", pack.Name)
 			fmt.Printf("   ID: %s\n", pack.ID[:8])
@@ -137,10 +144,15 @@ func contextPackDeleteCmd() *cli.Command {
 			packID := c.Args().First()
 
 			client := api.NewClient()
+			before, _ := client.GetContextPack(packID)
 			if err := client.DeleteContextPack(packID); err != nil {
 				fmt.Printf("Error deleting context pack: %v\n", err)
 				return err
 			}
+			if before != nil {
+				journal.Record("pack delete", before.Name,
+					journal.Delete(journal.KindPack, before.ID, journal.PackSnapshot(before)))
+			}
 
 			fmt.Printf("🗑️ Context pack %s deleted successfully.\n", packID[:8])
 			return nil
@@ -227,11 +239,16 @@ func contextPackUseCmd() *cli.Command {
 // contextPackAddCmd bulk-links memories or tasks to a pack.
 //
 //	ramorie pack add <pack> --memory id1 id2  --task id3 id4
+//	ramorie find deploy --pick | ramorie pack add <pack>
 func contextPackAddCmd() *cli.Command {
 	return &cli.Command{
 		Name:      "add",
 		Usage:     "Add memories and/or tasks to a context pack (bulk)",
 		ArgsUsage: "[pack-id]",
+		Description: "Without --memory or --task, hits are read from piped stdin, one per line —\n" +
+			"   e.g. from `ramorie find <term> --pick` or `--format ndjson`, whose type\n" +
+			"   routes task hits to the pack's tasks. Bare IDs (`--format ids`) are\n" +
+			"   linked as memories.",
 		Flags: []cli.Flag{
 			&cli.StringSliceFlag{Name: "memory", Aliases: []string{"m"}, Usage: "Memory IDs to link (repeat for multiple)"},
 			&cli.StringSliceFlag{Name: "task", Aliases: []string{"t"}, Usage: "Task IDs to link (repeat for multiple)"},
@@ -243,6 +260,12 @@ func contextPackAddCmd() *cli.Command {
 			packID := c.Args().First()
 			memIDs := c.StringSlice("memory")
 			taskIDs := c.StringSlice("task")
+			if len(memIDs) == 0 && len(taskIDs) == 0 && !term.IsTerminal(int(os.Stdin.Fd())) {
+				var err error
+				if memIDs, taskIDs, err = readPackRefs(os.Stdin); err != nil {
+					return err
+				}
+			}
 			if len(memIDs) == 0 && len(taskIDs) == 0 {
 				return fmt.Errorf("at least one --memory or --task required")
 			}
@@ -332,6 +355,42 @@ func contextPackCloneCmd() *cli.Command {
 	}
 }
 
+// readPackRefs reads memory and task IDs piped into `pack add`: one ID per
+// line (the first field; blank and # lines are skipped), or an ndjson find
+// hit whose type decides between memory and task.
+func readPackRefs(r io.Reader) (memIDs, taskIDs []string, err error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		id, kind := "", ""
+		if strings.HasPrefix(line, "{") {
+			var hit struct {
+				ID   string `json:"id"`
+				Type string `json:"type"`
+			}
+			if err := json.Unmarshal([]byte(line), &hit); err != nil {
+				return nil, nil, fmt.Errorf("stdin line %d: %w", n, err)
+			}
+			id, kind = hit.ID, hit.Type
+		} else {
+			id = strings.Fields(line)[0]
+		}
+		if !looksLikeUUID(id) {
+			return nil, nil, fmt.Errorf("stdin line %d: %q is not a memory or task ID", n, id)
+		}
+		if strings.EqualFold(kind, "task") {
+			taskIDs = append(taskIDs, id)
+		} else {
+			memIDs = append(memIDs, id)
+		}
+	}
+	return memIDs, taskIDs, sc.Err()
+}
+
 // looksLikeUUID — naive shape check (8-4-4-4-12). Avoids importing
 // google/uuid here when we just need a heuristic to gate name resolution.
 func looksLikeUUID(s string) bool {
@@ -0,0 +1,30 @@
+package commands
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadPackRefs(t *testing.T) {
+	in := strings.Join([]string{
+		"# picked",
+		"11111111-1111-1111-1111-111111111111",
+		"",
+		"22222222-2222-2222-2222-222222222222  Use pgx",
+		`{"id":"33333333-3333-3333-3333-333333333333","type":"task","title":"Migrate"}`,
+	}, "\n")
+	mems, tasks, err := readPackRefs(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"11111111-1111-1111-1111-111111111111", "22222222-2222-2222-2222-222222222222"}; !reflect.DeepEqual(mems, want) {
+		t.Errorf("memories = %v", mems)
+	}
+	if want := []string{"33333333-3333-3333-3333-333333333333"}; !reflect.DeepEqual(tasks, want) {
+		t.Errorf("tasks = %v", tasks)
+	}
+	if _, _, err := readPackRefs(strings.NewReader("not-an-id\n")); err == nil {
+		t.Error("a line without an ID should fail")
+	}
+}
@@ -0,0 +1,501 @@
+package commands
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"github.com/kutbudev/ramorie-cli/internal/api"
+	"github.com/kutbudev/ramorie-cli/internal/cli/display"
+	"github.com/kutbudev/ramorie-cli/internal/crypto"
+	apierrors "github.com/kutbudev/ramorie-cli/internal/errors"
+	"github.com/kutbudev/ramorie-cli/internal/journal"
+	"github.com/kutbudev/ramorie-cli/internal/mcp"
+	"github.com/kutbudev/ramorie-cli/internal/memrev"
+	"github.com/kutbudev/ramorie-cli/internal/models"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/term"
+	"gopkg.in/yaml.v3"
+)
+
+// taskEditFront is the YAML front matter of `task edit`; the Markdown body
+// below it is the description.
+type taskEditFront struct {
+	Title    string   `yaml:"title"`
+	Status   string   `yaml:"status"`
+	Priority string   `yaml:"priority"`
+	Tags     []string `yaml:"tags"`
+}
+
+// memoryEditFront is the YAML front matter of `memory edit`; the body is
+// the memory content. Trigger, steps and validation apply to skills.
+type memoryEditFront struct {
+	Type       string   `yaml:"type"`
+	Tags       []string `yaml:"tags"`
+	Trigger    string   `yaml:"trigger,omitempty"`
+	Steps      []string `yaml:"steps,omitempty"`
+	Validation string   `yaml:"validation,omitempty"`
+}
+
+// editDelimiter fences the front matter.
+const editDelimiter = "---"
+
+// renderEditDoc writes front as YAML front matter followed by body. The
+// comment lines are dropped again by the YAML parser.
+func renderEditDoc(comment string, front interface{}, body string) (string, error) {
+	var b bytes.Buffer
+	b.WriteString(editDelimiter + "\n")
+	for _, line := range strings.Split(strings.TrimSpace(comment), "\n") {
+		b.WriteString("# " + line + "\n")
+	}
+	enc := yaml.NewEncoder(&b)
+	enc.SetIndent(2)
+	if err := enc.Encode(front); err != nil {
+		return "", err
+	}
+	enc.Close()
+	b.WriteString(editDelimiter + "\n")
+	b.WriteString(body)
+	if body != "" && !strings.HasSuffix(body, "\n") {
+		b.WriteString("\n")
+	}
+	return b.String(), nil
+}
+
+// parseEditDoc splits an edited document into front matter (decoded into
+// front) and body. The body loses the trailing newline editors add.
+func parseEditDoc(doc string, front interface{}) (body string, err error) {
+	doc = strings.ReplaceAll(doc, "\r\n", "\n")
+	if !strings.HasPrefix(doc, editDelimiter+"\n") {
+		return "", fmt.Errorf("missing front matter: the file must start with %q", editDelimiter)
+	}
+	rest := doc[len(editDelimiter)+1:]
+	var yamlPart string
+	switch {
+	case rest == editDelimiter:
+		yamlPart, body = "", ""
+	case strings.HasPrefix(rest, editDelimiter+"\n"):
+		yamlPart, body = "", rest[len(editDelimiter)+1:]
+	default:
+		end := strings.Index(rest, "\n"+editDelimiter+"\n")
+		if end < 0 {
+			if !strings.HasSuffix(rest, "\n"+editDelimiter) {
+				return "", fmt.Errorf("unterminated front matter: add a closing %q line", editDelimiter)
+			}
+			end = len(rest) - len(editDelimiter) - 1
+		}
+		yamlPart = rest[:end]
+		body = rest[min(len(rest), end+len(editDelimiter)+2):]
+	}
+	if err := yaml.Unmarshal([]byte(yamlPart), front); err != nil {
+		return "", fmt.Errorf("invalid front matter: %w", err)
+	}
+	return strings.TrimSuffix(body, "\n"), nil
+}
+
+// fieldChange is one changed front-matter field, for the summary.
+type fieldChange struct {
+	Field, Old, New string
+}
+
+// taskEditChanges compares the original and edited task and returns the
+// changed fields plus the plaintext update payload. Status and priority
+// are validated and upper-cased.
+func taskEditChanges(before taskEditFront, beforeBody string, after taskEditFront, afterBody string) ([]fieldChange, map[string]interface{}, error) {
+	after.Title = strings.TrimSpace(after.Title)
+	after.Status = strings.ToUpper(strings.TrimSpace(after.Status))
+	after.Priority = strings.ToUpper(strings.TrimSpace(after.Priority))
+	if after.Title == "" {
+		return nil, nil, fmt.Errorf("title must not be empty")
+	}
+	switch after.Status {
+	case "TODO", "IN_PROGRESS", "COMPLETED":
+	default:
+		return nil, nil, fmt.Errorf("status must be TODO, IN_PROGRESS or COMPLETED, got %q", after.Status)
+	}
+	switch after.Priority {
+	case "H", "M", "L":
+	default:
+		return nil, nil, fmt.Errorf("priority must be H, M or L, got %q", after.Priority)
+	}
+
+	var changes []fieldChange
+	updates := map[string]interface{}{}
+	if after.Title != before.Title {
+		changes = append(changes, fieldChange{"title", before.Title, after.Title})
+		updates["title"] = after.Title
+	}
+	if after.Status != strings.ToUpper(before.Status) {
+		changes = append(changes, fieldChange{"status", before.Status, after.Status})
+		updates["status"] = after.Status
+	}
+	if after.Priority != strings.ToUpper(before.Priority) {
+		changes = append(changes, fieldChange{"priority", before.Priority, after.Priority})
+		updates["priority"] = after.Priority
+	}
+	if tags := cleanEditTags(after.Tags); !slices.Equal(tags, cleanEditTags(before.Tags)) {
+		changes = append(changes, fieldChange{"tags", strings.Join(before.Tags, ", "), strings.Join(tags, ", ")})
+		updates["tags"] = tags
+	}
+	if afterBody != strings.TrimSuffix(beforeBody, "\n") {
+		updates["description"] = afterBody
+	}
+	return changes, updates, nil
+}
+
+// memoryEditChanges is taskEditChanges for memories. Content must stay
+// non-empty and the type must be one the backend accepts.
+func memoryEditChanges(before memoryEditFront, beforeBody string, after memoryEditFront, afterBody string) ([]fieldChange, map[string]interface{}, error) {
+	after.Type = strings.ToLower(strings.TrimSpace(after.Type))
+	if strings.TrimSpace(afterBody) == "" {
+		return nil, nil, fmt.Errorf("content must not be empty — use `ramorie memory forget` to delete")
+	}
+	if after.Type == "" {
+		after.Type = before.Type
+	}
+	if after.Type != before.Type && !mcp.IsValidMemoryType(after.Type) {
+		return nil, nil, fmt.Errorf("invalid memory type %q (use one of: %s)", after.Type, strings.Join(mcp.ValidMemoryTypes(), ", "))
+	}
+
+	var changes []fieldChange
+	updates := map[string]interface{}{}
+	if after.Type != before.Type {
+		changes = append(changes, fieldChange{"type", before.Type, after.Type})
+		updates["type"] = after.Type
+	}
+	if tags := cleanEditTags(after.Tags); !slices.Equal(tags, cleanEditTags(before.Tags)) {
+		changes = append(changes, fieldChange{"tags", strings.Join(before.Tags, ", "), strings.Join(tags, ", ")})
+		updates["tags"] = tags
+	}
+	if t := strings.TrimSpace(after.Trigger); t != before.Trigger {
+		changes = append(changes, fieldChange{"trigger", before.Trigger, t})
+		updates["trigger"] = t
+	}
+	if !slices.Equal(after.Steps, before.Steps) {
+		changes = append(changes, fieldChange{"steps", fmt.Sprintf("%d step(s)", len(before.Steps)), fmt.Sprintf("%d step(s)", len(after.Steps))})
+		updates["steps"] = after.Steps
+	}
+	if v := strings.TrimSpace(after.Validation); v != before.Validation {
+		changes = append(changes, fieldChange{"validation", before.Validation, v})
+		updates["validation"] = v
+	}
+	if afterBody != strings.TrimSuffix(beforeBody, "\n") {
+		updates["content"] = afterBody
+	}
+	return changes, updates, nil
+}
+
+// cleanEditTags trims, drops empties and de-duplicates while keeping order.
+func cleanEditTags(tags []string) []string {
+	out := []string{}
+	seen := map[string]bool{}
+	for _, t := range tags {
+		t = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(t), "#"))
+		if t == "" || seen[strings.ToLower(t)] {
+			continue
+		}
+		seen[strings.ToLower(t)] = true
+		out = append(out, t)
+	}
+	return out
+}
+
+// editorCommand returns the user's editor: $VISUAL, then $EDITOR, then vi.
+func editorCommand() []string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if f := strings.Fields(os.Getenv(env)); len(f) > 0 {
+			return f
+		}
+	}
+	return []string{"vi"}
+}
+
+// editInEditor writes doc to a private temp file, opens the editor and
+// returns the saved text. The file may hold decrypted vault content, so it
+// is created 0600 and removed afterwards.
+func editInEditor(doc, pattern string) (string, error) {
+	f, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return "", err
+	}
+	path := f.Name()
+	defer os.Remove(path)
+	if _, err := f.WriteString(doc); err != nil {
+		f.Close()
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+
+	argv := append(editorCommand(), path)
+	cmd := exec.Command(argv[0], argv[1:]...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("editor %q failed: %w", argv[0], err)
+	}
+	out, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// editLoop opens the editor until the result parses (the user may re-edit
+// after an error on a terminal). ok is false when the user aborted by
+// emptying the file or leaving it unchanged.
+func editLoop(doc, pattern string, apply func(edited string) error) (ok bool, err error) {
+	current := doc
+	for {
+		edited, err := editInEditor(current, pattern)
+		if err != nil {
+			return false, err
+		}
+		if strings.TrimSpace(edited) == "" || edited == doc {
+			return false, nil
+		}
+		err = apply(edited)
+		if err == nil {
+			return true, nil
+		}
+		if !term.IsTerminal(int(os.Stdin.Fd())) {
+			return false, err
+		}
+		fmt.Fprintf(os.Stderr, "%s %v\n", display.Err.Render("✗"), err)
+		fmt.Fprint(os.Stderr, "Press Enter to edit again, or Ctrl-C to abort… ")
+		if _, rerr := bufio.NewReader(os.Stdin).ReadString('\n'); rerr != nil {
+			return false, err
+		}
+		current = edited
+	}
+}
+
+// printEditDiff shows the changed fields and a colored diff of the body.
+func printEditDiff(changes []fieldChange, bodyName, beforeBody, afterBody string) {
+	for _, ch := range changes {
+		old, nu := ch.Old, ch.New
+		if old == "" {
+			old = "∅"
+		}
+		if nu == "" {
+			nu = "∅"
+		}
+		fmt.Printf("  %s %s → %s\n", display.Label.Render(ch.Field+":"), display.Err.Render(old), display.Good.Render(nu))
+	}
+	if strings.TrimSuffix(beforeBody, "\n") != afterBody {
+		ops := diffLines(beforeBody, afterBody)
+		added, removed := diffStats(ops)
+		fmt.Printf("  %s %s\n", display.Label.Render(bodyName+":"),
+			display.Dim.Render(fmt.Sprintf("+%d −%d line(s)", added, removed)))
+		for _, line := range strings.Split(strings.TrimRight(formatUnifiedDiff(ops, 2, true), "\n"), "\n") {
+			fmt.Println("    " + line)
+		}
+	}
+}
+
+// encryptEditField re-encrypts one changed field under the item's own scope
+// and stores it under encKey/nonceKey, removing the plaintext key.
+func encryptEditField(updates map[string]interface{}, plainKey, encKey, nonceKey, scope, orgID string) error {
+	v, ok := updates[plainKey].(string)
+	if !ok {
+		return nil
+	}
+	enc, nonce, encrypted, err := crypto.EncryptContentWithScope(v, scope, orgID)
+	if err != nil {
+		return err
+	}
+	if !encrypted {
+		return fmt.Errorf("cannot re-encrypt %s: the vault key for this item is not available", plainKey)
+	}
+	delete(updates, plainKey)
+	updates[encKey] = enc
+	updates[nonceKey] = nonce
+	updates["is_encrypted"] = true
+	return nil
+}
+
+// taskEditCmd implements `ramorie task edit`.
+func taskEditCmd() *cli.Command {
+	return &cli.Command{
+		Name:      "edit",
+		Usage:     "Edit a task in $EDITOR (Markdown with YAML front matter)",
+		ArgsUsage: "[task-id]",
+		Description: "Opens the task as Markdown: title, status, priority and tags in the front\n" +
+			"   matter, the description below. Changed fields are shown and applied on save;\n" +
+			"   saving an unchanged or empty file aborts. Encrypted tasks are decrypted for\n" +
+			"   editing and re-encrypted with their original scope.",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "dry-run", Aliases: []string{"n"}, Usage: "Show the changes without applying them"},
+		},
+		Action: func(c *cli.Context) error {
+			if c.NArg() == 0 {
+				return fmt.Errorf("task ID is required")
+			}
+			client := api.NewClient()
+			task, err := client.GetTask(c.Args().First())
+			if err != nil {
+				fmt.Println(apierrors.ParseAPIError(err))
+				return err
+			}
+			if task.IsEncrypted && !crypto.IsVaultUnlocked() {
+				return fmt.Errorf("task is encrypted and the vault is locked — run 'ramorie vault unlock' first")
+			}
+
+			title, description := decryptTaskForCLI(task)
+			before := taskEditFront{Title: title, Status: task.Status, Priority: task.Priority, Tags: getTagsAsStrings(task.Tags)}
+			doc, err := renderEditDoc(fmt.Sprintf("ramorie task %s — save to apply, empty the file to abort.\nstatus: TODO | IN_PROGRESS | COMPLETED · priority: H | M | L", task.ID.String()[:8]), before, description)
+			if err != nil {
+				return err
+			}
+
+			var changes []fieldChange
+			var updates map[string]interface{}
+			var afterBody string
+			ok, err := editLoop(doc, "ramorie-task-*.md", func(edited string) error {
+				var after taskEditFront
+				body, err := parseEditDoc(edited, &after)
+				if err != nil {
+					return err
+				}
+				changes, updates, err = taskEditChanges(before, description, after, body)
+				afterBody = body
+				return err
+			})
+			if err != nil {
+				return err
+			}
+			if !ok || len(updates) == 0 {
+				fmt.Println(display.Dim.Render("No changes."))
+				return nil
+			}
+
+			fmt.Println(display.Header("✏️  task "+task.ID.String()[:8], display.SingleLine(title)))
+			printEditDiff(changes, "description", description, afterBody)
+			if c.Bool("dry-run") {
+				return nil
+			}
+
+			fields := updateFields(updates)
+			if task.IsEncrypted {
+				if err := encryptEditField(updates, "title", "encrypted_title", "title_nonce", task.EncryptionScope, task.EncryptionOrgID); err != nil {
+					return err
+				}
+				if err := encryptEditField(updates, "description", "encrypted_description", "description_nonce", task.EncryptionScope, task.EncryptionOrgID); err != nil {
+					return err
+				}
+			}
+			if _, err := client.UpdateTask(task.ID.String(), updates); err != nil {
+				fmt.Println(apierrors.ParseAPIError(err))
+				return err
+			}
+			journal.Record("task edit", taskLabel(task, task.ID.String()),
+				journal.Update(journal.KindTask, task.ID.String(), journal.TaskSnapshot(task), fields...))
+			fmt.Printf("✅ Task %s updated.\n", task.ID.String()[:8])
+			return nil
+		},
+	}
+}
+
+// memoryEditCmd implements `ramorie memory edit`.
+func memoryEditCmd() *cli.Command {
+	return &cli.Command{
+		Name:      "edit",
+		Usage:     "Edit a memory in $EDITOR (Markdown with YAML front matter)",
+		ArgsUsage: "[memory-id]",
+		Description: "Opens the memory as Markdown: type and tags (plus trigger, steps and\n" +
+			"   validation for skills) in the front matter, the content below. Changed\n" +
+			"   fields are shown and applied on save; saving an unchanged or empty file\n" +
+			"   aborts. Encrypted memories are re-encrypted with their original scope.",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "dry-run", Aliases: []string{"n"}, Usage: "Show the changes without applying them"},
+		},
+		Action: func(c *cli.Context) error {
+			if c.NArg() == 0 {
+				return fmt.Errorf("memory ID is required")
+			}
+			client := api.NewClient()
+			memory, err := client.GetMemory(c.Args().First())
+			if err != nil {
+				fmt.Println(apierrors.ParseAPIError(err))
+				return err
+			}
+			_, err = editMemory(client, memory, c.Bool("dry-run"))
+			return err
+		},
+	}
+}
+
+// editMemory opens memory in $EDITOR and applies the changes, reporting
+// whether anything was (or, with dryRun, would be) changed.
+func editMemory(client *api.Client, memory *models.Memory, dryRun bool) (bool, error) {
+	if memory.IsEncrypted && !crypto.IsVaultUnlocked() {
+		return false, fmt.Errorf("memory is encrypted and the vault is locked — run 'ramorie vault unlock' first")
+	}
+
+	content := decryptMemoryForCLI(memory)
+	before := memoryEditFrontFor(memory)
+	hint := "type: general | decision | bug_fix | preference | pattern | reference | skill"
+	if memory.Type == "skill" {
+		hint += "\nskills also take trigger, steps (list) and validation"
+	}
+	doc, err := renderEditDoc(fmt.Sprintf("ramorie memory %s — save to apply, empty the file to abort.\n%s", memory.ID.String()[:8], hint), before, content)
+	if err != nil {
+		return false, err
+	}
+
+	var changes []fieldChange
+	var updates map[string]interface{}
+	var afterBody string
+	ok, err := editLoop(doc, "ramorie-memory-*.md", func(edited string) error {
+		var after memoryEditFront
+		body, err := parseEditDoc(edited, &after)
+		if err != nil {
+			return err
+		}
+		changes, updates, err = memoryEditChanges(before, content, after, body)
+		afterBody = body
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+	if !ok || len(updates) == 0 {
+		fmt.Println(display.Dim.Render("No changes."))
+		return false, nil
+	}
+
+	first, _ := splitFirstLine(content)
+	fmt.Println(display.Header("✏️  memory "+memory.ID.String()[:8], display.Truncate(first, 60)))
+	printEditDiff(changes, "content", content, afterBody)
+	if dryRun {
+		return true, nil
+	}
+
+	fields := updateFields(updates)
+	if err := sealMemoryContent(client, memory, updates); err != nil {
+		return false, err
+	}
+	if _, err := memrev.Update(client, memory.ID.String(), updates, "cli"); err != nil {
+		fmt.Println(apierrors.ParseAPIError(err))
+		return false, err
+	}
+	journal.Record("memory edit", memoryLabel(memory, memory.ID.String()),
+		journal.Update(journal.KindMemory, memory.ID.String(), journal.MemorySnapshot(memory), fields...))
+	fmt.Printf("✅ Memory %s updated.\n", memory.ID.String()[:8])
+	return true, nil
+}
+
+func memoryEditFrontFor(m *models.Memory) memoryEditFront {
+	f := memoryEditFront{Type: m.Type, Tags: getTagsAsStrings(m.Tags), Steps: m.Steps}
+	if m.Trigger != nil {
+		f.Trigger = *m.Trigger
+	}
+	if m.Validation != nil {
+		f.Validation = *m.Validation
+	}
+	return f
+}
@@ -0,0 +1,111 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEditDocRoundTrip(t *testing.T) {
+	front := taskEditFront{Title: "Ship it", Status: "TODO", Priority: "M", Tags: []string{"cli"}}
+	doc, err := renderEditDoc("ramorie task 1234\nsecond line", front, "line one\n---\nline two")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(doc, "---\n# ramorie task 1234\n# second line\n") {
+		t.Fatalf("doc header = %q", doc)
+	}
+	var got taskEditFront
+	body, err := parseEditDoc(doc, &got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Title != "Ship it" || got.Priority != "M" || len(got.Tags) != 1 {
+		t.Errorf("front = %+v", got)
+	}
+	if body != "line one\n---\nline two" {
+		t.Errorf("body = %q", body)
+	}
+}
+
+func TestParseEditDocErrors(t *testing.T) {
+	var f taskEditFront
+	if _, err := parseEditDoc("title: x\n", &f); err == nil || !strings.Contains(err.Error(), "missing") {
+		t.Errorf("missing front matter: err = %v", err)
+	}
+	if _, err := parseEditDoc("---\ntitle: x\nbody\n", &f); err == nil || !strings.Contains(err.Error(), "unterminated") {
+		t.Errorf("unterminated front matter: err = %v", err)
+	}
+	if _, err := parseEditDoc("---\ntitle: [x\n---\n", &f); err == nil || !strings.Contains(err.Error(), "invalid") {
+		t.Errorf("invalid yaml: err = %v", err)
+	}
+	body, err := parseEditDoc("---\r\n---\r\nbody\r\n", &f)
+	if err != nil || body != "body" {
+		t.Errorf("empty front matter: body = %q, err = %v", body, err)
+	}
+}
+
+func TestTaskEditChanges(t *testing.T) {
+	before := taskEditFront{Title: "A", Status: "TODO", Priority: "M", Tags: []string{"x"}}
+
+	changes, updates, err := taskEditChanges(before, "desc\n", taskEditFront{Title: " A ", Status: "todo", Priority: "m", Tags: []string{"#x", ""}}, "desc")
+	if err != nil || len(changes) != 0 || len(updates) != 0 {
+		t.Fatalf("no-op edit: changes=%v updates=%v err=%v", changes, updates, err)
+	}
+
+	changes, updates, err = taskEditChanges(before, "desc", taskEditFront{Title: "B", Status: "in_progress", Priority: "H", Tags: []string{"x", "y"}}, "new")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 4 || updates["status"] != "IN_PROGRESS" || updates["description"] != "new" {
+		t.Errorf("changes=%v updates=%v", changes, updates)
+	}
+
+	for _, bad := range []taskEditFront{
+		{Title: "", Status: "TODO", Priority: "M"},
+		{Title: "A", Status: "DONE", Priority: "M"},
+		{Title: "A", Status: "TODO", Priority: "urgent"},
+	} {
+		if _, _, err := taskEditChanges(before, "", bad, ""); err == nil {
+			t.Errorf("%+v: expected a validation error", bad)
+		}
+	}
+}
+
+func TestMemoryEditChanges(t *testing.T) {
+	before := memoryEditFront{Type: "skill", Tags: []string{"go"}, Trigger: "on deploy", Steps: []string{"a", "b"}}
+
+	if _, _, err := memoryEditChanges(before, "body", before, "  \n"); err == nil {
+		t.Error("empty content should be rejected")
+	}
+
+	after := before
+	after.Type = ""
+	changes, updates, err := memoryEditChanges(before, "body\n", after, "body")
+	if err != nil || len(changes) != 0 || len(updates) != 0 {
+		t.Fatalf("no-op edit: changes=%v updates=%v err=%v", changes, updates, err)
+	}
+
+	after = memoryEditFront{Type: "patern", Tags: []string{"go"}}
+	if _, _, err := memoryEditChanges(before, "body", after, "body"); err == nil {
+		t.Error("unknown type should be rejected")
+	}
+
+	after = memoryEditFront{Type: "Pattern", Tags: []string{"go"}, Steps: []string{"a"}}
+	changes, updates, err = memoryEditChanges(before, "body", after, "body 2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updates["type"] != "pattern" || updates["trigger"] != "" || updates["content"] != "body 2" || len(changes) != 3 {
+		t.Errorf("changes=%v updates=%v", changes, updates)
+	}
+}
+
+func TestCleanEditTags(t *testing.T) {
+	got := cleanEditTags([]string{" go ", "#Go", "", "cli", "  "})
+	if strings.Join(got, ",") != "go,cli" {
+		t.Errorf("cleanEditTags = %v", got)
+	}
+	if got := cleanEditTags(nil); got == nil || len(got) != 0 {
+		t.Errorf("cleanEditTags(nil) = %#v, want empty slice", got)
+	}
+}
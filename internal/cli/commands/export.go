@@ -0,0 +1,683 @@
+package commands
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/kutbudev/ramorie-cli/internal/api"
+	"github.com/kutbudev/ramorie-cli/internal/cli/display"
+	"github.com/kutbudev/ramorie-cli/internal/cli/resolve"
+	"github.com/kutbudev/ramorie-cli/internal/crypto"
+	apierrors "github.com/kutbudev/ramorie-cli/internal/errors"
+	"github.com/kutbudev/ramorie-cli/internal/models"
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Supported `export` formats.
+const (
+	exportFormatCSV  = "csv"
+	exportFormatJSON = "json"
+	exportFormatMD   = "md"
+	exportFormatICS  = "ics"
+)
+
+// exportTask is the flattened, decrypted shape written by every format.
+type exportTask struct {
+	ID          string          `json:"id" yaml:"id"`
+	Title       string          `json:"title" yaml:"title"`
+	Description string          `json:"description,omitempty" yaml:"-"`
+	Status      string          `json:"status" yaml:"status"`
+	Priority    string          `json:"priority" yaml:"priority"`
+	Tags        []string        `json:"tags" yaml:"tags,omitempty"`
+	DependsOn   []exportRef     `json:"depends_on,omitempty" yaml:"depends_on,omitempty"`
+	Subtasks    []exportSubtask `json:"subtasks,omitempty" yaml:"-"`
+	Notes       []exportNote    `json:"notes,omitempty" yaml:"-"`
+	CreatedAt   time.Time       `json:"created_at" yaml:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at" yaml:"updated_at"`
+}
+
+type exportRef struct {
+	ID    string `json:"id" yaml:"id"`
+	Title string `json:"title,omitempty" yaml:"title,omitempty"`
+}
+
+type exportSubtask struct {
+	Description string `json:"description"`
+	Completed   bool   `json:"completed"`
+}
+
+type exportNote struct {
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type exportMemory struct {
+	ID           string    `json:"id" yaml:"id"`
+	Type         string    `json:"type" yaml:"type"`
+	Content      string    `json:"content" yaml:"-"`
+	Tags         []string  `json:"tags" yaml:"tags,omitempty"`
+	Visibility   string    `json:"visibility,omitempty" yaml:"visibility,omitempty"`
+	LinkedTaskID string    `json:"linked_task_id,omitempty" yaml:"linked_task,omitempty"`
+	CreatedAt    time.Time `json:"created_at" yaml:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at" yaml:"updated_at"`
+}
+
+// exportBundle is the full JSON document.
+type exportBundle struct {
+	Project    string         `json:"project"`
+	ExportedAt time.Time      `json:"exported_at"`
+	Tasks      []exportTask   `json:"tasks"`
+	Memories   []exportMemory `json:"memories"`
+	// Private is set when any exported item was decrypted; the directory
+	// tree is then written owner-only. Locked counts encrypted items left
+	// out because the vault is locked.
+	Private bool `json:"-"`
+	Locked  int  `json:"-"`
+}
+
+// NewExportCommand creates the 'export' command: `ramorie export` writes a
+// project's tasks and memories in a portable format.
+func NewExportCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "export",
+		Usage: "Export tasks and memories (csv, json, md, ics)",
+		Description: "Writes to stdout by default. With --out DIR the export becomes a directory tree:\n" +
+			"  csv   DIR/tasks.csv, DIR/memories.csv\n" +
+			"  json  DIR/<project>.json\n" +
+			"  md    DIR/tasks/<slug>.md, DIR/memories/<type>/<slug>.md (one file per item, YAML front matter)\n" +
+			"  ics   DIR/<project>.ics (tasks as VTODO, memories as VJOURNAL)\n\n" +
+			"Encrypted items are decrypted, which needs the unlocked vault; the tree is then\n" +
+			"written owner-only (files 0600). With the vault locked the export fails unless\n" +
+			"--skip-locked leaves encrypted items out.\n\n" +
+			"`ramorie export vault <dir>` writes an Obsidian vault with graph links instead.",
+		Subcommands: []*cli.Command{
+			exportVaultCmd(),
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "project", Aliases: []string{"p"}, Usage: "Project (name | short id | UUID). Optional: auto-detected when omitted."},
+			&cli.StringFlag{Name: "format", Aliases: []string{"f"}, Usage: "Output format: csv, json, md, ics", Value: exportFormatJSON},
+			&cli.StringFlag{Name: "out", Aliases: []string{"o"}, Usage: "Write a directory tree instead of streaming to stdout"},
+			&cli.BoolFlag{Name: "tasks", Usage: "Export tasks only"},
+			&cli.BoolFlag{Name: "memories", Usage: "Export memories only"},
+			&cli.BoolFlag{Name: "skip-locked", Usage: "Leave encrypted items out when the vault is locked instead of failing"},
+		},
+		Action: func(c *cli.Context) error {
+			format := strings.ToLower(strings.TrimSpace(c.String("format")))
+			switch format {
+			case exportFormatCSV, exportFormatJSON, exportFormatMD, exportFormatICS:
+			case "markdown":
+				format = exportFormatMD
+			default:
+				return fmt.Errorf("unknown format %q (use csv, json, md or ics)", format)
+			}
+			wantTasks, wantMemories := !c.Bool("memories") || c.Bool("tasks"), !c.Bool("tasks") || c.Bool("memories")
+
+			client := api.NewClient()
+			projectID, err := resolve.AutoResolveProject(c.String("project"), client)
+			if err != nil {
+				return err
+			}
+			projectName := projectID
+			if p, err := client.GetProject(projectID); err == nil && p.Name != "" {
+				projectName = p.Name
+			}
+
+			bundle := exportBundle{Project: projectName, ExportedAt: time.Now().UTC()}
+			if wantTasks {
+				if err = collectExportTasks(client, projectID, &bundle); err != nil {
+					fmt.Fprintln(os.Stderr, apierrors.ParseAPIError(err))
+					return err
+				}
+			}
+			if wantMemories {
+				if err = collectExportMemories(client, projectID, &bundle); err != nil {
+					fmt.Fprintln(os.Stderr, apierrors.ParseAPIError(err))
+					return err
+				}
+			}
+			if bundle.Locked > 0 {
+				if !c.Bool("skip-locked") {
+					return fmt.Errorf("%d encrypted item(s) cannot be exported while the vault is locked — run 'ramorie vault unlock' first, or pass --skip-locked to leave them out", bundle.Locked)
+				}
+				fmt.Fprintln(os.Stderr, display.Warn.Render(fmt.Sprintf("⚠ vault locked: skipped %d encrypted item(s) (--skip-locked)", bundle.Locked)))
+			}
+
+			if dir := c.String("out"); dir != "" {
+				files, err := writeExportTree(dir, format, &bundle)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(os.Stderr, "%s %d task(s), %d memor(ies) → %d file(s) in %s\n",
+					display.Good.Render("✓"), len(bundle.Tasks), len(bundle.Memories), files, dir)
+				return nil
+			}
+			return writeExport(os.Stdout, format, &bundle)
+		},
+	}
+}
+
+// collectExportTasks pages through every task in the project into b.Tasks
+// and attaches subtasks, notes and dependencies. Per-task lookups that fail
+// are left empty rather than aborting the export. Encrypted tasks and notes
+// are counted in b.Locked instead when the vault is locked.
+func collectExportTasks(client *api.Client, projectID string, b *exportBundle) error {
+	unlocked := crypto.IsVaultUnlocked()
+	for page := 1; ; page++ {
+		items, hasMore, err := client.ListTasksPage(projectID, "", page, 100)
+		if err != nil {
+			return err
+		}
+		for i := range items {
+			t := &items[i]
+			if t.IsEncrypted {
+				if !unlocked {
+					b.Locked++
+					continue
+				}
+				b.Private = true
+			}
+			title, desc := decryptTaskForCLI(t)
+			et := exportTask{
+				ID:          t.ID.String(),
+				Title:       title,
+				Description: desc,
+				Status:      t.Status,
+				Priority:    t.Priority,
+				Tags:        getTagsAsStrings(t.Tags),
+				CreatedAt:   t.CreatedAt,
+				UpdatedAt:   t.UpdatedAt,
+			}
+			if subs, err := client.ListSubtasks(et.ID); err == nil {
+				for _, s := range subs {
+					et.Subtasks = append(et.Subtasks, exportSubtask{Description: s.Description, Completed: subtaskDone(s)})
+				}
+			}
+			if notes, err := client.ListAnnotations(et.ID); err == nil {
+				for i := range notes {
+					if notes[i].IsEncrypted && notes[i].EncryptedContent != "" {
+						if !unlocked {
+							b.Locked++
+							continue
+						}
+						b.Private = true
+					}
+					et.Notes = append(et.Notes, exportNote{Content: decryptAnnotationForCLI(&notes[i]), CreatedAt: notes[i].CreatedAt})
+				}
+			}
+			if deps, err := client.GetTaskDependencies(et.ID); err == nil {
+				for _, d := range deps {
+					et.DependsOn = append(et.DependsOn, exportRef{ID: d.DependsOnID, Title: d.DependsOnTitle})
+				}
+			}
+			b.Tasks = append(b.Tasks, et)
+		}
+		if !hasMore {
+			return nil
+		}
+	}
+}
+
+// collectExportMemories pages through every memory in the project into
+// b.Memories, with the same locked-vault handling as collectExportTasks.
+func collectExportMemories(client *api.Client, projectID string, b *exportBundle) error {
+	unlocked := crypto.IsVaultUnlocked()
+	for page := 1; ; page++ {
+		items, hasMore, err := client.ListMemoriesPage(projectID, "", page, 100)
+		if err != nil {
+			return err
+		}
+		for i := range items {
+			m := &items[i]
+			if m.IsEncrypted && m.EncryptedContent != "" {
+				if !unlocked {
+					b.Locked++
+					continue
+				}
+				b.Private = true
+			}
+			em := exportMemory{
+				ID:         m.ID.String(),
+				Type:       m.Type,
+				Content:    decryptMemoryForCLI(m),
+				Tags:       getTagsAsStrings(m.Tags),
+				Visibility: m.Visibility,
+				CreatedAt:  m.CreatedAt,
+				UpdatedAt:  m.UpdatedAt,
+			}
+			if em.Type == "" {
+				em.Type = "general"
+			}
+			if m.LinkedTaskID != nil {
+				em.LinkedTaskID = m.LinkedTaskID.String()
+			}
+			b.Memories = append(b.Memories, em)
+		}
+		if !hasMore {
+			return nil
+		}
+	}
+}
+
+// decryptAnnotationForCLI returns a note's plaintext, decrypting when the
+// vault is unlocked.
+func decryptAnnotationForCLI(a *models.Annotation) string {
+	if !a.IsEncrypted || a.EncryptedContent == "" {
+		return a.Content
+	}
+	if !crypto.IsVaultUnlocked() {
+		return "[Vault Locked - run 'ramorie vault unlock']"
+	}
+	plaintext, err := crypto.DecryptContent(a.EncryptedContent, a.ContentNonce, true)
+	if err != nil {
+		return "[Decryption Failed]"
+	}
+	return plaintext
+}
+
+// writeExport streams the bundle to w as a single document.
+func writeExport(w io.Writer, format string, b *exportBundle) error {
+	switch format {
+	case exportFormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(b)
+	case exportFormatCSV:
+		// One table on stdout: a leading "kind" column tells rows apart.
+		cw := csv.NewWriter(w)
+		if err := cw.Write(append([]string{"kind"}, exportCSVHeader...)); err != nil {
+			return err
+		}
+		for _, t := range b.Tasks {
+			_ = cw.Write(append([]string{"task"}, exportTaskCSVRow(t)...))
+		}
+		for _, m := range b.Memories {
+			_ = cw.Write(append([]string{"memory"}, exportMemoryCSVRow(m)...))
+		}
+		cw.Flush()
+		return cw.Error()
+	case exportFormatMD:
+		for i, t := range b.Tasks {
+			if i > 0 {
+				fmt.Fprintln(w)
+			}
+			if err := writeTaskMarkdown(w, t); err != nil {
+				return err
+			}
+		}
+		for i, m := range b.Memories {
+			if i > 0 || len(b.Tasks) > 0 {
+				fmt.Fprintln(w)
+			}
+			if err := writeMemoryMarkdown(w, m); err != nil {
+				return err
+			}
+		}
+		return nil
+	case exportFormatICS:
+		return writeICS(w, b)
+	}
+	return fmt.Errorf("unknown format %q", format)
+}
+
+// writeExportTree writes the bundle under dir and returns the file count.
+// A private bundle (decrypted items) is written with mode 0600 files in 0700
+// folders.
+func writeExportTree(dir, format string, b *exportBundle) (int, error) {
+	fileMode, dirMode := os.FileMode(0o644), os.FileMode(0o755)
+	if b.Private {
+		fileMode, dirMode = 0o600, 0o700
+	}
+	if err := os.MkdirAll(dir, dirMode); err != nil {
+		return 0, err
+	}
+	writeFileWith := func(path string, fn func(io.Writer) error) error {
+		return writeExportFile(path, fileMode, dirMode, fn)
+	}
+	base := exportSlug(b.Project, "project")
+	switch format {
+	case exportFormatJSON, exportFormatICS:
+		path := filepath.Join(dir, base+"."+format)
+		return 1, writeFileWith(path, func(w io.Writer) error { return writeExport(w, format, b) })
+	case exportFormatCSV:
+		n := 0
+		if len(b.Tasks) > 0 {
+			n++
+			if err := writeFileWith(filepath.Join(dir, "tasks.csv"), func(w io.Writer) error {
+				return writeCSV(w, exportCSVHeader, len(b.Tasks), func(i int) []string { return exportTaskCSVRow(b.Tasks[i]) })
+			}); err != nil {
+				return 0, err
+			}
+		}
+		if len(b.Memories) > 0 {
+			n++
+			if err := writeFileWith(filepath.Join(dir, "memories.csv"), func(w io.Writer) error {
+				return writeCSV(w, exportCSVHeader, len(b.Memories), func(i int) []string { return exportMemoryCSVRow(b.Memories[i]) })
+			}); err != nil {
+				return n - 1, err
+			}
+		}
+		return n, nil
+	case exportFormatMD:
+		n := 0
+		used := map[string]bool{}
+		for _, t := range b.Tasks {
+			path := uniquePath(used, filepath.Join(dir, "tasks"), exportSlug(t.Title, "task"), t.ID)
+			if err := writeFileWith(path, func(w io.Writer) error { return writeTaskMarkdown(w, t) }); err != nil {
+				return n, err
+			}
+			n++
+		}
+		for _, m := range b.Memories {
+			path := uniquePath(used, filepath.Join(dir, "memories", exportSlug(m.Type, "general")), exportSlug(firstLine(m.Content), "memory"), m.ID)
+			if err := writeFileWith(path, func(w io.Writer) error { return writeMemoryMarkdown(w, m) }); err != nil {
+				return n, err
+			}
+			n++
+		}
+		return n, nil
+	}
+	return 0, fmt.Errorf("unknown format %q", format)
+}
+
+// writeExportFile writes path through fn with the given modes. An existing
+// file is truncated and chmod-ed, since OpenFile keeps its old mode.
+func writeExportFile(path string, fileMode, dirMode os.FileMode, fn func(io.Writer) error) error {
+	if err := os.MkdirAll(filepath.Dir(path), dirMode); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, fileMode)
+	if err != nil {
+		return err
+	}
+	if err := f.Chmod(fileMode); err != nil {
+		f.Close()
+		return err
+	}
+	if err := fn(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// uniquePath returns dir/slug.md, suffixing the short ID when two items
+// slugify to the same name.
+func uniquePath(used map[string]bool, dir, slug, id string) string {
+	path := filepath.Join(dir, slug+".md")
+	if used[path] {
+		short := id
+		if len(short) > 8 {
+			short = short[:8]
+		}
+		path = filepath.Join(dir, slug+"-"+short+".md")
+	}
+	used[path] = true
+	return path
+}
+
+var exportCSVHeader = []string{"id", "title", "status", "priority", "type", "tags", "depends_on", "subtasks", "notes", "content", "created_at", "updated_at"}
+
+func exportTaskCSVRow(t exportTask) []string {
+	deps := make([]string, 0, len(t.DependsOn))
+	for _, d := range t.DependsOn {
+		deps = append(deps, d.ID)
+	}
+	subs := make([]string, 0, len(t.Subtasks))
+	for _, s := range t.Subtasks {
+		mark := "[ ] "
+		if s.Completed {
+			mark = "[x] "
+		}
+		subs = append(subs, mark+s.Description)
+	}
+	notes := make([]string, 0, len(t.Notes))
+	for _, n := range t.Notes {
+		notes = append(notes, n.Content)
+	}
+	return []string{
+		t.ID, t.Title, t.Status, t.Priority, "",
+		strings.Join(t.Tags, ";"), strings.Join(deps, ";"), strings.Join(subs, "\n"), strings.Join(notes, "\n"),
+		t.Description, formatExportTime(t.CreatedAt), formatExportTime(t.UpdatedAt),
+	}
+}
+
+func exportMemoryCSVRow(m exportMemory) []string {
+	return []string{
+		m.ID, firstLine(m.Content), "", "", m.Type,
+		strings.Join(m.Tags, ";"), "", "", "",
+		m.Content, formatExportTime(m.CreatedAt), formatExportTime(m.UpdatedAt),
+	}
+}
+
+func writeCSV(w io.Writer, header []string, n int, row func(int) []string) error {
+	cw := csv.NewWriter(w)
+	_ = cw.Write(header)
+	for i := 0; i < n; i++ {
+		_ = cw.Write(row(i))
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func formatExportTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// writeFrontMatter writes a `---` delimited YAML block.
+func writeFrontMatter(w io.Writer, v interface{}) error {
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "---\n%s---\n", data)
+	return err
+}
+
+func writeTaskMarkdown(w io.Writer, t exportTask) error {
+	fm := struct {
+		exportTask `yaml:",inline"`
+		Kind       string `yaml:"kind"`
+	}{t, "task"}
+	if err := writeFrontMatter(w, fm); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "\n# %s\n", t.Title)
+	if t.Description != "" {
+		fmt.Fprintf(w, "\n%s\n", strings.TrimSpace(t.Description))
+	}
+	if len(t.Subtasks) > 0 {
+		fmt.Fprintf(w, "\n## Subtasks\n\n")
+		for _, s := range t.Subtasks {
+			mark := " "
+			if s.Completed {
+				mark = "x"
+			}
+			fmt.Fprintf(w, "- [%s] %s\n", mark, s.Description)
+		}
+	}
+	if len(t.Notes) > 0 {
+		fmt.Fprintf(w, "\n## Notes\n\n")
+		for _, n := range t.Notes {
+			fmt.Fprintf(w, "- %s — %s\n", n.CreatedAt.Format("2006-01-02"), display.SingleLine(n.Content))
+		}
+	}
+	return nil
+}
+
+func writeMemoryMarkdown(w io.Writer, m exportMemory) error {
+	fm := struct {
+		exportMemory `yaml:",inline"`
+		Kind         string `yaml:"kind"`
+	}{m, "memory"}
+	if err := writeFrontMatter(w, fm); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "\n%s\n", strings.TrimSpace(m.Content))
+	return err
+}
+
+// writeICS renders tasks as VTODO and memories as VJOURNAL entries (RFC 5545).
+func writeICS(w io.Writer, b *exportBundle) error {
+	iw := &icsWriter{w: w}
+	iw.line("BEGIN:VCALENDAR")
+	iw.line("VERSION:2.0")
+	iw.line("PRODID:-//Ramorie//ramorie-cli//EN")
+	iw.prop("X-WR-CALNAME", b.Project)
+	stamp := icsTime(b.ExportedAt)
+	for _, t := range b.Tasks {
+		iw.line("BEGIN:VTODO")
+		iw.line("UID:" + t.ID + "@ramorie")
+		iw.line("DTSTAMP:" + stamp)
+		iw.prop("SUMMARY", t.Title)
+		if t.Description != "" {
+			iw.prop("DESCRIPTION", t.Description)
+		}
+		iw.line("STATUS:" + icsTodoStatus(t.Status))
+		if p := icsPriority(t.Priority); p != "" {
+			iw.line("PRIORITY:" + p)
+		}
+		if len(t.Tags) > 0 {
+			escaped := make([]string, len(t.Tags))
+			for i, tag := range t.Tags {
+				escaped[i] = icsEscape(tag)
+			}
+			iw.line("CATEGORIES:" + strings.Join(escaped, ","))
+		}
+		if !t.CreatedAt.IsZero() {
+			iw.line("CREATED:" + icsTime(t.CreatedAt))
+		}
+		if !t.UpdatedAt.IsZero() {
+			iw.line("LAST-MODIFIED:" + icsTime(t.UpdatedAt))
+		}
+		// RFC 9253 DEPENDS-ON: this task cannot finish before the related one.
+		for _, d := range t.DependsOn {
+			iw.line("RELATED-TO;RELTYPE=DEPENDS-ON:" + d.ID + "@ramorie")
+		}
+		iw.line("END:VTODO")
+	}
+	for _, m := range b.Memories {
+		iw.line("BEGIN:VJOURNAL")
+		iw.line("UID:" + m.ID + "@ramorie")
+		iw.line("DTSTAMP:" + stamp)
+		if !m.CreatedAt.IsZero() {
+			iw.line("DTSTART:" + icsTime(m.CreatedAt))
+		}
+		iw.prop("SUMMARY", firstLine(m.Content))
+		iw.prop("DESCRIPTION", m.Content)
+		cats := append([]string{m.Type}, m.Tags...)
+		for i := range cats {
+			cats[i] = icsEscape(cats[i])
+		}
+		iw.line("CATEGORIES:" + strings.Join(cats, ","))
+		iw.line("END:VJOURNAL")
+	}
+	iw.line("END:VCALENDAR")
+	return iw.err
+}
+
+// icsWriter emits CRLF-terminated content lines folded at 75 octets.
+type icsWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (iw *icsWriter) prop(name, value string) {
+	iw.line(name + ":" + icsEscape(value))
+}
+
+func (iw *icsWriter) line(s string) {
+	if iw.err != nil {
+		return
+	}
+	_, iw.err = io.WriteString(iw.w, icsFold(s))
+}
+
+// icsFold splits a content line into 75-octet chunks without breaking UTF-8
+// sequences; continuation lines start with a single space.
+func icsFold(s string) string {
+	var b strings.Builder
+	limit := 75
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(s[cut]) {
+			cut--
+		}
+		b.WriteString(s[:cut])
+		b.WriteString("\r\n ")
+		s = s[cut:]
+		limit = 74 // the leading space counts towards the next line
+	}
+	b.WriteString(s)
+	b.WriteString("\r\n")
+	return b.String()
+}
+
+func isRuneStart(c byte) bool { return c&0xC0 != 0x80 }
+
+var icsEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+func icsEscape(s string) string { return icsEscaper.Replace(s) }
+
+func icsTime(t time.Time) string { return t.UTC().Format("20060102T150405Z") }
+
+func icsTodoStatus(status string) string {
+	switch strings.ToUpper(status) {
+	case "COMPLETED", "DONE":
+		return "COMPLETED"
+	case "IN_PROGRESS":
+		return "IN-PROCESS"
+	case "CANCELLED", "CANCELED":
+		return "CANCELLED"
+	}
+	return "NEEDS-ACTION"
+}
+
+// icsPriority maps H/M/L onto the RFC 5545 1 (high) … 9 (low) scale.
+func icsPriority(p string) string {
+	switch strings.ToUpper(p) {
+	case "H":
+		return "1"
+	case "M":
+		return "5"
+	case "L":
+		return "9"
+	}
+	return ""
+}
+
+var slugStripRe = regexp.MustCompile(`[^\p{L}\p{N}]+`)
+
+// exportSlug makes a filesystem-safe file name, capped at 60 runes.
+func exportSlug(s, fallback string) string {
+	slug := strings.Trim(slugStripRe.ReplaceAllString(strings.ToLower(s), "-"), "-")
+	if r := []rune(slug); len(r) > 60 {
+		slug = strings.TrimRight(string(r[:60]), "-")
+	}
+	if slug == "" {
+		return fallback
+	}
+	return slug
+}
+
+// firstLine returns the first non-empty line of s with Markdown heading
+// markers stripped, capped at 80 runes.
+func firstLine(s string) string {
+	for _, l := range strings.Split(s, "\n") {
+		l = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(l), "#"))
+		if l != "" {
+			return display.Truncate(l, 80)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,173 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func sampleExportBundle() *exportBundle {
+	ts := time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)
+	return &exportBundle{
+		Project:    "Docs Site",
+		ExportedAt: ts,
+		Tasks: []exportTask{{
+			ID:          "11111111-aaaa-bbbb-cccc-000000000001",
+			Title:       "Write: install guide",
+			Description: "Cover brew, npm; and curl installs",
+			Status:      "IN_PROGRESS",
+			Priority:    "H",
+			Tags:        []string{"docs"},
+			DependsOn:   []exportRef{{ID: "22222222-aaaa-bbbb-cccc-000000000002", Title: "Ship 1.0"}},
+			Subtasks:    []exportSubtask{{"brew", true}, {"npm", false}},
+			Notes:       []exportNote{{Content: "ask Ada", CreatedAt: ts}},
+			CreatedAt:   ts,
+			UpdatedAt:   ts,
+		}},
+		Memories: []exportMemory{{
+			ID:        "33333333-aaaa-bbbb-cccc-000000000003",
+			Type:      "decision",
+			Content:   "# Use Hugo\nStatic output keeps hosting free.",
+			Tags:      []string{"docs", "infra"},
+			CreatedAt: ts,
+			UpdatedAt: ts,
+		}},
+	}
+}
+
+func TestWriteExport_CSVStdout(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeExport(&buf, exportFormatCSV, sampleExportBundle()); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v", err)
+	}
+	if len(rows) != 3 || rows[0][0] != "kind" || rows[1][0] != "task" || rows[2][0] != "memory" {
+		t.Fatalf("unexpected rows: %v", rows)
+	}
+	if rows[1][8] != "[x] brew\n[ ] npm" {
+		t.Errorf("subtasks column = %q", rows[1][8])
+	}
+	if rows[2][2] != "Use Hugo" {
+		t.Errorf("memory title column = %q", rows[2][2])
+	}
+}
+
+func TestWriteTaskMarkdown_FrontMatter(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeTaskMarkdown(&buf, sampleExportBundle().Tasks[0]); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"---\nid: 11111111-aaaa-bbbb-cccc-000000000001\n",
+		"title: 'Write: install guide'\n",
+		"depends_on:\n    - id: 22222222-aaaa-bbbb-cccc-000000000002\n",
+		"kind: task\n---\n",
+		"# Write: install guide\n",
+		"- [x] brew\n- [ ] npm\n",
+		"- 2024-05-01 — ask Ada\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("markdown missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteICS_EscapesAndFolds(t *testing.T) {
+	b := sampleExportBundle()
+	b.Tasks[0].Description = strings.Repeat("long line ", 20)
+	var buf bytes.Buffer
+	if err := writeICS(&buf, b); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"SUMMARY:Write: install guide\r\n",
+		"STATUS:IN-PROCESS\r\n",
+		"PRIORITY:1\r\n",
+		"RELATED-TO;RELTYPE=DEPENDS-ON:22222222-aaaa-bbbb-cccc-000000000002@ramorie\r\n",
+		"BEGIN:VJOURNAL\r\n",
+		"CATEGORIES:decision,docs,infra\r\n",
+		"DESCRIPTION:# Use Hugo\\nStatic output keeps hosting free.\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("ics missing %q", want)
+		}
+	}
+	for _, l := range strings.Split(out, "\r\n") {
+		if len(l) > 75 {
+			t.Errorf("line exceeds 75 octets: %q", l)
+		}
+	}
+}
+
+func TestICSFold_KeepsRunesIntact(t *testing.T) {
+	folded := icsFold("SUMMARY:" + strings.Repeat("ş", 60))
+	for _, l := range strings.Split(strings.TrimSuffix(folded, "\r\n"), "\r\n") {
+		if !strings.HasPrefix(l, "SUMMARY") && !strings.HasPrefix(l, " ") {
+			t.Fatalf("continuation line must start with a space: %q", l)
+		}
+		if !utf8.ValidString(l) {
+			t.Fatalf("fold split a rune: %q", l)
+		}
+	}
+}
+
+func TestWriteExportTree_Markdown(t *testing.T) {
+	dir := t.TempDir()
+	b := sampleExportBundle()
+	// Two tasks that slugify identically get distinct files.
+	dup := b.Tasks[0]
+	dup.ID = "44444444-aaaa-bbbb-cccc-000000000004"
+	b.Tasks = append(b.Tasks, dup)
+
+	n, err := writeExportTree(dir, exportFormatMD, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 3 {
+		t.Fatalf("want 3 files, got %d", n)
+	}
+	for _, rel := range []string{
+		"tasks/write-install-guide.md",
+		"tasks/write-install-guide-44444444.md",
+		"memories/decision/use-hugo.md",
+	} {
+		if _, err := os.Stat(filepath.Join(dir, rel)); err != nil {
+			t.Errorf("expected %s: %v", rel, err)
+		}
+	}
+}
+
+func TestWriteExportTree_PrivateIsOwnerOnly(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "out")
+	b := sampleExportBundle()
+	b.Private = true
+
+	if _, err := writeExportTree(dir, exportFormatMD, b); err != nil {
+		t.Fatal(err)
+	}
+	for rel, want := range map[string]os.FileMode{
+		".":                             0o700,
+		"tasks":                         0o700,
+		"tasks/write-install-guide.md":  0o600,
+		"memories/decision/use-hugo.md": 0o600,
+	} {
+		info, err := os.Stat(filepath.Join(dir, rel))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := info.Mode().Perm(); got != want {
+			t.Errorf("%s: mode %o, want %o", rel, got, want)
+		}
+	}
+}
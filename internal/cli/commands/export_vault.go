@@ -0,0 +1,596 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/kutbudev/ramorie-cli/internal/api"
+	"github.com/kutbudev/ramorie-cli/internal/cli/display"
+	"github.com/kutbudev/ramorie-cli/internal/cli/resolve"
+	"github.com/kutbudev/ramorie-cli/internal/crypto"
+	apierrors "github.com/kutbudev/ramorie-cli/internal/errors"
+	"github.com/kutbudev/ramorie-cli/internal/models"
+	"github.com/urfave/cli/v2"
+)
+
+// vaultManifest maps the files a vault export owns, relative to the vault
+// root, to the memory or entity ID each was written for. Re-exports only
+// delete files listed here, never the user's own notes.
+const vaultManifest = ".ramorie-vault.json"
+
+// vaultMemory is a memory plus the graph data a vault note links to.
+type vaultMemory struct {
+	exportMemory
+	Importance *float64
+	Encrypted  bool     // a vault memory, rendered decrypted unless Locked
+	Locked     bool     // encrypted and not decrypted: keep the previous file
+	EntityIDs  []string // from GetMemoryEntities
+}
+
+// vaultBundle is everything `export vault` renders.
+type vaultBundle struct {
+	Project  string
+	Memories []vaultMemory
+	Tasks    map[string]string // task ID → title, for linked tasks ("" when encrypted and not decrypted)
+	// PrivateTasks marks linked tasks whose title was decrypted.
+	PrivateTasks map[string]bool
+	Entities     []models.Entity
+	Relations    map[string][]models.EntityRelationship // entity ID → outgoing edges
+}
+
+// vaultFiles is a rendered vault: relative path → content, plus the paths
+// whose previous content must be kept. IDs records the item behind each
+// path for the manifest. Private is set when decrypted memories were
+// rendered; the vault is then written owner-only.
+type vaultFiles struct {
+	Files   map[string][]byte
+	Keep    map[string]bool
+	IDs     map[string]string
+	Private bool
+}
+
+var vaultNameStripRe = regexp.MustCompile(`[\[\]#^|\\/:*?"<>\x00-\x1f]+`)
+
+// vaultNoteName makes a readable Obsidian note name: characters that break
+// wikilinks or file names are dropped, spaces are kept.
+func vaultNoteName(s, fallback string) string {
+	name := strings.Join(strings.Fields(vaultNameStripRe.ReplaceAllString(s, " ")), " ")
+	name = strings.TrimLeft(name, ".")
+	if r := []rune(name); len(r) > 80 {
+		name = strings.TrimSpace(string(r[:80]))
+	}
+	if name == "" {
+		return fallback
+	}
+	return name
+}
+
+// vaultPaths assigns each item a unique note path under dir, suffixing the
+// short ID on collisions (compared case-insensitively, as on macOS).
+type vaultPaths struct {
+	used map[string]bool
+}
+
+func (p *vaultPaths) assign(dir, name, id string) string {
+	rel := path.Join(dir, name+".md")
+	if p.used[strings.ToLower(rel)] {
+		rel = path.Join(dir, name+" "+shortID(id)+".md")
+	}
+	p.used[strings.ToLower(rel)] = true
+	return rel
+}
+
+// vaultLink is an Obsidian wikilink to a note path, shown as label.
+func vaultLink(rel, label string) string {
+	return "[[" + strings.TrimSuffix(rel, ".md") + "|" + label + "]]"
+}
+
+// buildVault renders the bundle as Obsidian notes: Memories/<type>/<title>.md
+// and Entities/<type>/<name>.md, linked both ways. Output is deterministic,
+// so unchanged items render byte-identical files. previous is the last
+// manifest; locked memories keep the note they were exported to.
+func buildVault(b *vaultBundle, previous map[string]string) (vaultFiles, error) {
+	out := vaultFiles{Files: map[string][]byte{}, Keep: map[string]bool{}, IDs: map[string]string{}}
+	paths := &vaultPaths{used: map[string]bool{}}
+	previousPath := map[string]string{}
+	for rel, id := range previous {
+		previousPath[id] = rel
+	}
+
+	memories := append([]vaultMemory(nil), b.Memories...)
+	sort.SliceStable(memories, func(i, j int) bool {
+		if !memories[i].CreatedAt.Equal(memories[j].CreatedAt) {
+			return memories[i].CreatedAt.Before(memories[j].CreatedAt)
+		}
+		return memories[i].ID < memories[j].ID
+	})
+	entities := append([]models.Entity(nil), b.Entities...)
+	sort.SliceStable(entities, func(i, j int) bool {
+		if !strings.EqualFold(entities[i].Name, entities[j].Name) {
+			return strings.ToLower(entities[i].Name) < strings.ToLower(entities[j].Name)
+		}
+		return entities[i].ID.String() < entities[j].ID.String()
+	})
+
+	memPath := map[string]string{}
+	memTitle := map[string]string{}
+	for _, m := range memories {
+		if !m.Locked {
+			continue
+		}
+		if rel, ok := previousPath[m.ID]; ok {
+			memPath[m.ID] = rel
+			memTitle[m.ID] = strings.TrimSuffix(path.Base(rel), ".md")
+			paths.used[strings.ToLower(rel)] = true
+		}
+	}
+	for _, m := range memories {
+		if m.Locked {
+			continue
+		}
+		memTitle[m.ID] = vaultNoteName(firstLine(m.Content), "memory "+shortID(m.ID))
+		memPath[m.ID] = paths.assign(path.Join("Memories", exportSlug(m.Type, "general")), memTitle[m.ID], m.ID)
+	}
+	entPath := map[string]string{}
+	entName := map[string]string{}
+	for _, e := range entities {
+		id := e.ID.String()
+		entName[id] = vaultNoteName(e.Name, "entity "+shortID(id))
+		entPath[id] = paths.assign(path.Join("Entities", exportSlug(string(e.Type), "other")), entName[id], id)
+	}
+
+	mentions := map[string][]string{} // entity ID → memory IDs
+	for _, m := range memories {
+		rel, ok := memPath[m.ID]
+		if !ok {
+			continue // locked and never exported: nothing to show
+		}
+		out.IDs[rel] = m.ID
+		for _, eid := range m.EntityIDs {
+			mentions[eid] = append(mentions[eid], m.ID)
+		}
+		if m.Locked {
+			out.Keep[rel] = true
+			continue
+		}
+		out.Private = out.Private || m.Encrypted || b.PrivateTasks[m.LinkedTaskID]
+		var buf bytes.Buffer
+		if err := writeVaultMemory(&buf, b, m, entPath, entName); err != nil {
+			return out, err
+		}
+		out.Files[rel] = buf.Bytes()
+	}
+
+	incoming := map[string][]models.EntityRelationship{}
+	for _, edges := range b.Relations {
+		for _, r := range edges {
+			incoming[r.TargetEntityID.String()] = append(incoming[r.TargetEntityID.String()], r)
+		}
+	}
+	for _, e := range entities {
+		id := e.ID.String()
+		var buf bytes.Buffer
+		fm := struct {
+			ID         string   `yaml:"id"`
+			Kind       string   `yaml:"kind"`
+			EntityType string   `yaml:"entity_type"`
+			Aliases    []string `yaml:"aliases,omitempty"`
+		}{id, "entity", string(e.Type), e.Aliases}
+		if err := writeFrontMatter(&buf, fm); err != nil {
+			return out, err
+		}
+		fmt.Fprintf(&buf, "\n# %s\n", e.Name)
+		if e.Description != nil && strings.TrimSpace(*e.Description) != "" {
+			fmt.Fprintf(&buf, "\n%s\n", strings.TrimSpace(*e.Description))
+		}
+		var rels []string
+		for _, r := range b.Relations[id] {
+			rels = append(rels, fmt.Sprintf("- %s → %s", r.RelationshipType, vaultEntityRef(r.TargetEntityID.String(), r.TargetEntity, entPath, entName)))
+		}
+		for _, r := range incoming[id] {
+			rels = append(rels, fmt.Sprintf("- ← %s %s", r.RelationshipType, vaultEntityRef(r.SourceEntityID.String(), r.SourceEntity, entPath, entName)))
+		}
+		sort.Strings(rels)
+		if len(rels) > 0 {
+			fmt.Fprintf(&buf, "\n## Relationships\n\n%s\n", strings.Join(rels, "\n"))
+		}
+		if ids := mentions[id]; len(ids) > 0 {
+			fmt.Fprintf(&buf, "\n## Mentioned in\n\n")
+			for _, mid := range ids {
+				fmt.Fprintf(&buf, "- %s\n", vaultLink(memPath[mid], memTitle[mid]))
+			}
+		}
+		out.Files[entPath[id]] = buf.Bytes()
+		out.IDs[entPath[id]] = id
+	}
+	return out, nil
+}
+
+// vaultEntityRef links to an exported entity, or names one outside the
+// export (which Obsidian shows as an unresolved link).
+func vaultEntityRef(id string, loaded *models.Entity, entPath, entName map[string]string) string {
+	if rel, ok := entPath[id]; ok {
+		return vaultLink(rel, entName[id])
+	}
+	if loaded != nil && loaded.Name != "" {
+		return "[[" + vaultNoteName(loaded.Name, shortID(id)) + "]]"
+	}
+	return "`" + shortID(id) + "`"
+}
+
+func writeVaultMemory(buf *bytes.Buffer, b *vaultBundle, m vaultMemory, entPath, entName map[string]string) error {
+	type taskRef struct {
+		ID    string `yaml:"id"`
+		Title string `yaml:"title,omitempty"`
+	}
+	fm := struct {
+		ID          string    `yaml:"id"`
+		Kind        string    `yaml:"kind"`
+		Type        string    `yaml:"type"`
+		Tags        []string  `yaml:"tags,omitempty"`
+		Importance  *float64  `yaml:"importance,omitempty"`
+		Visibility  string    `yaml:"visibility,omitempty"`
+		Project     string    `yaml:"project,omitempty"`
+		Created     string    `yaml:"created"`
+		Updated     string    `yaml:"updated"`
+		LinkedTasks []taskRef `yaml:"linked_tasks,omitempty"`
+	}{
+		ID: m.ID, Kind: "memory", Type: m.Type, Tags: m.Tags, Importance: m.Importance,
+		Visibility: m.Visibility, Project: b.Project,
+		Created: formatExportTime(m.CreatedAt), Updated: formatExportTime(m.UpdatedAt),
+	}
+	if m.LinkedTaskID != "" {
+		fm.LinkedTasks = []taskRef{{ID: m.LinkedTaskID, Title: b.Tasks[m.LinkedTaskID]}}
+	}
+	if err := writeFrontMatter(buf, fm); err != nil {
+		return err
+	}
+	fmt.Fprintf(buf, "\n%s\n", strings.TrimSpace(m.Content))
+	var links []string
+	for _, eid := range m.EntityIDs {
+		if rel, ok := entPath[eid]; ok {
+			links = append(links, "- "+vaultLink(rel, entName[eid]))
+		}
+	}
+	if len(links) > 0 {
+		fmt.Fprintf(buf, "\n## Entities\n\n%s\n", strings.Join(links, "\n"))
+	}
+	return nil
+}
+
+// vaultSync is the outcome of writing a vault.
+type vaultSync struct {
+	Written, Unchanged, Removed int
+}
+
+// vaultRelPath reports whether rel is a relative path that stays inside the
+// vault root.
+func vaultRelPath(rel string) bool {
+	if rel == "" || path.IsAbs(rel) || filepath.IsAbs(filepath.FromSlash(rel)) || filepath.VolumeName(rel) != "" {
+		return false
+	}
+	for _, part := range strings.FieldsFunc(rel, func(r rune) bool { return r == '/' || r == '\\' }) {
+		if part == ".." {
+			return false
+		}
+	}
+	return true
+}
+
+// loadVaultManifest reads the manifest of a previous export into dir, if
+// any. Re-exports delete the files it lists, so every entry must be a
+// relative path inside the vault.
+func loadVaultManifest(dir string) (map[string]string, error) {
+	previous := map[string]string{}
+	data, err := os.ReadFile(filepath.Join(dir, vaultManifest))
+	if os.IsNotExist(err) {
+		return previous, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &previous); err != nil {
+		return nil, fmt.Errorf("corrupt %s: %w", vaultManifest, err)
+	}
+	for rel := range previous {
+		if !vaultRelPath(rel) {
+			return nil, fmt.Errorf("%s lists %q, which is outside the vault — fix or delete the manifest", vaultManifest, rel)
+		}
+	}
+	return previous, nil
+}
+
+// syncVault writes files under dir, touching only files whose content
+// changed, and removes files the previous export wrote that are gone now.
+// A private vault (decrypted memories) is written with mode 0600 files in
+// 0700 folders.
+func syncVault(dir string, v vaultFiles, previous map[string]string) (vaultSync, error) {
+	var s vaultSync
+	fileMode, dirMode := os.FileMode(0o644), os.FileMode(0o755)
+	if v.Private {
+		fileMode, dirMode = 0o600, 0o700
+	}
+	if err := os.MkdirAll(dir, dirMode); err != nil {
+		return s, err
+	}
+	root := filepath.Clean(dir)
+	for rel, content := range v.Files {
+		if !vaultRelPath(rel) {
+			return s, fmt.Errorf("refusing to write %q outside the vault", rel)
+		}
+		full := filepath.Join(dir, filepath.FromSlash(rel))
+		if old, err := os.ReadFile(full); err == nil && bytes.Equal(old, content) {
+			s.Unchanged++
+			if v.Private {
+				if err := os.Chmod(full, fileMode); err != nil {
+					return s, err
+				}
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(full), dirMode); err != nil {
+			return s, err
+		}
+		if err := os.WriteFile(full, content, fileMode); err != nil {
+			return s, err
+		}
+		// WriteFile keeps the mode of an existing file.
+		if err := os.Chmod(full, fileMode); err != nil {
+			return s, err
+		}
+		s.Written++
+	}
+	for rel := range previous {
+		if _, ok := v.IDs[rel]; ok {
+			continue
+		}
+		if !vaultRelPath(rel) {
+			return s, fmt.Errorf("refusing to remove %q outside the vault", rel)
+		}
+		full := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.Remove(full); err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return s, err
+		}
+		s.Removed++
+		// Drop folders the removal emptied, up to the vault root.
+		for d := filepath.Dir(full); d != root; d = filepath.Dir(d) {
+			if os.Remove(d) != nil {
+				break
+			}
+		}
+	}
+
+	data, err := json.MarshalIndent(v.IDs, "", "  ")
+	if err != nil {
+		return s, err
+	}
+	return s, os.WriteFile(filepath.Join(dir, vaultManifest), data, fileMode)
+}
+
+// vaultFetchWorkers bounds the concurrent per-item graph requests.
+const vaultFetchWorkers = 8
+
+// fetchEach calls fetch for 0..n-1 on a few workers and returns the first
+// error by index.
+func fetchEach(n int, fetch func(i int) error) error {
+	errs := make([]error, n)
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(vaultFetchWorkers, n); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				errs[i] = fetch(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// collectVault gathers memories, linked task titles and, unless graph is
+// false, the project's entities with their relationships. Encrypted
+// memories and linked task titles are decrypted only when decrypt is set
+// and the vault is unlocked; otherwise memories count as locked and tasks
+// are linked by ID alone.
+func collectVault(client *api.Client, projectID, projectName string, graph, decrypt bool) (*vaultBundle, error) {
+	b := &vaultBundle{Project: projectName, Tasks: map[string]string{}, PrivateTasks: map[string]bool{}, Relations: map[string][]models.EntityRelationship{}}
+	for page := 1; ; page++ {
+		items, hasMore, err := client.ListMemoriesPage(projectID, "", page, 100)
+		if err != nil {
+			return nil, err
+		}
+		for i := range items {
+			m := &items[i]
+			vm := vaultMemory{
+				exportMemory: exportMemory{
+					ID:         m.ID.String(),
+					Type:       m.Type,
+					Tags:       getTagsAsStrings(m.Tags),
+					Visibility: m.Visibility,
+					CreatedAt:  m.CreatedAt,
+					UpdatedAt:  m.UpdatedAt,
+				},
+				Importance: m.Importance,
+				Encrypted:  m.IsEncrypted,
+				Locked:     m.IsEncrypted && !(decrypt && crypto.IsVaultUnlocked()),
+			}
+			if !vm.Locked {
+				vm.Content = decryptMemoryForCLI(m)
+			}
+			if vm.Type == "" {
+				vm.Type = "general"
+			}
+			if m.LinkedTaskID != nil {
+				vm.LinkedTaskID = m.LinkedTaskID.String()
+			}
+			b.Memories = append(b.Memories, vm)
+		}
+		if !hasMore || len(items) == 0 {
+			break
+		}
+	}
+
+	needTasks := false
+	for _, m := range b.Memories {
+		needTasks = needTasks || m.LinkedTaskID != ""
+	}
+	for page := 1; needTasks; page++ {
+		items, hasMore, err := client.ListTasksPage(projectID, "", page, 100)
+		if err != nil {
+			return nil, err
+		}
+		for i := range items {
+			id := items[i].ID.String()
+			if !items[i].IsEncrypted {
+				b.Tasks[id] = items[i].Title
+				continue
+			}
+			// Encrypted titles follow the memories: plaintext only under
+			// --decrypt, otherwise the front matter keeps just the ID.
+			if decrypt && crypto.IsVaultUnlocked() {
+				b.Tasks[id], _ = decryptTaskForCLI(&items[i])
+				b.PrivateTasks[id] = true
+			} else {
+				b.Tasks[id] = ""
+			}
+		}
+		if !hasMore || len(items) == 0 {
+			break
+		}
+	}
+
+	if !graph {
+		return b, nil
+	}
+	if err := fetchEach(len(b.Memories), func(i int) error {
+		res, err := client.GetMemoryEntities(b.Memories[i].ID)
+		if err != nil {
+			return fmt.Errorf("entities of memory %s: %w", shortID(b.Memories[i].ID), err)
+		}
+		for _, e := range res.Entities {
+			b.Memories[i].EntityIDs = append(b.Memories[i].EntityIDs, e.ID.String())
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	for offset := 0; ; {
+		res, err := client.ListEntities("", projectID, "", 100, offset)
+		if err != nil {
+			return nil, err
+		}
+		b.Entities = append(b.Entities, res.Entities...)
+		offset += len(res.Entities)
+		if len(res.Entities) == 0 || int64(offset) >= res.Total {
+			break
+		}
+	}
+	relations := make([][]models.EntityRelationship, len(b.Entities))
+	if err := fetchEach(len(b.Entities), func(i int) error {
+		res, err := client.GetEntityRelationships(b.Entities[i].ID.String(), "out")
+		if err != nil {
+			return fmt.Errorf("relationships of entity %s: %w", b.Entities[i].Name, err)
+		}
+		relations[i] = res.RelationshipsOut
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	for i, e := range b.Entities {
+		if len(relations[i]) > 0 {
+			b.Relations[e.ID.String()] = relations[i]
+		}
+	}
+	return b, nil
+}
+
+// exportVaultCmd implements `ramorie export vault`.
+func exportVaultCmd() *cli.Command {
+	return &cli.Command{
+		Name:      "vault",
+		Aliases:   []string{"obsidian"},
+		Usage:     "Export memories as an Obsidian-compatible Markdown vault",
+		ArgsUsage: "<dir>",
+		Description: "Writes one note per memory under Memories/<type>/ with YAML front matter (type,\n" +
+			"tags, importance, created/updated, linked tasks), and one note per knowledge-graph\n" +
+			"entity under Entities/<type>/. Memories link to the entities they mention;\n" +
+			"entities link to related entities and back to the memories.\n\n" +
+			"Re-exporting into the same directory rewrites only notes whose content changed\n" +
+			"and removes notes for deleted items. Files the export did not create are never\n" +
+			"touched. Encrypted memories are written in plaintext only with --decrypt and an\n" +
+			"unlocked vault, and the vault is then written owner-only (files 0600); otherwise\n" +
+			"their previously exported notes are left as they are.",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "project", Aliases: []string{"p"}, Usage: "Project (name | short id | UUID). Optional: auto-detected when omitted."},
+			&cli.BoolFlag{Name: "no-graph", Usage: "Skip entity notes and entity links"},
+			&cli.BoolFlag{Name: "decrypt", Usage: "Write encrypted memories in plaintext (needs the unlocked vault; files are 0600)"},
+		},
+		Action: func(c *cli.Context) error {
+			if c.NArg() == 0 {
+				return fmt.Errorf("usage: ramorie export vault <dir> [--project name]")
+			}
+			dir := c.Args().First()
+
+			client := api.NewClient()
+			projectID, err := resolve.AutoResolveProject(c.String("project"), client)
+			if err != nil {
+				return err
+			}
+			projectName := projectID
+			if p, err := client.GetProject(projectID); err == nil && p.Name != "" {
+				projectName = p.Name
+			}
+
+			b, err := collectVault(client, projectID, projectName, !c.Bool("no-graph"), c.Bool("decrypt"))
+			if err != nil {
+				fmt.Fprintln(os.Stderr, apierrors.ParseAPIError(err))
+				return err
+			}
+			previous, err := loadVaultManifest(dir)
+			if err != nil {
+				return err
+			}
+			files, err := buildVault(b, previous)
+			if err != nil {
+				return err
+			}
+			s, err := syncVault(dir, files, previous)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(os.Stderr, "%s %d memor(ies), %d entit(ies) → %s: %d written, %d unchanged, %d removed\n",
+				display.Good.Render("✓"), len(b.Memories), len(b.Entities), dir, s.Written, s.Unchanged, s.Removed)
+			if len(files.Keep) > 0 {
+				hint := "pass --decrypt to refresh them"
+				if c.Bool("decrypt") {
+					hint = "run 'ramorie vault unlock' to refresh them"
+				}
+				fmt.Fprintln(os.Stderr, display.Dim.Render(fmt.Sprintf("  %d encrypted memor(ies) kept from the last export — %s", len(files.Keep), hint)))
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,186 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kutbudev/ramorie-cli/internal/models"
+)
+
+func sampleVaultBundle() *vaultBundle {
+	ts := time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)
+	redis, pg := uuid.MustParse("aaaaaaaa-0000-0000-0000-000000000001"), uuid.MustParse("bbbbbbbb-0000-0000-0000-000000000002")
+	importance := 0.8
+	desc := "In-memory cache"
+	return &vaultBundle{
+		Project: "Docs Site",
+		Memories: []vaultMemory{{
+			exportMemory: exportMemory{
+				ID: "33333333-aaaa-bbbb-cccc-000000000003", Type: "decision",
+				Content: "# Use Redis: for sessions\nPostgres stays the source of truth.",
+				Tags:    []string{"infra"}, LinkedTaskID: "11111111-aaaa-bbbb-cccc-000000000001",
+				CreatedAt: ts, UpdatedAt: ts,
+			},
+			Importance: &importance,
+			EntityIDs:  []string{redis.String(), pg.String()},
+		}},
+		Tasks: map[string]string{"11111111-aaaa-bbbb-cccc-000000000001": "Add session cache"},
+		Entities: []models.Entity{
+			{ID: pg, Name: "Postgres", Type: models.GraphEntityTypeTool},
+			{ID: redis, Name: "Redis", Type: models.GraphEntityTypeTool, Description: &desc, Aliases: []string{"redis-server"}},
+		},
+		Relations: map[string][]models.EntityRelationship{
+			redis.String(): {{SourceEntityID: redis, TargetEntityID: pg, RelationshipType: models.RelationshipRelatedTo}},
+		},
+	}
+}
+
+func TestBuildVault(t *testing.T) {
+	v, err := buildVault(sampleVaultBundle(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mem := string(v.Files["Memories/decision/Use Redis for sessions.md"])
+	for _, want := range []string{
+		"type: decision", "importance: 0.8", "created: \"2024-05-01T09:30:00Z\"",
+		"title: Add session cache", "- [[Entities/tool/Redis|Redis]]", "- [[Entities/tool/Postgres|Postgres]]",
+	} {
+		if !strings.Contains(mem, want) {
+			t.Errorf("memory note missing %q:\n%s", want, mem)
+		}
+	}
+	redis := string(v.Files["Entities/tool/Redis.md"])
+	for _, want := range []string{"aliases:\n    - redis-server", "In-memory cache", "- related_to → [[Entities/tool/Postgres|Postgres]]", "- [[Memories/decision/Use Redis for sessions|Use Redis for sessions]]"} {
+		if !strings.Contains(redis, want) {
+			t.Errorf("entity note missing %q:\n%s", want, redis)
+		}
+	}
+	if pg := string(v.Files["Entities/tool/Postgres.md"]); !strings.Contains(pg, "- ← related_to [[Entities/tool/Redis|Redis]]") {
+		t.Errorf("incoming edge missing:\n%s", pg)
+	}
+	if len(v.Files) != 3 || len(v.IDs) != 3 {
+		t.Errorf("files = %d ids = %d", len(v.Files), len(v.IDs))
+	}
+}
+
+func TestBuildVaultDecryptedTaskTitleIsPrivate(t *testing.T) {
+	b := sampleVaultBundle()
+	if v, err := buildVault(b, nil); err != nil || v.Private {
+		t.Fatalf("plain bundle: private=%v err=%v", v.Private, err)
+	}
+	b.PrivateTasks = map[string]bool{"11111111-aaaa-bbbb-cccc-000000000001": true}
+	v, err := buildVault(b, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !v.Private {
+		t.Error("a rendered decrypted task title should make the vault private")
+	}
+}
+
+func TestSyncVaultIsIncremental(t *testing.T) {
+	dir := t.TempDir()
+	b := sampleVaultBundle()
+	export := func() vaultSync {
+		t.Helper()
+		previous, err := loadVaultManifest(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		v, err := buildVault(b, previous)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s, err := syncVault(dir, v, previous)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+	mine := filepath.Join(dir, "My own note.md")
+	if err := os.WriteFile(mine, []byte("hands off"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if s := export(); s.Written != 3 {
+		t.Fatalf("first export = %+v", s)
+	}
+	if s := export(); s.Written != 0 || s.Unchanged != 3 {
+		t.Errorf("re-export should touch nothing: %+v", s)
+	}
+
+	// A locked memory keeps its note; a dropped entity loses its note.
+	b.Memories[0].Locked, b.Memories[0].Content = true, "[Vault Locked - run 'ramorie vault unlock']"
+	b.Entities = b.Entities[1:]
+	b.Relations = nil
+	s := export()
+	if s.Removed != 1 || s.Written != 1 {
+		t.Errorf("after change = %+v", s)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "Memories/decision/Use Redis for sessions.md")); !strings.Contains(string(data), "source of truth") {
+		t.Error("locked memory's note was overwritten")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "Entities/tool/Postgres.md")); !os.IsNotExist(err) {
+		t.Error("stale entity note should be removed")
+	}
+	if _, err := os.Stat(mine); err != nil {
+		t.Error("files the export did not write must be left alone")
+	}
+}
+
+func TestVaultNoteName(t *testing.T) {
+	cases := map[string]string{
+		"Use Redis: for [[sessions]]": "Use Redis for sessions",
+		"a/b\\c|d#e^f":                "a b c d e f",
+		"...hidden":                   "hidden",
+		"   ":                         "fallback",
+	}
+	for in, want := range cases {
+		if got := vaultNoteName(in, "fallback"); got != want {
+			t.Errorf("vaultNoteName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestVaultManifestStaysInsideTheVault(t *testing.T) {
+	for _, rel := range []string{"../outside.md", "Memories/../../x.md", "/etc/passwd", `..\x.md`} {
+		dir := t.TempDir()
+		manifest := `{"` + strings.ReplaceAll(rel, `\`, `\\`) + `": "id"}`
+		if err := os.WriteFile(filepath.Join(dir, vaultManifest), []byte(manifest), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := loadVaultManifest(dir); err == nil {
+			t.Errorf("manifest entry %q was accepted", rel)
+		}
+	}
+	if !vaultRelPath("Memories/decision/a..b.md") {
+		t.Error("dots inside a name are fine")
+	}
+}
+
+func TestSyncVaultDecryptedIsPrivate(t *testing.T) {
+	dir := t.TempDir()
+	b := sampleVaultBundle()
+	b.Memories[0].Encrypted = true
+	v, err := buildVault(b, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !v.Private {
+		t.Fatal("a decrypted memory should make the vault private")
+	}
+	if _, err := syncVault(dir, v, nil); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(filepath.Join(dir, "Memories/decision/Use Redis for sessions.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("mode = %v, want 0600", info.Mode().Perm())
+	}
+}
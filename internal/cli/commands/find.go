@@ -2,14 +2,20 @@ package commands
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"strconv"
 	"strings"
 
+	"github.com/kutbudev/ramorie-cli/internal/anchor"
 	"github.com/kutbudev/ramorie-cli/internal/api"
 	"github.com/kutbudev/ramorie-cli/internal/cli/display"
 	"github.com/kutbudev/ramorie-cli/internal/cli/resolve"
+	"github.com/kutbudev/ramorie-cli/internal/cli/tui"
 	apierrors "github.com/kutbudev/ramorie-cli/internal/errors"
+	"github.com/kutbudev/ramorie-cli/internal/searches"
 	"github.com/urfave/cli/v2"
+	"golang.org/x/term"
 )
 
 // NewFindCommand returns the top-level `find` command — hybrid memory search
@@ -25,7 +31,21 @@ Stages: HyDE query expansion → pgvector + FTS hybrid scan → entity graph
 boost → propositional boost → intent routing → Gemini rerank.
 
 If --project is omitted, the backend auto-scopes via the X-Project-Hint
-header (cwd-derived project name).`,
+header (cwd-derived project name).
+
+--save <name> stores the term and every option; ` + "`ramorie find @name`" + ` runs it
+again. Flags given with @name override the saved ones for that run (add
+--save to keep them). ` + "`ramorie searches`" + ` lists and removes saved searches.
+
+--format picks the output: table (default), json, ndjson, markdown, ids, or
+template='{{.ID}} {{.Title}}' (a Go template run once per hit).
+
+--pick opens a fuzzy picker over the hits: type to filter, tab marks, enter
+prints the picked hits (as ndjson unless --format is given), ctrl+t toggles
+the preview, ctrl+y copies a hit's body, ctrl+o opens it in ` + "`ramorie ui`" + `.
+Each line keeps the hit's type, so picked memories and tasks can be piped on:
+
+  ramorie find "deploy" --pick | ramorie context packs add <pack>`,
 		Flags: []cli.Flag{
 			&cli.StringFlag{Name: "project", Aliases: []string{"p"}, Usage: "Filter by project (name, short id, or full UUID)"},
 			&cli.StringSliceFlag{Name: "types", Aliases: []string{"t"}, Usage: "Filter by memory types (e.g. -t decision -t pattern)"},
@@ -38,6 +58,9 @@ header (cwd-derived project name).`,
 			&cli.IntFlag{Name: "entity-hops", Value: 0, Usage: "Entity graph hops (0-3)"},
 			&cli.BoolFlag{Name: "include-superseded", Usage: "Include memories marked superseded"},
 			&cli.BoolFlag{Name: "fast", Usage: "Force HyDE + rerank off (literal queries)"},
+			&cli.StringFlag{Name: "save", Usage: "Save the term and options as a named search (run it with find @name)"},
+			&cli.StringFlag{Name: "format", Usage: "Output: table | json | ndjson | markdown | ids | template='{{.ID}} {{.Title}}'"},
+			&cli.BoolFlag{Name: "pick", Usage: "Choose hits in an interactive fuzzy picker"},
 		},
 		Action: func(c *cli.Context) error {
 			parsedArgs, err := parseFindArgs(c.Args().Slice())
@@ -48,14 +71,51 @@ header (cwd-derived project name).`,
 				return fmt.Errorf("search term is required. Usage: ramorie find \"yarn rule\"")
 			}
 			term := strings.Join(parsedArgs.TermParts, " ")
+
+			formatArg := c.String("format")
+			if parsedArgs.Format != nil {
+				formatArg = *parsedArgs.Format
+			}
+			pick := c.Bool("pick")
+			if parsedArgs.Pick != nil {
+				pick = *parsedArgs.Pick
+			}
+			// ndjson keeps each hit's type (memory or task), which
+			// `context packs add` needs to link tasks as tasks.
+			if pick && formatArg == "" {
+				formatArg = findFormatNDJSON
+			}
+			format, err := parseFindFormat(formatArg)
+			if err != nil {
+				return err
+			}
+			// Machine-readable output keeps stdout for the hits alone.
+			var status io.Writer = os.Stdout
+			if format.kind != findFormatTable {
+				status = os.Stderr
+			}
+
+			// find @name re-runs a saved search.
+			var saved *searches.Search
+			if len(parsedArgs.TermParts) == 1 && strings.HasPrefix(term, "@") && len(term) > 1 {
+				s, err := searches.Lookup(term)
+				if err != nil {
+					return err
+				}
+				saved = &s
+				term = s.Term
+			}
 			client := api.NewClient()
 
 			projectArg := c.String("project")
+			var projectID string
 			if parsedArgs.Project != nil {
 				projectArg = *parsedArgs.Project
+			} else if saved != nil && !c.IsSet("project") {
+				// A saved search runs against the project UUID it was saved with.
+				projectArg, projectID = saved.Project, saved.ProjectID
 			}
-			var projectID string
-			if projectArg != "" {
+			if projectArg != "" && projectID == "" {
 				resolved, err := resolve.ResolveProject(projectArg, client)
 				if err != nil {
 					return err
@@ -114,51 +174,127 @@ header (cwd-derived project name).`,
 				IncludeSuperseded: includeSuperseded,
 				FastMode:          fastMode,
 			}
+			if saved != nil {
+				applySavedSearch(&opts, *saved, func(name string) bool {
+					return c.IsSet(name) || parsedArgs.isSet(name)
+				})
+			}
 
-			resp, err := client.FindMemories(opts)
+			saveName := c.String("save")
+			if parsedArgs.Save != nil {
+				saveName = *parsedArgs.Save
+			}
+			if saveName != "" {
+				store, err := searches.Open()
+				if err != nil {
+					return err
+				}
+				if err := store.Put(searches.FromOptions(strings.TrimPrefix(saveName, "@"), projectArg, opts)); err != nil {
+					return err
+				}
+				fmt.Fprintln(status, display.Dim.Render(fmt.Sprintf("  💾 saved as @%s", strings.TrimPrefix(saveName, "@"))))
+			}
+
+			resp, err := findWithFallback(client, opts, findTimeout)
 			if err != nil {
-				fmt.Println(apierrors.ParseAPIError(err))
+				fmt.Fprintln(status, apierrors.ParseAPIError(err))
 				return err
 			}
-			if resp == nil || len(resp.Items) == 0 {
-				fmt.Println(display.Dim.Render("  no results — try a different term, or drop --fast"))
-				return nil
+			if resp == nil {
+				resp = &api.FindResponse{}
 			}
-
-			titlePart := fmt.Sprintf("🔎 %d hit", len(resp.Items))
-			if len(resp.Items) != 1 {
-				titlePart += "s"
-			}
-			subtitle := fmt.Sprintf("intent: %s · ranking: %s · %dms",
-				resp.Meta.Intent, resp.Meta.RankingMode, resp.Meta.LatencyMs)
-			fmt.Println(display.Header(titlePart, subtitle))
-			fmt.Println()
-
-			titleWidth := display.TerminalWidth() - 50
-			if titleWidth < 30 {
-				titleWidth = 30
+			if isLocalFallback(resp) {
+				fmt.Fprintln(os.Stderr, display.Warn.Render("⚠ backend unreachable — showing keyword matches from the local index (ramorie index rebuild refreshes it)"))
 			}
+			markStaleFindItems(resp.Items, anchor.Drift(findItemIDs(resp.Items)))
 
-			for _, it := range resp.Items {
-				title := display.Truncate(display.SingleLine(it.Title), titleWidth)
-				score := fmt.Sprintf("%.2f", it.Score)
-				shortID := it.ID
-				if len(shortID) > 8 {
-					shortID = shortID[:8]
-				}
-				fmt.Printf(" %s %s  %s%s%s\n",
-					display.TypeBadge(it.Type),
-					display.Dim.Render(shortID),
-					title,
-					display.Sep()+display.Dim.Render(it.Project),
-					display.Sep()+display.Dim.Render("score "+score),
-				)
+			if pick {
+				return pickFindItems(client, opts, resp.Items, format)
+			}
+			if format.kind != findFormatTable {
+				return writeFindItems(os.Stdout, format, resp.Items, &resp.Meta)
 			}
+			printFindTable(resp)
 			return nil
 		},
 	}
 }
 
+// printFindTable prints hits in the interactive layout: one row per hit
+// with its type, short ID, title, project and score.
+func printFindTable(resp *api.FindResponse) {
+	if len(resp.Items) == 0 {
+		fmt.Println(display.Dim.Render("  no results — try a different term, or drop --fast"))
+		return
+	}
+
+	titlePart := fmt.Sprintf("🔎 %d hit", len(resp.Items))
+	if len(resp.Items) != 1 {
+		titlePart += "s"
+	}
+	subtitle := fmt.Sprintf("intent: %s · ranking: %s · %dms",
+		resp.Meta.Intent, resp.Meta.RankingMode, resp.Meta.LatencyMs)
+	fmt.Println(display.Header(titlePart, subtitle))
+	fmt.Println()
+
+	titleWidth := display.TerminalWidth() - 50
+	if titleWidth < 30 {
+		titleWidth = 30
+	}
+
+	for _, it := range resp.Items {
+		title := display.Truncate(display.SingleLine(it.Title), titleWidth)
+		score := fmt.Sprintf("%.2f", it.Score)
+		shortID := it.ID
+		if len(shortID) > 8 {
+			shortID = shortID[:8]
+		}
+		stale := ""
+		if it.Stale {
+			stale = display.Sep() + display.Warn.Render("possibly stale")
+		}
+		fmt.Printf(" %s %s  %s%s%s%s\n",
+			display.TypeBadge(it.Type),
+			display.Dim.Render(shortID),
+			title,
+			display.Sep()+display.Dim.Render(it.Project),
+			display.Sep()+display.Dim.Render("score "+score),
+			stale,
+		)
+		if it.Stale && it.StaleReason != "" {
+			fmt.Printf("   %s\n", display.Dim.Render("⚠ "+it.StaleReason))
+		}
+	}
+}
+
+// pickFindItems runs the picker over the hits. The picker draws on stderr
+// when stdout is piped so the picked hits can feed another command.
+func pickFindItems(client *api.Client, opts api.FindMemoriesOptions, items []api.FindItem, format findFormat) error {
+	if len(items) == 0 {
+		fmt.Fprintln(os.Stderr, display.Dim.Render("  no results — try a different term, or drop --fast"))
+		return nil
+	}
+	var out io.Writer = os.Stdout
+	if !term.IsTerminal(int(os.Stdout.Fd())) {
+		out = os.Stderr
+	}
+	res, err := tui.Pick(items, tui.PickOptions{Term: opts.Term, Client: client, Output: out})
+	if err != nil {
+		return err
+	}
+	switch res.Action {
+	case tui.PickOpen:
+		return tui.Run(tui.RunOptions{Recall: &tui.Recall{Options: opts, Items: items, SelectID: res.Cursor}})
+	case tui.PickAccept:
+		if format.kind == findFormatTable {
+			printFindTable(&api.FindResponse{Items: res.Items})
+			return nil
+		}
+		return writeFindItems(os.Stdout, format, res.Items, nil)
+	}
+	return nil
+}
+
 type findParsedArgs struct {
 	TermParts         []string
 	Project           *string
@@ -172,6 +308,71 @@ type findParsedArgs struct {
 	EntityHops        *int
 	IncludeSuperseded *bool
 	FastMode          *bool
+	Save              *string
+	Format            *string
+	Pick              *bool
+}
+
+// isSet reports whether a flag was given among the positional arguments.
+func (a findParsedArgs) isSet(name string) bool {
+	switch name {
+	case "types":
+		return len(a.Types) > 0
+	case "tags":
+		return len(a.Tags) > 0
+	case "limit":
+		return a.Limit != nil
+	case "budget":
+		return a.Budget != nil
+	case "hyde":
+		return a.HyDE != nil
+	case "rerank":
+		return a.Rerank != nil
+	case "intent":
+		return a.Intent != nil
+	case "entity-hops":
+		return a.EntityHops != nil
+	case "include-superseded":
+		return a.IncludeSuperseded != nil
+	case "fast":
+		return a.FastMode != nil
+	}
+	return false
+}
+
+// applySavedSearch fills every option that was not given on the command
+// line from a saved search. Term and project are handled by the caller.
+func applySavedSearch(opts *api.FindMemoriesOptions, s searches.Search, isSet func(string) bool) {
+	if !isSet("types") {
+		opts.Types = append([]string{}, s.Types...)
+	}
+	if !isSet("tags") {
+		opts.Tags = append([]string{}, s.Tags...)
+	}
+	if !isSet("limit") && s.Limit > 0 {
+		opts.Limit = s.Limit
+	}
+	if !isSet("budget") && s.Budget > 0 {
+		opts.BudgetTokens = s.Budget
+	}
+	if !isSet("hyde") && s.HyDE != "" {
+		opts.HyDE = s.HyDE
+	}
+	if !isSet("rerank") && s.Rerank != "" {
+		opts.Rerank = s.Rerank
+	}
+	if !isSet("intent") && s.Intent != "" {
+		opts.Intent = s.Intent
+	}
+	if !isSet("entity-hops") {
+		opts.EntityHops = s.EntityHops
+	}
+	if !isSet("include-superseded") {
+		opts.IncludeSuperseded = s.IncludeSuperseded
+	}
+	if !isSet("fast") {
+		opts.FastMode = s.FastMode
+	}
 }
 
 func parseFindArgs(args []string) (findParsedArgs, error) {
@@ -255,6 +456,24 @@ func parseFindArgs(args []string) (findParsedArgs, error) {
 				return out, err
 			}
 			out.FastMode = &v
+		case "save":
+			v, err := consumeFindFlagValue(name, value, hasValue, args, &i)
+			if err != nil {
+				return out, err
+			}
+			out.Save = &v
+		case "format":
+			v, err := consumeFindFlagValue(name, value, hasValue, args, &i)
+			if err != nil {
+				return out, err
+			}
+			out.Format = &v
+		case "pick":
+			v, err := parseFindBoolFlag(name, value, hasValue)
+			if err != nil {
+				return out, err
+			}
+			out.Pick = &v
 		default:
 			return out, fmt.Errorf("unknown find flag %q; put literal flag-like search text after --", token)
 		}
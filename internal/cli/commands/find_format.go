@@ -0,0 +1,128 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/kutbudev/ramorie-cli/internal/api"
+	"github.com/kutbudev/ramorie-cli/internal/cli/display"
+)
+
+// find output formats.
+const (
+	findFormatTable    = "table"
+	findFormatJSON     = "json"
+	findFormatNDJSON   = "ndjson"
+	findFormatMarkdown = "markdown"
+	findFormatIDs      = "ids"
+	findFormatTemplate = "template"
+)
+
+// findFormat is a parsed --format value.
+type findFormat struct {
+	kind string
+	tmpl *template.Template // template only
+}
+
+// parseFindFormat parses --format: table, json, ndjson, markdown (md), ids,
+// or template=<Go template> executed once per hit, e.g.
+// template='{{.ID}} {{.Title}}'. The fields are those of a JSON hit in Go
+// spelling: ID, Type, Title, Preview, Score, Project, CreatedAt, Stale, …
+func parseFindFormat(s string) (findFormat, error) {
+	s = strings.TrimSpace(s)
+	if name, body, ok := strings.Cut(s, "="); ok && strings.EqualFold(strings.TrimSpace(name), findFormatTemplate) {
+		if body == "" {
+			return findFormat{}, fmt.Errorf("--format template= needs a template, e.g. template='{{.ID}} {{.Title}}'")
+		}
+		t, err := template.New("find").Option("missingkey=error").Parse(body)
+		if err != nil {
+			return findFormat{}, fmt.Errorf("invalid --format template: %w", err)
+		}
+		return findFormat{kind: findFormatTemplate, tmpl: t}, nil
+	}
+	switch k := strings.ToLower(s); k {
+	case "", findFormatTable:
+		return findFormat{kind: findFormatTable}, nil
+	case findFormatJSON, findFormatNDJSON, findFormatIDs:
+		return findFormat{kind: k}, nil
+	case findFormatMarkdown, "md":
+		return findFormat{kind: findFormatMarkdown}, nil
+	}
+	return findFormat{}, fmt.Errorf("unknown --format %q (use table, json, ndjson, markdown, ids or template='{{.ID}} {{.Title}}')", s)
+}
+
+// writeFindItems prints hits in a machine or document format. The table
+// format is the interactive layout and is printed by the find command
+// itself. meta is included in json output when given.
+func writeFindItems(w io.Writer, f findFormat, items []api.FindItem, meta *api.FindMeta) error {
+	switch f.kind {
+	case findFormatJSON:
+		var v interface{} = items
+		if meta != nil {
+			v = api.FindResponse{Items: items, Meta: *meta}
+		}
+		out, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(out))
+		return err
+	case findFormatNDJSON:
+		enc := json.NewEncoder(w)
+		for _, it := range items {
+			if err := enc.Encode(it); err != nil {
+				return err
+			}
+		}
+		return nil
+	case findFormatIDs:
+		for _, it := range items {
+			if _, err := fmt.Fprintln(w, it.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	case findFormatMarkdown:
+		for _, it := range items {
+			title := display.SingleLine(it.Title)
+			if title == "" {
+				title = display.SingleLine(it.Preview)
+			}
+			meta := []string{"`" + shortID(it.ID) + "`"}
+			if it.Project != "" {
+				meta = append(meta, it.Project)
+			}
+			meta = append(meta, fmt.Sprintf("score %.2f", it.Score))
+			if it.Stale {
+				meta = append(meta, "possibly stale")
+			}
+			fmt.Fprintf(w, "- **[%s]** %s (%s)\n", itemType(it), title, strings.Join(meta, ", "))
+			if preview := display.SingleLine(it.Preview); preview != "" && preview != title {
+				fmt.Fprintf(w, "  > %s\n", preview)
+			}
+		}
+		return nil
+	case findFormatTemplate:
+		for _, it := range items {
+			if err := f.tmpl.Execute(w, it); err != nil {
+				return fmt.Errorf("--format template: %w", err)
+			}
+			if _, err := fmt.Fprintln(w); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("format %q is printed by find itself", f.kind)
+}
+
+// itemType is the most descriptive type label of a hit.
+func itemType(it api.FindItem) string {
+	if it.Kind != "" {
+		return it.Kind
+	}
+	return it.Type
+}
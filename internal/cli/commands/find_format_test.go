@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/kutbudev/ramorie-cli/internal/api"
+)
+
+var formatHits = []api.FindItem{
+	{ID: "11111111-1111-1111-1111-111111111111", Type: "memory", Kind: "decision", Title: "Use pgx for Postgres", Preview: "pgx over lib/pq", Score: 0.91, Project: "api"},
+	{ID: "22222222-2222-2222-2222-222222222222", Type: "task", Title: "Migrate to pgx", Score: 0.5, Stale: true},
+}
+
+func TestParseFindFormat(t *testing.T) {
+	for in, want := range map[string]string{"": "table", "JSON": "json", "ndjson": "ndjson", "md": "markdown", "ids": "ids", "template={{.ID}}": "template"} {
+		f, err := parseFindFormat(in)
+		if err != nil || f.kind != want {
+			t.Errorf("parseFindFormat(%q) = %q, %v; want %q", in, f.kind, err, want)
+		}
+	}
+	for _, bad := range []string{"yaml", "template=", "template={{.ID"} {
+		if _, err := parseFindFormat(bad); err == nil {
+			t.Errorf("parseFindFormat(%q) should fail", bad)
+		}
+	}
+}
+
+func TestWriteFindItems(t *testing.T) {
+	render := func(format string) string {
+		t.Helper()
+		f, err := parseFindFormat(format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		if err := writeFindItems(&buf, f, formatHits, &api.FindMeta{Returned: 2}); err != nil {
+			t.Fatal(err)
+		}
+		return buf.String()
+	}
+
+	if got := render("ids"); got != formatHits[0].ID+"\n"+formatHits[1].ID+"\n" {
+		t.Errorf("ids = %q", got)
+	}
+	if got := render("template={{.Kind}}|{{.Title}}"); got != "decision|Use pgx for Postgres\n|Migrate to pgx\n" {
+		t.Errorf("template = %q", got)
+	}
+
+	lines := strings.Split(strings.TrimSpace(render("ndjson")), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("ndjson lines = %d", len(lines))
+	}
+	var hit api.FindItem
+	if err := json.Unmarshal([]byte(lines[1]), &hit); err != nil || hit.Type != "task" {
+		t.Errorf("ndjson line = %q (%v)", lines[1], err)
+	}
+
+	var resp api.FindResponse
+	if err := json.Unmarshal([]byte(render("json")), &resp); err != nil || len(resp.Items) != 2 || resp.Meta.Returned != 2 {
+		t.Errorf("json = %+v, %v", resp, err)
+	}
+
+	md := render("markdown")
+	for _, want := range []string{"- **[decision]** Use pgx for Postgres (`11111111`, api, score 0.91)", "  > pgx over lib/pq", "possibly stale"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q:\n%s", want, md)
+		}
+	}
+}
@@ -9,7 +9,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/kutbudev/ramorie-cli/internal/anchor"
 	"github.com/kutbudev/ramorie-cli/internal/api"
+	"github.com/kutbudev/ramorie-cli/internal/cli/display"
 	"github.com/urfave/cli/v2"
 )
 
@@ -51,31 +53,70 @@ func findRelatedAction(c *cli.Context) error {
 		}
 	}
 
-	resp, err := client.FindMemories(api.FindMemoriesOptions{
+	resp, err := findWithFallback(client, api.FindMemoriesOptions{
 		Term:         term,
 		ProjectHint:  projectHint,
 		Limit:        c.Int("limit"),
 		BudgetTokens: c.Int("budget"),
-	})
-	if err != nil || resp == nil || len(resp.Items) == 0 {
+	}, hookFindTimeout)
+	var found []api.FindItem
+	if err == nil && resp != nil {
+		found = resp.Items
+	}
+	// Memories anchored to this file are the most precise context, so they
+	// lead even when the search term misses them.
+	items := hookItems(append(anchoredFindItems(client, filePath, found, c.Int("limit")), found...))
+	if len(items) == 0 {
 		return nil // silent on no-hit / errors
 	}
 
 	var b strings.Builder
 	fmt.Fprintf(&b, "Ramorie context for %s:\n", filepath.Base(filePath))
-	for _, item := range resp.Items {
+	for _, item := range items {
 		tag := item.Type
 		if item.Kind != "" {
 			tag = fmt.Sprintf("%s/%s", item.Type, item.Kind)
 		}
-		fmt.Fprintf(&b, "- [%s] %s — %s\n", tag, item.Title, item.Preview)
+		fmt.Fprintf(&b, "- [%s] %s — %s%s\n", tag, item.Title, item.Preview, staleSuffix(item))
+	}
+	if resp != nil {
+		fmt.Fprintf(&b, "(ranking: %s, %d token est)", resp.Meta.RankingMode, resp.Meta.TokensEst)
 	}
-	fmt.Fprintf(&b, "(ranking: %s, %d token est)", resp.Meta.RankingMode, resp.Meta.TokensEst)
 
 	fmt.Println(b.String())
 	return nil
 }
 
+// anchoredFindItems loads up to limit memories anchored to filePath that
+// are not already in found. Memories that fail to load are skipped.
+func anchoredFindItems(client *api.Client, filePath string, found []api.FindItem, limit int) []api.FindItem {
+	have := map[string]bool{}
+	for _, it := range found {
+		have[it.ID] = true
+	}
+	var out []api.FindItem
+	for _, id := range anchor.ForFile(filePath) {
+		if len(out) >= limit {
+			break
+		}
+		if have[id] {
+			continue
+		}
+		m, err := client.GetMemory(id)
+		if err != nil {
+			continue
+		}
+		content := decryptMemoryForCLI(m)
+		out = append(out, api.FindItem{
+			ID:      id,
+			Type:    m.Type,
+			Title:   clipInlineRunes(firstUsefulLine(content), 80),
+			Preview: clipInlineRunes(display.SingleLine(content), 200),
+		})
+	}
+	return out
+}
+
 // deriveSearchTerm produces a useful query string from a file path. Uses the
 // filename stem plus the two closest directory names, which usually captures
 // the module/feature context.
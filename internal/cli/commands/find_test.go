@@ -3,6 +3,9 @@ package commands
 import (
 	"reflect"
 	"testing"
+
+	"github.com/kutbudev/ramorie-cli/internal/api"
+	"github.com/kutbudev/ramorie-cli/internal/searches"
 )
 
 func TestParseFindArgs_AllowsFlagsAfterSearchTerm(t *testing.T) {
@@ -78,3 +81,30 @@ func TestParseFindArgs_RejectsUnknownTrailingFlag(t *testing.T) {
 		t.Fatal("expected unknown trailing flag error")
 	}
 }
+
+func TestApplySavedSearch_FlagsOverrideSavedOptions(t *testing.T) {
+	got, err := parseFindArgs([]string{"@deploy", "--limit", "5", "--save", "deploy-5"})
+	if err != nil {
+		t.Fatalf("parseFindArgs: %v", err)
+	}
+	if got.Save == nil || *got.Save != "deploy-5" {
+		t.Fatalf("save: got %v", got.Save)
+	}
+
+	saved := searches.Search{
+		Name: "deploy", Term: "deploy runbook", Types: []string{"skill"},
+		Limit: 20, HyDE: "off", FastMode: true,
+	}
+	opts := api.FindMemoriesOptions{Limit: 5, HyDE: "default", Rerank: "default", Intent: "auto"}
+	applySavedSearch(&opts, saved, got.isSet)
+
+	if opts.Limit != 5 {
+		t.Fatalf("limit given on the command line should win, got %d", opts.Limit)
+	}
+	if opts.HyDE != "off" || !opts.FastMode || !reflect.DeepEqual(opts.Types, []string{"skill"}) {
+		t.Fatalf("saved options not applied: %+v", opts)
+	}
+	if opts.Rerank != "default" || opts.Intent != "auto" {
+		t.Fatalf("unsaved options should keep their defaults: %+v", opts)
+	}
+}
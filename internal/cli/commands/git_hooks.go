@@ -0,0 +1,440 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kutbudev/ramorie-cli/internal/activetask"
+	"github.com/kutbudev/ramorie-cli/internal/api"
+	"github.com/kutbudev/ramorie-cli/internal/cli/resolve"
+	"github.com/kutbudev/ramorie-cli/internal/config"
+	"github.com/kutbudev/ramorie-cli/internal/gitlink"
+	"github.com/kutbudev/ramorie-cli/internal/hooks"
+	"github.com/kutbudev/ramorie-cli/internal/journal"
+	"github.com/urfave/cli/v2"
+)
+
+// setupGitHooksCmd exposes `ramorie setup git-hooks` — installs commit-msg,
+// post-commit and post-checkout hooks in the current repository so commits
+// that mention `ram#<short-id>` are recorded on the task, `fixes ram#<id>`
+// completes it, and switching branches starts the branch's task. Uses the
+// same managed-entry installer contract as `setup hooks`.
+func setupGitHooksCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "git-hooks",
+		Usage: "Link commits and branches to tasks via git hooks (ram#<id> references)",
+		Description: "Installs commit-msg, post-commit and post-checkout hooks in the current repository.\n" +
+			"   A commit mentioning `ram#3f2a` gets a note on that task with its SHA and\n" +
+			"   subject; `fixes ram#3f2a` (also close/resolve) completes the task.\n" +
+			"   Checking out a branch such as feat/3f2a-login starts that task\n" +
+			"   (see `ramorie task from-branch`).\n" +
+			"   Existing hook scripts are preserved; re-running is safe.",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "dry-run", Usage: "Show what would be written without modifying files"},
+		},
+		Action: gitHooksInstallAction,
+		Subcommands: []*cli.Command{
+			{
+				Name:   "install",
+				Usage:  "Install the git hooks (default action)",
+				Flags:  []cli.Flag{&cli.BoolFlag{Name: "dry-run", Usage: "Show what would be written without modifying files"}},
+				Action: gitHooksInstallAction,
+			},
+			{
+				Name:   "uninstall",
+				Usage:  "Remove the Ramorie blocks from this repository's hooks",
+				Action: gitHooksUninstallAction,
+			},
+			{
+				Name:   "status",
+				Usage:  "Show which Ramorie git hooks are installed",
+				Action: gitHooksStatusAction,
+			},
+		},
+	}
+}
+
+func gitHooksInstallAction(c *cli.Context) error {
+	inst := hooks.NewGitInstaller()
+	if !inst.Detect() {
+		return fmt.Errorf("not inside a git repository")
+	}
+	entries := hooks.GitEntries()
+	if c.Bool("dry-run") {
+		for _, e := range entries {
+			fmt.Printf("  · would write %s block to %s/%s\n", e.ID, inst.SettingsPath(), e.Event)
+		}
+		return nil
+	}
+	if err := inst.Install(entries); err != nil {
+		return err
+	}
+	fmt.Printf("  ✓ git — %d hook(s) installed → %s\n", len(entries), inst.SettingsPath())
+	fmt.Println("    Reference tasks as ram#<short-id>; `fixes ram#<id>` completes the task.")
+	return nil
+}
+
+func gitHooksUninstallAction(c *cli.Context) error {
+	inst := hooks.NewGitInstaller()
+	if !inst.Detect() {
+		return fmt.Errorf("not inside a git repository")
+	}
+	ids := make([]string, 0, len(hooks.GitEntries()))
+	for _, e := range hooks.GitEntries() {
+		ids = append(ids, e.ID)
+	}
+	if err := inst.Uninstall(ids); err != nil {
+		return err
+	}
+	fmt.Printf("  ✓ git — hooks removed from %s\n", inst.SettingsPath())
+	return nil
+}
+
+func gitHooksStatusAction(c *cli.Context) error {
+	inst := hooks.NewGitInstaller()
+	if !inst.Detect() {
+		fmt.Println("  · git: not inside a git repository")
+		return nil
+	}
+	entries, err := inst.Status()
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		fmt.Printf("  ⚠ git: no Ramorie hooks installed in %s\n", inst.SettingsPath())
+		return nil
+	}
+	missing, stale := hooks.DiffEntries(hooks.GitEntries(), entries)
+	marker, suffix := "✓", "installed"
+	if len(missing) > 0 || len(stale) > 0 {
+		marker = "⚠"
+		suffix = fmt.Sprintf("outdated/incomplete (missing=%d stale=%d)", len(missing), len(stale))
+	}
+	fmt.Printf("  %s git: %d hook(s) %s → %s\n", marker, len(entries), suffix, inst.SettingsPath())
+	for _, e := range entries {
+		fmt.Printf("       · %s — id=%s\n", e.Event, e.ID)
+	}
+	if len(missing) > 0 || len(stale) > 0 {
+		fmt.Println("       → run `ramorie setup git-hooks` to refresh")
+	}
+	return nil
+}
+
+// hookGitCmd groups the shims the installed git hooks call. Hidden: humans
+// never run these directly.
+func hookGitCmd() *cli.Command {
+	return &cli.Command{
+		Name:   "git",
+		Usage:  "Git hook shims (commit-msg, post-commit, post-checkout)",
+		Hidden: true,
+		Subcommands: []*cli.Command{
+			{
+				Name:      "commit-msg",
+				Usage:     "Warn about ram#<id> references that match no task",
+				ArgsUsage: "<message-file>",
+				Action:    hookGitCommitMsg,
+			},
+			{
+				Name:   "post-commit",
+				Usage:  "Add a note to referenced tasks and complete fixed ones",
+				Action: hookGitPostCommit,
+			},
+			{
+				Name:      "post-checkout",
+				Usage:     "Start the task named by the newly checked-out branch",
+				ArgsUsage: "<prev-head> <new-head> <branch-flag>",
+				Action:    hookGitPostCheckout,
+			},
+		},
+	}
+}
+
+// taskRef is one `ram#<id>` mention in a commit message.
+type taskRef struct {
+	ID     string // short or full task ID, lower-cased
+	Closes bool   // preceded by fix/close/resolve
+}
+
+// taskRefRe matches `ram#3f2a` with an optional closing keyword in front
+// (`fixes ram#3f2a`, `Closes: ram#3f2a`). IDs are 4+ hex chars and may carry
+// the UUID dashes.
+var taskRefRe = regexp.MustCompile(`(?i)(?:\b(fix(?:e[sd])?|close[sd]?|resolve[sd]?)\b:?\s+)?\bram#([0-9a-f][0-9a-f-]{3,35})\b`)
+
+// parseTaskRefs extracts task references from a commit message. Comment
+// lines (git's `#` template) are ignored, and a task mentioned more than
+// once closes if any mention closes it.
+func parseTaskRefs(msg string) []taskRef {
+	var body []string
+	for _, line := range strings.Split(msg, "\n") {
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		body = append(body, line)
+	}
+	var refs []taskRef
+	index := map[string]int{}
+	for _, m := range taskRefRe.FindAllStringSubmatch(strings.Join(body, "\n"), -1) {
+		id := strings.ToLower(strings.TrimRight(m[2], "-"))
+		closes := m[1] != ""
+		if i, ok := index[id]; ok {
+			refs[i].Closes = refs[i].Closes || closes
+			continue
+		}
+		index[id] = len(refs)
+		refs = append(refs, taskRef{ID: id, Closes: closes})
+	}
+	return refs
+}
+
+// resolveTaskRef turns a short task ID into a full UUID by prefix-matching
+// the caller's tasks. Full UUIDs pass through untouched.
+func resolveTaskRef(client *api.Client, ref string) (string, error) {
+	return newTaskRefResolver(client, nil).resolve(ref)
+}
+
+// taskRefResolver resolves many ram#<id> references against one listing
+// of the caller's tasks, fetched on the first short reference. Resolved
+// references are also kept in cache, which callers may persist.
+type taskRefResolver struct {
+	client *api.Client
+	cache  map[string]string
+	ids    []string
+	listed bool
+}
+
+func newTaskRefResolver(client *api.Client, cache map[string]string) *taskRefResolver {
+	if cache == nil {
+		cache = map[string]string{}
+	}
+	return &taskRefResolver{client: client, cache: cache}
+}
+
+func (r *taskRefResolver) resolve(ref string) (string, error) {
+	if full, err := resolve.ResolveID(ref); err == nil {
+		return full, nil
+	}
+	if full, ok := r.cache[ref]; ok {
+		return full, nil
+	}
+	if !r.listed {
+		for page := 1; ; page++ {
+			items, hasMore, err := r.client.ListTasksPage("", "", page, 100)
+			if err != nil {
+				return "", err
+			}
+			for _, t := range items {
+				r.ids = append(r.ids, t.ID.String())
+			}
+			if !hasMore {
+				break
+			}
+		}
+		r.listed = true
+	}
+	var hits []string
+	for _, id := range r.ids {
+		if strings.HasPrefix(id, ref) {
+			hits = append(hits, id)
+		}
+	}
+	switch len(hits) {
+	case 0:
+		return "", fmt.Errorf("no task matches ram#%s", ref)
+	case 1:
+		r.cache[ref] = hits[0]
+		return hits[0], nil
+	}
+	return "", fmt.Errorf("ram#%s is ambiguous (%d tasks) — use more characters", ref, len(hits))
+}
+
+// forget drops a cached reference whose task turned out to be gone.
+func (r *taskRefResolver) forget(ref string) {
+	delete(r.cache, ref)
+}
+
+// hookGitCommitMsg validates references before the commit is recorded. It
+// never fails the commit; unknown references only produce a warning.
+func hookGitCommitMsg(c *cli.Context) error {
+	if c.NArg() == 0 {
+		return nil
+	}
+	data, err := os.ReadFile(c.Args().First())
+	if err != nil {
+		return nil
+	}
+	refs := parseTaskRefs(string(data))
+	if len(refs) == 0 {
+		return nil
+	}
+	store, st := openGitLinks()
+	resolver := newTaskRefResolver(api.NewClient(), st.Refs)
+	for _, r := range refs {
+		if _, err := resolver.resolve(r.ID); err != nil {
+			fmt.Fprintf(os.Stderr, "ramorie: ⚠ %v\n", err)
+		}
+	}
+	saveGitLinks(store, st)
+	return nil
+}
+
+// hookGitPostCommit records the new commit on every referenced task and
+// completes the ones the message says it fixes. A commit already linked to
+// a task — the same SHA, or the same author and author date after an amend
+// or rebase — is not noted again.
+func hookGitPostCommit(c *cli.Context) error {
+	msg, err := gitOutput("log", "-1", "--format=%B")
+	if err != nil {
+		return nil
+	}
+	refs := parseTaskRefs(msg)
+	if len(refs) == 0 {
+		return nil
+	}
+	sha, err := gitOutput("rev-parse", "HEAD")
+	if err != nil {
+		return nil
+	}
+	subject, _ := gitOutput("log", "-1", "--format=%s")
+	note := commitNote(sha, subject)
+	keys := commitLinkKeys(sha)
+
+	store, st := openGitLinks()
+	defer saveGitLinks(store, st)
+
+	client := api.NewClient()
+	resolver := newTaskRefResolver(client, st.Refs)
+	for _, r := range refs {
+		taskID, err := resolver.resolve(r.ID)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "ramorie: ⚠ %v\n", err)
+			continue
+		}
+		noted, closed := st.Linked(keys, taskID)
+		if noted && (closed || !r.Closes) {
+			continue
+		}
+		if !noted {
+			if _, err := client.CreateAnnotation(taskID, note); err != nil {
+				resolver.forget(r.ID)
+				fmt.Fprintf(os.Stderr, "ramorie: ⚠ could not add note to %s: %v\n", taskID[:8], err)
+				continue
+			}
+			st.Record(keys, taskID, false, time.Now())
+		}
+		if r.Closes {
+			before, _ := taskBefore(client, taskID)
+			if err := activetask.Complete(client, taskID); err != nil {
+				fmt.Fprintf(os.Stderr, "ramorie: ⚠ could not complete %s: %v\n", taskID[:8], err)
+				continue
+			}
+			st.Record(keys, taskID, true, time.Now())
+			journal.Record("git commit", fmt.Sprintf("%s completed by %s", taskLabel(before, taskID), sha[:min(len(sha), 8)]),
+				journal.Update(journal.KindTask, taskID, journal.TaskSnapshot(before), "status"))
+			fmt.Fprintf(os.Stderr, "ramorie: ✓ %s linked and completed\n", taskID[:8])
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "ramorie: ✓ %s linked\n", taskID[:8])
+	}
+	return nil
+}
+
+// commitLinkKeys returns the git-link ledger keys of HEAD.
+func commitLinkKeys(sha string) []string {
+	out, err := gitOutput("log", "-1", "--format=%ae %at")
+	if err != nil {
+		return gitlink.Keys(sha, "", 0)
+	}
+	email, at, _ := strings.Cut(out, " ")
+	ts, _ := strconv.ParseInt(at, 10, 64)
+	return gitlink.Keys(sha, email, ts)
+}
+
+// openGitLinks loads the git-link ledger. An unreadable ledger is replaced
+// by an empty one: hooks must never block a commit.
+func openGitLinks() (*gitlink.Store, *gitlink.State) {
+	empty := &gitlink.State{Commits: map[string][]gitlink.Link{}, Refs: map[string]string{}}
+	store, err := gitlink.Open()
+	if err != nil {
+		return nil, empty
+	}
+	st, err := store.Load()
+	if err != nil {
+		return store, empty
+	}
+	return store, st
+}
+
+func saveGitLinks(store *gitlink.Store, st *gitlink.State) {
+	if store == nil {
+		return
+	}
+	if err := store.Save(st); err != nil {
+		fmt.Fprintf(os.Stderr, "ramorie: ⚠ could not save %s: %v\n", store.Path, err)
+	}
+}
+
+// commitNote is the annotation text written for a commit.
+func commitNote(sha, subject string) string {
+	short := sha
+	if len(short) > 12 {
+		short = short[:12]
+	}
+	return fmt.Sprintf("commit %s: %s", short, strings.TrimSpace(subject))
+}
+
+func gitOutput(args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, "git", args...).Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// hookGitPostCheckout starts the branch's task after a branch checkout. File
+// checkouts and branches that name no task are ignored silently; completed
+// tasks and ID matches outside the checkout's project are only reported.
+func hookGitPostCheckout(c *cli.Context) error {
+	if c.Args().Get(2) != "1" {
+		return nil
+	}
+	branch, err := gitOutput("rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil || branch == "HEAD" {
+		return nil
+	}
+	re, err := compileBranchPattern(resolveBranchPattern(""))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ramorie: ⚠ %v\n", err)
+		return nil
+	}
+	if _, ok := parseBranch(re, branch); !ok {
+		return nil
+	}
+	client := api.NewClient()
+	m, err := taskForBranch(client, branch, "", "")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ramorie: %v\n", err)
+		return nil
+	}
+	if m.ID == config.LoadActiveTask() {
+		return nil
+	}
+	checkoutProject := ""
+	if m.ByID && !m.Completed {
+		checkoutProject = resolve.DetectProject(client)
+	}
+	if reason := hookSkipReason(m, checkoutProject); reason != "" {
+		fmt.Fprintf(os.Stderr, "ramorie: branch %s matches %s %s, but %s — not starting it\n",
+			branch, m.ID[:8], m.Title, reason)
+		return nil
+	}
+	_ = switchActiveTask(client, m.ID, m.Title)
+	return nil
+}
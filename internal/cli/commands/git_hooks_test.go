@@ -0,0 +1,44 @@
+package commands
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseTaskRefs(t *testing.T) {
+	msg := `Fix login redirect (ram#3F2A)
+
+Also touches ram#beef01 and Closes: ram#c0ffee-12.
+Refs ram#3f2a again; fixes ram#3f2a
+not a ref: ram#xyz, program#1234, ram#12
+# Please enter the commit message. ram#dead
+`
+	got := parseTaskRefs(msg)
+	want := []taskRef{
+		{ID: "3f2a", Closes: true},
+		{ID: "beef01", Closes: false},
+		{ID: "c0ffee-12", Closes: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v\nwant %+v", got, want)
+	}
+}
+
+func TestParseTaskRefs_ClosingKeywords(t *testing.T) {
+	for _, msg := range []string{"fix ram#abcd", "Fixed ram#abcd", "resolves ram#abcd", "close ram#abcd", "closed: ram#abcd"} {
+		refs := parseTaskRefs(msg)
+		if len(refs) != 1 || !refs[0].Closes {
+			t.Errorf("%q: got %+v, want one closing ref", msg, refs)
+		}
+	}
+	if refs := parseTaskRefs("prefix ram#abcd"); len(refs) != 1 || refs[0].Closes {
+		t.Errorf("prefix should not close: %+v", refs)
+	}
+}
+
+func TestCommitNote(t *testing.T) {
+	got := commitNote("0123456789abcdef0123", "  Add importer \n")
+	if got != "commit 0123456789ab: Add importer" {
+		t.Fatalf("got %q", got)
+	}
+}
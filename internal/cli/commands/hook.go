@@ -7,10 +7,13 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 
+	"github.com/kutbudev/ramorie-cli/internal/anchor"
 	"github.com/kutbudev/ramorie-cli/internal/api"
 	mcpcontext "github.com/kutbudev/ramorie-cli/internal/mcp"
 	"github.com/kutbudev/ramorie-cli/internal/protocol"
+	"github.com/kutbudev/ramorie-cli/internal/review"
 	"github.com/urfave/cli/v2"
 )
 
@@ -87,6 +90,7 @@ func NewHookCommand() *cli.Command {
 				},
 				Action: hookContext,
 			},
+			hookGitCmd(),
 		},
 	}
 }
@@ -453,7 +457,7 @@ func hookBeforeAction(c *cli.Context) error {
 		}
 	}
 
-	resp, err := client.FindMemories(api.FindMemoriesOptions{
+	resp, err := findWithFallback(client, api.FindMemoriesOptions{
 		Term:         buildBeforeActionQuery(intents, actionText),
 		Project:      project,
 		ProjectHint:  projectHint,
@@ -465,7 +469,7 @@ func hookBeforeAction(c *cli.Context) error {
 		HyDE:         "off",
 		Rerank:       "off",
 		FastMode:     true,
-	})
+	}, hookFindTimeout)
 	if err != nil || resp == nil || len(resp.Items) == 0 {
 		return nil
 	}
@@ -474,7 +478,7 @@ func hookBeforeAction(c *cli.Context) error {
 	// runbook pipeline untouched; preferences and decisions each get a separate,
 	// tightly-gated surfacing so an unrelated rule/decision never spams an
 	// unrelated command.
-	skillItems, prefItems, decisionItems := splitBeforeActionItems(resp.Items)
+	skillItems, prefItems, decisionItems := splitBeforeActionItems(hookItems(resp.Items))
 
 	runbooks := loadBeforeActionRunbooks(client, skillItems, intents, c.Int("limit"))
 	preferences := selectBeforeActionPreferences(prefItems, intents)
@@ -534,7 +538,7 @@ func hookPromptSubmit(c *cli.Context) error {
 		limit = 4
 	}
 
-	resp, err := client.FindMemories(api.FindMemoriesOptions{
+	resp, err := findWithFallback(client, api.FindMemoriesOptions{
 		Term:         clipRunes(prompt, promptSubmitMaxQueryRunes),
 		Project:      project,
 		ProjectHint:  projectHint,
@@ -545,12 +549,12 @@ func hookPromptSubmit(c *cli.Context) error {
 		// FastMode: this fires on EVERY prompt and blocks it until we return,
 		// so skip the slow HyDE+rerank stages to stay well under a second.
 		FastMode: true,
-	})
+	}, hookFindTimeout)
 	if err != nil || resp == nil || len(resp.Items) == 0 {
 		return nil
 	}
 
-	additional := formatPromptSubmitContext(resp.Items, c.Int("budget"))
+	additional := formatPromptSubmitContext(hookItems(resp.Items), c.Int("budget"))
 	if strings.TrimSpace(additional) == "" {
 		return nil
 	}
@@ -589,6 +593,62 @@ func promptWorthSurfacing(prompt string) bool {
 	return true
 }
 
+// dropExpiredFindItems removes memories whose expiry has passed, either by
+// the backend's valid_until or by the local review schedule.
+func dropExpiredFindItems(items []api.FindItem, expired map[string]bool, now time.Time) []api.FindItem {
+	out := items[:0:0]
+	for _, it := range items {
+		if expired[it.ID] || (it.ValidUntil != nil && now.After(*it.ValidUntil)) {
+			continue
+		}
+		out = append(out, it)
+	}
+	return out
+}
+
+// markStaleFindItems flags memories whose anchored code changed.
+func markStaleFindItems(items []api.FindItem, drift map[string]string) {
+	for i := range items {
+		reason, ok := drift[items[i].ID]
+		if !ok {
+			continue
+		}
+		items[i].Stale = true
+		if items[i].StaleReason != "" {
+			reason = items[i].StaleReason + "; " + reason
+		}
+		items[i].StaleReason = reason
+	}
+}
+
+// hookItems prepares find results for hook injection: expired memories are
+// dropped and ones whose anchored code changed are flagged stale.
+func hookItems(items []api.FindItem) []api.FindItem {
+	now := time.Now()
+	items = dropExpiredFindItems(items, review.Expired(now), now)
+	markStaleFindItems(items, anchor.Drift(findItemIDs(items)))
+	return items
+}
+
+func findItemIDs(items []api.FindItem) []string {
+	ids := make([]string, len(items))
+	for i, it := range items {
+		ids[i] = it.ID
+	}
+	return ids
+}
+
+// staleSuffix warns the agent to re-verify a memory before relying on it.
+func staleSuffix(it api.FindItem) string {
+	if !it.Stale {
+		return ""
+	}
+	if it.StaleReason == "" {
+		return " (possibly stale)"
+	}
+	return " (possibly stale: " + clipInlineRunes(it.StaleReason, 120) + ")"
+}
+
 // formatPromptSubmitContext renders a compact, budget-bounded context block of
 // the prompt-relevant memories. One short line per item, tagged by type, so the
 // injection informs the answer without dominating the prompt.
@@ -628,8 +688,11 @@ func formatPromptSubmitContext(items []api.FindItem, budgetTokens int) string {
 		}
 		if b.Len() == 0 {
 			b.WriteString("Ramorie relevant context (apply if pertinent; do not silently contradict):\n")
+			if it.LocalFallback {
+				b.WriteString("(local_fallback: backend unreachable, keyword matches from the local index)\n")
+			}
 		}
-		fmt.Fprintf(&b, "- [%s] %s\n", typeTag, clipInlineRunes(text, promptSubmitMaxItemRunes))
+		fmt.Fprintf(&b, "- [%s] %s%s\n", typeTag, clipInlineRunes(text, promptSubmitMaxItemRunes), staleSuffix(it))
 	}
 	return clipRunes(b.String(), maxChars)
 }
@@ -861,7 +924,7 @@ func selectBeforeActionPreferences(items []api.FindItem, intents []beforeActionI
 			continue
 		}
 		seen[key] = struct{}{}
-		out = append(out, clipInlineRunes(text, 160))
+		out = append(out, clipInlineRunes(text, 160)+staleSuffix(it))
 	}
 	return out
 }
@@ -918,7 +981,7 @@ func selectBeforeActionDecisions(items []api.FindItem, intents []beforeActionInt
 			continue
 		}
 		seen[key] = struct{}{}
-		out = append(out, clipInlineRunes(text, 160))
+		out = append(out, clipInlineRunes(text, 160)+staleSuffix(it))
 	}
 	return out
 }
@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/kutbudev/ramorie-cli/internal/api"
 )
@@ -543,3 +544,50 @@ func TestLoadSettings_InvalidJSONErrors(t *testing.T) {
 		t.Fatal("malformed JSON must surface an error so we never overwrite user config silently")
 	}
 }
+
+func TestDropExpiredFindItems(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+	items := []api.FindItem{
+		{ID: "keep"},
+		{ID: "local-expired"},
+		{ID: "server-expired", ValidUntil: &past},
+		{ID: "still-valid", ValidUntil: &future},
+	}
+	got := dropExpiredFindItems(items, map[string]bool{"local-expired": true}, now)
+	var ids []string
+	for _, it := range got {
+		ids = append(ids, it.ID)
+	}
+	if strings.Join(ids, ",") != "keep,still-valid" {
+		t.Errorf("kept %v", ids)
+	}
+	if len(items) != 4 || items[1].ID != "local-expired" {
+		t.Error("input slice must not be modified")
+	}
+}
+
+func TestMarkStaleFindItems(t *testing.T) {
+	items := []api.FindItem{
+		{ID: "a", Type: "decision", Preview: "Retry budget lives in client.go"},
+		{ID: "b", Type: "preference", Preview: "Use yarn", Stale: true, StaleReason: "400 days old"},
+		{ID: "c", Type: "pattern", Preview: "Wrap errors with %w"},
+	}
+	markStaleFindItems(items, map[string]string{
+		"a": "anchored code changed: client.go:10-20 changed since it was anchored",
+		"b": "anchored code changed: x.go was deleted or renamed",
+	})
+	if !items[0].Stale || items[2].Stale {
+		t.Fatalf("stale flags = %v %v", items[0].Stale, items[2].Stale)
+	}
+	if !strings.HasPrefix(items[1].StaleReason, "400 days old; anchored") {
+		t.Errorf("existing reason not kept: %q", items[1].StaleReason)
+	}
+	got := formatPromptSubmitContext(items, 700)
+	if !strings.Contains(got, "Retry budget lives in client.go (possibly stale: anchored code changed") {
+		t.Errorf("stale marker missing:\n%s", got)
+	}
+	if strings.Contains(got, "Wrap errors with %w (possibly stale") {
+		t.Errorf("fresh item marked:\n%s", got)
+	}
+}
@@ -0,0 +1,708 @@
+package commands
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/kutbudev/ramorie-cli/internal/api"
+	"github.com/kutbudev/ramorie-cli/internal/cli/display"
+	"github.com/kutbudev/ramorie-cli/internal/cli/resolve"
+	"github.com/kutbudev/ramorie-cli/internal/crypto"
+	"github.com/kutbudev/ramorie-cli/internal/encstate"
+	apierrors "github.com/kutbudev/ramorie-cli/internal/errors"
+	"github.com/kutbudev/ramorie-cli/internal/journal"
+	"github.com/kutbudev/ramorie-cli/internal/models"
+	"github.com/urfave/cli/v2"
+)
+
+// NewImportCommand creates the 'import' command group for migrating existing
+// backlogs and notes into Ramorie.
+func NewImportCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "import",
+		Usage: "Import data from other tools",
+		Subcommands: []*cli.Command{
+			importTasksCmd(),
+			importMarkdownCmd(),
+		},
+	}
+}
+
+// Supported `import tasks` formats.
+const (
+	importFormatMarkdown    = "markdown"
+	importFormatGitHub      = "github"
+	importFormatTaskwarrior = "taskwarrior"
+)
+
+// importedTask is the format-neutral shape every parser produces. Notes map
+// onto task annotations (models.Annotation) after the task is created.
+type importedTask struct {
+	Title       string
+	Description string
+	Priority    string // H, M, L or "" (server default)
+	Tags        []string
+	Subtasks    []importedSubtask
+	Notes       []models.Annotation
+	Completed   bool
+}
+
+type importedSubtask struct {
+	Description string
+	Completed   bool
+	Parent      int // index of the parent subtask in Subtasks, -1 for none
+}
+
+// importMapping translates source labels into Ramorie tags and priorities.
+// Label keys are matched case-insensitively.
+type importMapping struct {
+	Priority map[string]string // label → H/M/L; the label itself is not kept as a tag
+	Tags     map[string]string // label → tag; "" drops the label
+}
+
+// defaultPriorityLabels covers the label conventions most trackers use.
+var defaultPriorityLabels = map[string]string{
+	"critical": "H", "urgent": "H", "high": "H", "priority: high": "H", "priority:high": "H", "p0": "H", "p1": "H",
+	"medium": "M", "priority: medium": "M", "priority:medium": "M", "p2": "M",
+	"low": "L", "priority: low": "L", "priority:low": "L", "p3": "L", "p4": "L",
+}
+
+func newImportMapping(priorityPairs, tagPairs []string) (importMapping, error) {
+	m := importMapping{Priority: map[string]string{}, Tags: map[string]string{}}
+	for k, v := range defaultPriorityLabels {
+		m.Priority[k] = v
+	}
+	for _, p := range priorityPairs {
+		label, prio, ok := strings.Cut(p, "=")
+		prio = strings.ToUpper(strings.TrimSpace(prio))
+		if !ok || strings.TrimSpace(label) == "" || (prio != "H" && prio != "M" && prio != "L") {
+			return m, fmt.Errorf("invalid --priority-map %q — expected label=H|M|L", p)
+		}
+		m.Priority[strings.ToLower(strings.TrimSpace(label))] = prio
+	}
+	for _, p := range tagPairs {
+		label, tag, ok := strings.Cut(p, "=")
+		if !ok || strings.TrimSpace(label) == "" {
+			return m, fmt.Errorf("invalid --label-map %q — expected label=tag (empty tag drops the label)", p)
+		}
+		m.Tags[strings.ToLower(strings.TrimSpace(label))] = strings.TrimSpace(tag)
+	}
+	return m, nil
+}
+
+// apply folds labels into t: priority labels set the priority (the first
+// match wins), everything else becomes a tag after renaming.
+func (m importMapping) apply(t *importedTask, labels []string) {
+	for _, l := range labels {
+		key := strings.ToLower(strings.TrimSpace(l))
+		if key == "" {
+			continue
+		}
+		if prio, ok := m.Priority[key]; ok {
+			if t.Priority == "" {
+				t.Priority = prio
+			}
+			continue
+		}
+		tag := l
+		if renamed, ok := m.Tags[key]; ok {
+			tag = renamed
+		}
+		tag = strings.TrimSpace(tag)
+		if tag != "" && !containsFold(t.Tags, tag) {
+			t.Tags = append(t.Tags, tag)
+		}
+	}
+}
+
+// detectImportFormat sniffs the file contents: JSON arrays are told apart by
+// their field names (gh uses number/title, Taskwarrior uses uuid/description),
+// anything else is treated as a Markdown checklist.
+func detectImportFormat(path string, data []byte) (string, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || trimmed[0] != '[' {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".json":
+			return "", fmt.Errorf("%s: expected a JSON array (gh issue list --json … or task export)", path)
+		}
+		return importFormatMarkdown, nil
+	}
+	var probe []map[string]json.RawMessage
+	if err := json.Unmarshal(trimmed, &probe); err != nil {
+		return "", fmt.Errorf("%s: invalid JSON: %w", path, err)
+	}
+	if len(probe) == 0 {
+		return "", fmt.Errorf("%s: no items to import", path)
+	}
+	first := probe[0]
+	if _, ok := first["uuid"]; ok {
+		return importFormatTaskwarrior, nil
+	}
+	if _, ok := first["entry"]; ok {
+		return importFormatTaskwarrior, nil
+	}
+	if _, ok := first["title"]; ok {
+		return importFormatGitHub, nil
+	}
+	return "", fmt.Errorf("%s: unrecognised JSON — pass --format github or --format taskwarrior", path)
+}
+
+var (
+	checklistRe  = regexp.MustCompile(`^(\s*)[-*+]\s+\[([ xX])\]\s+(.*)$`)
+	bulletRe     = regexp.MustCompile(`^(\s*)[-*+]\s+(.*)$`)
+	inlineTagRe  = regexp.MustCompile(`(?:^|\s)#([\p{L}\p{N}_/-]+)`)
+	inlinePrioRe = regexp.MustCompile(`(?:^|\s)\((?i:(H|M|L))\)(?:\s|$)`)
+)
+
+// parseMarkdownTasks reads a Markdown checklist. Top-level `- [ ]` items
+// become tasks, nested checklist items become subtasks of the nearest task
+// above them, and nested plain bullets become notes. An item deeper than one
+// level becomes a child subtask of the checklist item it is nested under.
+// Inline `#tags` and a `(H)`/`(M)`/`(L)` marker are lifted out of the title.
+func parseMarkdownTasks(data []byte) []importedTask {
+	var out []importedTask
+	topIndent := -1
+	// path holds the open nested checklist items of the current task.
+	type level struct {
+		indent int
+		index  int // position in the task's Subtasks
+	}
+	var path []level
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		line := strings.ReplaceAll(sc.Text(), "\t", "    ")
+		if m := checklistRe.FindStringSubmatch(line); m != nil {
+			indent := len(m[1])
+			done := m[2] != " "
+			text := strings.TrimSpace(m[3])
+			if text == "" {
+				continue
+			}
+			if topIndent < 0 || indent <= topIndent || len(out) == 0 {
+				topIndent = indent
+				path = path[:0]
+				t := importedTask{Completed: done}
+				t.Title, t.Priority, t.Tags = splitInlineMarkers(text)
+				if t.Title != "" {
+					out = append(out, t)
+				}
+				continue
+			}
+			parent := &out[len(out)-1]
+			title, _, _ := splitInlineMarkers(text)
+			for len(path) > 0 && path[len(path)-1].indent >= indent {
+				path = path[:len(path)-1]
+			}
+			sub := importedSubtask{Description: title, Completed: done, Parent: -1}
+			if len(path) > 0 {
+				sub.Parent = path[len(path)-1].index
+			}
+			path = append(path, level{indent: indent, index: len(parent.Subtasks)})
+			parent.Subtasks = append(parent.Subtasks, sub)
+			continue
+		}
+		if m := bulletRe.FindStringSubmatch(line); m != nil && len(out) > 0 && len(m[1]) > topIndent {
+			if note := strings.TrimSpace(m[2]); note != "" {
+				last := &out[len(out)-1]
+				last.Notes = append(last.Notes, models.Annotation{Content: note})
+			}
+		}
+	}
+	return out
+}
+
+// splitInlineMarkers strips `#tag` tokens and a `(H)` priority marker from a
+// checklist line, returning the cleaned title.
+func splitInlineMarkers(text string) (title, priority string, tags []string) {
+	if m := inlinePrioRe.FindStringSubmatch(text); m != nil {
+		priority = strings.ToUpper(m[1])
+		text = inlinePrioRe.ReplaceAllString(text, " ")
+	}
+	for _, m := range inlineTagRe.FindAllStringSubmatch(text, -1) {
+		if !containsFold(tags, m[1]) {
+			tags = append(tags, m[1])
+		}
+	}
+	text = inlineTagRe.ReplaceAllString(text, "")
+	return strings.Join(strings.Fields(text), " "), priority, tags
+}
+
+// ghIssue mirrors the fields of `gh issue list --json number,title,body,labels,state,comments,url`.
+type ghIssue struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	Body   string `json:"body"`
+	State  string `json:"state"`
+	URL    string `json:"url"`
+	Labels []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	Comments []struct {
+		Body   string `json:"body"`
+		Author struct {
+			Login string `json:"login"`
+		} `json:"author"`
+		CreatedAt time.Time `json:"createdAt"`
+	} `json:"comments"`
+}
+
+func parseGitHubIssues(data []byte, mapping importMapping) ([]importedTask, error) {
+	var issues []ghIssue
+	if err := json.Unmarshal(data, &issues); err != nil {
+		return nil, fmt.Errorf("parse gh issue export: %w", err)
+	}
+	out := make([]importedTask, 0, len(issues))
+	for _, is := range issues {
+		title := strings.TrimSpace(is.Title)
+		if title == "" {
+			continue
+		}
+		t := importedTask{
+			Title:       title,
+			Description: strings.TrimSpace(is.Body),
+			Completed:   strings.EqualFold(is.State, "closed"),
+		}
+		if ref := ghIssueRef(is); ref != "" {
+			if t.Description != "" {
+				t.Description += "\n\n"
+			}
+			t.Description += "Imported from " + ref
+		}
+		labels := make([]string, 0, len(is.Labels))
+		for _, l := range is.Labels {
+			labels = append(labels, l.Name)
+		}
+		mapping.apply(&t, labels)
+		for _, c := range is.Comments {
+			body := strings.TrimSpace(c.Body)
+			if body == "" {
+				continue
+			}
+			if c.Author.Login != "" {
+				body = "@" + c.Author.Login + ": " + body
+			}
+			t.Notes = append(t.Notes, models.Annotation{Content: body, CreatedAt: c.CreatedAt})
+		}
+		out = append(out, t)
+	}
+	return out, nil
+}
+
+func ghIssueRef(is ghIssue) string {
+	switch {
+	case is.URL != "":
+		return is.URL
+	case is.Number > 0:
+		return fmt.Sprintf("GitHub issue #%d", is.Number)
+	}
+	return ""
+}
+
+// twTask mirrors the fields of Taskwarrior's `task export` we care about.
+type twTask struct {
+	UUID        string   `json:"uuid"`
+	Description string   `json:"description"`
+	Status      string   `json:"status"`
+	Priority    string   `json:"priority"`
+	Project     string   `json:"project"`
+	Tags        []string `json:"tags"`
+	Annotations []struct {
+		Entry       string `json:"entry"`
+		Description string `json:"description"`
+	} `json:"annotations"`
+}
+
+// taskwarriorTime is the compact ISO format Taskwarrior exports (20240102T150405Z).
+const taskwarriorTime = "20060102T150405Z"
+
+func parseTaskwarrior(data []byte, mapping importMapping) ([]importedTask, error) {
+	var items []twTask
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, fmt.Errorf("parse taskwarrior export: %w", err)
+	}
+	out := make([]importedTask, 0, len(items))
+	for _, it := range items {
+		title := strings.TrimSpace(it.Description)
+		if title == "" || it.Status == "deleted" || it.Status == "recurring" {
+			continue
+		}
+		t := importedTask{
+			Title:     title,
+			Priority:  strings.ToUpper(strings.TrimSpace(it.Priority)),
+			Completed: it.Status == "completed",
+		}
+		if t.Priority != "H" && t.Priority != "M" && t.Priority != "L" {
+			t.Priority = ""
+		}
+		labels := append([]string{}, it.Tags...)
+		if it.Project != "" {
+			labels = append(labels, it.Project)
+		}
+		mapping.apply(&t, labels)
+		for _, a := range it.Annotations {
+			note := strings.TrimSpace(a.Description)
+			if note == "" {
+				continue
+			}
+			ann := models.Annotation{Content: note}
+			if ts, err := time.Parse(taskwarriorTime, a.Entry); err == nil {
+				ann.CreatedAt = ts
+			}
+			t.Notes = append(t.Notes, ann)
+		}
+		out = append(out, t)
+	}
+	return out, nil
+}
+
+// importTitleKey normalises a title for duplicate detection: case-folded with
+// whitespace collapsed.
+func importTitleKey(title string) string {
+	return strings.ToLower(strings.Join(strings.Fields(title), " "))
+}
+
+// dedupeImported splits parsed tasks into those to create and those whose
+// title already exists in the project (or earlier in the same file).
+func dedupeImported(tasks []importedTask, existing map[string]bool) (fresh, dupes []importedTask) {
+	seen := make(map[string]bool, len(existing)+len(tasks))
+	for k := range existing {
+		seen[k] = true
+	}
+	for _, t := range tasks {
+		key := importTitleKey(t.Title)
+		if seen[key] {
+			dupes = append(dupes, t)
+			continue
+		}
+		seen[key] = true
+		fresh = append(fresh, t)
+	}
+	return fresh, dupes
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// importTasksCmd implements `ramorie import tasks <file>`.
+func importTasksCmd() *cli.Command {
+	return &cli.Command{
+		Name:      "tasks",
+		Usage:     "Import tasks from a Markdown checklist, gh issue JSON or Taskwarrior export",
+		ArgsUsage: "<file>",
+		Description: "Formats are detected from the file contents:\n" +
+			"  markdown     - [ ] items; nested checklist items become (child) subtasks, nested bullets become notes\n" +
+			"  github       gh issue list --state all --json number,title,body,labels,state,comments,url\n" +
+			"  taskwarrior  task export\n\n" +
+			"Tasks whose title already exists in the project are skipped.",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "project", Aliases: []string{"p"}, Usage: "Target project (name | short id | UUID). Optional: auto-detected when omitted."},
+			&cli.StringFlag{Name: "format", Aliases: []string{"f"}, Usage: "Force the input format (markdown, github, taskwarrior)"},
+			&cli.BoolFlag{Name: "dry-run", Aliases: []string{"n"}, Usage: "Show what would be imported without creating anything"},
+			&cli.StringSliceFlag{Name: "priority-map", Usage: "Map a label to a priority as label=H|M|L (repeatable)"},
+			&cli.StringSliceFlag{Name: "label-map", Usage: "Rename a label to a tag as label=tag; empty tag drops it (repeatable)"},
+			&cli.StringSliceFlag{Name: "tags", Aliases: []string{"t"}, Usage: "Extra tags added to every imported task"},
+			&cli.StringFlag{Name: "priority", Aliases: []string{"P"}, Usage: "Priority for tasks without one (H, M, L)", Value: "M"},
+			&cli.BoolFlag{Name: "allow-plaintext", Usage: "Import in plaintext when the vault is locked, and add subtasks in plaintext under encrypted tasks"},
+		},
+		Action: func(c *cli.Context) error {
+			if c.NArg() == 0 {
+				return fmt.Errorf("usage: ramorie import tasks <file> [--project name] [--dry-run]")
+			}
+			path := c.Args().First()
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+
+			format := strings.ToLower(c.String("format"))
+			if format == "" {
+				if format, err = detectImportFormat(path, data); err != nil {
+					return err
+				}
+			}
+			mapping, err := newImportMapping(c.StringSlice("priority-map"), c.StringSlice("label-map"))
+			if err != nil {
+				return err
+			}
+
+			var parsed []importedTask
+			switch format {
+			case importFormatMarkdown, "md":
+				parsed = parseMarkdownTasks(data)
+				// Inline #tags go through the same label mapping, so
+				// `#p1` or `#urgent` set the priority.
+				for i := range parsed {
+					labels := parsed[i].Tags
+					parsed[i].Tags = nil
+					mapping.apply(&parsed[i], labels)
+				}
+			case importFormatGitHub, "gh":
+				parsed, err = parseGitHubIssues(data, mapping)
+			case importFormatTaskwarrior, "tw":
+				parsed, err = parseTaskwarrior(data, mapping)
+			default:
+				return fmt.Errorf("unknown format %q (use markdown, github or taskwarrior)", format)
+			}
+			if err != nil {
+				return err
+			}
+			if len(parsed) == 0 {
+				fmt.Println(display.Dim.Render("  nothing to import in " + path))
+				return nil
+			}
+
+			defaultPriority := strings.ToUpper(strings.TrimSpace(c.String("priority")))
+			if defaultPriority != "H" && defaultPriority != "M" && defaultPriority != "L" {
+				return fmt.Errorf("invalid --priority %q (use H, M or L)", c.String("priority"))
+			}
+			for i := range parsed {
+				if parsed[i].Priority == "" {
+					parsed[i].Priority = defaultPriority
+				}
+				for _, tag := range c.StringSlice("tags") {
+					if !containsFold(parsed[i].Tags, tag) {
+						parsed[i].Tags = append(parsed[i].Tags, tag)
+					}
+				}
+			}
+
+			client := api.NewClient()
+			projectID, err := resolve.AutoResolveProject(c.String("project"), client)
+			if err != nil {
+				return err
+			}
+
+			existing, err := existingTaskTitles(client, projectID)
+			if err != nil {
+				fmt.Println(apierrors.ParseAPIError(err))
+				return err
+			}
+			fresh, dupes := dedupeImported(parsed, existing)
+
+			fmt.Println(display.Header(fmt.Sprintf("📥 %d task(s) from %s", len(parsed), filepath.Base(path)),
+				fmt.Sprintf("format: %s · new: %d · duplicates: %d", format, len(fresh), len(dupes))))
+			fmt.Println()
+
+			if c.Bool("dry-run") {
+				printImportPlan(fresh, dupes)
+				fmt.Println()
+				fmt.Println(display.Dim.Render("  dry run — nothing was created"))
+				return nil
+			}
+			if len(fresh) == 0 {
+				fmt.Println(display.Dim.Render("  every task already exists — nothing to do"))
+				return nil
+			}
+
+			projects, err := client.ListProjects()
+			if err != nil {
+				return fmt.Errorf("could not fetch projects: %w", err)
+			}
+			isOrgProject := false
+			for _, p := range projects {
+				if p.ID.String() == projectID && p.OrganizationID != nil {
+					isOrgProject = true
+					break
+				}
+			}
+			encrypt := encstate.ShouldEncryptPersonal(encstate.FetcherFor(client)) && !isOrgProject
+			if encrypt && !crypto.IsVaultUnlocked() {
+				if !c.Bool("allow-plaintext") {
+					return fmt.Errorf("encryption is enabled but the vault is locked — run 'ramorie vault unlock' first, or pass --allow-plaintext to import in plaintext")
+				}
+				fmt.Println(display.Warn.Render("⚠ vault locked: importing in plaintext (--allow-plaintext)"))
+				encrypt = false
+			}
+			// The subtask API has no encrypted form, so checklist items under
+			// an encrypted task would be the only plaintext part of it.
+			if encrypt {
+				if n := countImportedSubtasks(fresh); n > 0 {
+					if !c.Bool("allow-plaintext") {
+						return fmt.Errorf("tasks will be encrypted but their %d subtask(s) would be stored in plaintext — pass --allow-plaintext to add them anyway", n)
+					}
+					fmt.Println(display.Warn.Render("⚠ adding subtasks in plaintext under encrypted tasks (--allow-plaintext)"))
+				}
+			}
+
+			created, failed := 0, 0
+			var ops []journal.Op
+			for _, t := range fresh {
+				task, err := createImportedTask(client, projectID, t, encrypt)
+				if err != nil {
+					failed++
+					fmt.Printf("%s %s: %v\n", display.Err.Render("✗"), t.Title, apierrors.ParseAPIError(err))
+					continue
+				}
+				created++
+				id := task.ID.String()
+				ops = append(ops, journal.Create(journal.KindTask, id))
+				createImportedSubtasks(client, id, t.Subtasks)
+				for _, n := range t.Notes {
+					if err := createImportedNote(client, id, n, encrypt); err != nil {
+						fmt.Printf("  ⚠️  note: %v\n", err)
+					}
+				}
+				if t.Completed {
+					if err := client.CompleteTask(id); err != nil {
+						fmt.Printf("  ⚠️  could not mark completed: %v\n", err)
+					}
+				}
+				fmt.Printf("%s %s %s\n", display.Good.Render("✓"), display.Dim.Render(id[:8]), t.Title)
+			}
+
+			journal.Record("import tasks", fmt.Sprintf("%d task(s) from %s", created, c.Args().First()), ops...)
+
+			fmt.Println()
+			summary := fmt.Sprintf("Imported %d task(s)", created)
+			if len(dupes) > 0 {
+				summary += fmt.Sprintf(", skipped %d duplicate(s)", len(dupes))
+			}
+			if failed > 0 {
+				summary += fmt.Sprintf(", %d failed", failed)
+				fmt.Println(display.Warn.Render(summary))
+				return fmt.Errorf("%d task(s) failed to import", failed)
+			}
+			fmt.Println(display.Good.Render(summary))
+			return nil
+		},
+	}
+}
+
+// existingTaskTitles returns the normalised titles of every task already in
+// the project, decrypting where the vault allows.
+func existingTaskTitles(client *api.Client, projectID string) (map[string]bool, error) {
+	titles := map[string]bool{}
+	for page := 1; ; page++ {
+		items, hasMore, err := client.ListTasksPage(projectID, "", page, 100)
+		if err != nil {
+			return nil, err
+		}
+		for i := range items {
+			title, _ := decryptTaskForCLI(&items[i])
+			titles[importTitleKey(title)] = true
+		}
+		if !hasMore {
+			return titles, nil
+		}
+	}
+}
+
+func createImportedTask(client *api.Client, projectID string, t importedTask, encrypt bool) (*models.Task, error) {
+	if !encrypt {
+		return client.CreateTask(projectID, t.Title, t.Description, t.Priority, t.Tags...)
+	}
+	encTitle, titleNonce, titleEncrypted, err := crypto.EncryptContent(t.Title)
+	if err != nil {
+		return nil, fmt.Errorf("encryption failed: %w", err)
+	}
+	if !titleEncrypted {
+		return client.CreateTask(projectID, t.Title, t.Description, t.Priority, t.Tags...)
+	}
+	encDesc, descNonce := "", ""
+	if t.Description != "" {
+		if encDesc, descNonce, _, err = crypto.EncryptContent(t.Description); err != nil {
+			return nil, fmt.Errorf("encryption failed: %w", err)
+		}
+	}
+	return client.CreateEncryptedTask(projectID, encTitle, titleNonce, encDesc, descNonce, t.Priority, t.Tags...)
+}
+
+// countImportedSubtasks returns the number of subtasks across tasks.
+func countImportedSubtasks(tasks []importedTask) int {
+	n := 0
+	for _, t := range tasks {
+		n += len(t.Subtasks)
+	}
+	return n
+}
+
+// createImportedSubtasks creates a task's subtasks in order, nesting each
+// under its parent. A subtask whose parent failed is added to the task
+// directly.
+func createImportedSubtasks(client *api.Client, taskID string, subtasks []importedSubtask) {
+	ids := make([]string, len(subtasks))
+	for i, st := range subtasks {
+		var sub *models.Subtask
+		var err error
+		if st.Parent >= 0 && ids[st.Parent] != "" {
+			sub, err = client.CreateChildSubtask(taskID, ids[st.Parent], st.Description)
+		} else {
+			sub, err = client.CreateSubtask(taskID, st.Description)
+		}
+		if err != nil {
+			fmt.Printf("  ⚠️  subtask %q: %v\n", st.Description, err)
+			continue
+		}
+		ids[i] = sub.ID.String()
+		if st.Completed {
+			_, _ = client.CompleteSubtask(ids[i])
+		}
+	}
+}
+
+// createImportedNote stores one source comment/annotation. The original
+// timestamp is kept in the text since the API stamps notes at creation time.
+func createImportedNote(client *api.Client, taskID string, n models.Annotation, encrypt bool) error {
+	content := n.Content
+	if !n.CreatedAt.IsZero() {
+		content = fmt.Sprintf("[%s] %s", n.CreatedAt.Format("2006-01-02"), content)
+	}
+	if encrypt {
+		enc, nonce, ok, err := crypto.EncryptContent(content)
+		if err != nil {
+			return fmt.Errorf("encryption failed: %w", err)
+		}
+		if ok {
+			_, err = client.CreateEncryptedAnnotation(taskID, enc, nonce)
+			return err
+		}
+	}
+	_, err := client.CreateAnnotation(taskID, content)
+	return err
+}
+
+func printImportPlan(fresh, dupes []importedTask) {
+	cols := []display.Column{
+		{Title: "", Min: 4, Weight: 0},
+		{Title: "P", Min: 3, Weight: 0},
+		{Title: "TITLE", Min: 24, Weight: 4},
+		{Title: "TAGS", Min: 14, Weight: 1},
+		{Title: "SUB", Min: 3, Weight: 0},
+		{Title: "NOTES", Min: 5, Weight: 0},
+	}
+	rows := make([][]string, 0, len(fresh)+len(dupes))
+	add := func(mark string, t importedTask) {
+		if t.Completed {
+			mark += " ✓"
+		}
+		rows = append(rows, []string{
+			mark,
+			display.PriorityBadge(t.Priority),
+			display.SingleLine(t.Title),
+			display.Tags(t.Tags, 3),
+			fmt.Sprintf("%d", len(t.Subtasks)),
+			fmt.Sprintf("%d", len(t.Notes)),
+		})
+	}
+	for _, t := range fresh {
+		add(display.Good.Render("+"), t)
+	}
+	for _, t := range dupes {
+		add(display.Dim.Render("="), t)
+	}
+	fmt.Println(display.NewResponsiveTable(cols, rows))
+}
@@ -0,0 +1,688 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/kutbudev/ramorie-cli/internal/api"
+	"github.com/kutbudev/ramorie-cli/internal/cli/display"
+	"github.com/kutbudev/ramorie-cli/internal/cli/resolve"
+	"github.com/kutbudev/ramorie-cli/internal/constants"
+	"github.com/kutbudev/ramorie-cli/internal/crypto"
+	"github.com/kutbudev/ramorie-cli/internal/encstate"
+	apierrors "github.com/kutbudev/ramorie-cli/internal/errors"
+	"github.com/kutbudev/ramorie-cli/internal/journal"
+	"github.com/kutbudev/ramorie-cli/internal/mcp"
+	"github.com/kutbudev/ramorie-cli/internal/memrev"
+	"github.com/kutbudev/ramorie-cli/internal/models"
+	"github.com/kutbudev/ramorie-cli/internal/secrets"
+	"github.com/kutbudev/ramorie-cli/internal/statefile"
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// markdownNote is one note of a Markdown/Obsidian vault, ready to become a
+// memory.
+type markdownNote struct {
+	Path    string // absolute path, the ledger key
+	Rel     string // path relative to the vault root, for display
+	Title   string
+	Type    string
+	Tags    []string
+	Aliases []string
+	Content string   // memory content: "# Title" plus the note body
+	Links   []string // distinct [[wikilink]] targets, by note title
+}
+
+// markdownFront is the front matter Obsidian and most static-site tools
+// write. Tags and aliases may be a list or a comma/space separated string.
+type markdownFront struct {
+	Title   string         `yaml:"title"`
+	Type    string         `yaml:"type"`
+	Tags    yamlStringList `yaml:"tags"`
+	Aliases yamlStringList `yaml:"aliases"`
+}
+
+// yamlStringList accepts both `tags: [a, b]` and `tags: a, b`.
+type yamlStringList []string
+
+func (l *yamlStringList) UnmarshalYAML(n *yaml.Node) error {
+	switch n.Kind {
+	case yaml.ScalarNode:
+		*l = strings.FieldsFunc(n.Value, func(r rune) bool { return r == ',' || r == ' ' })
+		return nil
+	case yaml.SequenceNode:
+		var items []string
+		if err := n.Decode(&items); err != nil {
+			return err
+		}
+		*l = items
+		return nil
+	}
+	return fmt.Errorf("line %d: expected a string or a list", n.Line)
+}
+
+// markdownTypeAliases maps common note-type names onto memory types.
+var markdownTypeAliases = map[string]string{
+	"adr":      mcp.MemoryTypeDecision,
+	"bug":      mcp.MemoryTypeBugFix,
+	"bugfix":   mcp.MemoryTypeBugFix,
+	"bug-fix":  mcp.MemoryTypeBugFix,
+	"fix":      mcp.MemoryTypeBugFix,
+	"link":     mcp.MemoryTypeReference,
+	"ref":      mcp.MemoryTypeReference,
+	"runbook":  mcp.MemoryTypeSkill,
+	"howto":    mcp.MemoryTypeSkill,
+	"how-to":   mcp.MemoryTypeSkill,
+	"note":     mcp.MemoryTypeGeneral,
+	"practice": mcp.MemoryTypePattern,
+}
+
+// wikilinkRe matches [[Target]], [[Target|Label]], [[Target#Heading]] and
+// embeds (![[...]]); group 1 is the embed bang, group 2 the target.
+var wikilinkRe = regexp.MustCompile(`(!?)\[\[([^\]|#^]*)(?:[#^][^\]|]*)?(?:\|[^\]]*)?\]\]`)
+
+// parseMarkdownNote turns a note file into a markdownNote. Front matter
+// tags and type are kept; without a valid type one is inferred from the
+// body. Returns an empty Content for notes with nothing but front matter.
+func parseMarkdownNote(abs, rel string, data []byte) (markdownNote, error) {
+	n := markdownNote{Path: abs, Rel: rel, Title: strings.TrimSuffix(path.Base(rel), path.Ext(rel))}
+	doc := strings.ReplaceAll(string(data), "\r\n", "\n")
+	body := doc
+	var front markdownFront
+	if strings.HasPrefix(doc, editDelimiter+"\n") {
+		var err error
+		if body, err = parseEditDoc(doc, &front); err != nil {
+			return n, fmt.Errorf("%s: %w", rel, err)
+		}
+	}
+	if t := strings.TrimSpace(front.Title); t != "" {
+		n.Title = t
+	}
+	for _, tag := range front.Tags {
+		n.Tags = append(n.Tags, strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+	}
+	n.Tags = cleanEditTags(n.Tags)
+	n.Aliases = cleanEditTags(front.Aliases)
+
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return n, nil
+	}
+	n.Type = markdownMemoryType(front.Type, body)
+	n.Content = body
+	if !strings.HasPrefix(body, "# ") {
+		n.Content = "# " + n.Title + "\n\n" + body
+	}
+	n.Links = wikilinkTargets(body, n.Title)
+	return n, nil
+}
+
+// markdownMemoryType validates a front-matter type, falling back to
+// mcp.DetectMemoryType.
+func markdownMemoryType(frontType, body string) string {
+	t := strings.ToLower(strings.TrimSpace(frontType))
+	if mcp.IsValidMemoryType(t) {
+		return t
+	}
+	if alias, ok := markdownTypeAliases[t]; ok {
+		return alias
+	}
+	return mcp.DetectMemoryType(body)
+}
+
+// wikilinkTargets returns the distinct note titles body links to, in order
+// of first appearance. Folder prefixes and a .md extension are dropped;
+// embedded attachments (![[diagram.png]]) and self-links are skipped.
+func wikilinkTargets(body, self string) []string {
+	var out []string
+	seen := map[string]bool{strings.ToLower(self): true}
+	for _, m := range wikilinkRe.FindAllStringSubmatch(body, -1) {
+		target := strings.TrimSpace(m[2])
+		if ext := strings.ToLower(path.Ext(target)); ext == ".md" {
+			target = strings.TrimSuffix(target, path.Ext(target))
+		} else if m[1] == "!" && ext != "" {
+			continue
+		}
+		target = path.Base(target)
+		if target == "" || target == "." || target == "/" || seen[strings.ToLower(target)] {
+			continue
+		}
+		seen[strings.ToLower(target)] = true
+		out = append(out, target)
+	}
+	return out
+}
+
+// walkMarkdownNotes reads every .md/.markdown file under root, skipping
+// hidden directories such as .obsidian, .trash and .git.
+func walkMarkdownNotes(root string) ([]markdownNote, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+	var notes []markdownNote
+	err = filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if p != root && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if ext := strings.ToLower(filepath.Ext(p)); ext != ".md" && ext != ".markdown" {
+			return nil
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(root, p)
+		n, err := parseMarkdownNote(p, filepath.ToSlash(rel), data)
+		if err != nil {
+			return err
+		}
+		notes = append(notes, n)
+		return nil
+	})
+	sort.Slice(notes, func(i, j int) bool { return notes[i].Rel < notes[j].Rel })
+	return notes, err
+}
+
+// markdownLedgerEntry records what a note was imported as.
+type markdownLedgerEntry struct {
+	MemoryID string   `json:"memory_id"`
+	Hash     string   `json:"hash"`
+	Links    []string `json:"links,omitempty"`
+}
+
+// markdownLedger remembers imported notes per project, keyed by absolute
+// path, so re-running an import only touches new or changed notes. It
+// lives in ~/.ramorie/imports/markdown.json.
+type markdownLedger struct {
+	Path     string                                    `json:"-"`
+	Projects map[string]map[string]markdownLedgerEntry `json:"projects"`
+}
+
+func openMarkdownLedger() (*markdownLedger, error) {
+	p, err := statefile.Path("imports", "markdown.json")
+	if err != nil {
+		return nil, err
+	}
+	return loadMarkdownLedger(p)
+}
+
+func loadMarkdownLedger(p string) (*markdownLedger, error) {
+	l := &markdownLedger{Path: p, Projects: map[string]map[string]markdownLedgerEntry{}}
+	data, err := os.ReadFile(p)
+	if os.IsNotExist(err) {
+		return l, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, l); err != nil {
+		return nil, fmt.Errorf("corrupt import ledger %s: %w", p, err)
+	}
+	if l.Projects == nil {
+		l.Projects = map[string]map[string]markdownLedgerEntry{}
+	}
+	return l, nil
+}
+
+// project returns the entries for projectID, creating the map if needed.
+func (l *markdownLedger) project(projectID string) map[string]markdownLedgerEntry {
+	if l.Projects[projectID] == nil {
+		l.Projects[projectID] = map[string]markdownLedgerEntry{}
+	}
+	return l.Projects[projectID]
+}
+
+func (l *markdownLedger) save() error {
+	return statefile.WriteJSON(l.Path, l)
+}
+
+// What happens to a note on import.
+const (
+	mdCreate    = "create"
+	mdUpdate    = "update"
+	mdUnchanged = "unchanged"
+	mdExists    = "exists" // same content already in the project, adopted without a write
+	mdEmpty     = "empty"
+)
+
+type markdownPlanItem struct {
+	Note     markdownNote
+	Action   string
+	Hash     string
+	MemoryID string // set for update, unchanged and exists
+}
+
+// planMarkdownImport decides each note's action from the ledger and the
+// content hashes (hash → memory ID) already in the project.
+func planMarkdownImport(notes []markdownNote, entries map[string]markdownLedgerEntry, existing map[string]string) []markdownPlanItem {
+	plan := make([]markdownPlanItem, 0, len(notes))
+	for _, n := range notes {
+		item := markdownPlanItem{Note: n}
+		if n.Content == "" {
+			item.Action = mdEmpty
+			plan = append(plan, item)
+			continue
+		}
+		item.Hash = crypto.ComputeContentHash(n.Content)
+		entry, tracked := entries[n.Path]
+		switch {
+		case tracked && entry.Hash == item.Hash:
+			item.Action, item.MemoryID = mdUnchanged, entry.MemoryID
+		case tracked:
+			item.Action, item.MemoryID = mdUpdate, entry.MemoryID
+		case existing[item.Hash] != "":
+			item.Action, item.MemoryID = mdExists, existing[item.Hash]
+		default:
+			item.Action = mdCreate
+		}
+		plan = append(plan, item)
+	}
+	return plan
+}
+
+// existingMemoryHashes hashes the content of every memory in the project
+// that can be read (encrypted ones need the unlocked vault).
+func existingMemoryHashes(client *api.Client, projectID string) (map[string]string, error) {
+	hashes := map[string]string{}
+	for page := 1; ; page++ {
+		items, hasMore, err := client.ListMemoriesPage(projectID, "", page, 100)
+		if err != nil {
+			return nil, err
+		}
+		for i := range items {
+			m := &items[i]
+			if m.IsEncrypted && !crypto.IsVaultUnlocked() {
+				continue
+			}
+			if content := decryptMemoryForCLI(m); content != "" {
+				hashes[crypto.ComputeContentHash(content)] = m.ID.String()
+			}
+		}
+		if !hasMore || len(items) == 0 {
+			return hashes, nil
+		}
+	}
+}
+
+// markdownImporter holds the per-run state of `import markdown`.
+type markdownImporter struct {
+	client    *api.Client
+	projectID string
+	encrypt   bool
+	isOrg     bool              // org projects skip personal-vault encryption
+	titles    map[string]bool   // lower-cased titles and aliases of notes in the vault
+	entities  map[string]string // lower-cased name → entity ID
+}
+
+// create stores a note as a new memory after the secret scan, encrypted when
+// the vault or the scanner requires it.
+func (im *markdownImporter) create(item markdownPlanItem) (*models.Memory, error) {
+	n := item.Note
+	scan, err := checkSecrets(im.client, im.projectID, n.Content)
+	if err != nil {
+		return nil, err
+	}
+	content := scan.Content
+	encrypt := im.encrypt
+	if scan.Encrypt && !encrypt {
+		if !crypto.IsVaultUnlocked() || im.isOrg {
+			return nil, secrets.ErrNeedsEncryption
+		}
+		encrypt = true
+	}
+	if encrypt {
+		enc, nonce, encrypted, err := crypto.EncryptContent(content)
+		if err != nil {
+			return nil, fmt.Errorf("encryption failed: %w", err)
+		}
+		if encrypted {
+			return im.client.CreateEncryptedMemoryWithOptions(api.CreateEncryptedMemoryOptions{
+				ProjectID:        im.projectID,
+				EncryptedContent: enc,
+				ContentNonce:     nonce,
+				ContentHash:      crypto.ComputeContentHash(content),
+				Type:             n.Type,
+				Tags:             n.Tags,
+			})
+		}
+		if scan.Encrypt {
+			return nil, secrets.ErrNeedsEncryption
+		}
+	}
+	return im.client.CreateMemoryWithOptions(api.CreateMemoryOptions{
+		ProjectID: im.projectID,
+		Content:   content,
+		Type:      n.Type,
+		Tags:      n.Tags,
+	})
+}
+
+// update rewrites a previously imported memory whose note changed,
+// re-encrypting under the memory's own scope. It returns the journal op.
+func (im *markdownImporter) update(item markdownPlanItem) (journal.Op, error) {
+	before, err := im.client.GetMemory(item.MemoryID)
+	if err != nil {
+		return journal.Op{}, err
+	}
+	if before.IsEncrypted && !crypto.IsVaultUnlocked() {
+		return journal.Op{}, fmt.Errorf("memory is encrypted and the vault is locked — run 'ramorie vault unlock' first")
+	}
+	n := item.Note
+	updates := map[string]interface{}{"content": n.Content, "type": n.Type, "tags": n.Tags}
+	fields := updateFields(updates)
+	if err := sealMemoryContent(im.client, before, updates); err != nil {
+		return journal.Op{}, err
+	}
+	if _, err := memrev.Update(im.client, item.MemoryID, updates, "import"); err != nil {
+		return journal.Op{}, err
+	}
+	return journal.Update(journal.KindMemory, item.MemoryID, journal.MemorySnapshot(before), fields...), nil
+}
+
+// entity returns the ID of the graph entity named name, creating it when the
+// project has none. Notes in the vault become documents; link targets with
+// no note (Obsidian's unresolved links) become concepts.
+func (im *markdownImporter) entity(name string, aliases []string) (string, error) {
+	key := strings.ToLower(name)
+	if id, ok := im.entities[key]; ok {
+		return id, nil
+	}
+	typ := models.GraphEntityTypeConcept
+	if im.titles[key] {
+		typ = models.GraphEntityTypeDocument
+	}
+	found, err := im.client.ListEntities(string(typ), im.projectID, name, 20, 0)
+	if err != nil {
+		return "", err
+	}
+	for _, e := range found.Entities {
+		if strings.EqualFold(e.Name, name) {
+			im.entities[key] = e.ID.String()
+			return e.ID.String(), nil
+		}
+	}
+	projectID := im.projectID
+	e, err := im.client.CreateEntity(&models.CreateEntityRequest{Name: name, Type: typ, Aliases: aliases, ProjectID: &projectID})
+	if err != nil {
+		return "", err
+	}
+	im.entities[key] = e.ID.String()
+	return e.ID.String(), nil
+}
+
+// link creates a references relationship from the note to each target not
+// linked before, and returns the targets now linked.
+func (im *markdownImporter) link(n markdownNote, memoryID string, done []string) ([]string, error) {
+	var todo []string
+	for _, t := range n.Links {
+		if !containsFold(done, t) {
+			todo = append(todo, t)
+		}
+	}
+	if len(todo) == 0 {
+		return done, nil
+	}
+	source, err := im.entity(n.Title, n.Aliases)
+	if err != nil {
+		return done, err
+	}
+	for _, t := range todo {
+		target, err := im.entity(t, nil)
+		if err != nil {
+			return done, err
+		}
+		_, err = im.client.CreateRelationship(&models.CreateRelationshipRequest{
+			SourceEntityID:   source,
+			TargetEntityID:   target,
+			RelationshipType: models.RelationshipReferences,
+			SourceMemoryID:   &memoryID,
+		})
+		if err != nil {
+			return done, err
+		}
+		done = append(done, t)
+	}
+	return done, nil
+}
+
+// importMarkdownCmd implements `ramorie import markdown`.
+func importMarkdownCmd() *cli.Command {
+	return &cli.Command{
+		Name:      "markdown",
+		Aliases:   []string{"md", "obsidian"},
+		Usage:     "Import a Markdown or Obsidian folder, one memory per note",
+		ArgsUsage: "<dir>",
+		Description: "Every .md file under <dir> becomes a memory (hidden folders such as .obsidian\n" +
+			"are skipped). Front matter `tags` and `type` are kept; notes without a type get\n" +
+			"one inferred from their content. [[wikilinks]] become `references` relationships\n" +
+			"between note entities in the knowledge graph. Entity names are note titles and\n" +
+			"are not encrypted, so an encrypted import skips the graph unless --links is\n" +
+			"passed; --no-links always skips it.\n\n" +
+			"Re-running is safe: imported notes are tracked by content hash in\n" +
+			"~/.ramorie/imports/markdown.json, so unchanged notes are skipped, edited notes\n" +
+			"update their memory, and notes whose content already exists in the project are\n" +
+			"adopted instead of duplicated.",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "project", Aliases: []string{"p"}, Usage: "Target project (name | short id | UUID). Optional: auto-detected when omitted."},
+			&cli.BoolFlag{Name: "dry-run", Aliases: []string{"n"}, Usage: "Show what would be imported without creating anything"},
+			&cli.StringSliceFlag{Name: "tags", Aliases: []string{"t"}, Usage: "Extra tags added to every imported memory"},
+			&cli.BoolFlag{Name: "no-links", Usage: "Do not turn [[wikilinks]] into entity relationships"},
+			&cli.BoolFlag{Name: "links", Usage: "Create wikilink relationships for an encrypted import too (note titles are stored in plaintext)"},
+			&cli.BoolFlag{Name: "allow-plaintext", Usage: "Import in plaintext when encryption is enabled but the vault is locked"},
+		},
+		Action: func(c *cli.Context) error {
+			if c.NArg() == 0 {
+				return fmt.Errorf("usage: ramorie import markdown <dir> [--project name] [--dry-run]")
+			}
+			dir := c.Args().First()
+			if info, err := os.Stat(dir); err != nil {
+				return err
+			} else if !info.IsDir() {
+				return fmt.Errorf("%s is not a directory", dir)
+			}
+			notes, err := walkMarkdownNotes(dir)
+			if err != nil {
+				return err
+			}
+			if len(notes) == 0 {
+				fmt.Println(display.Dim.Render("  no Markdown notes in " + dir))
+				return nil
+			}
+			for i := range notes {
+				for _, tag := range c.StringSlice("tags") {
+					if !containsFold(notes[i].Tags, tag) {
+						notes[i].Tags = append(notes[i].Tags, tag)
+					}
+				}
+			}
+
+			client := api.NewClient()
+			projectID, err := resolve.AutoResolveProject(c.String("project"), client)
+			if err != nil {
+				return err
+			}
+			ledger, err := openMarkdownLedger()
+			if err != nil {
+				return err
+			}
+			existing, err := existingMemoryHashes(client, projectID)
+			if err != nil {
+				fmt.Println(apierrors.ParseAPIError(err))
+				return err
+			}
+			entries := ledger.project(projectID)
+			plan := planMarkdownImport(notes, entries, existing)
+			counts := map[string]int{}
+			for _, item := range plan {
+				counts[item.Action]++
+			}
+
+			fmt.Println(display.Header(fmt.Sprintf("📥 %d note(s) from %s", len(notes), filepath.Base(filepath.Clean(dir))),
+				fmt.Sprintf("new: %d · changed: %d · unchanged: %d · already in project: %d",
+					counts[mdCreate], counts[mdUpdate], counts[mdUnchanged], counts[mdExists])))
+			fmt.Println()
+
+			if c.Bool("dry-run") {
+				printMarkdownImportPlan(plan)
+				fmt.Println()
+				fmt.Println(display.Dim.Render("  dry run — nothing was created"))
+				return nil
+			}
+
+			projects, err := client.ListProjects()
+			if err != nil {
+				return fmt.Errorf("could not fetch projects: %w", err)
+			}
+			isOrgProject := false
+			for _, p := range projects {
+				if p.ID.String() == projectID && p.OrganizationID != nil {
+					isOrgProject = true
+					break
+				}
+			}
+			encrypt := encstate.ShouldEncryptPersonal(encstate.FetcherFor(client)) && !isOrgProject
+			if encrypt && !crypto.IsVaultUnlocked() {
+				if !c.Bool("allow-plaintext") {
+					return fmt.Errorf("encryption is enabled but the vault is locked — run 'ramorie vault unlock' first, or pass --allow-plaintext to import in plaintext")
+				}
+				fmt.Println(display.Warn.Render("⚠ vault locked: importing in plaintext (--allow-plaintext)"))
+				encrypt = false
+			}
+			im := &markdownImporter{
+				client:    client,
+				projectID: projectID,
+				encrypt:   encrypt,
+				isOrg:     isOrgProject,
+				titles:    map[string]bool{},
+				entities:  map[string]string{},
+			}
+			for _, n := range notes {
+				im.titles[strings.ToLower(n.Title)] = true
+			}
+			// Entity names and relationships have no encrypted form, so the
+			// graph would expose every encrypted note's title and links.
+			linksWithheld := encrypt && !c.Bool("links") && !c.Bool("no-links")
+			skipLinks := c.Bool("no-links") || linksWithheld
+
+			var ops []journal.Op
+			created, updated, linked, failed := 0, 0, 0, 0
+			fail := func(n markdownNote, err error) {
+				failed++
+				fmt.Printf("%s %s: %v\n", display.Err.Render("✗"), n.Rel, apierrors.ParseAPIError(err))
+			}
+			for _, item := range plan {
+				n := item.Note
+				entry := entries[n.Path]
+				switch item.Action {
+				case mdEmpty:
+					continue
+				case mdCreate:
+					if !constants.IsWithinMemoryLimit(n.Content) {
+						fail(n, fmt.Errorf("note exceeds the %d character memory limit", constants.MaxMemoryChars))
+						continue
+					}
+					m, err := im.create(item)
+					if err != nil {
+						fail(n, err)
+						continue
+					}
+					created++
+					entry = markdownLedgerEntry{MemoryID: m.ID.String()}
+					ops = append(ops, journal.Create(journal.KindMemory, entry.MemoryID))
+					fmt.Printf("%s %s %s\n", display.Good.Render("✓"), display.Dim.Render(shortID(entry.MemoryID)), n.Rel)
+				case mdUpdate:
+					op, err := im.update(item)
+					if err != nil {
+						fail(n, err)
+						continue
+					}
+					updated++
+					ops = append(ops, op)
+					fmt.Printf("%s %s %s %s\n", display.Good.Render("↻"), display.Dim.Render(shortID(item.MemoryID)), n.Rel, display.Dim.Render("updated"))
+				case mdExists:
+					entry = markdownLedgerEntry{MemoryID: item.MemoryID}
+				}
+				entry.Hash = item.Hash
+				entries[n.Path] = entry
+
+				if skipLinks {
+					continue
+				}
+				before := len(entry.Links)
+				entry.Links, err = im.link(n, entry.MemoryID, entry.Links)
+				linked += len(entry.Links) - before
+				entries[n.Path] = entry
+				if err != nil {
+					fmt.Printf("  ⚠️  %s links: %v\n", n.Rel, apierrors.ParseAPIError(err))
+				}
+			}
+
+			if err := ledger.save(); err != nil {
+				fmt.Printf("  ⚠️  could not save the import ledger: %v\n", err)
+			}
+			journal.Record("import markdown", fmt.Sprintf("%d note(s) from %s", created+updated, dir), ops...)
+
+			fmt.Println()
+			summary := fmt.Sprintf("Imported %d note(s), updated %d, unchanged %d", created, updated, counts[mdUnchanged]+counts[mdExists])
+			if linked > 0 {
+				summary += fmt.Sprintf(", %d new link(s)", linked)
+			}
+			if counts[mdEmpty] > 0 {
+				summary += fmt.Sprintf(", %d empty note(s) skipped", counts[mdEmpty])
+			}
+			if linksWithheld {
+				summary += ", links skipped (encrypted import — pass --links to add them in plaintext)"
+			}
+			if failed > 0 {
+				summary += fmt.Sprintf(", %d failed", failed)
+				fmt.Println(display.Warn.Render(summary))
+				return fmt.Errorf("%d note(s) failed to import", failed)
+			}
+			fmt.Println(display.Good.Render(summary))
+			return nil
+		},
+	}
+}
+
+func printMarkdownImportPlan(plan []markdownPlanItem) {
+	cols := []display.Column{
+		{Title: "", Min: 2, Weight: 0},
+		{Title: "NOTE", Min: 24, Weight: 4},
+		{Title: "TYPE", Min: 10, Weight: 0},
+		{Title: "TAGS", Min: 14, Weight: 1},
+		{Title: "LINKS", Min: 5, Weight: 0},
+	}
+	marks := map[string]string{
+		mdCreate:    display.Good.Render("+"),
+		mdUpdate:    display.Warn.Render("~"),
+		mdUnchanged: display.Dim.Render("="),
+		mdExists:    display.Dim.Render("="),
+		mdEmpty:     display.Dim.Render("·"),
+	}
+	rows := make([][]string, 0, len(plan))
+	for _, item := range plan {
+		rows = append(rows, []string{
+			marks[item.Action],
+			item.Note.Rel,
+			display.TypeBadge(item.Note.Type),
+			display.Tags(item.Note.Tags, 3),
+			fmt.Sprintf("%d", len(item.Note.Links)),
+		})
+	}
+	fmt.Println(display.NewResponsiveTable(cols, rows))
+}
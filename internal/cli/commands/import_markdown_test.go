@@ -0,0 +1,119 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseMarkdownNote(t *testing.T) {
+	doc := "---\ntags: [infra, \"#deploy\"]\ntype: ADR\naliases: Prod Deploys\n---\nWe chose Railway over Fly.\nSee [[Runbooks/Deploy.md|the runbook]] and [[Postgres#Backups]], [[postgres]] again.\n![[diagram.png]] ![[Embedded Note]] [[ADR - Hosting]]\n"
+	n, err := parseMarkdownNote("/v/ADR - Hosting.md", "ADR - Hosting.md", []byte(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n.Title != "ADR - Hosting" || n.Type != "decision" {
+		t.Errorf("title/type = %q/%q", n.Title, n.Type)
+	}
+	if strings.Join(n.Tags, ",") != "infra,deploy" || strings.Join(n.Aliases, ",") != "Prod,Deploys" {
+		t.Errorf("tags %q aliases %q", n.Tags, n.Aliases)
+	}
+	if !strings.HasPrefix(n.Content, "# ADR - Hosting\n\nWe chose Railway") {
+		t.Errorf("content = %q", n.Content)
+	}
+	if got := strings.Join(n.Links, "|"); got != "Deploy|Postgres|Embedded Note" {
+		t.Errorf("links = %q", got)
+	}
+
+	// No front matter: the type is inferred and an existing heading kept.
+	n, err = parseMarkdownNote("/v/fix.md", "fix.md", []byte("# Login fix\nFixed the bug where the session token expired early."))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n.Type != "bug_fix" || !strings.HasPrefix(n.Content, "# Login fix\n") {
+		t.Errorf("inferred note = %+v", n)
+	}
+
+	if n, _ := parseMarkdownNote("/v/empty.md", "empty.md", []byte("---\ntags: x\n---\n\n")); n.Content != "" {
+		t.Errorf("front matter only should be empty, got %q", n.Content)
+	}
+	if _, err := parseMarkdownNote("/v/bad.md", "bad.md", []byte("---\ntags: [a\n---\nbody")); err == nil {
+		t.Error("broken front matter should fail")
+	}
+}
+
+func TestWalkMarkdownNotesSkipsHiddenFolders(t *testing.T) {
+	root := t.TempDir()
+	for p, body := range map[string]string{
+		"a.md":                   "alpha",
+		"sub/b.markdown":         "beta",
+		"sub/c.txt":              "not a note",
+		".obsidian/workspace.md": "settings",
+		".trash/old.md":          "deleted",
+	} {
+		full := filepath.Join(root, p)
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	notes, err := walkMarkdownNotes(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rels []string
+	for _, n := range notes {
+		rels = append(rels, n.Rel)
+	}
+	if strings.Join(rels, ",") != "a.md,sub/b.markdown" {
+		t.Errorf("notes = %v", rels)
+	}
+}
+
+func TestPlanMarkdownImport(t *testing.T) {
+	notes := []markdownNote{
+		{Path: "/v/new.md", Content: "# New\n\nfresh"},
+		{Path: "/v/same.md", Content: "# Same\n\nkept"},
+		{Path: "/v/edited.md", Content: "# Edited\n\nv2"},
+		{Path: "/v/dup.md", Content: "# Dup\n\nalready saved"},
+		{Path: "/v/empty.md"},
+	}
+	entries := map[string]markdownLedgerEntry{}
+	for _, n := range notes[1:3] {
+		entries[n.Path] = markdownLedgerEntry{MemoryID: "m-" + filepath.Base(n.Path)}
+	}
+	same := planMarkdownImport(notes[1:2], nil, nil)[0].Hash
+	entries["/v/same.md"] = markdownLedgerEntry{MemoryID: "m-same", Hash: same}
+	existing := map[string]string{planMarkdownImport(notes[3:4], nil, nil)[0].Hash: "m-dup"}
+
+	var got []string
+	for _, item := range planMarkdownImport(notes, entries, existing) {
+		got = append(got, item.Action+":"+item.MemoryID)
+	}
+	want := "create:|unchanged:m-same|update:m-edited.md|exists:m-dup|empty:"
+	if strings.Join(got, "|") != want {
+		t.Errorf("plan = %v, want %s", got, want)
+	}
+}
+
+func TestMarkdownLedgerRoundTrip(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "imports", "markdown.json")
+	l, err := loadMarkdownLedger(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.project("proj")["/v/a.md"] = markdownLedgerEntry{MemoryID: "m1", Hash: "h1", Links: []string{"B"}}
+	if err := l.save(); err != nil {
+		t.Fatal(err)
+	}
+	l, err = loadMarkdownLedger(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e := l.Projects["proj"]["/v/a.md"]; e.MemoryID != "m1" || e.Hash != "h1" || len(e.Links) != 1 {
+		t.Errorf("entry = %+v", e)
+	}
+}
@@ -0,0 +1,139 @@
+package commands
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDetectImportFormat(t *testing.T) {
+	cases := []struct {
+		name, path, data, want string
+	}{
+		{"markdown", "backlog.md", "# Backlog\n- [ ] one\n", importFormatMarkdown},
+		{"gh", "issues.json", `[{"number":1,"title":"Bug","labels":[]}]`, importFormatGitHub},
+		{"taskwarrior", "tw.json", `[{"uuid":"a","description":"x","entry":"20240101T000000Z"}]`, importFormatTaskwarrior},
+	}
+	for _, tc := range cases {
+		got, err := detectImportFormat(tc.path, []byte(tc.data))
+		if err != nil || got != tc.want {
+			t.Errorf("%s: got %q, %v; want %q", tc.name, got, err, tc.want)
+		}
+	}
+	if _, err := detectImportFormat("x.json", []byte(`[{"foo":1}]`)); err == nil {
+		t.Error("unknown JSON shape should be an error")
+	}
+}
+
+func TestParseMarkdownTasks_NestingAndMarkers(t *testing.T) {
+	md := `# Sprint
+- [ ] Ship importer #cli (H)
+  - [x] Parse markdown
+  - [ ] Parse gh JSON
+  - remember the dry run
+- [x] Write docs #docs
+	- [ ] tab-indented subtask
+Some prose that is ignored.
+`
+	got := parseMarkdownTasks([]byte(md))
+	if len(got) != 2 {
+		t.Fatalf("want 2 tasks, got %d: %+v", len(got), got)
+	}
+	first := got[0]
+	if first.Title != "Ship importer" || first.Priority != "H" || !reflect.DeepEqual(first.Tags, []string{"cli"}) {
+		t.Errorf("first task markers not lifted: %+v", first)
+	}
+	wantSubs := []importedSubtask{{"Parse markdown", true, -1}, {"Parse gh JSON", false, -1}}
+	if !reflect.DeepEqual(first.Subtasks, wantSubs) {
+		t.Errorf("subtasks = %+v, want %+v", first.Subtasks, wantSubs)
+	}
+	if len(first.Notes) != 1 || first.Notes[0].Content != "remember the dry run" {
+		t.Errorf("nested bullet should become a note: %+v", first.Notes)
+	}
+	if !got[1].Completed || len(got[1].Subtasks) != 1 {
+		t.Errorf("second task: %+v", got[1])
+	}
+}
+
+func TestParseMarkdownTasks_DeepNestingBuildsChildren(t *testing.T) {
+	md := "- [ ] Top\n  - [ ] A\n    - [x] A1\n      - [ ] A1a\n  - [ ] B\n"
+	got := parseMarkdownTasks([]byte(md))
+	if len(got) != 1 {
+		t.Fatalf("want 1 task, got %+v", got)
+	}
+	want := []importedSubtask{{"A", false, -1}, {"A1", true, 0}, {"A1a", false, 1}, {"B", false, -1}}
+	if !reflect.DeepEqual(got[0].Subtasks, want) {
+		t.Errorf("subtasks = %+v, want %+v", got[0].Subtasks, want)
+	}
+}
+
+func TestParseGitHubIssues_LabelMapping(t *testing.T) {
+	data := `[
+	  {"number": 12, "title": "Crash on start", "body": "stack trace", "state": "OPEN",
+	   "labels": [{"name": "bug"}, {"name": "P1"}, {"name": "needs-triage"}],
+	   "comments": [{"body": "repro attached", "author": {"login": "ada"}, "createdAt": "2024-03-01T10:00:00Z"}]},
+	  {"number": 13, "title": "Old thing", "state": "CLOSED", "labels": [{"name": "sev-low"}]}
+	]`
+	mapping, err := newImportMapping([]string{"sev-low=L"}, []string{"needs-triage=", "bug=bugfix"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := parseGitHubIssues([]byte(data), mapping)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("want 2 tasks, got %d", len(got))
+	}
+	if got[0].Priority != "H" || !reflect.DeepEqual(got[0].Tags, []string{"bugfix"}) {
+		t.Errorf("label mapping: priority=%q tags=%v", got[0].Priority, got[0].Tags)
+	}
+	if got[0].Description != "stack trace\n\nImported from GitHub issue #12" {
+		t.Errorf("description = %q", got[0].Description)
+	}
+	if len(got[0].Notes) != 1 || got[0].Notes[0].Content != "@ada: repro attached" || got[0].Notes[0].CreatedAt.IsZero() {
+		t.Errorf("comments should map to annotations: %+v", got[0].Notes)
+	}
+	if !got[1].Completed || got[1].Priority != "L" || len(got[1].Tags) != 0 {
+		t.Errorf("closed issue: %+v", got[1])
+	}
+}
+
+func TestParseTaskwarrior(t *testing.T) {
+	data := `[
+	  {"uuid":"1","description":"Pay invoice","status":"pending","priority":"H","project":"finance","tags":["home"],
+	   "annotations":[{"entry":"20240102T150405Z","description":"due Friday"}]},
+	  {"uuid":"2","description":"Gone","status":"deleted"},
+	  {"uuid":"3","description":"Done one","status":"completed"}
+	]`
+	mapping, _ := newImportMapping(nil, nil)
+	got, err := parseTaskwarrior([]byte(data), mapping)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("deleted tasks should be skipped, got %d", len(got))
+	}
+	if got[0].Priority != "H" || !reflect.DeepEqual(got[0].Tags, []string{"home", "finance"}) {
+		t.Errorf("first: %+v", got[0])
+	}
+	if len(got[0].Notes) != 1 || got[0].Notes[0].CreatedAt.Year() != 2024 {
+		t.Errorf("annotation: %+v", got[0].Notes)
+	}
+	if !got[1].Completed || got[1].Priority != "" {
+		t.Errorf("second: %+v", got[1])
+	}
+}
+
+func TestDedupeImported(t *testing.T) {
+	tasks := []importedTask{{Title: "Fix  Login"}, {Title: "New thing"}, {Title: "new THING"}}
+	fresh, dupes := dedupeImported(tasks, map[string]bool{importTitleKey("fix login"): true})
+	if len(fresh) != 1 || fresh[0].Title != "New thing" || len(dupes) != 2 {
+		t.Fatalf("fresh=%+v dupes=%+v", fresh, dupes)
+	}
+}
+
+func TestNewImportMapping_RejectsBadPriority(t *testing.T) {
+	if _, err := newImportMapping([]string{"blocker=X"}, nil); err == nil {
+		t.Fatal("expected error for invalid priority")
+	}
+}
@@ -1,29 +1,42 @@
 package commands
 
 import (
+	"encoding/json"
 	"fmt"
-	"strings"
+	"io"
+	"os"
 
 	"github.com/kutbudev/ramorie-cli/internal/api"
 	"github.com/kutbudev/ramorie-cli/internal/cli/display"
 	"github.com/kutbudev/ramorie-cli/internal/cli/resolve"
 	apierrors "github.com/kutbudev/ramorie-cli/internal/errors"
+	"github.com/kutbudev/ramorie-cli/internal/journal"
+	"github.com/kutbudev/ramorie-cli/internal/kanban"
 	"github.com/kutbudev/ramorie-cli/internal/models"
 	"github.com/urfave/cli/v2"
 )
 
-// NewKanbanCmd renders a three-column board (TODO / IN_PROGRESS / COMPLETED)
-// for a single project. Project arg accepts name, short ID prefix, or full UUID.
+// NewKanbanCmd renders a project's board. Columns, WIP limits and swimlanes
+// come from the project configuration (see internal/kanban); projects
+// without a board get TODO / IN PROGRESS / COMPLETED. Project arg accepts
+// name, short ID prefix, or full UUID.
 func NewKanbanCmd() *cli.Command {
 	return &cli.Command{
 		Name:      "kanban",
 		Usage:     "Display tasks in a beautified kanban board",
 		ArgsUsage: "[project]",
-		Description: `Three-column board (TODO / IN PROGRESS / COMPLETED) for one project.
+		Description: `Board for one project. Columns map a status (optionally + tag) and may
+carry a WIP limit; swimlanes split rows by priority or tag. The board is
+stored in the project configuration — see ` + "`ramorie kanban board --help`" + `.
+Without one, the classic TODO / IN PROGRESS / COMPLETED columns are used.
 
 Project arg accepts name, short ID prefix, or full UUID.`,
 		Flags: []cli.Flag{
 			&cli.StringFlag{Name: "project", Aliases: []string{"p"}, Usage: "Project (name, short id, or UUID)"},
+			&cli.StringFlag{Name: "swimlanes", Usage: "Override swimlanes for this run: priority, tag or none"},
+		},
+		Subcommands: []*cli.Command{
+			kanbanBoardCmd(),
 		},
 		Action: func(c *cli.Context) error {
 			arg := c.String("project")
@@ -40,132 +53,160 @@ Project arg accepts name, short ID prefix, or full UUID.`,
 				return err
 			}
 
-			todo, err := client.ListTasks(projectID, "TODO")
+			board, err := kanban.BoardFor(client, projectID)
 			if err != nil {
 				fmt.Println(apierrors.ParseAPIError(err))
 				return err
 			}
-			inProgress, err := client.ListTasks(projectID, "IN_PROGRESS")
-			if err != nil {
-				fmt.Println(apierrors.ParseAPIError(err))
-				return err
+			if c.IsSet("swimlanes") {
+				board.Swimlanes = c.String("swimlanes")
+				if board.Swimlanes == "none" {
+					board.Swimlanes = kanban.LanesNone
+				}
+				if err := board.Validate(); err != nil {
+					return err
+				}
 			}
-			completed, err := client.ListTasks(projectID, "COMPLETED")
+
+			layout, err := kanban.Load(client, projectID, board)
 			if err != nil {
 				fmt.Println(apierrors.ParseAPIError(err))
 				return err
 			}
 
-			renderBoard(arg, todo, inProgress, completed)
+			renderBoard(arg, layout)
 			return nil
 		},
 	}
 }
 
-func renderBoard(projectLabel string, todo, inProgress, completed []models.Task) {
-	cols := [3]struct {
-		title string
-		tasks []models.Task
-	}{
-		{"📝 TODO", todo},
-		{"🚀 IN PROGRESS", inProgress},
-		{"✅ COMPLETED", completed},
-	}
-
+func renderBoard(projectLabel string, layout kanban.Layout) {
 	totalWidth := display.TerminalWidth()
 	if totalWidth < 90 {
 		totalWidth = 90
 	}
-	colWidth := (totalWidth - 8) / 3
-	if colWidth < 22 {
-		colWidth = 22
-	}
-	if colWidth > 42 {
-		colWidth = 42
-	}
 
-	subtitle := fmt.Sprintf("%d todo · %d in progress · %d done",
-		len(todo), len(inProgress), len(completed))
-	fmt.Println(display.Header("🗂  Kanban — "+projectLabel, subtitle))
+	fmt.Println(display.Header("🗂  Kanban — "+projectLabel, layout.Summary()))
 	fmt.Println()
-
-	fmt.Printf(" %s | %s | %s\n",
-		padRight(display.Dim.Render(cols[0].title), colWidth),
-		padRight(display.Dim.Render(cols[1].title), colWidth),
-		padRight(display.Dim.Render(cols[2].title), colWidth))
-	fmt.Printf(" %s-+-%s-+-%s\n",
-		strings.Repeat("─", colWidth),
-		strings.Repeat("─", colWidth),
-		strings.Repeat("─", colWidth))
-
-	rows := maxLen(cols[0].tasks, cols[1].tasks, cols[2].tasks)
-	if rows == 0 {
-		fmt.Println(" " + display.Dim.Render("  (no tasks in this project yet)"))
-		return
-	}
-	for i := 0; i < rows; i++ {
-		fmt.Printf(" %s | %s | %s\n",
-			padRight(cellFor(cols[0].tasks, i, colWidth), colWidth),
-			padRight(cellFor(cols[1].tasks, i, colWidth), colWidth),
-			padRight(cellFor(cols[2].tasks, i, colWidth), colWidth))
-	}
+	fmt.Print(kanban.Render(layout, totalWidth, func(t *models.Task) string {
+		title, _ := decryptTaskForCLI(t)
+		return title
+	}))
 
 	fmt.Println()
 	fmt.Println(display.Dim.Render(" priority: ") +
 		display.PriorityBadge("H") + " high  " +
 		display.PriorityBadge("M") + " med  " +
 		display.PriorityBadge("L") + " low")
-}
-
-func cellFor(tasks []models.Task, i, width int) string {
-	if i >= len(tasks) {
-		return ""
-	}
-	t := tasks[i]
-	title, _ := decryptTaskForCLI(&t)
-	title = display.SingleLine(title)
-	titleBudget := width - 13 // 3 (badge) + 1 + 8 (id) + 1 = 13
-	if titleBudget < 8 {
-		titleBudget = 8
+	for i := range layout.Board.Columns {
+		if layout.OverLimit(i) {
+			fmt.Println(display.Err.Render(fmt.Sprintf(" ⚠ %s is over its WIP limit", layout.Board.Columns[i].Title)))
+		}
 	}
-	return fmt.Sprintf("%s %s %s",
-		display.PriorityBadge(t.Priority),
-		display.Dim.Render(t.ID.String()[:8]),
-		display.Truncate(title, titleBudget))
 }
 
-// padRight pads a (possibly ANSI-decorated) string to visible width n.
-func padRight(s string, n int) string {
-	visible := stripANSI(s)
-	if len(visible) >= n {
-		return s
-	}
-	return s + strings.Repeat(" ", n-len(visible))
-}
+// kanbanBoardCmd shows or replaces the board definition stored in the
+// project configuration. Other configuration keys are preserved.
+func kanbanBoardCmd() *cli.Command {
+	return &cli.Command{
+		Name:      "board",
+		Usage:     "Show or set the project's board definition (columns, WIP limits, swimlanes)",
+		ArgsUsage: "[project]",
+		Description: `Prints the effective board as JSON. With --set, stores a new definition
+read from a file (or - for stdin); --reset removes it.
+
+Example board:
+  {
+    "columns": [
+      {"title": "Todo",   "status": "TODO"},
+      {"title": "Doing",  "status": "IN_PROGRESS", "wip": 3},
+      {"title": "Review", "status": "IN_PROGRESS", "tag": "review", "wip": 2},
+      {"title": "Done",   "status": "COMPLETED"}
+    ],
+    "swimlanes": "priority"
+  }
+
+A tag column takes matching tasks away from a plain column of the same
+status. swimlanes is "priority" or "tag" (lane_tags fixes the tag order).`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "project", Aliases: []string{"p"}, Usage: "Project (name, short id, or UUID)"},
+			&cli.StringFlag{Name: "set", Usage: "Board JSON file to store (- for stdin)"},
+			&cli.BoolFlag{Name: "reset", Usage: "Remove the custom board (back to the default columns)"},
+		},
+		Action: func(c *cli.Context) error {
+			arg := c.String("project")
+			if arg == "" && c.NArg() > 0 {
+				arg = c.Args().First()
+			}
+			if arg == "" {
+				return fmt.Errorf("project is required. Usage: ramorie kanban board -p <project>")
+			}
+			if c.IsSet("set") && c.Bool("reset") {
+				return fmt.Errorf("--set and --reset are mutually exclusive")
+			}
 
-func stripANSI(s string) string {
-	var b strings.Builder
-	inEsc := false
-	for _, r := range s {
-		switch {
-		case r == 0x1b:
-			inEsc = true
-		case inEsc && r == 'm':
-			inEsc = false
-		case !inEsc:
-			b.WriteRune(r)
-		}
+			client := api.NewClient()
+			projectID, err := resolve.ResolveProject(arg, client)
+			if err != nil {
+				return err
+			}
+			project, err := client.GetProject(projectID)
+			if err != nil {
+				fmt.Println(apierrors.ParseAPIError(err))
+				return err
+			}
+
+			if !c.IsSet("set") && !c.Bool("reset") {
+				board, err := kanban.FromConfiguration(project.Configuration)
+				if err != nil {
+					return err
+				}
+				out, _ := json.MarshalIndent(board, "", "  ")
+				fmt.Println(string(out))
+				return nil
+			}
+
+			cfg := map[string]interface{}{}
+			for k, v := range project.Configuration {
+				cfg[k] = v
+			}
+			if c.Bool("reset") {
+				delete(cfg, kanban.ConfigKey)
+			} else {
+				data, err := readBoardFile(c.String("set"))
+				if err != nil {
+					return err
+				}
+				board, err := kanban.Parse(data)
+				if err != nil {
+					return err
+				}
+				cfg[kanban.ConfigKey] = board
+			}
+
+			if _, err := client.UpdateProject(projectID, map[string]interface{}{"configuration": cfg}); err != nil {
+				fmt.Printf("Error updating project: %v\n", err)
+				return err
+			}
+			journal.Record("kanban board", project.Name,
+				journal.Update(journal.KindProject, projectID, journal.ProjectSnapshot(project), "configuration"))
+			if c.Bool("reset") {
+				fmt.Printf("✅ Board for '%s' reset to the default columns.\n", project.Name)
+			} else {
+				fmt.Printf("✅ Board for '%s' updated.\n", project.Name)
+			}
+			return nil
+		},
 	}
-	return b.String()
 }
 
-func maxLen(a, b, c []models.Task) int {
-	m := len(a)
-	if len(b) > m {
-		m = len(b)
+func readBoardFile(path string) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(os.Stdin)
 	}
-	if len(c) > m {
-		m = len(c)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read board file: %w", err)
 	}
-	return m
+	return data, nil
 }
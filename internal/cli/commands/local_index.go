@@ -0,0 +1,174 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kutbudev/ramorie-cli/internal/api"
+	"github.com/kutbudev/ramorie-cli/internal/cli/display"
+	"github.com/kutbudev/ramorie-cli/internal/localindex"
+	"github.com/kutbudev/ramorie-cli/internal/models"
+	"github.com/urfave/cli/v2"
+)
+
+// hookFindTimeout bounds the backend find in the hooks. They block the
+// agent until they return, so a slow backend falls back to the local
+// index instead.
+const hookFindTimeout = 3 * time.Second
+
+// findTimeout bounds the backend find of `ramorie find`. It leaves room for
+// a cold HyDE + rerank pass (5-10s) and stays under the HTTP client's 30s
+// timeout, so a stalled backend falls back to the local index rather than
+// failing.
+const findTimeout = 15 * time.Second
+
+// NewIndexCommand creates the 'index' command group for the local search
+// index that find and the hooks fall back to.
+func NewIndexCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "index",
+		Usage: "Manage the local search index used when the backend is unreachable",
+		Description: "find, hook prompt-submit and find-related answer from a local BM25 index when\n" +
+			"   the backend errors or times out; those results are marked local_fallback.\n" +
+			"   Memories are added as the CLI fetches them. Content of encrypted memories\n" +
+			"   is kept encrypted with the vault key and is only searched while the vault\n" +
+			"   is unlocked. Stored in ~/.ramorie/index.json.",
+		Subcommands: []*cli.Command{
+			indexRebuildCmd(),
+			indexStatusCmd(),
+		},
+	}
+}
+
+func indexRebuildCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "rebuild",
+		Usage: "Re-fetch all memories and rebuild the local index",
+		Action: func(c *cli.Context) error {
+			ix, err := localindex.Open()
+			if err != nil {
+				// An index that no longer opens (corrupt, or sealed with an
+				// older vault key) is simply replaced.
+				if ix, err = localindex.Empty(); err != nil {
+					return err
+				}
+			}
+			client := api.NewClient()
+			var all []models.Memory
+			for page := 1; ; page++ {
+				items, hasMore, err := client.ListMemoriesPage("", "", page, 100)
+				if err != nil {
+					return fmt.Errorf("fetch memories: %w", err)
+				}
+				all = append(all, items...)
+				if !hasMore || len(items) == 0 {
+					break
+				}
+			}
+
+			ix.Reset()
+			indexed, skipped := 0, 0
+			for i := range all {
+				if ix.Add(&all[i]) {
+					indexed++
+				} else {
+					skipped++
+				}
+			}
+			if err := ix.Save(); err != nil {
+				return err
+			}
+			fmt.Println(display.Good.Render(fmt.Sprintf("✓ indexed %d memories", indexed)))
+			if skipped > 0 {
+				fmt.Println(display.Dim.Render(fmt.Sprintf("  %d encrypted memories skipped — unlock the vault (ramorie unlock) and rebuild to include them", skipped)))
+			}
+			if ix.Locked() {
+				fmt.Println(display.Dim.Render("  the encrypted part of the index was kept as is; it is searched once the vault is unlocked"))
+			}
+			return nil
+		},
+	}
+}
+
+func indexStatusCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "status",
+		Usage: "Show what the local index holds",
+		Action: func(c *cli.Context) error {
+			ix, err := localindex.Open()
+			if err != nil {
+				return err
+			}
+			plain, sealed := ix.Len()
+			fmt.Printf("memories:  %d\n", plain+sealed)
+			switch {
+			case ix.Locked():
+				fmt.Println("encrypted: present, vault locked (not searched)")
+			default:
+				fmt.Printf("encrypted: %d\n", sealed)
+			}
+			if ix.UpdatedAt().IsZero() {
+				fmt.Println("updated:   never — run `ramorie index rebuild`")
+			} else {
+				fmt.Printf("updated:   %s\n", display.Relative(ix.UpdatedAt()))
+			}
+			fmt.Printf("path:      %s\n", ix.Path)
+			return nil
+		},
+	}
+}
+
+// findWithFallback runs a backend find, bounded by timeout when it is
+// positive, and answers from the local index when the backend times out,
+// cannot be reached or fails with a server error. Requests the backend
+// rejects (4xx) return that error, as does a failure with an empty index.
+func findWithFallback(client *api.Client, opts api.FindMemoriesOptions, timeout time.Duration) (*api.FindResponse, error) {
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	resp, err := client.FindMemoriesWithContext(ctx, opts)
+	if err == nil {
+		return resp, nil
+	}
+	reason := findFallbackReason(ctx, err)
+	if reason == "" {
+		return nil, err
+	}
+	ix, ixErr := localindex.Open()
+	if ixErr != nil {
+		return nil, err
+	}
+	if plain, sealed := ix.Len(); plain+sealed == 0 {
+		return nil, err
+	}
+	return ix.Find(opts, reason), nil
+}
+
+// findFallbackReason says why a failed find should fall back to the local
+// index, or "" when it should not.
+func findFallbackReason(ctx context.Context, err error) string {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return "backend_timeout"
+	}
+	if strings.Contains(err.Error(), "API request failed with status 4") {
+		return ""
+	}
+	return "backend_unreachable"
+}
+
+// isLocalFallback reports whether a find response came from the local index.
+func isLocalFallback(resp *api.FindResponse) bool {
+	return resp != nil && resp.Meta.RankingMode == localindex.RankingMode
+}
+
+// recordMemories adds fetched memories to the local index. Failures only
+// cost fallback coverage, so they are ignored.
+func recordMemories(memories ...models.Memory) {
+	_ = localindex.Record(memories...)
+}
@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kutbudev/ramorie-cli/internal/api"
+	"github.com/kutbudev/ramorie-cli/internal/models"
+)
+
+func TestFindWithFallback_UsesLocalIndexWhenBackendFails(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	recordMemories(models.Memory{ID: uuid.New(), Type: "decision", Content: "We deploy with blue-green releases"})
+
+	status := http.StatusBadGateway
+	delay := time.Duration(0)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(delay)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"items":[],"_meta":{"ranking_mode":"weighted"}}`))
+	}))
+	defer srv.Close()
+	client := &api.Client{BaseURL: srv.URL, HTTPClient: srv.Client()}
+	opts := api.FindMemoriesOptions{Term: "how do we deploy releases"}
+
+	resp, err := findWithFallback(client, opts, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isLocalFallback(resp) || resp.Meta.FallbackReason != "backend_unreachable" {
+		t.Fatalf("meta = %+v", resp.Meta)
+	}
+	if len(resp.Items) != 1 || !resp.Items[0].LocalFallback || resp.Items[0].Type != "decision" {
+		t.Fatalf("items = %+v", resp.Items)
+	}
+
+	// A slow backend times out into the fallback.
+	status, delay = http.StatusOK, 200*time.Millisecond
+	resp, err = findWithFallback(client, opts, 20*time.Millisecond)
+	if err != nil || resp.Meta.FallbackReason != "backend_timeout" {
+		t.Fatalf("timeout: resp %+v, err %v", resp, err)
+	}
+
+	// Requests the backend rejects are not papered over.
+	status, delay = http.StatusUnauthorized, 0
+	if _, err := findWithFallback(client, opts, 0); err == nil {
+		t.Fatal("4xx fell back to the local index")
+	}
+
+	// A healthy backend is used as is.
+	status = http.StatusOK
+	resp, err = findWithFallback(client, opts, 0)
+	if err != nil || resp.Meta.RankingMode != "weighted" {
+		t.Fatalf("healthy: resp %+v, err %v", resp, err)
+	}
+}
@@ -10,6 +10,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/kutbudev/ramorie-cli/internal/acl"
+	"github.com/kutbudev/ramorie-cli/internal/anchor"
 	"github.com/kutbudev/ramorie-cli/internal/api"
 	"github.com/kutbudev/ramorie-cli/internal/cli/display"
 	"github.com/kutbudev/ramorie-cli/internal/cli/resolve"
@@ -17,7 +20,14 @@ import (
 	"github.com/kutbudev/ramorie-cli/internal/crypto"
 	"github.com/kutbudev/ramorie-cli/internal/encstate"
 	apierrors "github.com/kutbudev/ramorie-cli/internal/errors"
+	"github.com/kutbudev/ramorie-cli/internal/gitmem"
+	"github.com/kutbudev/ramorie-cli/internal/journal"
+	"github.com/kutbudev/ramorie-cli/internal/localindex"
+	"github.com/kutbudev/ramorie-cli/internal/memrev"
 	"github.com/kutbudev/ramorie-cli/internal/models"
+	"github.com/kutbudev/ramorie-cli/internal/review"
+	"github.com/kutbudev/ramorie-cli/internal/secrets"
+	"github.com/kutbudev/ramorie-cli/internal/similarity"
 	"github.com/urfave/cli/v2"
 	"golang.org/x/term"
 )
@@ -31,7 +41,15 @@ func NewMemoryCommand() *cli.Command {
 		Subcommands: []*cli.Command{
 			memoriesCmd(),
 			memoryHygieneCmd(),
+			memoryClassifyCmd(),
+			memoryAnchorsCmd(),
 			getCmd(),
+			memoryEditCmd(),
+			memoryHistoryCmd(),
+			memoryDiffCmd(),
+			memoryRevertCmd(),
+			memoryShareCmd(),
+			memoryACLCmd(),
 			forgetCmd(),
 			memoryLinkCmd(),
 			memoryLinksCmd(),
@@ -70,7 +88,16 @@ For multi-line content with leading "-" bullets, prefer piping via stdin
 
   cat memory.md | ramorie remember -p "Ramorie Backend"
   ramorie remember "my note"                 # project auto-detected
-  ramorie remember "my note" -p ramorie-cli  # -p after content also works`,
+  ramorie remember "my note" -p ramorie-cli  # -p after content also works
+
+From git: --from-commit <sha|a..b> or --since <rev|date> builds one memory
+per commit from its message, author, diffstat and touched paths. The type
+follows the conventional-commit prefix (fix: → bug_fix) or the classifier,
+top-level directories become tags, and ram#<id> references link the task.
+Commits already imported are skipped; --dry-run previews.
+
+  ramorie remember --from-commit HEAD
+  ramorie remember --since v1.2.0 --dry-run`,
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:    "project",
@@ -86,8 +113,41 @@ For multi-line content with leading "-" bullets, prefer piping via stdin
 				Name:  "json",
 				Usage: "Print result as JSON (for agents / scripts)",
 			},
+			&cli.StringFlag{
+				Name:  "review-in",
+				Usage: "Queue for `ramorie review` after this long (e.g. 90d, 12w, 6m); the interval grows each time it is confirmed",
+			},
+			&cli.StringFlag{
+				Name:  "expires",
+				Usage: "No longer valid after this date (YYYY-MM-DD or e.g. 90d); expired memories are left out of hook injections",
+			},
+			&cli.StringSliceFlag{
+				Name:  "anchor",
+				Usage: "Link to code: `path[:start[-end]][#Symbol]` (repeatable); the memory is flagged possibly stale when that code changes",
+			},
+			&cli.StringFlag{
+				Name:  "from-commit",
+				Usage: "Remember a commit (`sha`) or each commit of a range (a..b) from its message, author, diffstat and paths",
+			},
+			&cli.StringFlag{
+				Name:  "since",
+				Usage: "Remember each commit after this revision (e.g. v1.2.0) or date (2026-01-01, 30d); commits already imported are skipped",
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "With --from-commit/--since: show the memories without creating them",
+			},
 		},
 		Action: func(c *cli.Context) error {
+			reviewDays, expires, err := review.Parse(c.String("review-in"), c.String("expires"), time.Now())
+			if err != nil {
+				return err
+			}
+			cwd, _ := os.Getwd()
+			anchors, err := anchor.ResolveAll(c.StringSlice("anchor"), cwd)
+			if err != nil {
+				return err
+			}
 			client := api.NewClient()
 
 			// 1. Resolve project (name, short id, UUID, or auto-detect).
@@ -106,7 +166,27 @@ For multi-line content with leading "-" bullets, prefer piping via stdin
 				return err
 			}
 
-			// 2. Get content from positionals or piped stdin.
+			// 2. Tags: split CSV — turn -t "a,b" into [a,b].
+			rawTags := c.StringSlice("tags")
+			tags := make([]string, 0, len(rawTags))
+			for _, t := range rawTags {
+				for _, sub := range strings.Split(t, ",") {
+					s := strings.TrimSpace(sub)
+					if s != "" {
+						tags = append(tags, s)
+					}
+				}
+			}
+
+			// Commit mode builds one memory per commit instead.
+			if c.String("from-commit") != "" || c.String("since") != "" {
+				if len(posArgs) > 0 {
+					return fmt.Errorf("--from-commit and --since build the content from git; drop the text argument")
+				}
+				return rememberCommits(c, newRememberTarget(client, projectID, expires), tags, reviewDays, expires, anchors)
+			}
+
+			// 3. Get content from positionals or piped stdin.
 			content := strings.TrimSpace(strings.Join(posArgs, " "))
 			if content == "" {
 				if !term.IsTerminal(int(os.Stdin.Fd())) {
@@ -120,18 +200,11 @@ For multi-line content with leading "-" bullets, prefer piping via stdin
 			if content == "" {
 				return fmt.Errorf("memory content is required (positional arg or piped stdin)")
 			}
-
-			// 3. Tags: split CSV — turn -t "a,b" into [a,b].
-			rawTags := c.StringSlice("tags")
-			tags := make([]string, 0, len(rawTags))
-			for _, t := range rawTags {
-				for _, sub := range strings.Split(t, ",") {
-					s := strings.TrimSpace(sub)
-					if s != "" {
-						tags = append(tags, s)
-					}
-				}
+			scan, err := checkSecrets(client, projectID, content)
+			if err != nil {
+				return err
 			}
+			content = scan.Content
 
 			// 4. Length guard.
 			if !constants.IsWithinMemoryLimit(content) {
@@ -147,45 +220,18 @@ For multi-line content with leading "-" bullets, prefer piping via stdin
 				fmt.Fprintf(os.Stderr, "⚠️  Warning: Content is %.1f%% of maximum limit (%d chars)\n", usage, chars)
 			}
 
-			// 5. Encryption decision: org projects skip personal-vault encryption.
-			isOrgProject := false
-			projects, _ := client.ListProjects()
-			for _, p := range projects {
-				if p.ID.String() == projectID && p.OrganizationID != nil {
-					isOrgProject = true
-					break
-				}
+			// 5. Save: encrypted per the server's encryption setting and the
+			//    secret scanner, in plain text otherwise.
+			memory, err := newRememberTarget(client, projectID, expires).create(content, "", tags, scan.Encrypt)
+			if err != nil {
+				return err
 			}
-
-			var memory *models.Memory
-			// Gate on the SERVER's CURRENT encryption status (encstate) in
-			// addition to the unlocked vault. Previously this path skipped the
-			// encryption-enabled flag entirely, so it kept encrypting with the
-			// old personal key after the user disabled encryption server-side.
-			if encstate.ShouldEncryptPersonal(encstate.FetcherFor(client)) && crypto.IsVaultUnlocked() && !isOrgProject {
-				contentHash := crypto.ComputeContentHash(content)
-				encryptedContent, nonce, isEncrypted, encErr := crypto.EncryptContent(content)
-				if encErr != nil {
-					return fmt.Errorf("encryption failed: %w", encErr)
-				}
-				if isEncrypted {
-					memory, err = client.CreateEncryptedMemory(projectID, encryptedContent, nonce, contentHash, tags...)
-				} else {
-					memory, err = client.CreateMemory(projectID, content, tags...)
-				}
-			} else {
-				memory, err = client.CreateMemory(projectID, content, tags...)
+			journal.Record("remember", memoryLabel(memory, memory.ID.String()), journal.Create(journal.KindMemory, memory.ID.String()))
+			if err := review.Set(memory.ID.String(), reviewDays, expires); err != nil {
+				fmt.Fprintf(os.Stderr, "⚠️  Could not save the review schedule: %v\n", err)
 			}
-			if err != nil {
-				if apierrors.IsEncryptionRequiredError(err) {
-					projectName := projectNameFor(projects, projectID)
-					return fmt.Errorf("%s", apierrors.EncryptionRequiredMessage(
-						projectName,
-						crypto.IsVaultUnlocked(),
-						encstate.ShouldEncryptPersonal(encstate.FetcherFor(client)),
-					))
-				}
-				return fmt.Errorf("%s", apierrors.ParseAPIError(err))
+			if err := anchor.Add(memrev.Tracked{Client: client, Source: "cli"}, memory, anchors); err != nil {
+				fmt.Fprintf(os.Stderr, "⚠️  Could not save the code anchors: %v\n", err)
 			}
 
 			// 6. Output.
@@ -202,6 +248,15 @@ For multi-line content with leading "-" bullets, prefer piping via stdin
 				if memory.LinkedTaskID != nil {
 					out["linked_task_id"] = memory.LinkedTaskID.String()
 				}
+				if reviewDays > 0 {
+					out["review_due"] = time.Now().AddDate(0, 0, reviewDays).Format("2006-01-02")
+				}
+				if expires != nil {
+					out["expires"] = expires.Format(time.RFC3339)
+				}
+				if len(anchors) > 0 {
+					out["anchors"] = anchors
+				}
 				b, mErr := json.MarshalIndent(out, "", "  ")
 				if mErr != nil {
 					return fmt.Errorf("marshal json: %w", mErr)
@@ -220,6 +275,15 @@ For multi-line content with leading "-" bullets, prefer piping via stdin
 			if len(tags) > 0 {
 				fmt.Printf("   Tags: %s\n", strings.Join(tags, ", "))
 			}
+			if reviewDays > 0 {
+				fmt.Printf("   Review: %s\n", time.Now().AddDate(0, 0, reviewDays).Format("2006-01-02"))
+			}
+			if expires != nil {
+				fmt.Printf("   Expires: %s\n", expires.Format("2006-01-02"))
+			}
+			for _, a := range anchors {
+				fmt.Printf("   Anchor: %s\n", a)
+			}
 			if memory.LinkedTaskID != nil {
 				fmt.Printf("🔗 Auto-linked to active task: %s\n", memory.LinkedTaskID.String()[:8])
 			}
@@ -228,6 +292,145 @@ For multi-line content with leading "-" bullets, prefer piping via stdin
 	}
 }
 
+// rememberTarget is the project remember writes to and how it saves there.
+type rememberTarget struct {
+	client     *api.Client
+	projectID  string
+	projects   []models.Project
+	isOrg      bool
+	validUntil string
+}
+
+// newRememberTarget looks up whether projectID belongs to an organization:
+// org projects skip personal-vault encryption.
+func newRememberTarget(client *api.Client, projectID string, expires *time.Time) *rememberTarget {
+	t := &rememberTarget{client: client, projectID: projectID}
+	t.projects, _ = client.ListProjects()
+	for _, p := range t.projects {
+		if p.ID.String() == projectID && p.OrganizationID != nil {
+			t.isOrg = true
+			break
+		}
+	}
+	if expires != nil {
+		t.validUntil = expires.UTC().Format(time.RFC3339)
+	}
+	return t
+}
+
+// create saves one memory and adds it to the local index. An empty memType
+// lets the backend detect it. forceEncrypt is set when the secret scanner
+// found secrets whose action is encrypt. Errors are ready to print.
+func (t *rememberTarget) create(content, memType string, tags []string, forceEncrypt bool) (*models.Memory, error) {
+	client := t.client
+	// Gate on the SERVER's CURRENT encryption status (encstate) in
+	// addition to the unlocked vault. Previously this path skipped the
+	// encryption-enabled flag entirely, so it kept encrypting with the
+	// old personal key after the user disabled encryption server-side.
+	encrypt := encstate.ShouldEncryptPersonal(encstate.FetcherFor(client)) && crypto.IsVaultUnlocked() && !t.isOrg
+	if forceEncrypt && !encrypt {
+		// Save encrypted even where encryption is otherwise off.
+		if !crypto.IsVaultUnlocked() || t.isOrg {
+			return nil, secrets.ErrNeedsEncryption
+		}
+		encrypt = true
+	}
+
+	var memory *models.Memory
+	var err error
+	if encrypt {
+		contentHash := crypto.ComputeContentHash(content)
+		encryptedContent, nonce, isEncrypted, encErr := crypto.EncryptContent(content)
+		if encErr != nil {
+			return nil, fmt.Errorf("encryption failed: %w", encErr)
+		}
+		switch {
+		case isEncrypted && (t.validUntil != "" || memType != ""):
+			memory, err = client.CreateEncryptedMemoryWithOptions(api.CreateEncryptedMemoryOptions{
+				ProjectID: t.projectID, EncryptedContent: encryptedContent, ContentNonce: nonce,
+				ContentHash: contentHash, Type: memType, Tags: tags, ValidUntil: t.validUntil,
+			})
+		case isEncrypted:
+			memory, err = client.CreateEncryptedMemory(t.projectID, encryptedContent, nonce, contentHash, tags...)
+		default:
+			memory, err = createPlainMemory(client, t.projectID, content, memType, tags, t.validUntil)
+		}
+	} else {
+		memory, err = createPlainMemory(client, t.projectID, content, memType, tags, t.validUntil)
+	}
+	if err != nil {
+		if apierrors.IsEncryptionRequiredError(err) {
+			return nil, fmt.Errorf("%s", apierrors.EncryptionRequiredMessage(
+				projectNameFor(t.projects, t.projectID),
+				crypto.IsVaultUnlocked(),
+				encstate.ShouldEncryptPersonal(encstate.FetcherFor(client)),
+			))
+		}
+		return nil, fmt.Errorf("%s", apierrors.ParseAPIError(err))
+	}
+	// Index it now, so the local fallback finds it before the next fetch.
+	recordMemories(*memory)
+	return memory, nil
+}
+
+// createPlainMemory creates an unencrypted memory, sending the type and
+// valid_until when set.
+func createPlainMemory(client *api.Client, projectID, content, memType string, tags []string, validUntil string) (*models.Memory, error) {
+	if validUntil == "" && memType == "" {
+		return client.CreateMemory(projectID, content, tags...)
+	}
+	resp, err := client.CreateMemoryWithOptionsFull(api.CreateMemoryOptions{ProjectID: projectID, Content: content, Type: memType, Tags: tags, ValidUntil: validUntil})
+	if err != nil {
+		return nil, err
+	}
+	m := resp.Memory
+	if resp.LinkedTaskID != nil {
+		if id, err := uuid.Parse(*resp.LinkedTaskID); err == nil {
+			m.LinkedTaskID = &id
+		}
+	}
+	return &m, nil
+}
+
+// archiveMemory sets a memory's lifecycle to archived and journals the
+// lifecycle it had before (read fresh, since listings may omit it; unset
+// means active), so undo puts back exactly that.
+func archiveMemory(client *api.Client, id, command, source string) error {
+	m, fullID := memoryBefore(client, id)
+	if _, err := memrev.Update(client, fullID, map[string]interface{}{"lifecycle": "archived"}, source); err != nil {
+		return err
+	}
+	before := journal.MemorySnapshot(m)
+	if before == nil {
+		before = map[string]interface{}{}
+	}
+	before["lifecycle"] = "active"
+	if m != nil && m.Lifecycle != "" {
+		before["lifecycle"] = m.Lifecycle
+	}
+	journal.Record(command, "archive "+memoryLabel(m, fullID),
+		journal.Update(journal.KindMemory, fullID, before, "lifecycle"))
+	return nil
+}
+
+// forgetLocalMemories drops the local state kept for deleted memories: their
+// entries in the offline search index, so the local fallback stops finding
+// them, and their commit imports, so those commits can be imported again.
+// Every delete path calls it. Failures only warn.
+func forgetLocalMemories(ids ...string) {
+	if len(ids) == 0 {
+		return
+	}
+	if err := localindex.Forget(ids...); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Could not update the local search index: %v\n", err)
+	}
+	if store, err := gitmem.Open(); err == nil {
+		if _, err := store.Forget(ids...); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  Could not update the commit import record: %v\n", err)
+		}
+	}
+}
+
 // memoriesCmd lists all memory items.
 func memoriesCmd() *cli.Command {
 	return &cli.Command{
@@ -250,6 +453,10 @@ func memoriesCmd() *cli.Command {
 				Name:  "org-only",
 				Usage: "Only show memories from organization projects",
 			},
+			&cli.StringFlag{
+				Name:  "visibility",
+				Usage: "Filter by visibility (private | project | organization)",
+			},
 			&cli.IntFlag{
 				Name:    "limit",
 				Aliases: []string{"n"},
@@ -273,6 +480,10 @@ func memoriesCmd() *cli.Command {
 			limit := c.Int("limit")
 			tagFilter := c.String("tag")
 			newestFirst := c.Bool("newest-first")
+			visibilityFilter, err := acl.ParseVisibility(c.String("visibility"))
+			if err != nil {
+				return err
+			}
 
 			client := api.NewClient()
 
@@ -290,6 +501,7 @@ func memoriesCmd() *cli.Command {
 				fmt.Println(apierrors.ParseAPIError(err))
 				return err
 			}
+			recordMemories(memories...)
 
 			// Filter by tag if requested
 			if tagFilter != "" {
@@ -317,6 +529,17 @@ func memoriesCmd() *cli.Command {
 				memories = filtered
 			}
 
+			// Filter by visibility if requested
+			if visibilityFilter != "" {
+				var filtered []models.Memory
+				for _, m := range memories {
+					if strings.EqualFold(m.Visibility, visibilityFilter) {
+						filtered = append(filtered, m)
+					}
+				}
+				memories = filtered
+			}
+
 			if len(memories) == 0 {
 				fmt.Println(display.Dim.Render("  no memories — use `ramorie remember` to add one"))
 				return nil
@@ -411,11 +634,30 @@ type memoryHygieneIssue struct {
 func memoryHygieneCmd() *cli.Command {
 	return &cli.Command{
 		Name:  "hygiene",
-		Usage: "Dry-run memory hygiene report (stale, duplicate, low-value, unstructured runbooks)",
-		Description: `Scans memories and reports hygiene risks without changing anything.
-
-No archive, delete, merge, or consolidation is performed. Use this before manual
-cleanup or before deciding whether to run a consolidation job.`,
+		Usage: "Memory hygiene report (stale, duplicate, low-value, unstructured runbooks), with optional cleanup",
+		Description: `Scans memories and reports hygiene risks. Without --apply nothing is changed.
+
+--apply reviews the issues one by one on the terminal: merge duplicates, convert
+runbook prose into a skill with trigger/steps, archive stale items or delete
+low-value ones. --policy <file> decides per issue kind instead, for CI-style
+cleanup (YAML or JSON):
+
+   actions:
+     duplicate_exact: merge      # merge | delete | skip
+     duplicate_near: merge       # merge | delete | skip
+     runbook_prose: skill        # skill | archive | skip
+     skill_unstructured: skill   # skill | archive | skip
+     stale: archive              # archive | delete | skip
+     thin_low_value: delete      # delete | archive | skip
+   min_severity: medium
+   max_actions: 50
+
+Near-duplicates are found with a pluggable similarity engine (--similarity):
+hybrid (default; word and character-shingle overlap, any script, code
+identifiers split), words or shingles.
+
+--policy alone prints the plan; add --apply to carry it out. Every change is
+journaled and can be reverted with 'ramorie undo'.`,
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:    "project",
@@ -431,8 +673,36 @@ cleanup or before deciding whether to run a consolidation job.`,
 				Name:  "json",
 				Usage: "Print machine-readable JSON report",
 			},
+			&cli.BoolFlag{
+				Name:  "apply",
+				Usage: "Act on the issues (interactive review, or the --policy file)",
+			},
+			&cli.StringFlag{
+				Name:  "policy",
+				Usage: "Non-interactive cleanup policy file (YAML or JSON)",
+			},
+			&cli.StringFlag{
+				Name:  "similarity",
+				Usage: "Near-duplicate engine: hybrid | words | shingles",
+				Value: "hybrid",
+			},
 		},
 		Action: func(c *cli.Context) error {
+			engine, ok := similarity.Lookup(c.String("similarity"))
+			if !ok {
+				return fmt.Errorf("unknown similarity engine %q (use hybrid, words or shingles)", c.String("similarity"))
+			}
+			var policy *hygienePolicy
+			if path := c.String("policy"); path != "" {
+				p, err := loadHygienePolicy(path)
+				if err != nil {
+					return err
+				}
+				policy = p
+			} else if c.Bool("apply") && !term.IsTerminal(int(os.Stdin.Fd())) {
+				return fmt.Errorf("--apply reviews issues interactively and needs a terminal; use --policy <file> for non-interactive cleanup")
+			}
+
 			client := api.NewClient()
 			projectID, err := resolve.AutoResolveProject(c.String("project"), client)
 			if err != nil {
@@ -447,7 +717,10 @@ cleanup or before deciding whether to run a consolidation job.`,
 			if err != nil {
 				return err
 			}
-			report := analyzeMemoryHygiene(projectID, memories, time.Now())
+			report := analyzeMemoryHygieneWith(projectID, memories, time.Now(), engine)
+			if c.Bool("apply") || policy != nil {
+				return runMemoryHygieneApply(c, client, memories, report, policy)
+			}
 
 			if c.Bool("json") {
 				b, err := json.MarshalIndent(report, "", "  ")
@@ -494,6 +767,12 @@ func fetchMemoryHygienePageSet(client *api.Client, projectID string, maxItems in
 }
 
 func analyzeMemoryHygiene(projectID string, memories []models.Memory, now time.Time) memoryHygieneReport {
+	return analyzeMemoryHygieneWith(projectID, memories, now, similarity.Default)
+}
+
+// analyzeMemoryHygieneWith is analyzeMemoryHygiene with a chosen similarity
+// engine for near-duplicate detection.
+func analyzeMemoryHygieneWith(projectID string, memories []models.Memory, now time.Time, engine similarity.Engine) memoryHygieneReport {
 	report := memoryHygieneReport{
 		ProjectID: projectID,
 		Scanned:   len(memories),
@@ -502,8 +781,12 @@ func analyzeMemoryHygiene(projectID string, memories []models.Memory, now time.T
 	}
 
 	byNormalizedContent := map[string]models.Memory{}
+	exactDup := map[string]bool{}
+	near := similarity.NewIndex(engine)
+	contents := map[string]string{}
 	for _, m := range memories {
 		content := strings.TrimSpace(decryptMemoryForCLI(&m))
+		readable := !m.IsEncrypted || crypto.IsVaultUnlocked()
 		ageDays := int(now.Sub(m.CreatedAt).Hours()/24 + 0.5)
 		if ageDays < 0 {
 			ageDays = 0
@@ -557,8 +840,10 @@ func analyzeMemoryHygiene(projectID string, memories []models.Memory, now time.T
 			})
 		}
 
-		if norm := normalizeMemoryForHygiene(content); norm != "" {
+		if norm := normalizeMemoryForHygiene(content); norm != "" && readable {
+			contents[m.ID.String()] = content
 			if prev, ok := byNormalizedContent[norm]; ok && prev.ID != m.ID {
+				exactDup[m.ID.String()] = true
 				report.addIssue(memoryHygieneIssue{
 					Kind:      "duplicate_exact",
 					Severity:  "medium",
@@ -571,10 +856,33 @@ func analyzeMemoryHygiene(projectID string, memories []models.Memory, now time.T
 				})
 			} else {
 				byNormalizedContent[norm] = m
+				near.Add(m.ID.String(), content)
 			}
 		}
 	}
 
+	// Near-duplicates: reworded, re-ordered or re-typed versions of the same
+	// memory. The earlier memory in the list is kept, like for exact ones.
+	flagged := map[string]bool{}
+	for _, p := range near.Pairs(similarity.DuplicateThreshold) {
+		if flagged[p.B] || exactDup[p.B] {
+			continue
+		}
+		flagged[p.B] = true
+		m := hygieneMemoryByID(memories, p.B)
+		ageDays := int(now.Sub(m.CreatedAt).Hours()/24 + 0.5)
+		report.addIssue(memoryHygieneIssue{
+			Kind:      "duplicate_near",
+			Severity:  "low",
+			MemoryID:  p.B,
+			Type:      m.Type,
+			AgeDays:   max(ageDays, 0),
+			Reason:    fmt.Sprintf("%.0f%% similar to another memory (%s); review and merge if it repeats the same knowledge", p.Score*100, engine.Name()),
+			Preview:   display.SingleLine(contents[p.B]),
+			RelatedID: p.A,
+		})
+	}
+
 	sort.SliceStable(report.Issues, func(i, j int) bool {
 		if severityRank(report.Issues[i].Severity) != severityRank(report.Issues[j].Severity) {
 			return severityRank(report.Issues[i].Severity) > severityRank(report.Issues[j].Severity)
@@ -588,6 +896,15 @@ func analyzeMemoryHygiene(projectID string, memories []models.Memory, now time.T
 	return report
 }
 
+func hygieneMemoryByID(memories []models.Memory, id string) models.Memory {
+	for _, m := range memories {
+		if m.ID.String() == id {
+			return m
+		}
+	}
+	return models.Memory{}
+}
+
 func (r *memoryHygieneReport) addIssue(issue memoryHygieneIssue) {
 	r.Issues = append(r.Issues, issue)
 	r.Counts[issue.Kind]++
@@ -756,6 +1073,7 @@ func getCmd() *cli.Command {
 				fmt.Println(apierrors.ParseAPIError(err))
 				return err
 			}
+			recordMemories(*memory)
 
 			// CRITICAL: Decrypt memory content before displaying
 			displayContent := decryptMemoryForCLI(memory)
@@ -819,11 +1137,15 @@ func forgetCmd() *cli.Command {
 			memoryID := c.Args().First()
 
 			client := api.NewClient()
+			before, fullID := memoryBefore(client, memoryID)
 			err := client.DeleteMemory(memoryID)
 			if err != nil {
 				fmt.Println(apierrors.ParseAPIError(err))
 				return err
 			}
+			journal.Record("memory forget", memoryLabel(before, fullID),
+				journal.Delete(journal.KindMemory, fullID, journal.MemorySnapshot(before)))
+			forgetLocalMemories(fullID)
 
 			fmt.Printf("🗑️ Memory %s forgotten successfully.\n", memoryID[:8])
 			return nil
@@ -0,0 +1,235 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/kutbudev/ramorie-cli/internal/anchor"
+	"github.com/kutbudev/ramorie-cli/internal/api"
+	"github.com/kutbudev/ramorie-cli/internal/cli/display"
+	"github.com/kutbudev/ramorie-cli/internal/memrev"
+	"github.com/kutbudev/ramorie-cli/internal/models"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/term"
+)
+
+// anchorCheck is one anchor of a memory and its current status.
+type anchorCheck struct {
+	MemoryID string        `json:"memory_id"`
+	Anchor   anchor.Anchor `json:"anchor"`
+	Status   anchor.Status `json:"status"`
+}
+
+// memoryAnchorsCmd implements `ramorie memory anchors`.
+func memoryAnchorsCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "anchors",
+		Usage: "List code-anchored memories and whether their code changed",
+		Description: "Memories created with `remember --anchor path:120-160` are linked to that\n" +
+			"   range. Each anchor is diffed against the git blob stored when it was made:\n" +
+			"   fresh (unchanged), moved (edits above shifted it), drifted (the lines\n" +
+			"   changed), missing (file gone) or unknown (repository not on this machine).\n" +
+			"   --check lists only drifted and missing anchors and exits 1 when there are\n" +
+			"   any; --refresh <memory-id> re-anchors a memory at its current lines once\n" +
+			"   you have confirmed it still holds.\n\n" +
+			"   Anchors are saved on the memory; ~/.ramorie/anchors.json indexes them on\n" +
+			"   this machine. --sync rebuilds that index from your memories, e.g. on a\n" +
+			"   new machine.",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "check", Usage: "Only list drifted and missing anchors; exit 1 when there are any"},
+			&cli.StringFlag{Name: "refresh", Usage: "Re-anchor this memory (ID or prefix) at its current lines"},
+			&cli.BoolFlag{Name: "sync", Usage: "Copy the anchors saved on your memories into this machine's index first"},
+			&cli.BoolFlag{Name: "json", Usage: "Output raw JSON (always on when piped)"},
+		},
+		Action: func(c *cli.Context) error {
+			client := api.NewClient()
+			if c.Bool("sync") {
+				n, err := syncAnchors(client)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(os.Stderr, "⚓ %d anchored memory(ies) synced\n", n)
+			}
+			store, err := anchor.Open()
+			if err != nil {
+				return err
+			}
+			all, err := store.Load()
+			if err != nil {
+				return err
+			}
+			if id := c.String("refresh"); id != "" {
+				return refreshAnchors(client, all, id)
+			}
+
+			checks := checkAnchors(all)
+			if c.Bool("check") {
+				checks = staleAnchorChecks(checks)
+			}
+			if c.Bool("json") || !term.IsTerminal(int(os.Stdout.Fd())) {
+				if checks == nil {
+					checks = []anchorCheck{}
+				}
+				out, _ := json.MarshalIndent(checks, "", "  ")
+				fmt.Println(string(out))
+			} else {
+				printAnchorChecks(checks, c.Bool("check"))
+			}
+			if c.Bool("check") && len(checks) > 0 {
+				return cli.Exit("", 1)
+			}
+			return nil
+		},
+	}
+}
+
+// checkAnchors checks every stored anchor, ordered by memory then path.
+func checkAnchors(all map[string][]anchor.Anchor) []anchorCheck {
+	var out []anchorCheck
+	for id, anchors := range all {
+		for _, a := range anchors {
+			out = append(out, anchorCheck{MemoryID: id, Anchor: a, Status: anchor.Check(a)})
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].MemoryID != out[j].MemoryID {
+			return out[i].MemoryID < out[j].MemoryID
+		}
+		return out[i].Anchor.String() < out[j].Anchor.String()
+	})
+	return out
+}
+
+// staleAnchorChecks keeps the anchors whose memories should be re-verified.
+func staleAnchorChecks(checks []anchorCheck) []anchorCheck {
+	var out []anchorCheck
+	for _, ch := range checks {
+		if ch.Status.Stale() {
+			out = append(out, ch)
+		}
+	}
+	return out
+}
+
+func anchorStateLabel(state string) string {
+	switch state {
+	case anchor.Fresh:
+		return display.Good.Render(state)
+	case anchor.Moved:
+		return display.Label.Render(state)
+	case anchor.Drifted, anchor.Missing:
+		return display.Warn.Render(state)
+	}
+	return display.Dim.Render(state)
+}
+
+func printAnchorChecks(checks []anchorCheck, onlyStale bool) {
+	if len(checks) == 0 {
+		if onlyStale {
+			fmt.Println(display.Good.Render("✓ no anchored code changed"))
+		} else {
+			fmt.Println(display.Dim.Render("  no anchored memories — use remember --anchor path:120-160"))
+		}
+		return
+	}
+	rows := make([][]string, 0, len(checks))
+	stale := 0
+	for _, ch := range checks {
+		if ch.Status.Stale() {
+			stale++
+		}
+		rows = append(rows, []string{
+			shortID(ch.MemoryID),
+			ch.Anchor.String(),
+			anchorStateLabel(ch.Status.State),
+			ch.Status.Reason,
+		})
+	}
+	fmt.Println(display.Header("⚓ anchors", fmt.Sprintf("%d anchor(s), %d stale", len(checks), stale)))
+	fmt.Println(display.NewResponsiveTable([]display.Column{
+		{Title: "MEMORY", Min: 8, Weight: 0},
+		{Title: "ANCHOR", Min: 20, Weight: 3},
+		{Title: "STATE", Min: 7, Weight: 0},
+		{Title: "NOTE", Min: 16, Weight: 2},
+	}, rows))
+	if stale > 0 {
+		fmt.Println(display.Dim.Render("Re-read the memory against the code, edit it if needed, then: ramorie memory anchors --refresh <id>"))
+	}
+}
+
+// matchAnchoredMemory finds the stored memory ID an ID or prefix names.
+func matchAnchoredMemory(all map[string][]anchor.Anchor, ref string) (string, error) {
+	ref = strings.ToLower(strings.TrimSpace(ref))
+	if _, ok := all[ref]; ok {
+		return ref, nil
+	}
+	var matches []string
+	for id := range all {
+		if strings.HasPrefix(id, ref) {
+			matches = append(matches, id)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no anchored memory matches %q (anchored on another machine? try --sync)", ref)
+	case 1:
+		return matches[0], nil
+	}
+	sort.Strings(matches)
+	return "", fmt.Errorf("%q is ambiguous: %s", ref, strings.Join(matches, ", "))
+}
+
+// syncAnchors pages through every memory and indexes the anchors saved on
+// them.
+func syncAnchors(client *api.Client) (int, error) {
+	var memories []models.Memory
+	for page := 1; ; page++ {
+		items, hasMore, err := client.ListMemoriesPage("", "", page, 100)
+		if err != nil {
+			return 0, err
+		}
+		memories = append(memories, items...)
+		if !hasMore || len(items) == 0 {
+			break
+		}
+	}
+	return anchor.Import(memories)
+}
+
+// refreshAnchors re-anchors a memory at the current location of each of its
+// anchors. Missing anchors and ones in repositories not on this machine are
+// kept as they are.
+func refreshAnchors(client *api.Client, all map[string][]anchor.Anchor, ref string) error {
+	id, err := matchAnchoredMemory(all, ref)
+	if err != nil {
+		return err
+	}
+	memory, err := client.GetMemory(id)
+	if err != nil {
+		return err
+	}
+	var refreshed []anchor.Anchor
+	for _, a := range all[id] {
+		st := anchor.Check(a)
+		if st.State == anchor.Missing || st.State == anchor.Unknown {
+			fmt.Fprintf(os.Stderr, "⚠️  %s: %s — kept as is\n", a, st.Reason)
+			refreshed = append(refreshed, a)
+			continue
+		}
+		spec := a.Path
+		if st.Start > 0 {
+			spec = fmt.Sprintf("%s:%d-%d", a.Path, st.Start, st.End)
+		}
+		root, _ := a.Root()
+		next, err := anchor.Resolve(spec, root)
+		if err != nil {
+			return err
+		}
+		refreshed = append(refreshed, next)
+		fmt.Printf("⚓ %s → %s\n", a, next)
+	}
+	return anchor.Set(memrev.Tracked{Client: client, Source: "cli"}, memory, refreshed)
+}
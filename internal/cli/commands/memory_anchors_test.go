@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kutbudev/ramorie-cli/internal/anchor"
+)
+
+func TestMatchAnchoredMemory(t *testing.T) {
+	all := map[string][]anchor.Anchor{
+		"3f2a9c10-0000-4000-8000-000000000001": nil,
+		"3f2a9c10-0000-4000-8000-000000000002": nil,
+		"7b1d0e44-0000-4000-8000-000000000003": nil,
+	}
+	if id, err := matchAnchoredMemory(all, "7B1D"); err != nil || id != "7b1d0e44-0000-4000-8000-000000000003" {
+		t.Errorf("prefix: %q %v", id, err)
+	}
+	if _, err := matchAnchoredMemory(all, "3f2a"); err == nil || !strings.Contains(err.Error(), "ambiguous") {
+		t.Errorf("ambiguous: %v", err)
+	}
+	if _, err := matchAnchoredMemory(all, "ffff"); err == nil {
+		t.Error("unknown ID should fail")
+	}
+}
+
+func TestStaleAnchorChecks(t *testing.T) {
+	checks := []anchorCheck{
+		{MemoryID: "a", Status: anchor.Status{State: anchor.Fresh}},
+		{MemoryID: "b", Status: anchor.Status{State: anchor.Moved}},
+		{MemoryID: "c", Status: anchor.Status{State: anchor.Drifted}},
+		{MemoryID: "d", Status: anchor.Status{State: anchor.Missing}},
+		{MemoryID: "e", Status: anchor.Status{State: anchor.Unknown}},
+	}
+	got := staleAnchorChecks(checks)
+	if len(got) != 2 || got[0].MemoryID != "c" || got[1].MemoryID != "d" {
+		t.Errorf("stale = %+v", got)
+	}
+}
@@ -0,0 +1,159 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/kutbudev/ramorie-cli/internal/cli/display"
+	"github.com/kutbudev/ramorie-cli/internal/memtype"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/term"
+)
+
+// memoryClassifyCmd implements `ramorie memory classify`.
+func memoryClassifyCmd() *cli.Command {
+	return &cli.Command{
+		Name:      "classify",
+		Usage:     "Show which memory type a text would get, and why",
+		ArgsUsage: "<text>  (or pipe it on stdin)",
+		Description: "Runs the same classifier remember uses and prints every type's score with\n" +
+			"   the phrases that matched. Keywords come from the built-in language packs\n" +
+			"   (" + strings.Join(memtype.Languages(), ", ") + ") plus ~/.ramorie/" + memtype.ConfigFile + " and <repo>/.ramorie/" + memtype.ConfigFile + ",\n" +
+			"   which can pick languages, add or reweight phrases (weight 0 drops one)\n" +
+			"   and change the tie-break priority.",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "json", Usage: "Output raw JSON (always on when piped)"},
+			&cli.StringFlag{Name: "keywords", Usage: "List the effective keywords of this type instead"},
+		},
+		Action: func(c *cli.Context) error {
+			cl, err := memtype.Load()
+			if err != nil {
+				return fmt.Errorf("classifier config: %w", err)
+			}
+			asJSON := c.Bool("json") || !term.IsTerminal(int(os.Stdout.Fd()))
+
+			if t := c.String("keywords"); t != "" {
+				return printClassifierKeywords(cl, t, asJSON)
+			}
+
+			text := strings.Join(c.Args().Slice(), " ")
+			if strings.TrimSpace(text) == "" && !term.IsTerminal(int(os.Stdin.Fd())) {
+				data, err := io.ReadAll(os.Stdin)
+				if err != nil {
+					return err
+				}
+				text = string(data)
+			}
+			if strings.TrimSpace(text) == "" {
+				return fmt.Errorf("text is required")
+			}
+
+			r := cl.Classify(text)
+			if asJSON {
+				if r.Matches == nil {
+					r.Matches = []memtype.Match{}
+				}
+				out, _ := json.MarshalIndent(map[string]interface{}{
+					"result":    r,
+					"languages": cl.Languages,
+					"priority":  cl.Priority,
+					"config":    cl.Sources,
+				}, "", "  ")
+				fmt.Println(string(out))
+				return nil
+			}
+
+			fmt.Println(display.Header("🏷  classify", "→ "+r.Type))
+			fmt.Println(display.NewResponsiveTable([]display.Column{
+				{Title: "TYPE", Min: 10, Weight: 0},
+				{Title: "SCORE", Min: 5, Weight: 0},
+				{Title: "MATCHED", Min: 24, Weight: 5},
+			}, classifyRows(r, cl.Priority)))
+			fmt.Println(r.Reason)
+			fmt.Println(display.Dim.Render(classifierSummary(cl)))
+			return nil
+		},
+	}
+}
+
+// classifyRows renders a result's breakdown, one row per type that matched
+// anything, in priority order.
+func classifyRows(r memtype.Result, priority []string) [][]string {
+	var rows [][]string
+	for _, t := range priority {
+		var parts []string
+		for _, m := range r.Matches {
+			if m.Type != t {
+				continue
+			}
+			part := fmt.Sprintf("%q +%d (%s)", m.Phrase, m.Weight, classifierSourceLabel(m.Source))
+			if !m.Counted {
+				part += " — ignored, no URL"
+			}
+			parts = append(parts, part)
+		}
+		if t == memtype.Reference && r.ShortURLBonus {
+			parts = append(parts, "short text with a URL +2")
+		}
+		if len(parts) == 0 {
+			continue
+		}
+		score := fmt.Sprintf("%d", r.Scores[t])
+		if t == r.Type {
+			score = display.Good.Render(score)
+		}
+		rows = append(rows, []string{display.TypeBadge(t), score, strings.Join(parts, ", ")})
+	}
+	return rows
+}
+
+// classifierSourceLabel shortens a config path to its directory's role.
+func classifierSourceLabel(source string) string {
+	user, _ := memtype.Paths()
+	switch {
+	case source == user:
+		return "user config"
+	case strings.HasSuffix(source, memtype.ConfigFile):
+		return "project config"
+	}
+	return source
+}
+
+func classifierSummary(cl *memtype.Classifier) string {
+	config := "built-in packs only"
+	if len(cl.Sources) > 0 {
+		config = strings.Join(cl.Sources, ", ")
+	}
+	return fmt.Sprintf("languages: %s · priority: %s · config: %s",
+		strings.Join(cl.Languages, ", "), strings.Join(cl.Priority, " > "), config)
+}
+
+func printClassifierKeywords(cl *memtype.Classifier, memoryType string, asJSON bool) error {
+	kws := cl.Keywords(memoryType)
+	if asJSON {
+		if kws == nil {
+			kws = []memtype.Keyword{}
+		}
+		out, _ := json.MarshalIndent(map[string]interface{}{"type": memoryType, "keywords": kws}, "", "  ")
+		fmt.Println(string(out))
+		return nil
+	}
+	if len(kws) == 0 {
+		return fmt.Errorf("no keywords for %q (types: %s)", memoryType, strings.Join(memtype.DefaultPriority, ", "))
+	}
+	rows := make([][]string, 0, len(kws))
+	for _, k := range kws {
+		rows = append(rows, []string{k.Phrase, fmt.Sprintf("%d", k.Weight), classifierSourceLabel(k.Source)})
+	}
+	fmt.Println(display.Header("🏷  "+memoryType, fmt.Sprintf("%d keyword(s)", len(kws))))
+	fmt.Println(display.NewResponsiveTable([]display.Column{
+		{Title: "PHRASE", Min: 16, Weight: 3},
+		{Title: "WEIGHT", Min: 6, Weight: 0},
+		{Title: "SOURCE", Min: 8, Weight: 1},
+	}, rows))
+	fmt.Println(display.Dim.Render(classifierSummary(cl)))
+	return nil
+}
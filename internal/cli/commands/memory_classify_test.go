@@ -0,0 +1,26 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kutbudev/ramorie-cli/internal/memtype"
+)
+
+func TestClassifyRows(t *testing.T) {
+	cl, err := memtype.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := cl.Classify("we decided to read the documentation")
+	rows := classifyRows(r, cl.Priority)
+	if len(rows) != 2 {
+		t.Fatalf("rows = %v", rows)
+	}
+	if !strings.Contains(rows[0][2], `"we decided" +3 (en)`) {
+		t.Errorf("decision row = %q", rows[0][2])
+	}
+	if !strings.Contains(rows[1][2], "ignored, no URL") {
+		t.Errorf("reference row = %q", rows[1][2])
+	}
+}
@@ -0,0 +1,221 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/kutbudev/ramorie-cli/internal/anchor"
+	"github.com/kutbudev/ramorie-cli/internal/api"
+	"github.com/kutbudev/ramorie-cli/internal/cli/display"
+	"github.com/kutbudev/ramorie-cli/internal/config"
+	"github.com/kutbudev/ramorie-cli/internal/gitmem"
+	"github.com/kutbudev/ramorie-cli/internal/journal"
+	"github.com/kutbudev/ramorie-cli/internal/memrev"
+	"github.com/kutbudev/ramorie-cli/internal/review"
+	"github.com/urfave/cli/v2"
+)
+
+// commitTagLimit caps the directory tags added to a commit memory.
+const commitTagLimit = 5
+
+// commitImport is what remember did with one commit.
+type commitImport struct {
+	SHA         string   `json:"sha"`
+	Subject     string   `json:"subject"`
+	Type        string   `json:"type"`
+	TypeReason  string   `json:"type_reason"`
+	Tags        []string `json:"tags,omitempty"`
+	TaskRefs    []string `json:"task_refs,omitempty"`
+	MemoryID    string   `json:"memory_id,omitempty"`
+	LinkedTasks []string `json:"linked_tasks,omitempty"`
+	Skipped     string   `json:"skipped,omitempty"`
+}
+
+// rememberCommits implements `remember --from-commit` and `--since`: one
+// memory per commit of the current repository.
+func rememberCommits(c *cli.Context, target *rememberTarget, tags []string, reviewDays int, expires *time.Time, anchors []anchor.Anchor) error {
+	repo := config.RepoRoot()
+	commits, err := gitmem.Select(repo, c.String("from-commit"), c.String("since"))
+	if err != nil {
+		return err
+	}
+	store, err := gitmem.Open()
+	if err != nil {
+		return err
+	}
+	imported, err := store.For(target.projectID)
+	if err != nil {
+		return err
+	}
+	dropDeletedImports(target.client, store, imported, commits)
+	batch := len(commits) > 1 || c.String("since") != ""
+	dryRun := c.Bool("dry-run")
+	tasks := newTaskRefResolver(target.client, nil)
+
+	var results []commitImport
+	var ops []journal.Op
+	// One journal entry for the whole import, so `ramorie undo` reverts it
+	// as a unit — also when a later commit fails.
+	defer func() {
+		if len(ops) > 0 {
+			journal.Record("remember --from-commit", fmt.Sprintf("%d commit memories", len(ops)), ops...)
+		}
+	}()
+
+	for _, cm := range commits {
+		r := planCommitImport(cm, tags, imported, batch)
+		if r.Skipped != "" || dryRun {
+			results = append(results, r)
+			continue
+		}
+		scan, err := checkSecrets(target.client, target.projectID, gitmem.Content(cm))
+		if err != nil {
+			if !batch {
+				return err
+			}
+			r.Skipped = err.Error()
+			results = append(results, r)
+			continue
+		}
+		memory, err := target.create(scan.Content, r.Type, r.Tags, scan.Encrypt)
+		if err != nil {
+			printCommitImports(results, dryRun)
+			return err
+		}
+		id := memory.ID.String()
+		r.MemoryID = id
+		ops = append(ops, journal.Create(journal.KindMemory, id))
+		if err := store.Put(target.projectID, cm.SHA, gitmem.Import{MemoryID: id, Repo: repo, Imported: time.Now().UTC()}); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  Could not record %s as imported: %v\n", cm.Short(), err)
+		}
+		if err := review.Set(id, reviewDays, expires); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  Could not save the review schedule: %v\n", err)
+		}
+		if err := anchor.Add(memrev.Tracked{Client: target.client, Source: "cli"}, memory, anchors); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  Could not save the code anchors: %v\n", err)
+		}
+		r.LinkedTasks = linkCommitTasks(target.client, tasks, r.TaskRefs, id)
+		results = append(results, r)
+	}
+
+	if c.Bool("json") {
+		if results == nil {
+			results = []commitImport{}
+		}
+		out, _ := json.MarshalIndent(map[string]interface{}{"dry_run": dryRun, "commits": results}, "", "  ")
+		fmt.Println(string(out))
+		return nil
+	}
+	printCommitImports(results, dryRun)
+	return nil
+}
+
+// planCommitImport works out a commit's type, tags and task references, and
+// whether it is skipped: already imported, or a fixup commit in a batch.
+func planCommitImport(cm gitmem.Commit, tags []string, imported map[string]gitmem.Import, batch bool) commitImport {
+	memType, reason := gitmem.Type(cm)
+	r := commitImport{
+		SHA:        cm.SHA,
+		Subject:    cm.Subject,
+		Type:       memType,
+		TypeReason: reason,
+		Tags:       cleanEditTags(append(append([]string{}, tags...), gitmem.Tags(cm, commitTagLimit)...)),
+	}
+	for _, ref := range parseTaskRefs(cm.Message()) {
+		r.TaskRefs = append(r.TaskRefs, ref.ID)
+	}
+	switch {
+	case imported[cm.SHA].MemoryID != "":
+		r.Skipped = "already imported as " + shortID(imported[cm.SHA].MemoryID)
+	case batch && gitmem.Squashable(cm):
+		r.Skipped = "fixup commit"
+	}
+	return r
+}
+
+// dropDeletedImports forgets the imports of the selected commits whose
+// memory no longer exists (deleted, or the import was undone), so those
+// commits are imported again. Lookups that fail otherwise keep the entry.
+func dropDeletedImports(client *api.Client, store *gitmem.Store, imported map[string]gitmem.Import, commits []gitmem.Commit) {
+	var gone []string
+	for _, cm := range commits {
+		imp, ok := imported[cm.SHA]
+		if !ok || imp.MemoryID == "" {
+			continue
+		}
+		if _, err := client.GetMemory(imp.MemoryID); err != nil && strings.Contains(err.Error(), "404") {
+			gone = append(gone, imp.MemoryID)
+			delete(imported, cm.SHA)
+		}
+	}
+	if _, err := store.Forget(gone...); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Could not update the commit import record: %v\n", err)
+	}
+}
+
+// linkCommitTasks links a commit memory to the tasks its message references
+// (ram#<id>) and returns the linked task IDs. Failures only warn. tasks is
+// shared across the import so the task list is fetched at most once.
+func linkCommitTasks(client *api.Client, tasks *taskRefResolver, refs []string, memoryID string) []string {
+	var linked []string
+	for _, ref := range refs {
+		taskID, err := tasks.resolve(ref)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  %v\n", err)
+			continue
+		}
+		if _, err := client.CreateMemoryTaskLink(taskID, memoryID, ""); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  Could not link %s to task %s: %v\n", shortID(memoryID), shortID(taskID), err)
+			continue
+		}
+		linked = append(linked, taskID)
+	}
+	return linked
+}
+
+func printCommitImports(results []commitImport, dryRun bool) {
+	if len(results) == 0 {
+		fmt.Println(display.Dim.Render("  no commits to import"))
+		return
+	}
+	created, skipped := 0, 0
+	rows := make([][]string, 0, len(results))
+	for _, r := range results {
+		var result string
+		switch {
+		case r.Skipped != "":
+			skipped++
+			result = display.Dim.Render("skipped: " + r.Skipped)
+		case dryRun:
+			result = "would import"
+		default:
+			created++
+			result = display.Good.Render("→ " + shortID(r.MemoryID))
+		}
+		if len(r.LinkedTasks) > 0 {
+			short := make([]string, len(r.LinkedTasks))
+			for i, id := range r.LinkedTasks {
+				short[i] = shortID(id)
+			}
+			result += " · task " + strings.Join(short, ", ")
+		} else if dryRun && len(r.TaskRefs) > 0 {
+			result += " · ram#" + strings.Join(r.TaskRefs, ", ram#")
+		}
+		rows = append(rows, []string{shortID(r.SHA), display.TypeBadge(r.Type), r.Subject, strings.Join(r.Tags, ", "), result})
+	}
+	subtitle := fmt.Sprintf("%d imported, %d skipped", created, skipped)
+	if dryRun {
+		subtitle = fmt.Sprintf("dry run — %d would be imported, %d skipped", len(results)-skipped, skipped)
+	}
+	fmt.Println(display.Header("🧠 commits", subtitle))
+	fmt.Println(display.NewResponsiveTable([]display.Column{
+		{Title: "COMMIT", Min: 8, Weight: 0},
+		{Title: "TYPE", Min: 10, Weight: 0},
+		{Title: "SUBJECT", Min: 20, Weight: 4},
+		{Title: "TAGS", Min: 8, Weight: 1},
+		{Title: "RESULT", Min: 12, Weight: 2},
+	}, rows))
+}
@@ -0,0 +1,42 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kutbudev/ramorie-cli/internal/gitmem"
+)
+
+func TestPlanCommitImport(t *testing.T) {
+	cm := gitmem.Commit{
+		SHA:     "3f2a9c10d2b4e6f8a0b1c2d3e4f5a6b7c8d9e0f1",
+		Subject: "fix(api): retry on 502",
+		Body:    "Fixes ram#7b1d0e44, see also ram#9c0a.",
+		Files: []gitmem.File{
+			{Path: "internal/api/client.go"},
+			{Path: "cmd/main.go"},
+		},
+	}
+	r := planCommitImport(cm, []string{"backend", "internal"}, nil, false)
+	if r.Type != "bug_fix" || r.Skipped != "" {
+		t.Errorf("plan = %+v", r)
+	}
+	if got := strings.Join(r.Tags, ","); got != "backend,internal,cmd" {
+		t.Errorf("tags = %s", got)
+	}
+	if got := strings.Join(r.TaskRefs, ","); got != "7b1d0e44,9c0a" {
+		t.Errorf("task refs = %s", got)
+	}
+
+	imported := map[string]gitmem.Import{cm.SHA: {MemoryID: "a1b2c3d4-0000-4000-8000-000000000000"}}
+	if r := planCommitImport(cm, nil, imported, false); r.Skipped != "already imported as a1b2c3d4" {
+		t.Errorf("imported: %q", r.Skipped)
+	}
+	fixup := gitmem.Commit{SHA: "x", Subject: "fixup! fix(api): retry on 502"}
+	if r := planCommitImport(fixup, nil, nil, true); r.Skipped != "fixup commit" {
+		t.Errorf("fixup in batch: %q", r.Skipped)
+	}
+	if r := planCommitImport(fixup, nil, nil, false); r.Skipped != "" {
+		t.Errorf("explicit fixup should import: %q", r.Skipped)
+	}
+}
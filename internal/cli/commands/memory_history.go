@@ -0,0 +1,264 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/kutbudev/ramorie-cli/internal/api"
+	"github.com/kutbudev/ramorie-cli/internal/cli/display"
+	"github.com/kutbudev/ramorie-cli/internal/crypto"
+	apierrors "github.com/kutbudev/ramorie-cli/internal/errors"
+	"github.com/kutbudev/ramorie-cli/internal/journal"
+	"github.com/kutbudev/ramorie-cli/internal/memrev"
+	"github.com/kutbudev/ramorie-cli/internal/models"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/term"
+)
+
+// loadMemoryHistory fetches the live memory and its revisions.
+func loadMemoryHistory(client *api.Client, ref string) (*models.Memory, []memrev.Revision, string, error) {
+	memory, err := client.GetMemory(ref)
+	if err != nil {
+		fmt.Println(apierrors.ParseAPIError(err))
+		return nil, nil, "", err
+	}
+	revs, from, err := memrev.History(client, memory.ID.String())
+	if err != nil {
+		return nil, nil, "", err
+	}
+	return memory, revs, from, nil
+}
+
+// revisionText returns the readable content of a memory version. Encrypted
+// versions need the unlocked vault.
+func revisionText(m *models.Memory) (string, error) {
+	if m.IsEncrypted && m.EncryptedContent != "" && !crypto.IsVaultUnlocked() {
+		return "", fmt.Errorf("revision is encrypted and the vault is locked — run 'ramorie vault unlock' first")
+	}
+	return decryptMemoryForCLI(m), nil
+}
+
+// parseRev accepts "3" or "r3".
+func parseRev(s string) (int, error) {
+	n, err := strconv.Atoi(strings.TrimPrefix(strings.ToLower(s), "r"))
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("revision must be a positive number, got %q", s)
+	}
+	return n, nil
+}
+
+// memoryHistoryCmd implements `ramorie memory history`.
+func memoryHistoryCmd() *cli.Command {
+	return &cli.Command{
+		Name:      "history",
+		Usage:     "List the prior versions of a memory",
+		ArgsUsage: "<memory-id>",
+		Description: "Revisions are recorded whenever the CLI, MCP server or TUI updates a memory\n" +
+			"   (kept in ~/.ramorie/revisions, or read from the backend when it keeps\n" +
+			"   them). Encrypted revisions are stored as ciphertext.",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "json", Usage: "Output raw JSON (always on when piped)"},
+		},
+		Action: func(c *cli.Context) error {
+			if c.NArg() == 0 {
+				return fmt.Errorf("memory ID is required")
+			}
+			client := api.NewClient()
+			memory, revs, from, err := loadMemoryHistory(client, c.Args().First())
+			if err != nil {
+				return err
+			}
+
+			if c.Bool("json") || !term.IsTerminal(int(os.Stdout.Fd())) {
+				if revs == nil {
+					revs = []memrev.Revision{}
+				}
+				out, _ := json.MarshalIndent(map[string]interface{}{
+					"memory_id": memory.ID.String(),
+					"source":    from,
+					"revisions": revs,
+				}, "", "  ")
+				fmt.Println(string(out))
+				return nil
+			}
+
+			fmt.Println(display.Header("🕘 memory "+memory.ID.String()[:8],
+				fmt.Sprintf("%d revision(s) · %s", len(revs), from)))
+			if len(revs) == 0 {
+				fmt.Println(display.Dim.Render("No earlier versions recorded yet."))
+				return nil
+			}
+			rows := make([][]string, 0, len(revs)+1)
+			for _, r := range revs {
+				rows = append(rows, revisionRow(fmt.Sprintf("r%d", r.Rev), display.Relative(r.At), r.Source, &r.Memory))
+			}
+			rows = append(rows, revisionRow(display.Good.Render("current"), display.Relative(memory.UpdatedAt), "", memory))
+			fmt.Println(display.NewResponsiveTable([]display.Column{
+				{Title: "REV", Min: 7, Weight: 0},
+				{Title: "WHEN", Min: 8, Weight: 0},
+				{Title: "SOURCE", Min: 7, Weight: 0},
+				{Title: "TYPE", Min: 10, Weight: 0},
+				{Title: "CONTENT", Min: 24, Weight: 5},
+			}, rows))
+			fmt.Println(display.Dim.Render("ramorie memory diff <id> [rev] · ramorie memory revert <id> <rev>"))
+			return nil
+		},
+	}
+}
+
+func revisionRow(rev, when, source string, m *models.Memory) []string {
+	content := "🔒 encrypted"
+	if text, err := revisionText(m); err == nil {
+		first, _ := splitFirstLine(text)
+		content = fmt.Sprintf("%s %s", first, display.Dim.Render(fmt.Sprintf("(%d chars)", len([]rune(text)))))
+	}
+	return []string{rev, when, source, display.TypeBadge(m.Type), content}
+}
+
+// memoryDiffCmd implements `ramorie memory diff`.
+func memoryDiffCmd() *cli.Command {
+	return &cli.Command{
+		Name:      "diff",
+		Usage:     "Show a unified diff between a revision and the current memory",
+		ArgsUsage: "<memory-id> [rev]",
+		Description: "Compares revision rev (default: the newest) with the current version, or\n" +
+			"   with another revision via --to. Colored on a terminal.",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "to", Usage: "Compare against this revision instead of the current version"},
+			&cli.IntFlag{Name: "context", Aliases: []string{"U"}, Usage: "Lines of context", Value: 3},
+		},
+		Action: func(c *cli.Context) error {
+			if c.NArg() == 0 {
+				return fmt.Errorf("memory ID is required")
+			}
+			client := api.NewClient()
+			memory, revs, _, err := loadMemoryHistory(client, c.Args().First())
+			if err != nil {
+				return err
+			}
+			if len(revs) == 0 {
+				return fmt.Errorf("memory %s has no recorded revisions", memory.ID.String()[:8])
+			}
+
+			from := revs[len(revs)-1]
+			if c.NArg() > 1 {
+				n, err := parseRev(c.Args().Get(1))
+				if err != nil {
+					return err
+				}
+				if from, err = memrev.Find(revs, n); err != nil {
+					return err
+				}
+			}
+			to, toName := memory, "current"
+			if ref := c.String("to"); ref != "" {
+				n, err := parseRev(ref)
+				if err != nil {
+					return err
+				}
+				r, err := memrev.Find(revs, n)
+				if err != nil {
+					return err
+				}
+				to, toName = &r.Memory, fmt.Sprintf("r%d", r.Rev)
+			}
+
+			a, err := revisionText(&from.Memory)
+			if err != nil {
+				return err
+			}
+			b, err := revisionText(to)
+			if err != nil {
+				return err
+			}
+			color := term.IsTerminal(int(os.Stdout.Fd())) && os.Getenv("NO_COLOR") == ""
+			fmt.Print(memoryDiff(fmt.Sprintf("r%d", from.Rev), toName, &from.Memory, to, a, b, c.Int("context"), color))
+			return nil
+		},
+	}
+}
+
+// memoryDiff renders metadata changes plus a unified diff of the content.
+func memoryDiff(fromName, toName string, from, to *models.Memory, a, b string, context int, color bool) string {
+	var out strings.Builder
+	header := fmt.Sprintf("--- %s\n+++ %s\n", fromName, toName)
+	if color {
+		header = display.Dim.Render(strings.TrimSuffix(header, "\n")) + "\n"
+	}
+	out.WriteString(header)
+	if from.Type != to.Type {
+		fmt.Fprintf(&out, "type: %s → %s\n", from.Type, to.Type)
+	}
+	if ta, tb := strings.Join(getTagsAsStrings(from.Tags), ", "), strings.Join(getTagsAsStrings(to.Tags), ", "); ta != tb {
+		fmt.Fprintf(&out, "tags: [%s] → [%s]\n", ta, tb)
+	}
+	diff := formatUnifiedDiff(diffLines(a, b), context, color)
+	if diff == "" {
+		diff = "(content unchanged)\n"
+		if color {
+			diff = display.Dim.Render("(content unchanged)") + "\n"
+		}
+	}
+	out.WriteString(diff)
+	return out.String()
+}
+
+// memoryRevertCmd implements `ramorie memory revert`.
+func memoryRevertCmd() *cli.Command {
+	return &cli.Command{
+		Name:      "revert",
+		Usage:     "Restore a memory to an earlier revision",
+		ArgsUsage: "<memory-id> <rev>",
+		Description: "Writes the revision's content, type, tags and skill fields back. The\n" +
+			"   version being replaced is recorded as a new revision first, and the revert\n" +
+			"   is journaled for `ramorie undo`. Encrypted revisions are restored from their\n" +
+			"   ciphertext, so the vault is only needed to preview the diff.",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "dry-run", Aliases: []string{"n"}, Usage: "Show the diff without restoring"},
+		},
+		Action: func(c *cli.Context) error {
+			if c.NArg() < 2 {
+				return fmt.Errorf("usage: ramorie memory revert <memory-id> <rev>")
+			}
+			n, err := parseRev(c.Args().Get(1))
+			if err != nil {
+				return err
+			}
+			client := api.NewClient()
+			memory, revs, _, err := loadMemoryHistory(client, c.Args().First())
+			if err != nil {
+				return err
+			}
+			rev, err := memrev.Find(revs, n)
+			if err != nil {
+				return err
+			}
+
+			a, aerr := revisionText(memory)
+			b, berr := revisionText(&rev.Memory)
+			if aerr == nil && berr == nil {
+				fmt.Print(memoryDiff("current", fmt.Sprintf("r%d", rev.Rev), memory, &rev.Memory, a, b, 3,
+					term.IsTerminal(int(os.Stdout.Fd())) && os.Getenv("NO_COLOR") == ""))
+			} else {
+				fmt.Println(display.Dim.Render("🔒 encrypted content — unlock the vault to preview the diff"))
+			}
+			if c.Bool("dry-run") {
+				return nil
+			}
+
+			payload := memrev.RestorePayload(rev)
+			id := memory.ID.String()
+			if _, err := memrev.Update(client, id, payload, "revert"); err != nil {
+				fmt.Println(apierrors.ParseAPIError(err))
+				return err
+			}
+			journal.Record("memory revert", fmt.Sprintf("%s → r%d", memoryLabel(memory, id), rev.Rev),
+				journal.Update(journal.KindMemory, id, journal.MemorySnapshot(memory), updateFields(payload)...))
+			fmt.Printf("✅ Memory %s restored to r%d.\n", id[:8], rev.Rev)
+			return nil
+		},
+	}
+}
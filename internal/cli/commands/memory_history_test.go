@@ -0,0 +1,34 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kutbudev/ramorie-cli/internal/models"
+)
+
+func TestParseRev(t *testing.T) {
+	for in, want := range map[string]int{"3": 3, "r12": 12, "R1": 1} {
+		if got, err := parseRev(in); err != nil || got != want {
+			t.Errorf("parseRev(%q) = %d, %v", in, got, err)
+		}
+	}
+	for _, bad := range []string{"0", "r", "-1", "two"} {
+		if _, err := parseRev(bad); err == nil {
+			t.Errorf("parseRev(%q) should fail", bad)
+		}
+	}
+}
+
+func TestMemoryDiff(t *testing.T) {
+	from := &models.Memory{Type: "preference", Tags: []interface{}{"go"}}
+	to := &models.Memory{Type: "decision", Tags: []interface{}{"go"}}
+	out := memoryDiff("r1", "current", from, to, "use tabs\nalways", "use spaces\nalways", 3, false)
+	want := "--- r1\n+++ current\ntype: preference → decision\n@@ -1,2 +1,2 @@\n-use tabs\n+use spaces\n always\n"
+	if out != want {
+		t.Errorf("memoryDiff =\n%s\nwant\n%s", out, want)
+	}
+	if out := memoryDiff("r1", "r2", from, from, "x", "x", 3, false); !strings.Contains(out, "(content unchanged)") {
+		t.Errorf("unchanged diff = %q", out)
+	}
+}
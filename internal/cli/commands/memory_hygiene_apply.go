@@ -0,0 +1,605 @@
+package commands
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/kutbudev/ramorie-cli/internal/api"
+	"github.com/kutbudev/ramorie-cli/internal/cli/display"
+	"github.com/kutbudev/ramorie-cli/internal/journal"
+	"github.com/kutbudev/ramorie-cli/internal/memrev"
+	"github.com/kutbudev/ramorie-cli/internal/models"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/term"
+	"gopkg.in/yaml.v3"
+)
+
+// Hygiene actions.
+const (
+	hygieneMerge   = "merge"
+	hygieneSkill   = "skill"
+	hygieneArchive = "archive"
+	hygieneDelete  = "delete"
+	hygieneSkip    = "skip"
+)
+
+// hygieneActions lists the actions that make sense for each issue kind. The
+// first one is what the interactive review suggests.
+var hygieneActions = map[string][]string{
+	"duplicate_exact":    {hygieneMerge, hygieneDelete, hygieneSkip},
+	"duplicate_near":     {hygieneMerge, hygieneDelete, hygieneSkip},
+	"runbook_prose":      {hygieneSkill, hygieneArchive, hygieneSkip},
+	"skill_unstructured": {hygieneSkill, hygieneArchive, hygieneSkip},
+	"stale":              {hygieneArchive, hygieneDelete, hygieneSkip},
+	"thin_low_value":     {hygieneDelete, hygieneArchive, hygieneSkip},
+}
+
+// hygienePolicy is the non-interactive cleanup plan read by --policy (YAML
+// or JSON):
+//
+//	actions:
+//	  duplicate_exact: merge
+//	  runbook_prose: skill
+//	  stale: archive
+//	min_severity: medium   # leave lower-severity issues alone
+//	max_actions: 50        # stop after this many changes
+//
+// Kinds without an action are skipped.
+type hygienePolicy struct {
+	Actions     map[string]string `yaml:"actions"`
+	MinSeverity string            `yaml:"min_severity"`
+	MaxActions  int               `yaml:"max_actions"`
+}
+
+// parseHygienePolicy decodes and validates a policy file.
+func parseHygienePolicy(data []byte) (*hygienePolicy, error) {
+	var p hygienePolicy
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("invalid policy: %w", err)
+	}
+	for kind, action := range p.Actions {
+		allowed, ok := hygieneActions[kind]
+		if !ok {
+			return nil, fmt.Errorf("invalid policy: unknown issue kind %q", kind)
+		}
+		action = strings.ToLower(strings.TrimSpace(action))
+		if !slices.Contains(allowed, action) {
+			return nil, fmt.Errorf("invalid policy: %s cannot be %q (use %s)", kind, action, strings.Join(allowed, ", "))
+		}
+		p.Actions[kind] = action
+	}
+	p.MinSeverity = strings.ToLower(strings.TrimSpace(p.MinSeverity))
+	if p.MinSeverity != "" && severityRank(p.MinSeverity) == 0 {
+		return nil, fmt.Errorf("invalid policy: min_severity must be low, medium or high")
+	}
+	if p.MaxActions < 0 {
+		return nil, fmt.Errorf("invalid policy: max_actions must not be negative")
+	}
+	return &p, nil
+}
+
+// actionFor returns the policy's action for issue, or skip.
+func (p *hygienePolicy) actionFor(issue memoryHygieneIssue) string {
+	if p.MinSeverity != "" && severityRank(issue.Severity) < severityRank(p.MinSeverity) {
+		return hygieneSkip
+	}
+	if a, ok := p.Actions[issue.Kind]; ok {
+		return a
+	}
+	return hygieneSkip
+}
+
+var (
+	skillTriggerToken = regexp.MustCompile(`(?i)\bbefore:[a-z0-9][a-z0-9_-]*`)
+	skillTriggerLine  = regexp.MustCompile(`(?i)^(?:when|trigger)\s*:\s*(.+)$`)
+	skillCheckLine    = regexp.MustCompile(`(?i)^(?:verify|validation|validate|check)\s*:\s*(.+)$`)
+	skillStepsLine    = regexp.MustCompile(`(?i)^steps?\s*:\s*(.*)$`)
+	skillListItem     = regexp.MustCompile(`^(?:\d+[.)]|[-*•])\s+(.+)$`)
+	skillStepSplit    = regexp.MustCompile(`(?i)\s*(?:;|\.\s+|,?\s+then\s+)\s*`)
+)
+
+// draftSkill derives skill fields from runbook prose: the trigger from a
+// before:<intent> token, a "when:" line or the before-action intent the text
+// matches; steps from a numbered/bulleted list or a "Steps:" sentence; the
+// validation from a "verify:" line. Fields it cannot find are left empty.
+func draftSkill(content string) memoryEditFront {
+	f := memoryEditFront{Type: "skill"}
+	if tok := skillTriggerToken.FindString(content); tok != "" {
+		f.Trigger = strings.ToLower(tok)
+	}
+	var prose []string
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "#"))
+		if line == "" {
+			continue
+		}
+		if m := skillTriggerLine.FindStringSubmatch(line); m != nil {
+			if f.Trigger == "" {
+				f.Trigger = strings.TrimSpace(m[1])
+			}
+			continue
+		}
+		if m := skillCheckLine.FindStringSubmatch(line); m != nil {
+			f.Validation = strings.TrimSpace(m[1])
+			continue
+		}
+		if m := skillListItem.FindStringSubmatch(line); m != nil {
+			f.Steps = append(f.Steps, strings.TrimSpace(m[1]))
+			continue
+		}
+		if m := skillStepsLine.FindStringSubmatch(line); m != nil {
+			f.Steps = append(f.Steps, splitSkillSteps(m[1])...)
+			continue
+		}
+		prose = append(prose, line)
+	}
+	if len(f.Steps) == 0 {
+		for _, line := range prose {
+			if skillTriggerToken.ReplaceAllString(line, "") != line {
+				continue // the "Runbook: before:x" heading
+			}
+			f.Steps = append(f.Steps, splitSkillSteps(line)...)
+		}
+	}
+	if f.Trigger == "" {
+		if intents := classifyBeforeActionIntents(content); len(intents) > 0 {
+			f.Trigger = "before:" + intents[0].Key
+		}
+	}
+	return f
+}
+
+func splitSkillSteps(s string) []string {
+	var out []string
+	for _, part := range skillStepSplit.Split(strings.TrimSpace(s), -1) {
+		if part = strings.TrimRight(strings.TrimSpace(part), "."); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+// hygieneResult is the outcome of one reviewed issue.
+type hygieneResult struct {
+	Kind     string `json:"kind"`
+	MemoryID string `json:"memory_id"`
+	Action   string `json:"action"`
+	Status   string `json:"status"` // applied, planned, skipped, failed
+	Note     string `json:"note,omitempty"`
+}
+
+// hygieneApplier carries out hygiene actions and journals each one, so any
+// of them can be reverted with `ramorie undo`.
+type hygieneApplier struct {
+	client *api.Client
+	byID   map[string]*models.Memory
+	// gone marks memories that were archived, deleted or merged away, so
+	// later issues about them are skipped.
+	gone map[string]bool
+}
+
+func newHygieneApplier(client *api.Client, memories []models.Memory) *hygieneApplier {
+	a := &hygieneApplier{client: client, byID: map[string]*models.Memory{}, gone: map[string]bool{}}
+	for i := range memories {
+		a.byID[memories[i].ID.String()] = &memories[i]
+	}
+	return a
+}
+
+// blocked reports why issue can no longer be acted on, or "".
+func (a *hygieneApplier) blocked(issue memoryHygieneIssue) string {
+	if a.gone[issue.MemoryID] {
+		return "memory was already archived or removed in this run"
+	}
+	if issue.RelatedID != "" && a.gone[issue.RelatedID] {
+		return "related memory was already removed in this run"
+	}
+	return ""
+}
+
+// apply performs action on issue. draft overrides the derived skill fields
+// (interactive edit).
+func (a *hygieneApplier) apply(issue memoryHygieneIssue, action string, draft *memoryEditFront) (string, error) {
+	m := a.byID[issue.MemoryID]
+	if m == nil {
+		return "", fmt.Errorf("memory %s was not loaded", shortID(issue.MemoryID))
+	}
+	id := issue.MemoryID
+	switch action {
+	case hygieneMerge:
+		return a.merge(m, a.byID[issue.RelatedID], issue.Kind == "duplicate_near")
+
+	case hygieneSkill:
+		if m.IsEncrypted {
+			return "", fmt.Errorf("encrypted memory: converting would copy vault content into plaintext skill fields — use 'ramorie memory edit %s'", shortID(id))
+		}
+		before := memoryEditFrontFor(m)
+		after := draftSkill(m.Content)
+		if draft != nil {
+			after = *draft
+		}
+		after.Type, after.Tags = "skill", before.Tags
+		if strings.TrimSpace(after.Trigger) == "" || len(after.Steps) == 0 {
+			return "", fmt.Errorf("could not derive a trigger and steps from the content")
+		}
+		_, updates, err := memoryEditChanges(before, m.Content, after, m.Content)
+		if err != nil {
+			return "", err
+		}
+		if len(updates) == 0 {
+			return "already a structured skill", nil
+		}
+		if _, err := memrev.Update(a.client, id, updates, "hygiene"); err != nil {
+			return "", err
+		}
+		journal.Record("memory hygiene", "skill "+memoryLabel(m, id),
+			journal.Update(journal.KindMemory, id, journal.MemorySnapshot(m), updateFields(updates)...))
+		return fmt.Sprintf("trigger %s · %d step(s)", after.Trigger, len(after.Steps)), nil
+
+	case hygieneArchive:
+		if err := archiveMemory(a.client, id, "memory hygiene", "hygiene"); err != nil {
+			return "", err
+		}
+		a.gone[id] = true
+		return "archived", nil
+
+	case hygieneDelete:
+		if err := a.client.DeleteMemory(id); err != nil {
+			return "", err
+		}
+		journal.Record("memory hygiene", "delete "+memoryLabel(m, id),
+			journal.Delete(journal.KindMemory, id, journal.MemorySnapshot(m)))
+		forgetLocalMemories(id)
+		a.gone[id] = true
+		return "deleted", nil
+	}
+	return "", fmt.Errorf("unknown action %q", action)
+}
+
+// merge folds dup into kept: kept gains dup's tags and, with content (near
+// duplicates), the lines it does not already have; dup is deleted. All steps
+// go into one journal entry.
+func (a *hygieneApplier) merge(dup, kept *models.Memory, content bool) (string, error) {
+	if kept == nil {
+		return "", fmt.Errorf("the memory to merge into was not loaded")
+	}
+	dupID, keptID := dup.ID.String(), kept.ID.String()
+	updates := map[string]interface{}{}
+	keptTags := cleanEditTags(getTagsAsStrings(kept.Tags))
+	if tags := cleanEditTags(append(slices.Clone(keptTags), getTagsAsStrings(dup.Tags)...)); !slices.Equal(tags, keptTags) {
+		updates["tags"] = tags
+	}
+	keptText, dupText := decryptMemoryForCLI(kept), decryptMemoryForCLI(dup)
+	if merged := mergeDuplicateContent(keptText, dupText); content && merged != keptText {
+		if kept.IsEncrypted || dup.IsEncrypted {
+			return "", fmt.Errorf("encrypted memories differ in content — merge them with 'ramorie memory edit %s'", shortID(keptID))
+		}
+		updates["content"] = merged
+	}
+	var ops []journal.Op
+	if len(updates) > 0 {
+		if err := sealMemoryContent(a.client, kept, updates); err != nil {
+			return "", err
+		}
+		if _, err := memrev.Update(a.client, keptID, updates, "hygiene"); err != nil {
+			return "", err
+		}
+		ops = append(ops, journal.Update(journal.KindMemory, keptID, journal.MemorySnapshot(kept), updateFields(updates)...))
+	}
+	err := a.client.DeleteMemory(dupID)
+	if err == nil {
+		ops = append(ops, journal.Delete(journal.KindMemory, dupID, journal.MemorySnapshot(dup)))
+		forgetLocalMemories(dupID)
+		a.gone[dupID] = true
+	}
+	journal.Record("memory hygiene", fmt.Sprintf("merge %s into %s", shortID(dupID), shortID(keptID)), ops...)
+	if err != nil {
+		return "", err
+	}
+	return "merged into " + shortID(keptID), nil
+}
+
+// mergeDuplicateContent appends the lines of dup that kept lacks (compared
+// case- and space-insensitively), separated by a rule.
+func mergeDuplicateContent(kept, dup string) string {
+	have := map[string]bool{}
+	for _, line := range strings.Split(kept, "\n") {
+		have[strings.Join(strings.Fields(strings.ToLower(line)), " ")] = true
+	}
+	var extra []string
+	for _, line := range strings.Split(dup, "\n") {
+		norm := strings.Join(strings.Fields(strings.ToLower(line)), " ")
+		if norm == "" || have[norm] {
+			continue
+		}
+		have[norm] = true
+		extra = append(extra, strings.TrimSpace(line))
+	}
+	if len(extra) == 0 {
+		return kept
+	}
+	return strings.TrimRight(kept, "\n") + "\n\n---\n" + strings.Join(extra, "\n")
+}
+
+// runHygienePolicy plans (apply=false) or applies the policy to every issue.
+func runHygienePolicy(a *hygieneApplier, issues []memoryHygieneIssue, p *hygienePolicy, apply bool) []hygieneResult {
+	var results []hygieneResult
+	acted := 0
+	for _, issue := range issues {
+		r := hygieneResult{Kind: issue.Kind, MemoryID: issue.MemoryID, Action: p.actionFor(issue)}
+		switch {
+		case r.Action == hygieneSkip:
+			r.Status = "skipped"
+		case p.MaxActions > 0 && acted >= p.MaxActions:
+			r.Status, r.Note = "skipped", fmt.Sprintf("max_actions (%d) reached", p.MaxActions)
+		case a.blocked(issue) != "":
+			r.Status, r.Note = "skipped", a.blocked(issue)
+		case !apply:
+			r.Status = "planned"
+			acted++
+			if r.Action == hygieneArchive || r.Action == hygieneDelete || r.Action == hygieneMerge {
+				a.gone[issue.MemoryID] = true
+			}
+		default:
+			note, err := a.apply(issue, r.Action, nil)
+			if err != nil {
+				r.Status, r.Note = "failed", err.Error()
+			} else {
+				r.Status, r.Note = "applied", note
+				acted++
+			}
+		}
+		results = append(results, r)
+	}
+	return results
+}
+
+// hygieneKeys are the single-letter answers of the interactive review.
+var hygieneKeys = map[string]string{
+	"m": hygieneMerge, "s": hygieneSkill, "a": hygieneArchive, "d": hygieneDelete, "k": hygieneSkip,
+}
+
+// parseHygieneAnswer maps an answer to an action allowed for kind. "" picks
+// the suggestion; "e" is skill with editing, "q" quits.
+func parseHygieneAnswer(answer, kind string) (action string, edit, quit bool, err error) {
+	allowed := hygieneActions[kind]
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	switch answer {
+	case "":
+		return allowed[0], false, false, nil
+	case "q", "quit":
+		return "", false, true, nil
+	case "e", "edit":
+		answer, edit = hygieneSkill, true
+	}
+	if a, ok := hygieneKeys[answer]; ok {
+		answer = a
+	}
+	if !slices.Contains(allowed, answer) {
+		return "", false, false, fmt.Errorf("%q is not an option for %s", answer, kind)
+	}
+	return answer, edit, false, nil
+}
+
+// hygieneMenu renders the options for kind, suggestion first and bold.
+func hygieneMenu(kind string) string {
+	var parts []string
+	for i, a := range hygieneActions[kind] {
+		label := "[" + a[:1] + "]" + a[1:]
+		if a == hygieneSkip {
+			label = "s[k]ip"
+		}
+		if i == 0 {
+			label = display.Label.Render(label)
+		}
+		parts = append(parts, label)
+		if a == hygieneSkill {
+			parts = append(parts, "[e]dit as skill")
+		}
+	}
+	return strings.Join(append(parts, "[q]uit"), " · ")
+}
+
+// reviewHygiene walks the issues on the terminal, asking for an action each.
+func reviewHygiene(a *hygieneApplier, issues []memoryHygieneIssue, in io.Reader) []hygieneResult {
+	reader := bufio.NewReader(in)
+	var results []hygieneResult
+	for i, issue := range issues {
+		if reason := a.blocked(issue); reason != "" {
+			results = append(results, hygieneResult{Kind: issue.Kind, MemoryID: issue.MemoryID, Action: hygieneSkip, Status: "skipped", Note: reason})
+			continue
+		}
+		fmt.Println()
+		fmt.Printf("%s %s · %s · %s · %s\n", display.Dim.Render(fmt.Sprintf("[%d/%d]", i+1, len(issues))),
+			issue.Severity, display.Label.Render(issue.Kind), shortID(issue.MemoryID), display.TypeBadge(issue.Type))
+		fmt.Println("  " + display.Dim.Render(issue.Reason))
+		fmt.Println("  " + display.Truncate(issue.Preview, 100))
+		if issue.RelatedID != "" {
+			fmt.Println("  " + display.Dim.Render("duplicate of "+shortID(issue.RelatedID)))
+		}
+		m := a.byID[issue.MemoryID]
+		if slices.Contains(hygieneActions[issue.Kind], hygieneSkill) && m != nil && !m.IsEncrypted {
+			d := draftSkill(m.Content)
+			fmt.Printf("  %s trigger %q · %d step(s)\n", display.Dim.Render("→ skill:"), d.Trigger, len(d.Steps))
+		}
+
+		var action string
+		var edit bool
+		for {
+			fmt.Printf("  %s: ", hygieneMenu(issue.Kind))
+			answer, rerr := reader.ReadString('\n')
+			var quit bool
+			var err error
+			action, edit, quit, err = parseHygieneAnswer(answer, issue.Kind)
+			if rerr != nil && strings.TrimSpace(answer) == "" {
+				quit = true // EOF
+			}
+			if quit {
+				return results
+			}
+			if err == nil {
+				break
+			}
+			fmt.Println("  " + display.Err.Render(err.Error()))
+		}
+
+		r := hygieneResult{Kind: issue.Kind, MemoryID: issue.MemoryID, Action: action}
+		if action == hygieneSkip {
+			r.Status = "skipped"
+			results = append(results, r)
+			continue
+		}
+		var draft *memoryEditFront
+		if edit {
+			d, err := editSkillDraft(m)
+			if err != nil || d == nil {
+				r.Status = "skipped"
+				if err != nil {
+					r.Status, r.Note = "failed", err.Error()
+					fmt.Println("  " + display.Err.Render("✗ "+err.Error()))
+				}
+				results = append(results, r)
+				continue
+			}
+			draft = d
+		}
+		note, err := a.apply(issue, action, draft)
+		if err != nil {
+			r.Status, r.Note = "failed", err.Error()
+			fmt.Println("  " + display.Err.Render("✗ "+err.Error()))
+		} else {
+			r.Status, r.Note = "applied", note
+			fmt.Println("  " + display.Good.Render("✓ "+action) + " " + display.Dim.Render(note))
+		}
+		results = append(results, r)
+	}
+	return results
+}
+
+// editSkillDraft opens the derived skill fields in $EDITOR. A nil draft
+// means the user aborted.
+func editSkillDraft(m *models.Memory) (*memoryEditFront, error) {
+	if m == nil {
+		return nil, fmt.Errorf("memory was not loaded")
+	}
+	if m.IsEncrypted {
+		return nil, fmt.Errorf("encrypted memory: use 'ramorie memory edit %s'", shortID(m.ID.String()))
+	}
+	front := draftSkill(m.Content)
+	front.Tags = getTagsAsStrings(m.Tags)
+	doc, err := renderEditDoc("ramorie memory hygiene — adjust the skill fields, save to convert.\n"+
+		"The content below is shown for reference and is not changed.", front, m.Content)
+	if err != nil {
+		return nil, err
+	}
+	var draft memoryEditFront
+	ok, err := editLoop(doc, "ramorie-skill-*.md", func(edited string) error {
+		_, err := parseEditDoc(edited, &draft)
+		return err
+	})
+	if err != nil || !ok {
+		return nil, err
+	}
+	return &draft, nil
+}
+
+// printHygieneResults summarizes an apply or policy run.
+func printHygieneResults(results []hygieneResult, title string) {
+	counts := map[string]int{}
+	rows := make([][]string, 0, len(results))
+	for _, r := range results {
+		counts[r.Status]++
+		if r.Status == "skipped" && r.Note == "" {
+			continue
+		}
+		status := r.Status
+		switch r.Status {
+		case "applied":
+			status = display.Good.Render(status)
+		case "failed":
+			status = display.Err.Render(status)
+		case "planned":
+			status = display.Warn.Render(status)
+		default:
+			status = display.Dim.Render(status)
+		}
+		rows = append(rows, []string{status, r.Kind, display.Dim.Render(shortID(r.MemoryID)), r.Action, r.Note})
+	}
+	fmt.Println(display.Header(title, fmt.Sprintf("applied %d · planned %d · skipped %d · failed %d",
+		counts["applied"], counts["planned"], counts["skipped"], counts["failed"])))
+	if len(rows) > 0 {
+		fmt.Println(display.NewResponsiveTable([]display.Column{
+			{Title: "STATUS", Min: 8, Weight: 0},
+			{Title: "KIND", Min: 18, Weight: 0},
+			{Title: "ID", Min: 8, Weight: 0},
+			{Title: "ACTION", Min: 8, Weight: 0},
+			{Title: "NOTE", Min: 24, Weight: 3},
+		}, rows))
+	}
+	if counts["applied"] > 0 {
+		fmt.Println(display.Dim.Render("Every change is journaled — see 'ramorie history', revert with 'ramorie undo'."))
+	}
+}
+
+// runMemoryHygieneApply handles --apply and --policy for `memory hygiene`.
+// A policy without --apply only prints the plan.
+func runMemoryHygieneApply(c *cli.Context, client *api.Client, memories []models.Memory, report memoryHygieneReport, policy *hygienePolicy) error {
+	a := newHygieneApplier(client, memories)
+	apply := c.Bool("apply")
+	var results []hygieneResult
+	title := "Memory hygiene"
+	switch {
+	case policy != nil:
+		results = runHygienePolicy(a, report.Issues, policy, apply)
+		if !apply {
+			title += " plan"
+		}
+	case report.IssueCount == 0:
+		fmt.Println(display.Dim.Render("No hygiene issues found."))
+		return nil
+	default:
+		fmt.Println(display.Header("Memory hygiene review", fmt.Sprintf("scanned %d · issues %d", report.Scanned, report.IssueCount)))
+		fmt.Println(display.Dim.Render("Enter takes the highlighted suggestion."))
+		results = reviewHygiene(a, report.Issues, os.Stdin)
+		fmt.Println()
+	}
+	if results == nil {
+		results = []hygieneResult{}
+	}
+
+	if c.Bool("json") || !term.IsTerminal(int(os.Stdout.Fd())) {
+		out, _ := json.MarshalIndent(map[string]interface{}{
+			"project_id": report.ProjectID,
+			"scanned":    report.Scanned,
+			"dry_run":    !apply,
+			"results":    results,
+		}, "", "  ")
+		fmt.Println(string(out))
+	} else {
+		printHygieneResults(results, title)
+		if !apply {
+			fmt.Println(display.Dim.Render("Nothing was changed — add --apply to carry out the plan."))
+		}
+	}
+	for _, r := range results {
+		if r.Status == "failed" {
+			return fmt.Errorf("some hygiene actions failed")
+		}
+	}
+	return nil
+}
+
+// loadHygienePolicy reads a policy file.
+func loadHygienePolicy(path string) (*hygienePolicy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseHygienePolicy(data)
+}
@@ -0,0 +1,123 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kutbudev/ramorie-cli/internal/models"
+)
+
+func TestParseHygienePolicy(t *testing.T) {
+	p, err := parseHygienePolicy([]byte("actions:\n  duplicate_exact: Merge\n  stale: archive\nmin_severity: medium\nmax_actions: 2\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Actions["duplicate_exact"] != hygieneMerge || p.MaxActions != 2 {
+		t.Errorf("policy = %+v", p)
+	}
+	if got := p.actionFor(memoryHygieneIssue{Kind: "stale", Severity: "low"}); got != hygieneSkip {
+		t.Errorf("low-severity issue below min_severity = %q, want skip", got)
+	}
+	if got := p.actionFor(memoryHygieneIssue{Kind: "stale", Severity: "medium"}); got != hygieneArchive {
+		t.Errorf("stale = %q, want archive", got)
+	}
+	if got := p.actionFor(memoryHygieneIssue{Kind: "runbook_prose", Severity: "high"}); got != hygieneSkip {
+		t.Errorf("unmapped kind = %q, want skip", got)
+	}
+
+	if _, err := parseHygienePolicy([]byte(`{"actions": {"thin_low_value": "delete"}}`)); err != nil {
+		t.Errorf("JSON policy: %v", err)
+	}
+	for _, bad := range []string{
+		"actions:\n  stale: merge\n",
+		"actions:\n  nonsense: skip\n",
+		"min_severity: urgent\n",
+		"max_actions: -1\n",
+	} {
+		if _, err := parseHygienePolicy([]byte(bad)); err == nil {
+			t.Errorf("policy %q should be rejected", bad)
+		}
+	}
+}
+
+func TestDraftSkill(t *testing.T) {
+	f := draftSkill("Runbook: before:ios-build\nSteps: set OTHER_LDFLAGS then pod install.")
+	if f.Type != "skill" || f.Trigger != "before:ios-build" {
+		t.Errorf("draft = %+v", f)
+	}
+	if strings.Join(f.Steps, "|") != "set OTHER_LDFLAGS|pod install" {
+		t.Errorf("steps = %q", f.Steps)
+	}
+
+	f = draftSkill("# Deploy runbook\nWhen: releasing the API\n1. run migrations\n2. railway up\n- tag the release\nVerify: /health returns 200")
+	if f.Trigger != "releasing the API" || f.Validation != "/health returns 200" {
+		t.Errorf("draft = %+v", f)
+	}
+	if strings.Join(f.Steps, "|") != "run migrations|railway up|tag the release" {
+		t.Errorf("steps = %q", f.Steps)
+	}
+
+	// No explicit trigger: fall back to the before-action intent.
+	if f := draftSkill("Docker build runbook: prune the cache; docker build --no-cache ."); f.Trigger != "before:docker-build" || len(f.Steps) != 2 {
+		t.Errorf("intent fallback = %+v", f)
+	}
+}
+
+func TestParseHygieneAnswer(t *testing.T) {
+	cases := []struct {
+		answer, kind, action string
+		edit, quit, err      bool
+	}{
+		{"", "stale", hygieneArchive, false, false, false},
+		{"d\n", "stale", hygieneDelete, false, false, false},
+		{"K", "duplicate_exact", hygieneSkip, false, false, false},
+		{"e", "runbook_prose", hygieneSkill, true, false, false},
+		{"q", "stale", "", false, true, false},
+		{"m", "stale", "", false, false, true},
+		{"e", "thin_low_value", "", false, false, true},
+	}
+	for _, tc := range cases {
+		action, edit, quit, err := parseHygieneAnswer(tc.answer, tc.kind)
+		if action != tc.action || edit != tc.edit || quit != tc.quit || (err != nil) != tc.err {
+			t.Errorf("parseHygieneAnswer(%q, %s) = %q %v %v %v", tc.answer, tc.kind, action, edit, quit, err)
+		}
+	}
+}
+
+func TestRunHygienePolicyPlan(t *testing.T) {
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+	app := newHygieneApplier(nil, []models.Memory{{ID: a}, {ID: b}, {ID: c}})
+	issues := []memoryHygieneIssue{
+		{Kind: "duplicate_exact", Severity: "medium", MemoryID: b.String(), RelatedID: a.String()},
+		{Kind: "stale", Severity: "low", MemoryID: b.String()},
+		{Kind: "stale", Severity: "low", MemoryID: a.String()},
+		{Kind: "thin_low_value", Severity: "low", MemoryID: c.String()},
+	}
+	p := &hygienePolicy{Actions: map[string]string{"duplicate_exact": hygieneMerge, "stale": hygieneArchive, "thin_low_value": hygieneDelete}, MaxActions: 2}
+
+	results := runHygienePolicy(app, issues, p, false)
+	var got []string
+	for _, r := range results {
+		got = append(got, r.Action+":"+r.Status)
+	}
+	// b is merged away, so its stale issue is moot; the cap stops the delete.
+	want := "merge:planned|archive:skipped|archive:planned|delete:skipped"
+	if strings.Join(got, "|") != want {
+		t.Errorf("results = %v, want %s", got, want)
+	}
+	if results[3].Note == "" {
+		t.Error("capped action should say why it was skipped")
+	}
+}
+
+func TestMergeDuplicateContent(t *testing.T) {
+	kept := "Run migrations before deploy\nUse the staging token"
+	if got := mergeDuplicateContent(kept, "run  MIGRATIONS before deploy"); got != kept {
+		t.Errorf("nothing new to add, got %q", got)
+	}
+	want := kept + "\n\n---\nRollback with goose down"
+	if got := mergeDuplicateContent(kept+"\n", "Use the staging token\nRollback with goose down\n"); got != want {
+		t.Errorf("merge = %q, want %q", got, want)
+	}
+}
@@ -84,3 +84,29 @@ func hygieneHasKind(report memoryHygieneReport, kind string) bool {
 	}
 	return false
 }
+
+func TestAnalyzeMemoryHygiene_FindsNearDuplicates(t *testing.T) {
+	now := time.Date(2026, 6, 23, 12, 0, 0, 0, time.UTC)
+	kept, reworded, turkish, other := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	memories := []models.Memory{
+		{ID: kept, Content: "Always run database migrations before deploying the backend service", Type: "decision", CreatedAt: now, UpdatedAt: now},
+		{ID: reworded, Content: "Before deploying the backend service, always run the database migrations", Type: "decision", CreatedAt: now, UpdatedAt: now},
+		{ID: turkish, Content: "Dağıtımdan önce veritabanı göçlerini mutlaka çalıştır, yoksa servis açılmaz", Type: "decision", CreatedAt: now, UpdatedAt: now},
+		{ID: other, Content: "Frontend uses Tailwind with the prettier plugin for class sorting", Type: "decision", CreatedAt: now, UpdatedAt: now},
+	}
+
+	report := analyzeMemoryHygiene("proj", memories, now)
+
+	var near []memoryHygieneIssue
+	for _, issue := range report.Issues {
+		if issue.Kind == "duplicate_near" {
+			near = append(near, issue)
+		}
+	}
+	if len(near) != 1 || near[0].MemoryID != reworded.String() || near[0].RelatedID != kept.String() {
+		t.Fatalf("expected one near-duplicate of the first memory, got %+v", near)
+	}
+	if hygieneHasKind(report, "duplicate_exact") {
+		t.Fatalf("reworded memories are not exact duplicates: %+v", report.Issues)
+	}
+}
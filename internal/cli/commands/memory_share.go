@@ -0,0 +1,183 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/kutbudev/ramorie-cli/internal/acl"
+	"github.com/kutbudev/ramorie-cli/internal/api"
+	"github.com/kutbudev/ramorie-cli/internal/cli/display"
+	apierrors "github.com/kutbudev/ramorie-cli/internal/errors"
+	"github.com/kutbudev/ramorie-cli/internal/journal"
+	"github.com/kutbudev/ramorie-cli/internal/memrev"
+	"github.com/kutbudev/ramorie-cli/internal/models"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/term"
+)
+
+// memoryShareCmd implements `ramorie memory share`.
+func memoryShareCmd() *cli.Command {
+	return &cli.Command{
+		Name:      "share",
+		Usage:     "Change who can see or edit a memory",
+		ArgsUsage: "<memory-id>",
+		Description: "Sets the visibility (private | project | organization) and grants or revokes\n" +
+			"   read and write access. Users are given by email (looked up among the members\n" +
+			"   of the project's organization) or by user ID.\n\n" +
+			"   Encrypted memories are checked against their key: one encrypted with your\n" +
+			"   personal key cannot be shared at all (nobody else can decrypt it), and one\n" +
+			"   encrypted with an organization key only with members of that organization.\n" +
+			"   Narrowing access (private, --revoke) is always allowed.",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "visibility", Usage: "private | project | organization"},
+			&cli.StringSliceFlag{Name: "reader", Usage: "Grant read access (email or user ID; repeatable)"},
+			&cli.StringSliceFlag{Name: "writer", Usage: "Grant write access (email or user ID; repeatable)"},
+			&cli.StringSliceFlag{Name: "revoke", Usage: "Remove a user's read and write access (repeatable)"},
+			&cli.BoolFlag{Name: "json", Usage: "Output raw JSON (always on when piped)"},
+		},
+		Action: func(c *cli.Context) error {
+			if c.NArg() == 0 {
+				return fmt.Errorf("memory ID is required")
+			}
+			visibility, err := acl.ParseVisibility(c.String("visibility"))
+			if err != nil {
+				return err
+			}
+			if visibility == "" && len(c.StringSlice("reader")) == 0 && len(c.StringSlice("writer")) == 0 && len(c.StringSlice("revoke")) == 0 {
+				return fmt.Errorf("nothing to change: pass --visibility, --reader, --writer or --revoke (see `ramorie memory acl` for the current state)")
+			}
+
+			client := api.NewClient()
+			memory, err := client.GetMemory(c.Args().First())
+			if err != nil {
+				fmt.Println(apierrors.ParseAPIError(err))
+				return err
+			}
+			orgID := memoryProjectOrg(client, memory)
+			members := orgMembers(client, orgID)
+
+			change := acl.Change{Visibility: visibility}
+			if change.Readers, err = acl.ResolveUsers(c.StringSlice("reader"), orgID, members); err != nil {
+				return err
+			}
+			if change.Writers, err = acl.ResolveUsers(c.StringSlice("writer"), orgID, members); err != nil {
+				return err
+			}
+			if change.Revoke, err = acl.ResolveUsers(c.StringSlice("revoke"), "", members); err != nil {
+				return err
+			}
+			if err := acl.Check(memory, orgID, change); err != nil {
+				return err
+			}
+
+			before := acl.Of(memory)
+			next := acl.Apply(before, change)
+			updates := acl.Updates(before, next)
+			id := memory.ID.String()
+			if len(updates) == 0 {
+				fmt.Println(display.Dim.Render("  no change"))
+				return nil
+			}
+			updated, err := memrev.Update(client, id, updates, "cli")
+			if err != nil {
+				fmt.Println(apierrors.ParseAPIError(err))
+				return err
+			}
+			journal.Record("memory share", memoryLabel(memory, id),
+				journal.Update(journal.KindMemory, id, journal.MemorySnapshot(memory), acl.Fields(updates)...))
+			if updated != nil && updated.ID == memory.ID {
+				next = acl.Of(updated)
+			}
+			return printMemoryACL(next, members, c.Bool("json"), "🔗 shared")
+		},
+	}
+}
+
+// memoryACLCmd implements `ramorie memory acl`.
+func memoryACLCmd() *cli.Command {
+	return &cli.Command{
+		Name:      "acl",
+		Usage:     "Show a memory's visibility, readers, writers and encryption scope",
+		ArgsUsage: "<memory-id>",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "json", Usage: "Output raw JSON (always on when piped)"},
+		},
+		Action: func(c *cli.Context) error {
+			if c.NArg() == 0 {
+				return fmt.Errorf("memory ID is required")
+			}
+			client := api.NewClient()
+			memory, err := client.GetMemory(c.Args().First())
+			if err != nil {
+				fmt.Println(apierrors.ParseAPIError(err))
+				return err
+			}
+			members := orgMembers(client, memoryProjectOrg(client, memory))
+			return printMemoryACL(acl.Of(memory), members, c.Bool("json"), "🔐 access")
+		},
+	}
+}
+
+// memoryProjectOrg returns the organization of a memory's project, or "".
+func memoryProjectOrg(client *api.Client, m *models.Memory) string {
+	if m.Project != nil && m.Project.OrganizationID != nil {
+		return m.Project.OrganizationID.String()
+	}
+	projects, _ := client.ListProjects()
+	for _, p := range projects {
+		if p.ID == m.ProjectID && p.OrganizationID != nil {
+			return p.OrganizationID.String()
+		}
+	}
+	return ""
+}
+
+// orgMembers lists an organization's members; nil when there is none or
+// the list cannot be loaded.
+func orgMembers(client *api.Client, orgID string) []api.OrganizationMember {
+	if orgID == "" {
+		return nil
+	}
+	members, err := client.GetOrganizationMembers(orgID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Could not load organization members: %v\n", err)
+		return nil
+	}
+	return members
+}
+
+func printMemoryACL(s acl.State, members []api.OrganizationMember, asJSON bool, title string) error {
+	if asJSON || !term.IsTerminal(int(os.Stdout.Fd())) {
+		out, _ := json.MarshalIndent(s, "", "  ")
+		fmt.Println(string(out))
+		return nil
+	}
+	visibility := s.Visibility
+	if visibility == "" {
+		visibility = "default (backend decides)"
+	}
+	encryption := map[string]string{
+		acl.EncryptionNone:         "not encrypted",
+		acl.EncryptionPersonal:     "personal key — only you can read it",
+		acl.EncryptionOrganization: "organization key",
+	}[s.Encryption]
+	fmt.Println(display.Header(title, shortID(s.MemoryID)))
+	fmt.Printf("  %s %s\n", display.Label.Render("Visibility:"), visibility)
+	fmt.Printf("  %s %s\n", display.Label.Render("Encryption:"), encryption)
+	fmt.Printf("  %s %s\n", display.Label.Render("Readers:   "), aclUserList(s.Readers, members))
+	fmt.Printf("  %s %s\n", display.Label.Render("Writers:   "), aclUserList(s.Writers, members))
+	return nil
+}
+
+func aclUserList(ids []string, members []api.OrganizationMember) string {
+	if len(ids) == 0 {
+		return display.Dim.Render("—")
+	}
+	names := make([]string, 0, len(ids))
+	for _, id := range ids {
+		names = append(names, acl.UserName(id, members))
+	}
+	return strings.Join(names, ", ")
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/kutbudev/ramorie-cli/internal/cli/resolve"
 	"github.com/kutbudev/ramorie-cli/internal/crypto"
 	apierrors "github.com/kutbudev/ramorie-cli/internal/errors"
+	"github.com/kutbudev/ramorie-cli/internal/journal"
 	"github.com/urfave/cli/v2"
 	"golang.org/x/term"
 )
@@ -131,6 +132,7 @@ func orgCreateCmd() *cli.Command {
 				fmt.Println(apierrors.ParseAPIError(err))
 				return err
 			}
+			journal.Record("org create", org.Name, journal.Create(journal.KindOrganization, org.ID))
 
 			fmt.Printf("✅ Organization '%s' created successfully!\n", org.Name)
 			fmt.Printf("   ID: %s\n", org.ID[:8])
@@ -9,6 +9,7 @@ import (
 	"github.com/kutbudev/ramorie-cli/internal/api"
 	"github.com/kutbudev/ramorie-cli/internal/cli/display"
 	"github.com/kutbudev/ramorie-cli/internal/cli/resolve"
+	"github.com/kutbudev/ramorie-cli/internal/journal"
 	"github.com/urfave/cli/v2"
 )
 
@@ -93,6 +94,7 @@ func projectCreateCmd() *cli.Command {
 				fmt.Printf("Error creating project: %v\n", err)
 				return err
 			}
+			journal.Record("project create", project.Name, journal.Create(journal.KindProject, project.ID.String()))
 
 			fmt.Printf("✅ Project '%s' created successfully!\n", project.Name)
 			fmt.Printf("ID: %s\n", project.ID.String())
@@ -157,11 +159,16 @@ func projectDeleteCmd() *cli.Command {
 			if err != nil {
 				return err
 			}
+			before, _ := client.GetProject(projectID)
 			err = client.DeleteProject(projectID)
 			if err != nil {
 				fmt.Printf("Error deleting project: %v\n", err)
 				return err
 			}
+			if before != nil {
+				journal.Record("project delete", before.Name,
+					journal.Delete(journal.KindProject, projectID, journal.ProjectSnapshot(before)))
+			}
 
 			fmt.Printf("🗑️ Project %s deleted successfully.\n", projectID)
 			return nil
@@ -239,11 +246,16 @@ func projectUpdateCmd() *cli.Command {
 			if err != nil {
 				return err
 			}
+			before, _ := client.GetProject(projectID)
 			project, err := client.UpdateProject(projectID, updateData)
 			if err != nil {
 				fmt.Printf("Error updating project: %v\n", err)
 				return err
 			}
+			if before != nil {
+				journal.Record("project update", before.Name,
+					journal.Update(journal.KindProject, projectID, journal.ProjectSnapshot(before), updateFields(updateData)...))
+			}
 
 			fmt.Printf("✅ Project '%s' (ID: %s) updated successfully.\n", project.Name, project.ID.String()[:8])
 			return nil
@@ -284,6 +296,7 @@ func projectSetEncryptionCmd() *cli.Command {
 				return err
 			}
 
+			before, _ := client.GetProject(projectID)
 			project, err := client.UpdateProject(projectID, map[string]interface{}{
 				"encryption_required": required,
 			})
@@ -291,6 +304,10 @@ func projectSetEncryptionCmd() *cli.Command {
 				fmt.Printf("Error updating project encryption: %v\n", err)
 				return err
 			}
+			if before != nil {
+				journal.Record("project set-encryption", before.Name,
+					journal.Update(journal.KindProject, projectID, journal.ProjectSnapshot(before), "encryption_required"))
+			}
 
 			if required {
 				fmt.Printf("🔒 Project '%s' now REQUIRES encrypted writes. Make sure your vault is unlocked (`ramorie setup unlock`).\n", project.Name)
@@ -0,0 +1,417 @@
+package commands
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kutbudev/ramorie-cli/internal/api"
+	"github.com/kutbudev/ramorie-cli/internal/cli/display"
+	"github.com/kutbudev/ramorie-cli/internal/cli/resolve"
+	apierrors "github.com/kutbudev/ramorie-cli/internal/errors"
+	"github.com/kutbudev/ramorie-cli/internal/journal"
+	"github.com/kutbudev/ramorie-cli/internal/memrev"
+	"github.com/kutbudev/ramorie-cli/internal/models"
+	"github.com/kutbudev/ramorie-cli/internal/review"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/term"
+)
+
+// Review actions.
+const (
+	reviewKeep    = "keep"
+	reviewEdit    = "edit"
+	reviewArchive = "archive"
+	reviewSkip    = "skip"
+)
+
+// reviewItem is one memory in the review queue.
+type reviewItem struct {
+	MemoryID  string          `json:"memory_id"`
+	Reason    string          `json:"reason"`
+	Expired   bool            `json:"expired,omitempty"`
+	Scheduled bool            `json:"scheduled"`
+	Schedule  review.Schedule `json:"schedule"`
+	Type      string          `json:"type,omitempty"`
+	Preview   string          `json:"preview,omitempty"`
+
+	memory *models.Memory
+}
+
+// dueSchedules returns the schedules that are expired or due at now:
+// expired first, then the longest overdue.
+func dueSchedules(all map[string]review.Schedule, now time.Time) []review.Schedule {
+	var out []review.Schedule
+	for _, s := range all {
+		if s.IsExpired(now) || s.IsDue(now) {
+			out = append(out, s)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		ei, ej := out[i].IsExpired(now), out[j].IsExpired(now)
+		if ei != ej {
+			return ei
+		}
+		if !out[i].Due.Equal(out[j].Due) {
+			return out[i].Due.Before(out[j].Due)
+		}
+		return out[i].MemoryID < out[j].MemoryID
+	})
+	return out
+}
+
+// scheduleReason explains why a scheduled memory is in the queue.
+func scheduleReason(s review.Schedule, now time.Time) string {
+	if s.IsExpired(now) {
+		return "expired " + s.Expires.Format("2006-01-02")
+	}
+	overdue := int(now.Sub(s.Due).Hours() / 24)
+	reason := fmt.Sprintf("review due %s", s.Due.Format("2006-01-02"))
+	if overdue > 0 {
+		reason += fmt.Sprintf(" (%dd overdue)", overdue)
+	}
+	if s.Reviews > 0 {
+		reason += fmt.Sprintf(" · confirmed %d×", s.Reviews)
+	}
+	return reason
+}
+
+// defaultReviewDays is the first interval for a stale memory that is kept
+// without a schedule: the hygiene freshness window of its type.
+func defaultReviewDays(memoryType string) int {
+	if isHygieneEvergreenType(memoryType) {
+		return 90
+	}
+	return 30
+}
+
+// staleReviewItems turns unscheduled stale memories into queue items.
+func staleReviewItems(memories []models.Memory, scheduled map[string]review.Schedule, now time.Time) []reviewItem {
+	var out []reviewItem
+	for i := range memories {
+		m := &memories[i]
+		id := m.ID.String()
+		if _, ok := scheduled[id]; ok {
+			continue
+		}
+		if stale, reason := memoryLooksStale(*m, now); stale {
+			out = append(out, reviewItem{
+				MemoryID: id,
+				Reason:   reason,
+				Schedule: review.New(id, defaultReviewDays(m.Type), nil, now),
+				Type:     m.Type,
+				Preview:  display.SingleLine(decryptMemoryForCLI(m)),
+				memory:   m,
+			})
+		}
+	}
+	return out
+}
+
+// parseReviewAnswer maps a prompt answer to an action. Enter keeps a due
+// memory and archives an expired one.
+func parseReviewAnswer(answer string, expired bool) (action string, quit bool, err error) {
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "":
+		if expired {
+			return reviewArchive, false, nil
+		}
+		return reviewKeep, false, nil
+	case "k", "keep", "y", "yes":
+		return reviewKeep, false, nil
+	case "e", "edit":
+		return reviewEdit, false, nil
+	case "a", "archive":
+		return reviewArchive, false, nil
+	case "s", "skip", "n", "no":
+		return reviewSkip, false, nil
+	case "q", "quit":
+		return "", true, nil
+	}
+	return "", false, fmt.Errorf("%q is not an option — k, e, a, s or q", strings.TrimSpace(answer))
+}
+
+func reviewMenu(expired bool) string {
+	keep, archive := "[k]eep", "[a]rchive"
+	if expired {
+		archive = display.Label.Render(archive)
+	} else {
+		keep = display.Label.Render(keep)
+	}
+	return strings.Join([]string{keep, "[e]dit", archive, "[s]kip", "[q]uit"}, " · ")
+}
+
+// reviewResult is the outcome of one reviewed memory.
+type reviewResult struct {
+	MemoryID string `json:"memory_id"`
+	Action   string `json:"action"`
+	Status   string `json:"status"` // done, skipped, failed
+	Note     string `json:"note,omitempty"`
+}
+
+// applyReview carries out action on item and updates its schedule.
+func applyReview(client *api.Client, store *review.Store, item reviewItem, action string, now time.Time) (string, error) {
+	id := item.MemoryID
+	switch action {
+	case reviewKeep:
+		// An unscheduled stale memory starts on its new schedule as-is;
+		// confirming it would double the interval before its first review.
+		s := item.Schedule
+		if item.Scheduled {
+			s = s.Confirm(now)
+		}
+		s.Expires = nil // kept past its expiry: still valid
+		if err := clearMemoryExpiry(client, item); err != nil {
+			return "", err
+		}
+		if err := store.Put(s); err != nil {
+			return "", err
+		}
+		return reviewNote(s), nil
+
+	case reviewEdit:
+		changed, err := editMemory(client, item.memory, false)
+		if err != nil {
+			return "", err
+		}
+		s := item.Schedule
+		switch {
+		case !item.Scheduled:
+			// New schedule, kept as-is (see reviewKeep).
+		case changed:
+			s = s.Edited(now)
+		default:
+			s = s.Confirm(now)
+		}
+		if item.Expired {
+			s.Expires = nil
+			if err := clearMemoryExpiry(client, item); err != nil {
+				return "", err
+			}
+		}
+		if err := store.Put(s); err != nil {
+			return "", err
+		}
+		return reviewNote(s), nil
+
+	case reviewArchive:
+		if err := archiveMemory(client, id, "review", "review"); err != nil {
+			return "", err
+		}
+		return "archived", store.Remove(id)
+	}
+	return "", fmt.Errorf("unknown action %q", action)
+}
+
+// clearMemoryExpiry drops the memory's valid_until on the backend, which
+// search results and hooks filter on, when it or the local schedule has an
+// expiry. The previous value is journaled.
+func clearMemoryExpiry(client *api.Client, item reviewItem) error {
+	var until *time.Time
+	if item.memory != nil && item.memory.ValidUntil != nil {
+		until = item.memory.ValidUntil
+	} else if item.Schedule.Expires != nil {
+		until = item.Schedule.Expires
+	}
+	if until == nil {
+		return nil
+	}
+	id := item.MemoryID
+	if _, err := memrev.Update(client, id, map[string]interface{}{"valid_until": nil}, "review"); err != nil {
+		return err
+	}
+	journal.Record("review", "keep "+memoryLabel(item.memory, id),
+		journal.Update(journal.KindMemory, id, map[string]interface{}{"valid_until": until.UTC().Format(time.RFC3339)}, "valid_until"))
+	return nil
+}
+
+func reviewNote(s review.Schedule) string {
+	if s.IntervalDays <= 0 {
+		return "kept; no longer expires"
+	}
+	return "next review " + s.Due.Format("2006-01-02")
+}
+
+// runReview walks the queue on the terminal.
+func runReview(client *api.Client, store *review.Store, items []reviewItem, in io.Reader, now time.Time) []reviewResult {
+	reader := bufio.NewReader(in)
+	var results []reviewResult
+	for i, item := range items {
+		fmt.Println()
+		fmt.Printf("%s %s · %s\n", display.Dim.Render(fmt.Sprintf("[%d/%d]", i+1, len(items))),
+			shortID(item.MemoryID), display.TypeBadge(item.Type))
+		fmt.Println("  " + display.Dim.Render(item.Reason))
+		fmt.Println("  " + display.Truncate(item.Preview, 100))
+
+		var action string
+		for {
+			fmt.Printf("  %s: ", reviewMenu(item.Expired))
+			answer, rerr := reader.ReadString('\n')
+			var quit bool
+			var err error
+			action, quit, err = parseReviewAnswer(answer, item.Expired)
+			if rerr != nil && strings.TrimSpace(answer) == "" {
+				quit = true // EOF
+			}
+			if quit {
+				return results
+			}
+			if err == nil {
+				break
+			}
+			fmt.Println("  " + display.Err.Render(err.Error()))
+		}
+
+		r := reviewResult{MemoryID: item.MemoryID, Action: action}
+		if action == reviewSkip {
+			r.Status = "skipped"
+			results = append(results, r)
+			continue
+		}
+		note, err := applyReview(client, store, item, action, now)
+		if err != nil {
+			r.Status, r.Note = "failed", apierrors.ParseAPIError(err)
+			fmt.Println("  " + display.Err.Render("✗ "+r.Note))
+		} else {
+			r.Status, r.Note = "done", note
+			fmt.Println("  " + display.Good.Render("✓ "+note))
+		}
+		results = append(results, r)
+	}
+	return results
+}
+
+// NewReviewCommand creates the 'review' command: a queue of memories whose
+// review is due or that have expired.
+func NewReviewCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "review",
+		Usage: "Review memories that are due for re-confirmation or have expired",
+		Description: "Memories saved with `remember --review-in 90d` come back here when due;\n" +
+			"   `--expires` ones come back once expired. For each: keep (still right — the\n" +
+			"   next review is twice as far out), edit in $EDITOR (same interval again),\n" +
+			"   archive, or skip. Enter takes the highlighted choice.\n\n" +
+			"   --stale also queues unscheduled memories the hygiene report calls stale;\n" +
+			"   keeping one puts it on a schedule. Schedules live in ~/.ramorie/reviews.json.",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "project", Aliases: []string{"p"}, Usage: "Only this project (name | short id | UUID)"},
+			&cli.BoolFlag{Name: "stale", Usage: "Also queue unscheduled stale memories of the project (auto-detected when -p is omitted)"},
+			&cli.BoolFlag{Name: "list", Aliases: []string{"l"}, Usage: "Show the queue without reviewing"},
+			&cli.BoolFlag{Name: "json", Usage: "Print the queue as JSON"},
+			&cli.IntFlag{Name: "limit", Usage: "Review at most this many memories", Value: 20},
+		},
+		Action: func(c *cli.Context) error {
+			client := api.NewClient()
+			store, err := review.Open()
+			if err != nil {
+				return err
+			}
+			schedules, err := store.Load()
+			if err != nil {
+				return err
+			}
+			now := time.Now()
+
+			projectID := ""
+			if c.String("project") != "" || c.Bool("stale") {
+				if projectID, err = resolve.AutoResolveProject(c.String("project"), client); err != nil {
+					return err
+				}
+			}
+
+			var items []reviewItem
+			for _, s := range dueSchedules(schedules, now) {
+				m, err := client.GetMemory(s.MemoryID)
+				if err != nil {
+					if strings.Contains(err.Error(), "404") {
+						_ = store.Remove(s.MemoryID) // deleted elsewhere
+					}
+					continue
+				}
+				if projectID != "" && m.ProjectID.String() != projectID {
+					continue
+				}
+				items = append(items, reviewItem{
+					MemoryID:  s.MemoryID,
+					Reason:    scheduleReason(s, now),
+					Expired:   s.IsExpired(now),
+					Scheduled: true,
+					Schedule:  s,
+					Type:      m.Type,
+					Preview:   display.SingleLine(decryptMemoryForCLI(m)),
+					memory:    m,
+				})
+			}
+			if c.Bool("stale") {
+				memories, err := fetchMemoryHygienePageSet(client, projectID, 1000)
+				if err != nil {
+					fmt.Println(apierrors.ParseAPIError(err))
+					return err
+				}
+				items = append(items, staleReviewItems(memories, schedules, now)...)
+			}
+			if limit := c.Int("limit"); limit > 0 && len(items) > limit {
+				items = items[:limit]
+			}
+
+			if c.Bool("json") || !term.IsTerminal(int(os.Stdout.Fd())) {
+				if items == nil {
+					items = []reviewItem{}
+				}
+				out, _ := json.MarshalIndent(map[string]interface{}{"count": len(items), "items": items}, "", "  ")
+				fmt.Println(string(out))
+				return nil
+			}
+			if len(items) == 0 {
+				fmt.Println(display.Dim.Render("Nothing to review."))
+				return nil
+			}
+			if c.Bool("list") || !term.IsTerminal(int(os.Stdin.Fd())) {
+				printReviewQueue(items)
+				return nil
+			}
+
+			fmt.Println(display.Header("Memory review", fmt.Sprintf("%d due", len(items))))
+			fmt.Println(display.Dim.Render("Enter takes the highlighted choice."))
+			results := runReview(client, store, items, os.Stdin, now)
+			fmt.Println()
+			counts := map[string]int{}
+			failed := 0
+			for _, r := range results {
+				counts[r.Action]++
+				if r.Status == "failed" {
+					failed++
+				}
+			}
+			fmt.Println(display.Dim.Render(fmt.Sprintf("kept %d · edited %d · archived %d · skipped %d",
+				counts[reviewKeep], counts[reviewEdit], counts[reviewArchive], counts[reviewSkip])))
+			if failed > 0 {
+				return fmt.Errorf("%d review action(s) failed", failed)
+			}
+			return nil
+		},
+	}
+}
+
+func printReviewQueue(items []reviewItem) {
+	cols := []display.Column{
+		{Title: "ID", Min: 8, Weight: 0},
+		{Title: "TYPE", Min: 10, Weight: 0},
+		{Title: "WHY", Min: 22, Weight: 2},
+		{Title: "MEMORY", Min: 24, Weight: 4},
+	}
+	rows := make([][]string, 0, len(items))
+	for _, it := range items {
+		why := it.Reason
+		if it.Expired {
+			why = display.Warn.Render(why)
+		}
+		rows = append(rows, []string{shortID(it.MemoryID), display.TypeBadge(it.Type), why, it.Preview})
+	}
+	fmt.Println(display.NewResponsiveTable(cols, rows))
+}
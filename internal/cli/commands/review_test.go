@@ -0,0 +1,100 @@
+package commands
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kutbudev/ramorie-cli/internal/models"
+	"github.com/kutbudev/ramorie-cli/internal/review"
+)
+
+func TestDueSchedules(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	gone := now.AddDate(0, 0, -1)
+	all := map[string]review.Schedule{
+		"later":   review.New("later", 30, nil, now),
+		"old":     review.New("old", 30, nil, now.AddDate(0, 0, -90)),
+		"recent":  review.New("recent", 30, nil, now.AddDate(0, 0, -31)),
+		"expired": review.New("expired", 0, &gone, now.AddDate(0, 0, -10)),
+	}
+	var ids []string
+	for _, s := range dueSchedules(all, now) {
+		ids = append(ids, s.MemoryID)
+	}
+	if strings.Join(ids, ",") != "expired,old,recent" {
+		t.Errorf("queue = %v", ids)
+	}
+	if r := scheduleReason(all["old"], now); !strings.Contains(r, "60d overdue") {
+		t.Errorf("reason = %q", r)
+	}
+}
+
+func TestParseReviewAnswer(t *testing.T) {
+	cases := []struct {
+		in      string
+		expired bool
+		want    string
+	}{
+		{"", false, reviewKeep},
+		{"", true, reviewArchive},
+		{"K", true, reviewKeep},
+		{" e\n", false, reviewEdit},
+		{"a", false, reviewArchive},
+		{"s", false, reviewSkip},
+	}
+	for _, tc := range cases {
+		if got, quit, err := parseReviewAnswer(tc.in, tc.expired); err != nil || quit || got != tc.want {
+			t.Errorf("parseReviewAnswer(%q, %v) = %q, %v, %v", tc.in, tc.expired, got, quit, err)
+		}
+	}
+	if _, quit, _ := parseReviewAnswer("q", false); !quit {
+		t.Error("q should quit")
+	}
+	if _, _, err := parseReviewAnswer("x", false); err == nil {
+		t.Error("unknown answer should fail")
+	}
+}
+
+func TestStaleReviewItemsSkipScheduled(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	old := now.AddDate(-1, 0, 0)
+	a, b := uuid.New(), uuid.New()
+	memories := []models.Memory{
+		{ID: a, Type: "decision", Content: "Use Redis for sessions", CreatedAt: old, UpdatedAt: old},
+		{ID: b, Type: "decision", Content: "Use Postgres", CreatedAt: old, UpdatedAt: old},
+	}
+	scheduled := map[string]review.Schedule{b.String(): review.New(b.String(), 30, nil, now)}
+	items := staleReviewItems(memories, scheduled, now)
+	if len(items) != 1 || items[0].MemoryID != a.String() {
+		t.Fatalf("items = %+v", items)
+	}
+	if items[0].Scheduled || items[0].Schedule.IntervalDays != defaultReviewDays("decision") {
+		t.Errorf("stale item = %+v", items[0])
+	}
+}
+
+func TestApplyReviewKeepsNewScheduleAsIs(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	store := &review.Store{Path: filepath.Join(t.TempDir(), "reviews.json")}
+	fresh := reviewItem{MemoryID: "m1", Schedule: review.New("m1", 30, nil, now)}
+	if _, err := applyReview(nil, store, fresh, reviewKeep, now); err != nil {
+		t.Fatal(err)
+	}
+	scheduled := reviewItem{MemoryID: "m2", Scheduled: true, Schedule: review.New("m2", 30, nil, now.AddDate(0, 0, -30))}
+	if _, err := applyReview(nil, store, scheduled, reviewKeep, now); err != nil {
+		t.Fatal(err)
+	}
+	all, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := all["m1"]; got.IntervalDays != 30 || !got.Due.Equal(now.AddDate(0, 0, 30)) {
+		t.Errorf("first keep changed the new schedule: %+v", got)
+	}
+	if got := all["m2"]; got.IntervalDays != 60 || got.Reviews != 1 {
+		t.Errorf("keeping a scheduled memory should confirm it: %+v", got)
+	}
+}
@@ -0,0 +1,108 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/kutbudev/ramorie-cli/internal/cli/display"
+	"github.com/kutbudev/ramorie-cli/internal/searches"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/term"
+)
+
+// NewSearchesCommand creates the 'searches' command group for searches
+// saved with `find --save`.
+func NewSearchesCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "searches",
+		Usage: "List and remove saved searches (find --save <name>, find @name)",
+		Description: "Saved searches keep a find term with all of its options. Save one with\n" +
+			"   `ramorie find \"deploy runbook\" -t skill --save deploy` and run it again with\n" +
+			"   `ramorie find @deploy`. They also show up in the `ramorie ui` sidebar and as\n" +
+			"   the MCP resource ramorie://search/{name}. Stored in ~/.ramorie/searches.json.",
+		Subcommands: []*cli.Command{
+			searchesListCmd(),
+			searchesRemoveCmd(),
+		},
+	}
+}
+
+func searchesListCmd() *cli.Command {
+	return &cli.Command{
+		Name:    "list",
+		Aliases: []string{"ls"},
+		Usage:   "List saved searches",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "json", Usage: "Output raw JSON (always on when piped)"},
+		},
+		Action: func(c *cli.Context) error {
+			all, err := searches.All()
+			if err != nil {
+				return err
+			}
+			if c.Bool("json") || !term.IsTerminal(int(os.Stdout.Fd())) {
+				out, _ := json.MarshalIndent(all, "", "  ")
+				fmt.Println(string(out))
+				return nil
+			}
+			if len(all) == 0 {
+				fmt.Println(display.Dim.Render("  no saved searches — use `ramorie find <term> --save <name>`"))
+				return nil
+			}
+			title := fmt.Sprintf("🔖 %d saved search", len(all))
+			if len(all) != 1 {
+				title += "es"
+			}
+			fmt.Println(display.Header(title, "run with find @name"))
+			fmt.Println()
+			cols := []display.Column{
+				{Title: "NAME", Min: 10, Weight: 1},
+				{Title: "TERM", Min: 20, Weight: 3},
+				{Title: "OPTIONS", Min: 16, Weight: 2},
+				{Title: "SAVED", Min: 8, Weight: 0},
+			}
+			rows := make([][]string, 0, len(all))
+			for _, s := range all {
+				rows = append(rows, []string{
+					"@" + s.Name,
+					display.SingleLine(s.Term),
+					display.Dim.Render(s.Summary()),
+					display.Dim.Render(display.Relative(s.UpdatedAt)),
+				})
+			}
+			fmt.Println(display.NewResponsiveTable(cols, rows))
+			return nil
+		},
+	}
+}
+
+func searchesRemoveCmd() *cli.Command {
+	return &cli.Command{
+		Name:      "rm",
+		Aliases:   []string{"remove", "delete"},
+		Usage:     "Remove saved searches",
+		ArgsUsage: "<name> [name...]",
+		Action: func(c *cli.Context) error {
+			if c.NArg() == 0 {
+				return fmt.Errorf("search name is required")
+			}
+			store, err := searches.Open()
+			if err != nil {
+				return err
+			}
+			for _, name := range c.Args().Slice() {
+				ok, err := store.Remove(name)
+				if err != nil {
+					return err
+				}
+				if !ok {
+					return fmt.Errorf("no saved search @%s", strings.TrimPrefix(name, "@"))
+				}
+				fmt.Println(display.Good.Render("✓ removed @" + strings.TrimPrefix(name, "@")))
+			}
+			return nil
+		},
+	}
+}
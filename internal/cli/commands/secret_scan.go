@@ -0,0 +1,97 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/kutbudev/ramorie-cli/internal/api"
+	"github.com/kutbudev/ramorie-cli/internal/cli/resolve"
+	"github.com/kutbudev/ramorie-cli/internal/crypto"
+	"github.com/kutbudev/ramorie-cli/internal/models"
+	"github.com/kutbudev/ramorie-cli/internal/secrets"
+)
+
+// checkSecrets runs content through the secret scanner of projectID (see
+// secretScanner) before it is saved. Blocked content is an error;
+// redactions are reported on stderr. The caller saves r.Content and
+// encrypts when r.Encrypt is set.
+func checkSecrets(client *api.Client, projectID, content string) (secrets.Result, error) {
+	s, cfgErr := secretScanner(client, projectID)
+	if cfgErr != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  %v — scanning with the built-in rules\n", cfgErr)
+	}
+	r := s.Check(content)
+	if err := r.Err(); err != nil {
+		return r, err
+	}
+	if red := r.Redacted(); len(red) > 0 {
+		fmt.Fprintf(os.Stderr, "🔒 Redacted %d secret(s) before saving: %s\n", len(red), secrets.Summary(red))
+	}
+	return r, nil
+}
+
+// secretScanner returns the scanner for a write to projectID, and the
+// config error it fell back from: the working directory's secrets.yaml
+// applies only when projectID is unknown ("") or is the project detected
+// from the working directory, the user-level file alone to any other — as
+// in the MCP server.
+func secretScanner(client *api.Client, projectID string) (*secrets.Scanner, error) {
+	if projectID == "" || projectID == cwdProjectID(client) {
+		return secrets.Default(), secrets.DefaultErr()
+	}
+	return secrets.UserOnly(), secrets.UserOnlyErr()
+}
+
+var (
+	cwdProjectOnce sync.Once
+	cwdProject     string
+)
+
+// cwdProjectID returns the project detected from the working directory,
+// looked up once per process.
+func cwdProjectID(client *api.Client) string {
+	cwdProjectOnce.Do(func() { cwdProject = resolve.DetectProject(client) })
+	return cwdProject
+}
+
+// sealMemoryContent runs a memory update's new content through the secret
+// scanner of the memory's project, just before the write: redacted content
+// replaces updates["content"], and the content is re-encrypted under the
+// memory's own scope when the memory is encrypted or the scanner wants it
+// encrypted. Encrypting a plain memory uses the personal key, so it needs
+// the unlocked vault and a personal project.
+func sealMemoryContent(client *api.Client, m *models.Memory, updates map[string]interface{}) error {
+	plain, ok := updates["content"].(string)
+	if !ok {
+		return nil
+	}
+	projectID := m.ProjectID.String()
+	scan, err := checkSecrets(client, projectID, plain)
+	if err != nil {
+		return err
+	}
+	updates["content"] = scan.Content
+	if !m.IsEncrypted {
+		if !scan.Encrypt {
+			return nil
+		}
+		if !crypto.IsVaultUnlocked() || isOrgProjectID(client, projectID) {
+			return secrets.ErrNeedsEncryption
+		}
+	}
+	updates["content_hash"] = crypto.ComputeContentHash(scan.Content)
+	return encryptEditField(updates, "content", "encrypted_content", "content_nonce", m.EncryptionScope, m.EncryptionOrgID)
+}
+
+// isOrgProjectID reports whether projectID belongs to an organization.
+// Those projects skip personal-vault encryption.
+func isOrgProjectID(client *api.Client, projectID string) bool {
+	projects, _ := client.ListProjects()
+	for _, p := range projects {
+		if p.ID.String() == projectID && p.OrganizationID != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,33 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kutbudev/ramorie-cli/internal/models"
+)
+
+func TestSealMemoryContentRedacts(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := &models.Memory{ID: uuid.New(), ProjectID: uuid.New()}
+	// Settle the working directory's project up front, so no lookup runs;
+	// with no secrets.yaml anywhere both scanners use the built-in rules.
+	cwdProjectOnce.Do(func() { cwdProject = m.ProjectID.String() })
+
+	token := "ghp_" + strings.Repeat("a1B2", 9)
+	updates := map[string]interface{}{"content": "deploy with " + token, "type": "general"}
+	if err := sealMemoryContent(nil, m, updates); err != nil {
+		t.Fatal(err)
+	}
+	got, _ := updates["content"].(string)
+	if strings.Contains(got, token) || !strings.Contains(got, "[REDACTED:github-token]") {
+		t.Errorf("content = %q, want the token redacted", got)
+	}
+	if _, ok := updates["encrypted_content"]; ok {
+		t.Error("a plain memory with redacted secrets should stay plain")
+	}
+	if updates["type"] != "general" {
+		t.Error("other fields must be left alone")
+	}
+}
@@ -81,6 +81,7 @@ func NewSetupCommand() *cli.Command {
 				},
 			},
 			hooksAlias,
+			setupGitHooksCmd(),
 			{
 				Name:  "vault",
 				Usage: "Encrypted vault operations (unlock | lock | status)",
@@ -31,6 +31,9 @@ func NewStatsCommand() *cli.Command {
 			&cli.StringFlag{Name: "project", Aliases: []string{"p"}, Usage: "Project name or ID"},
 			&cli.BoolFlag{Name: "json", Usage: "Output raw JSON (always on when piped)"},
 		},
+		Subcommands: []*cli.Command{
+			statsFlowCmd(),
+		},
 		Action: func(c *cli.Context) error {
 			client := api.NewClient()
 			project := c.String("project")
@@ -85,6 +88,14 @@ func NewStatsCommand() *cli.Command {
 				{display.Dim.Render("completed"), fmt.Sprintf("%d", s.Completed)},
 			}
 			fmt.Println(display.NewResponsiveTable(cols, rows))
+			if s.Total > 0 {
+				fmt.Println()
+				fmt.Println(display.BarChart(
+					[]string{"todo", "in_progress", "completed"},
+					[]float64{float64(s.Todo), float64(s.InProgress), float64(s.Completed)},
+					display.ChartOptions{Width: min(display.TerminalWidth(), 80)},
+				))
+			}
 			return nil
 		},
 	}
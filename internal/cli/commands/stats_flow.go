@@ -0,0 +1,542 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kutbudev/ramorie-cli/internal/api"
+	"github.com/kutbudev/ramorie-cli/internal/cli/display"
+	"github.com/kutbudev/ramorie-cli/internal/cli/resolve"
+	apierrors "github.com/kutbudev/ramorie-cli/internal/errors"
+	"github.com/kutbudev/ramorie-cli/internal/models"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/term"
+)
+
+// flowPercentiles are the quantiles reported for lead and cycle time.
+var flowPercentiles = []int{50, 85, 95}
+
+// flowTransition is one reconstructed status change of a task.
+type flowTransition struct {
+	TaskID string
+	Status string // TODO, IN_PROGRESS or COMPLETED
+	At     time.Time
+}
+
+// flowTask is the per-task input to computeFlow.
+type flowTask struct {
+	ID        string
+	Title     string
+	Status    string
+	Tags      []string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// flowStats summarises one set of durations, in hours.
+type flowStats struct {
+	Count       int                `json:"count"`
+	MeanHours   float64            `json:"mean_hours"`
+	Percentiles map[string]float64 `json:"percentiles_hours"`
+}
+
+// flowWeek is completed-task throughput for one ISO week.
+type flowWeek struct {
+	Week      string `json:"week"` // 2026-W07
+	Start     string `json:"start"`
+	Completed int    `json:"completed"`
+}
+
+// flowAging is one task currently in progress.
+type flowAging struct {
+	ID        string  `json:"id"`
+	Title     string  `json:"title"`
+	AgeHours  float64 `json:"age_hours"`
+	StartedAt string  `json:"started_at"`
+}
+
+// flowTagStats is the per-tag breakdown.
+type flowTagStats struct {
+	Tag       string    `json:"tag"`
+	Completed int       `json:"completed"`
+	LeadTime  flowStats `json:"lead_time"`
+	CycleTime flowStats `json:"cycle_time"`
+}
+
+// flowReport is the full `stats flow` result (also the --json shape).
+type flowReport struct {
+	Since      time.Time      `json:"since"`
+	Until      time.Time      `json:"until"`
+	Completed  int            `json:"completed"`
+	LeadTime   flowStats      `json:"lead_time"`
+	CycleTime  flowStats      `json:"cycle_time"`
+	Throughput []flowWeek     `json:"throughput"`
+	AgingWIP   []flowAging    `json:"aging_wip"`
+	ByTag      []flowTagStats `json:"by_tag,omitempty"`
+	// Warnings name gaps in the data the report was computed from.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// flowEventStatus maps the task event types the backend emits to the
+// status they move a task into. Creation and plain edits are absent.
+var flowEventStatus = map[string]string{
+	"task_started":     "IN_PROGRESS",
+	"task_resumed":     "IN_PROGRESS",
+	"task_completed":   "COMPLETED",
+	"task_uncompleted": "TODO",
+	"task_reopened":    "TODO",
+	"task_stopped":     "TODO",
+}
+
+// statusFromEvent returns the status an event moved a task into, from its
+// structured status when given and otherwise from its event type. It
+// returns "" for events that do not change the status.
+func statusFromEvent(eventType, status string) string {
+	switch st := strings.ToUpper(strings.TrimSpace(status)); st {
+	case "TODO", "IN_PROGRESS", "COMPLETED":
+		return st
+	}
+	return flowEventStatus[strings.ToLower(strings.TrimSpace(eventType))]
+}
+
+// transitionsFromActivity extracts task status changes from the activity
+// feed. Only entries with a structured event type or status count; the
+// free-text summary is not parsed.
+func transitionsFromActivity(items []models.ActivityItem) []flowTransition {
+	var out []flowTransition
+	for _, it := range items {
+		if !strings.EqualFold(it.EntityType, "task") {
+			continue
+		}
+		if st := statusFromEvent(it.EventType, it.Status); st != "" {
+			out = append(out, flowTransition{TaskID: it.EntityID.String(), Status: st, At: it.Timestamp})
+		}
+	}
+	return out
+}
+
+// transitionsFromAgentEvents extracts task status changes from agent events.
+func transitionsFromAgentEvents(events []api.AgentEventItem) []flowTransition {
+	var out []flowTransition
+	for _, e := range events {
+		if !strings.EqualFold(e.EntityType, "task") {
+			continue
+		}
+		st := statusFromEvent(e.EventType, "")
+		if st == "" {
+			continue
+		}
+		at, err := time.Parse(time.RFC3339, e.CreatedAt)
+		if err != nil {
+			continue
+		}
+		out = append(out, flowTransition{TaskID: e.EntityID, Status: st, At: at})
+	}
+	return out
+}
+
+// flowEventPage is the page size used when walking agent events.
+const flowEventPage = 500
+
+// flowHistoryLimit caps the activity history read. The endpoint does not
+// page, so a full read means the window may not be covered.
+const flowHistoryLimit = 1000
+
+// historyTruncation returns a warning when an activity read came back full
+// and so may have missed older items, naming the oldest item it still
+// covers; "" otherwise.
+func historyTruncation(items []models.ActivityItem, limit int) string {
+	if len(items) < limit {
+		return ""
+	}
+	var oldest time.Time
+	for _, it := range items {
+		if oldest.IsZero() || it.Timestamp.Before(oldest) {
+			oldest = it.Timestamp
+		}
+	}
+	return fmt.Sprintf("activity history is capped at %d items and only reaches back to %s — "+
+		"older starts and completions come from agent events and task timestamps only",
+		limit, oldest.Format("2006-01-02"))
+}
+
+// taskAgentEvents pages through the task agent events of a project, newest
+// first, until the events are older than since or the feed ends.
+func taskAgentEvents(client *api.Client, projectID string, since time.Time) ([]api.AgentEventItem, error) {
+	var out []api.AgentEventItem
+	filter := api.AgentEventFilter{ProjectID: projectID, EntityType: "task", Limit: flowEventPage}
+	for {
+		ev, err := client.GetAgentEvents(filter)
+		if err != nil {
+			return out, err
+		}
+		if ev == nil {
+			return out, nil
+		}
+		out = append(out, ev.Events...)
+		if !ev.HasMore || ev.NextCursor == "" || ev.NextCursor == filter.Cursor || len(ev.Events) == 0 {
+			return out, nil
+		}
+		if at, err := time.Parse(time.RFC3339, ev.Events[len(ev.Events)-1].CreatedAt); err == nil && at.Before(since) {
+			return out, nil
+		}
+		filter.Cursor = ev.NextCursor
+	}
+}
+
+// taskTimeline is what the transitions say about one task.
+type taskTimeline struct {
+	started   time.Time // first move to IN_PROGRESS
+	completed time.Time // last move to COMPLETED
+}
+
+func buildTimelines(transitions []flowTransition) map[string]*taskTimeline {
+	sorted := append([]flowTransition(nil), transitions...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].At.Before(sorted[j].At) })
+	out := map[string]*taskTimeline{}
+	for _, tr := range sorted {
+		tl := out[tr.TaskID]
+		if tl == nil {
+			tl = &taskTimeline{}
+			out[tr.TaskID] = tl
+		}
+		switch tr.Status {
+		case "IN_PROGRESS":
+			if tl.started.IsZero() {
+				tl.started = tr.At
+			}
+		case "COMPLETED":
+			tl.completed = tr.At
+		case "TODO":
+			// Reopened: a later completion is the one that counts.
+			tl.completed = time.Time{}
+		}
+	}
+	return out
+}
+
+// computeFlow derives lead time (created → completed), cycle time (first
+// IN_PROGRESS → completed), weekly throughput and aging WIP for tasks
+// completed in [now-days, now]. Completed tasks without a completion event
+// fall back to UpdatedAt; cycle time is only counted when a start was seen.
+func computeFlow(tasks []flowTask, transitions []flowTransition, now time.Time, days int) flowReport {
+	since := now.AddDate(0, 0, -days)
+	timelines := buildTimelines(transitions)
+	rep := flowReport{Since: since, Until: now}
+
+	var lead, cycle []float64
+	tagLead := map[string][]float64{}
+	tagCycle := map[string][]float64{}
+	weekly := map[string]int{}
+
+	for _, t := range tasks {
+		tl := timelines[t.ID]
+		if tl == nil {
+			tl = &taskTimeline{}
+		}
+		switch strings.ToUpper(t.Status) {
+		case "COMPLETED":
+			done := tl.completed
+			if done.IsZero() {
+				done = t.UpdatedAt
+			}
+			if done.Before(since) || done.After(now) {
+				continue
+			}
+			rep.Completed++
+			weekly[isoWeek(done)]++
+			l := done.Sub(t.CreatedAt).Hours()
+			lead = append(lead, l)
+			var cy float64
+			hasCycle := !tl.started.IsZero() && !tl.started.After(done)
+			if hasCycle {
+				cy = done.Sub(tl.started).Hours()
+				cycle = append(cycle, cy)
+			}
+			for _, tag := range t.Tags {
+				tag = strings.ToLower(tag)
+				tagLead[tag] = append(tagLead[tag], l)
+				if hasCycle {
+					tagCycle[tag] = append(tagCycle[tag], cy)
+				}
+			}
+		case "IN_PROGRESS":
+			started := tl.started
+			if started.IsZero() {
+				started = t.UpdatedAt
+			}
+			rep.AgingWIP = append(rep.AgingWIP, flowAging{
+				ID:        t.ID,
+				Title:     t.Title,
+				AgeHours:  round1(now.Sub(started).Hours()),
+				StartedAt: started.Format(time.RFC3339),
+			})
+		}
+	}
+
+	rep.LeadTime = summarizeDurations(lead)
+	rep.CycleTime = summarizeDurations(cycle)
+	sort.SliceStable(rep.AgingWIP, func(i, j int) bool { return rep.AgingWIP[i].AgeHours > rep.AgingWIP[j].AgeHours })
+
+	// One bucket per ISO week the window touches, so completions early in
+	// the first (partial) week are counted too.
+	for day := weekStart(since); !day.After(now); day = day.AddDate(0, 0, 7) {
+		key := isoWeek(day)
+		rep.Throughput = append(rep.Throughput, flowWeek{
+			Week:      key,
+			Start:     day.Format("2006-01-02"),
+			Completed: weekly[key],
+		})
+	}
+
+	for tag, ls := range tagLead {
+		rep.ByTag = append(rep.ByTag, flowTagStats{
+			Tag:       tag,
+			Completed: len(ls),
+			LeadTime:  summarizeDurations(ls),
+			CycleTime: summarizeDurations(tagCycle[tag]),
+		})
+	}
+	sort.Slice(rep.ByTag, func(i, j int) bool {
+		if rep.ByTag[i].Completed != rep.ByTag[j].Completed {
+			return rep.ByTag[i].Completed > rep.ByTag[j].Completed
+		}
+		return rep.ByTag[i].Tag < rep.ByTag[j].Tag
+	})
+	return rep
+}
+
+func summarizeDurations(hours []float64) flowStats {
+	s := flowStats{Count: len(hours), Percentiles: map[string]float64{}}
+	if len(hours) == 0 {
+		return s
+	}
+	sorted := append([]float64(nil), hours...)
+	sort.Float64s(sorted)
+	var sum float64
+	for _, h := range sorted {
+		sum += h
+	}
+	s.MeanHours = round1(sum / float64(len(sorted)))
+	for _, p := range flowPercentiles {
+		s.Percentiles[fmt.Sprintf("p%d", p)] = round1(percentile(sorted, p))
+	}
+	return s
+}
+
+// percentile uses the nearest-rank method on an ascending slice.
+func percentile(sorted []float64, p int) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(float64(p) / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func round1(f float64) float64 { return math.Round(f*10) / 10 }
+
+func isoWeek(t time.Time) string {
+	y, w := t.ISOWeek()
+	return fmt.Sprintf("%d-W%02d", y, w)
+}
+
+func weekStart(t time.Time) time.Time {
+	wd := (int(t.Weekday()) + 6) % 7 // Monday = 0
+	y, m, d := t.AddDate(0, 0, -wd).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// formatHours renders a duration in hours as "5.5h" or "3.2d".
+func formatHours(h float64) string {
+	if h < 48 {
+		return fmt.Sprintf("%.1fh", h)
+	}
+	return fmt.Sprintf("%.1fd", h/24)
+}
+
+// statsFlowCmd implements `ramorie stats flow`.
+func statsFlowCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "flow",
+		Usage: "Lead time, cycle time, weekly throughput and aging work in progress",
+		Description: "Reconstructs task status changes from the activity history and agent\n" +
+			"   events. Lead time runs from creation to completion; cycle time from the\n" +
+			"   first move to IN_PROGRESS to completion (only when a start was recorded).",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "project", Aliases: []string{"p"}, Usage: "Project name or ID (default: all projects)"},
+			&cli.IntFlag{Name: "days", Aliases: []string{"d"}, Usage: "Window of completed tasks to analyse", Value: 56},
+			&cli.IntFlag{Name: "tags", Usage: "Max tags in the per-tag breakdown", Value: 8},
+			&cli.BoolFlag{Name: "json", Usage: "Output raw JSON (always on when piped)"},
+		},
+		Action: func(c *cli.Context) error {
+			client := api.NewClient()
+			days := c.Int("days")
+			if days <= 0 {
+				days = 56
+			}
+
+			projectID := ""
+			if p := c.String("project"); p != "" {
+				id, err := resolve.ResolveProject(p, client)
+				if err != nil {
+					return err
+				}
+				projectID = id
+			}
+
+			var tasks []flowTask
+			for page := 1; ; page++ {
+				items, hasMore, err := client.ListTasksPage(projectID, "", page, 100)
+				if err != nil {
+					fmt.Fprintln(os.Stderr, apierrors.ParseAPIError(err))
+					return err
+				}
+				for i := range items {
+					title, _ := decryptTaskForCLI(&items[i])
+					tasks = append(tasks, flowTask{
+						ID:        items[i].ID.String(),
+						Title:     title,
+						Status:    items[i].Status,
+						Tags:      getTagsAsStrings(items[i].Tags),
+						CreatedAt: items[i].CreatedAt,
+						UpdatedAt: items[i].UpdatedAt,
+					})
+				}
+				if !hasMore {
+					break
+				}
+			}
+
+			// History and events only sharpen the timelines; without them
+			// the report falls back to UpdatedAt, so failures are soft.
+			var transitions []flowTransition
+			truncated := ""
+			if items, err := client.GetActivityHistory(days+30, flowHistoryLimit, projectID); err == nil {
+				transitions = append(transitions, transitionsFromActivity(items)...)
+				truncated = historyTruncation(items, flowHistoryLimit)
+			}
+			events, _ := taskAgentEvents(client, projectID, time.Now().AddDate(0, 0, -(days+30)))
+			transitions = append(transitions, transitionsFromAgentEvents(events)...)
+
+			rep := computeFlow(tasks, transitions, time.Now(), days)
+			if truncated != "" {
+				rep.Warnings = append(rep.Warnings, truncated)
+			}
+			if limit := c.Int("tags"); limit >= 0 && len(rep.ByTag) > limit {
+				rep.ByTag = rep.ByTag[:limit]
+			}
+
+			if c.Bool("json") || !term.IsTerminal(int(os.Stdout.Fd())) {
+				out, _ := json.MarshalIndent(rep, "", "  ")
+				os.Stdout.Write(out)
+				os.Stdout.Write([]byte("\n"))
+				return nil
+			}
+			printFlowReport(rep, days, c.String("project"))
+			return nil
+		},
+	}
+}
+
+func printFlowReport(rep flowReport, days int, project string) {
+	subtitle := fmt.Sprintf("last %dd", days)
+	if project != "" {
+		subtitle += " · project: " + project
+	}
+	fmt.Println(display.Header(fmt.Sprintf("⏱  flow · %d completed", rep.Completed), subtitle))
+	fmt.Println()
+
+	statRow := func(name string, s flowStats) []string {
+		if s.Count == 0 {
+			return []string{display.Dim.Render(name), "—", "—", "—", "—", "0"}
+		}
+		return []string{
+			display.Dim.Render(name),
+			formatHours(s.Percentiles["p50"]),
+			formatHours(s.Percentiles["p85"]),
+			formatHours(s.Percentiles["p95"]),
+			formatHours(s.MeanHours),
+			fmt.Sprintf("%d", s.Count),
+		}
+	}
+	cols := []display.Column{
+		{Title: "METRIC", Min: 12, Weight: 0},
+		{Title: "P50", Min: 7, Weight: 0},
+		{Title: "P85", Min: 7, Weight: 0},
+		{Title: "P95", Min: 7, Weight: 0},
+		{Title: "MEAN", Min: 7, Weight: 0},
+		{Title: "N", Min: 4, Weight: 1},
+	}
+	fmt.Println(display.NewResponsiveTable(cols, [][]string{
+		statRow("lead time", rep.LeadTime),
+		statRow("cycle time", rep.CycleTime),
+	}))
+
+	counts := make([]float64, len(rep.Throughput))
+	for i, w := range rep.Throughput {
+		counts[i] = float64(w.Completed)
+	}
+	if len(counts) > 0 {
+		fmt.Println()
+		fmt.Printf(" %s %s %s\n",
+			display.Label.Render("throughput/week"),
+			display.Sparkline(counts),
+			display.Dim.Render(fmt.Sprintf("%s → %s · last %.0f", rep.Throughput[0].Week, rep.Throughput[len(counts)-1].Week, counts[len(counts)-1])))
+	}
+
+	if len(rep.AgingWIP) > 0 {
+		fmt.Println()
+		fmt.Println(" " + display.Label.Render(fmt.Sprintf("aging wip (%d)", len(rep.AgingWIP))))
+		shown := rep.AgingWIP
+		if len(shown) > 10 {
+			shown = shown[:10]
+		}
+		p85 := rep.CycleTime.Percentiles["p85"]
+		for _, a := range shown {
+			age := formatHours(a.AgeHours)
+			if rep.CycleTime.Count > 0 && a.AgeHours > p85 {
+				age = display.Warn.Render(age + " ⚠")
+			}
+			fmt.Printf("   %s  %s  %s\n", display.Dim.Render(a.ID[:8]), age, display.Truncate(display.SingleLine(a.Title), 60))
+		}
+	}
+
+	if len(rep.ByTag) > 0 {
+		fmt.Println()
+		tagCols := []display.Column{
+			{Title: "TAG", Min: 12, Weight: 1},
+			{Title: "DONE", Min: 5, Weight: 0},
+			{Title: "LEAD P50", Min: 9, Weight: 0},
+			{Title: "CYCLE P50", Min: 9, Weight: 0},
+		}
+		rows := make([][]string, 0, len(rep.ByTag))
+		for _, t := range rep.ByTag {
+			cycle := "—"
+			if t.CycleTime.Count > 0 {
+				cycle = formatHours(t.CycleTime.Percentiles["p50"])
+			}
+			rows = append(rows, []string{
+				"#" + t.Tag,
+				fmt.Sprintf("%d", t.Completed),
+				formatHours(t.LeadTime.Percentiles["p50"]),
+				cycle,
+			})
+		}
+		fmt.Println(display.NewResponsiveTable(tagCols, rows))
+	}
+
+	if len(rep.Warnings) > 0 {
+		fmt.Println()
+		for _, w := range rep.Warnings {
+			fmt.Println(" " + display.Warn.Render("⚠ "+w))
+		}
+	}
+}
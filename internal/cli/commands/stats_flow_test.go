@@ -0,0 +1,136 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kutbudev/ramorie-cli/internal/api"
+	"github.com/kutbudev/ramorie-cli/internal/models"
+)
+
+func TestStatusFromEvent(t *testing.T) {
+	cases := []struct{ eventType, status, want string }{
+		{"task_started", "", "IN_PROGRESS"},
+		{"task_completed", "", "COMPLETED"},
+		{"task_uncompleted", "", "TODO"},
+		{"task_stopped", "", "TODO"},
+		{"task_created", "", ""},
+		{"task_updated", "in_progress", "IN_PROGRESS"},
+		{"", "COMPLETED", "COMPLETED"},
+		// Free text is not guessed at.
+		{"Completed task: ship it", "", ""},
+		{"task_updated", "", ""},
+	}
+	for _, c := range cases {
+		if got := statusFromEvent(c.eventType, c.status); got != c.want {
+			t.Errorf("statusFromEvent(%q, %q) = %q, want %q", c.eventType, c.status, got, c.want)
+		}
+	}
+}
+
+func TestTransitionsFromSources(t *testing.T) {
+	id := uuid.New()
+	at := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	act := transitionsFromActivity([]models.ActivityItem{
+		{EntityType: "task", EntityID: id, Summary: "Task started", EventType: "task_started", Timestamp: at},
+		{EntityType: "task", EntityID: id, Summary: "Task completed", Timestamp: at},
+		{EntityType: "memory", EntityID: id, Summary: "Completed memory", Status: "COMPLETED", Timestamp: at},
+	})
+	if len(act) != 1 || act[0].Status != "IN_PROGRESS" || act[0].TaskID != id.String() {
+		t.Fatalf("activity transitions = %+v", act)
+	}
+	ev := transitionsFromAgentEvents([]api.AgentEventItem{
+		{EventType: "task_completed", EntityType: "task", EntityID: id.String(), CreatedAt: at.Format(time.RFC3339)},
+		{EventType: "task_completed", EntityType: "task", EntityID: id.String(), CreatedAt: "garbage"},
+	})
+	if len(ev) != 1 || ev[0].Status != "COMPLETED" || !ev[0].At.Equal(at) {
+		t.Fatalf("agent event transitions = %+v", ev)
+	}
+}
+
+func TestComputeFlow(t *testing.T) {
+	now := time.Date(2026, 3, 20, 12, 0, 0, 0, time.UTC)
+	h := func(hoursAgo int) time.Time { return now.Add(-time.Duration(hoursAgo) * time.Hour) }
+
+	tasks := []flowTask{
+		// Created 100h ago, started 50h ago, done 10h ago → lead 90h, cycle 40h.
+		{ID: "a", Status: "COMPLETED", Tags: []string{"Backend"}, CreatedAt: h(100), UpdatedAt: h(10)},
+		// No events: completion falls back to UpdatedAt, no cycle time.
+		{ID: "b", Status: "COMPLETED", Tags: []string{"backend", "ui"}, CreatedAt: h(30), UpdatedAt: h(20)},
+		// Completed before the window → ignored.
+		{ID: "c", Status: "COMPLETED", CreatedAt: h(2000), UpdatedAt: h(1900)},
+		// Reopened and completed again: only the last completion counts.
+		{ID: "d", Status: "COMPLETED", CreatedAt: h(80), UpdatedAt: h(5)},
+		{ID: "wip-old", Title: "old", Status: "IN_PROGRESS", CreatedAt: h(300), UpdatedAt: h(1)},
+		{ID: "wip-new", Title: "new", Status: "IN_PROGRESS", CreatedAt: h(10), UpdatedAt: h(3)},
+		{ID: "todo", Status: "TODO", CreatedAt: h(10), UpdatedAt: h(10)},
+	}
+	transitions := []flowTransition{
+		{TaskID: "a", Status: "COMPLETED", At: h(10)},
+		{TaskID: "a", Status: "IN_PROGRESS", At: h(50)},
+		{TaskID: "d", Status: "IN_PROGRESS", At: h(70)},
+		{TaskID: "d", Status: "COMPLETED", At: h(60)},
+		{TaskID: "d", Status: "TODO", At: h(40)},
+		{TaskID: "d", Status: "COMPLETED", At: h(20)},
+		{TaskID: "wip-old", Status: "IN_PROGRESS", At: h(200)},
+	}
+
+	rep := computeFlow(tasks, transitions, now, 28)
+	if rep.Completed != 3 {
+		t.Fatalf("Completed = %d, want 3", rep.Completed)
+	}
+	// Lead: a=90, b=10, d=60 → sorted 10, 60, 90.
+	if rep.LeadTime.Count != 3 || rep.LeadTime.Percentiles["p50"] != 60 || rep.LeadTime.Percentiles["p95"] != 90 {
+		t.Errorf("lead = %+v", rep.LeadTime)
+	}
+	// Cycle: a=40, d=50 (first start 70h ago → last completion 20h ago).
+	if rep.CycleTime.Count != 2 || rep.CycleTime.MeanHours != 45 {
+		t.Errorf("cycle = %+v", rep.CycleTime)
+	}
+	if len(rep.AgingWIP) != 2 || rep.AgingWIP[0].ID != "wip-old" || rep.AgingWIP[0].AgeHours != 200 {
+		t.Errorf("aging = %+v", rep.AgingWIP)
+	}
+	// The 28-day window starts on Friday 2026-02-20, so it touches five
+	// ISO weeks, the first from Monday 2026-02-16.
+	if len(rep.Throughput) != 5 || rep.Throughput[0].Start != "2026-02-16" {
+		t.Fatalf("throughput weeks = %+v, want 5 from 2026-02-16", rep.Throughput)
+	}
+	total := 0
+	for _, w := range rep.Throughput {
+		total += w.Completed
+	}
+	if total != 3 || rep.Throughput[4].Completed == 0 {
+		t.Errorf("throughput = %+v", rep.Throughput)
+	}
+	if len(rep.ByTag) != 2 || rep.ByTag[0].Tag != "backend" || rep.ByTag[0].Completed != 2 || rep.ByTag[0].CycleTime.Count != 1 {
+		t.Errorf("by tag = %+v", rep.ByTag)
+	}
+}
+
+func TestPercentileNearestRank(t *testing.T) {
+	v := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	if percentile(v, 50) != 5 || percentile(v, 85) != 9 || percentile(v, 95) != 10 {
+		t.Errorf("p50=%v p85=%v p95=%v", percentile(v, 50), percentile(v, 85), percentile(v, 95))
+	}
+	if percentile(nil, 50) != 0 {
+		t.Error("empty input should give 0")
+	}
+}
+
+func TestHistoryTruncation(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	items := []models.ActivityItem{
+		{Timestamp: now},
+		{Timestamp: now.AddDate(0, 0, -9)},
+		{Timestamp: now.AddDate(0, 0, -2)},
+	}
+	if w := historyTruncation(items, 4); w != "" {
+		t.Errorf("a short read is complete, got %q", w)
+	}
+	w := historyTruncation(items, 3)
+	if !strings.Contains(w, "capped at 3") || !strings.Contains(w, "2026-03-01") {
+		t.Errorf("warning = %q, want the cap and the oldest date", w)
+	}
+}
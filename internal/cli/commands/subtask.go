@@ -3,9 +3,12 @@ package commands
 import (
 	"fmt"
 	"slices"
+	"strings"
 
 	"github.com/kutbudev/ramorie-cli/internal/api"
 	"github.com/kutbudev/ramorie-cli/internal/cli/display"
+	"github.com/kutbudev/ramorie-cli/internal/journal"
+	"github.com/kutbudev/ramorie-cli/internal/models"
 	"github.com/urfave/cli/v2"
 )
 
@@ -18,6 +21,8 @@ func NewSubtaskCommand() *cli.Command {
 		Subcommands: []*cli.Command{
 			subtaskListCmd(),
 			subtaskAddCmd(),
+			subtaskUpdateCmd(),
+			subtaskMoveCmd(),
 			subtaskCompleteCmd(),
 			subtaskDeleteCmd(),
 		},
@@ -33,6 +38,7 @@ func subtaskListCmd() *cli.Command {
 		ArgsUsage: "[task-id]",
 		Flags: []cli.Flag{
 			&cli.BoolFlag{Name: "newest-first", Usage: "Show newest item at the top (default: oldest at top)"},
+			&cli.BoolFlag{Name: "tree", Usage: "Show nested subtasks as a tree with roll-up completion"},
 		},
 		Action: func(c *cli.Context) error {
 			if c.NArg() == 0 {
@@ -53,6 +59,17 @@ func subtaskListCmd() *cli.Command {
 				return nil
 			}
 
+			if c.Bool("tree") {
+				roots := buildSubtaskTree(subtasks)
+				fmt.Println(display.Header(fmt.Sprintf("🌳 %d subtask(s)", len(subtasks)),
+					fmt.Sprintf("%d%% complete", subtaskRollup(roots))))
+				fmt.Println()
+				for _, line := range renderSubtaskTree(roots) {
+					fmt.Println("  " + line)
+				}
+				return nil
+			}
+
 			// Default: chronological asc (oldest top, newest bottom).
 			if !newestFirst {
 				slices.Reverse(subtasks)
@@ -66,7 +83,7 @@ func subtaskListCmd() *cli.Command {
 			rows := make([][]string, 0, len(subtasks))
 			for _, s := range subtasks {
 				done := display.Dim.Render("○")
-				if s.Completed == 1 {
+				if subtaskDone(s) {
 					done = display.Good.Render("✓")
 				}
 				rows = append(rows, []string{
@@ -87,6 +104,9 @@ func subtaskAddCmd() *cli.Command {
 		Name:      "add",
 		Usage:     "Add a subtask to a task",
 		ArgsUsage: "[task-id] [description]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "parent", Usage: "Nest under this subtask (ID or short ID)"},
+		},
 		Action: func(c *cli.Context) error {
 			if c.NArg() < 2 {
 				return fmt.Errorf("usage: ramorie subtask add <task-id> <description>")
@@ -108,12 +128,29 @@ func subtaskAddCmd() *cli.Command {
 			}
 
 			client := api.NewClient()
-			subtask, err := client.CreateSubtask(taskID, description)
+			var subtask *models.Subtask
+			var err error
+			if parentRef := c.String("parent"); parentRef != "" {
+				subs, lerr := client.ListSubtasks(taskID)
+				if lerr != nil {
+					fmt.Printf("Error listing subtasks: %v\n", lerr)
+					return lerr
+				}
+				parent, rerr := resolveSubtaskRef(subs, parentRef)
+				if rerr != nil {
+					return rerr
+				}
+				subtask, err = client.CreateChildSubtask(taskID, parent.ID.String(), description)
+			} else {
+				subtask, err = client.CreateSubtask(taskID, description)
+			}
 			if err != nil {
 				fmt.Printf("Error creating subtask: %v\n", err)
 				return err
 			}
 
+			journal.Record("subtask add", subtask.ID.String()[:8]+" "+display.Truncate(subtask.Description, 40),
+				journal.Create(journal.KindSubtask, subtask.ID.String()))
 			fmt.Printf("✅ Subtask added: %s\n", subtask.Description)
 			fmt.Printf("   ID: %s\n", subtask.ID.String()[:8])
 			return nil
@@ -121,6 +158,151 @@ func subtaskAddCmd() *cli.Command {
 	}
 }
 
+// subtaskUpdateCmd edits a subtask's description, status, priority or
+// completion.
+func subtaskUpdateCmd() *cli.Command {
+	return &cli.Command{
+		Name:      "update",
+		Usage:     "Update a subtask",
+		ArgsUsage: "[task-id] [subtask-id]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "description", Aliases: []string{"d"}, Usage: "New description"},
+			&cli.StringFlag{Name: "status", Aliases: []string{"s"}, Usage: "New status (TODO, IN_PROGRESS, COMPLETED)"},
+			&cli.StringFlag{Name: "priority", Aliases: []string{"P"}, Usage: "New priority (H, M, L)"},
+			&cli.BoolFlag{Name: "done", Usage: "Mark completed"},
+			&cli.BoolFlag{Name: "undone", Usage: "Mark not completed"},
+		},
+		Action: func(c *cli.Context) error {
+			if c.NArg() < 2 {
+				return fmt.Errorf("usage: ramorie subtask update <task-id> <subtask-id> [--description ...] [--status ...] [--priority H|M|L] [--done|--undone]")
+			}
+			if c.Bool("done") && c.Bool("undone") {
+				return fmt.Errorf("--done and --undone are mutually exclusive")
+			}
+
+			req := api.UpdateSubtaskRequest{}
+			if v := c.String("description"); v != "" {
+				req.Description = &v
+			}
+			if v := strings.ToUpper(c.String("status")); v != "" {
+				if v != "TODO" && v != "IN_PROGRESS" && v != "COMPLETED" {
+					return fmt.Errorf("status must be TODO, IN_PROGRESS or COMPLETED")
+				}
+				req.Status = &v
+			}
+			if v := strings.ToUpper(c.String("priority")); v != "" {
+				if v != "H" && v != "M" && v != "L" {
+					return fmt.Errorf("priority must be H, M or L")
+				}
+				req.Priority = &v
+			}
+			if c.Bool("done") || c.Bool("undone") {
+				completed := 0
+				if c.Bool("done") {
+					completed = 1
+				}
+				req.Completed = &completed
+			}
+			if req == (api.UpdateSubtaskRequest{}) {
+				return fmt.Errorf("nothing to update — pass --description, --status, --priority, --done or --undone")
+			}
+
+			client := api.NewClient()
+			subs, err := client.ListSubtasks(c.Args().Get(0))
+			if err != nil {
+				fmt.Printf("Error listing subtasks: %v\n", err)
+				return err
+			}
+			target, err := resolveSubtaskRef(subs, c.Args().Get(1))
+			if err != nil {
+				return err
+			}
+			updated, err := client.UpdateSubtask(target.ID.String(), req)
+			if err != nil {
+				fmt.Printf("Error updating subtask: %v\n", err)
+				return err
+			}
+			var fields []string
+			if req.Description != nil {
+				fields = append(fields, "description")
+			}
+			if req.Status != nil {
+				fields = append(fields, "status")
+			}
+			if req.Priority != nil {
+				fields = append(fields, "priority")
+			}
+			if req.Completed != nil {
+				fields = append(fields, "completed")
+			}
+			journal.Record("subtask update", target.ID.String()[:8]+" "+display.Truncate(target.Description, 40),
+				journal.Update(journal.KindSubtask, target.ID.String(), journal.SubtaskSnapshot(target), fields...))
+			fmt.Printf("✅ Subtask %s updated: %s\n", target.ID.String()[:8], updated.Description)
+			return nil
+		},
+	}
+}
+
+// subtaskMoveCmd reparents a subtask within the same task.
+func subtaskMoveCmd() *cli.Command {
+	return &cli.Command{
+		Name:      "move",
+		Aliases:   []string{"mv"},
+		Usage:     "Move a subtask under another subtask (or to the top level with --root)",
+		ArgsUsage: "[task-id] [subtask-id]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "parent", Usage: "New parent subtask (ID or short ID)"},
+			&cli.BoolFlag{Name: "root", Usage: "Move to the top level"},
+		},
+		Action: func(c *cli.Context) error {
+			if c.NArg() < 2 {
+				return fmt.Errorf("usage: ramorie subtask move <task-id> <subtask-id> (--parent <subtask-id> | --root)")
+			}
+			parentRef, toRoot := c.String("parent"), c.Bool("root")
+			if (parentRef == "") == !toRoot {
+				return fmt.Errorf("pass exactly one of --parent <subtask-id> or --root")
+			}
+
+			client := api.NewClient()
+			subs, err := client.ListSubtasks(c.Args().Get(0))
+			if err != nil {
+				fmt.Printf("Error listing subtasks: %v\n", err)
+				return err
+			}
+			target, err := resolveSubtaskRef(subs, c.Args().Get(1))
+			if err != nil {
+				return err
+			}
+			targetID := target.ID.String()
+
+			parentID := ""
+			if !toRoot {
+				parent, err := resolveSubtaskRef(subs, parentRef)
+				if err != nil {
+					return err
+				}
+				parentID = parent.ID.String()
+				if parentID == targetID || isSubtaskDescendant(subs, targetID, parentID) {
+					return fmt.Errorf("cannot move %s under itself or one of its own subtasks", targetID[:8])
+				}
+			}
+
+			if _, err := client.MoveSubtask(targetID, parentID); err != nil {
+				fmt.Printf("Error moving subtask: %v\n", err)
+				return err
+			}
+			journal.Record("subtask move", targetID[:8]+" "+display.Truncate(target.Description, 40),
+				journal.Update(journal.KindSubtask, targetID, journal.SubtaskSnapshot(target), "parent_subtask_id"))
+			if toRoot {
+				fmt.Printf("✅ Subtask %s moved to the top level.\n", targetID[:8])
+			} else {
+				fmt.Printf("✅ Subtask %s moved under %s.\n", targetID[:8], parentID[:8])
+			}
+			return nil
+		},
+	}
+}
+
 // subtaskCompleteCmd marks a subtask as completed.
 func subtaskCompleteCmd() *cli.Command {
 	return &cli.Command{
@@ -138,6 +320,8 @@ func subtaskCompleteCmd() *cli.Command {
 
 			client := api.NewClient()
 
+			before, fullID := subtaskBefore(client, taskID, subtaskID)
+
 			// Update subtask to completed
 			updateData := map[string]interface{}{"completed": 1}
 			_, err := client.Request("PUT", fmt.Sprintf("/tasks/%s/subtasks/%s", taskID, subtaskID), updateData)
@@ -145,6 +329,8 @@ func subtaskCompleteCmd() *cli.Command {
 				fmt.Printf("Error completing subtask: %v\n", err)
 				return err
 			}
+			journal.Record("subtask complete", shortID(fullID),
+				journal.Update(journal.KindSubtask, fullID, journal.SubtaskSnapshot(before), "completed"))
 
 			fmt.Printf("✅ Subtask %s marked as completed.\n", subtaskID[:8])
 			return nil
@@ -169,11 +355,14 @@ func subtaskDeleteCmd() *cli.Command {
 
 			client := api.NewClient()
 
+			before, fullID := subtaskBefore(client, taskID, subtaskID)
 			_, err := client.Request("DELETE", fmt.Sprintf("/tasks/%s/subtasks/%s", taskID, subtaskID), nil)
 			if err != nil {
 				fmt.Printf("Error deleting subtask: %v\n", err)
 				return err
 			}
+			journal.Record("subtask delete", shortID(fullID),
+				journal.Delete(journal.KindSubtask, fullID, journal.SubtaskSnapshot(before)))
 
 			fmt.Printf("✅ Subtask %s deleted.\n", subtaskID[:8])
 			return nil
@@ -0,0 +1,171 @@
+package commands
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/kutbudev/ramorie-cli/internal/cli/display"
+	"github.com/kutbudev/ramorie-cli/internal/models"
+)
+
+// subtaskNode is one subtask with its nested children.
+type subtaskNode struct {
+	Subtask  models.Subtask
+	Children []*subtaskNode
+}
+
+// buildSubtaskTree nests subtasks under their ParentSubtaskID. Subtasks whose
+// parent is missing — or that sit on a parent cycle — are promoted to the top
+// level so nothing disappears from the listing. Siblings keep creation order.
+func buildSubtaskTree(subs []models.Subtask) []*subtaskNode {
+	nodes := make(map[string]*subtaskNode, len(subs))
+	ordered := make([]*subtaskNode, 0, len(subs))
+	for _, s := range subs {
+		n := &subtaskNode{Subtask: s}
+		nodes[s.ID.String()] = n
+		ordered = append(ordered, n)
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Subtask.CreatedAt.Before(ordered[j].Subtask.CreatedAt)
+	})
+
+	var roots []*subtaskNode
+	for _, n := range ordered {
+		parent := parentNode(nodes, n)
+		if parent == nil || createsCycle(nodes, n, parent) {
+			roots = append(roots, n)
+			continue
+		}
+		parent.Children = append(parent.Children, n)
+	}
+	return roots
+}
+
+func parentNode(nodes map[string]*subtaskNode, n *subtaskNode) *subtaskNode {
+	if n.Subtask.ParentSubtaskID == nil {
+		return nil
+	}
+	return nodes[n.Subtask.ParentSubtaskID.String()]
+}
+
+// createsCycle reports whether walking up from parent leads back to n.
+func createsCycle(nodes map[string]*subtaskNode, n, parent *subtaskNode) bool {
+	seen := map[*subtaskNode]bool{}
+	for p := parent; p != nil && !seen[p]; p = parentNode(nodes, p) {
+		if p == n {
+			return true
+		}
+		seen[p] = true
+	}
+	return false
+}
+
+// subtaskDone reports a finished subtask. `subtask update --status
+// COMPLETED` sets only the status, so either field may carry it.
+func subtaskDone(s models.Subtask) bool {
+	return s.Completed == 1 || strings.EqualFold(s.Status, "COMPLETED")
+}
+
+// percent is the node's roll-up completion: 100 when the node itself is
+// done, otherwise the mean of its children (0 for an open leaf).
+func (n *subtaskNode) percent() float64 {
+	if subtaskDone(n.Subtask) {
+		return 100
+	}
+	if len(n.Children) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, c := range n.Children {
+		sum += c.percent()
+	}
+	return sum / float64(len(n.Children))
+}
+
+// subtaskRollup is the task-level completion derived from its top-level
+// subtasks, rounded to a whole percent.
+func subtaskRollup(roots []*subtaskNode) int {
+	if len(roots) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, r := range roots {
+		sum += r.percent()
+	}
+	return int(math.Round(sum / float64(len(roots))))
+}
+
+// renderSubtaskTree draws the hierarchy with box-drawing guides. Parents
+// carry their roll-up percentage; leaves show only their check mark.
+func renderSubtaskTree(roots []*subtaskNode) []string {
+	var lines []string
+	var walk func(nodes []*subtaskNode, prefix string)
+	walk = func(nodes []*subtaskNode, prefix string) {
+		for i, n := range nodes {
+			last := i == len(nodes)-1
+			branch, next := "├─ ", "│  "
+			if last {
+				branch, next = "└─ ", "   "
+			}
+			mark := display.Dim.Render("○")
+			if subtaskDone(n.Subtask) {
+				mark = display.Good.Render("✓")
+			}
+			line := fmt.Sprintf("%s%s%s %s %s",
+				display.Dim.Render(prefix), display.Dim.Render(branch), mark,
+				display.Dim.Render(n.Subtask.ID.String()[:8]),
+				display.SingleLine(n.Subtask.Description))
+			if p := strings.ToUpper(n.Subtask.Priority); p != "" {
+				line += " " + display.PriorityBadge(p)
+			}
+			if len(n.Children) > 0 {
+				line += " " + display.Dim.Render(fmt.Sprintf("%d%%", int(math.Round(n.percent()))))
+			}
+			lines = append(lines, line)
+			walk(n.Children, prefix+next)
+		}
+	}
+	walk(roots, "")
+	return lines
+}
+
+// resolveSubtaskRef matches a full or short subtask ID against subs.
+func resolveSubtaskRef(subs []models.Subtask, ref string) (*models.Subtask, error) {
+	ref = strings.ToLower(strings.TrimSpace(ref))
+	if ref == "" {
+		return nil, fmt.Errorf("subtask ID is required")
+	}
+	var hits []*models.Subtask
+	for i := range subs {
+		if strings.HasPrefix(subs[i].ID.String(), ref) {
+			hits = append(hits, &subs[i])
+		}
+	}
+	switch len(hits) {
+	case 0:
+		return nil, fmt.Errorf("no subtask matches %q", ref)
+	case 1:
+		return hits[0], nil
+	}
+	return nil, fmt.Errorf("subtask %q is ambiguous (%d matches) — use more characters", ref, len(hits))
+}
+
+// isSubtaskDescendant reports whether candidate sits somewhere below ancestor.
+func isSubtaskDescendant(subs []models.Subtask, ancestorID, candidateID string) bool {
+	parents := make(map[string]string, len(subs))
+	for _, s := range subs {
+		if s.ParentSubtaskID != nil {
+			parents[s.ID.String()] = s.ParentSubtaskID.String()
+		}
+	}
+	seen := map[string]bool{}
+	for id := parents[candidateID]; id != "" && !seen[id]; id = parents[id] {
+		if id == ancestorID {
+			return true
+		}
+		seen[id] = true
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kutbudev/ramorie-cli/internal/models"
+)
+
+func testSubtask(id string, parent string, done bool, minute int) models.Subtask {
+	s := models.Subtask{
+		ID:          uuid.MustParse(id),
+		Description: id[:4],
+		CreatedAt:   time.Date(2026, 1, 1, 0, minute, 0, 0, time.UTC),
+	}
+	if parent != "" {
+		p := uuid.MustParse(parent)
+		s.ParentSubtaskID = &p
+	}
+	if done {
+		s.Completed = 1
+	}
+	return s
+}
+
+const (
+	subA = "aaaaaaaa-0000-0000-0000-000000000001"
+	subB = "bbbbbbbb-0000-0000-0000-000000000002"
+	subC = "cccccccc-0000-0000-0000-000000000003"
+	subD = "dddddddd-0000-0000-0000-000000000004"
+	subE = "eeeeeeee-0000-0000-0000-000000000005"
+)
+
+func TestBuildSubtaskTree_NestsAndRollsUp(t *testing.T) {
+	// A ─┬─ B (done)
+	//    └─ C ── D (done)
+	// E (open)
+	subs := []models.Subtask{
+		testSubtask(subD, subC, true, 4),
+		testSubtask(subA, "", false, 1),
+		testSubtask(subC, subA, false, 3),
+		testSubtask(subB, subA, true, 2),
+		testSubtask(subE, "", false, 5),
+	}
+	roots := buildSubtaskTree(subs)
+	if len(roots) != 2 || roots[0].Subtask.ID.String() != subA || roots[1].Subtask.ID.String() != subE {
+		t.Fatalf("unexpected roots: %+v", roots)
+	}
+	if kids := roots[0].Children; len(kids) != 2 || kids[0].Subtask.ID.String() != subB {
+		t.Fatalf("children should keep creation order: %+v", kids)
+	}
+	if got := roots[0].percent(); got != 100 {
+		t.Errorf("A percent = %v, want 100 (B done, C's only child done)", got)
+	}
+	if got := subtaskRollup(roots); got != 50 {
+		t.Errorf("rollup = %d, want 50", got)
+	}
+	lines := renderSubtaskTree(roots)
+	if len(lines) != 5 || !strings.Contains(lines[3], "dddddddd") {
+		t.Errorf("unexpected render:\n%s", strings.Join(lines, "\n"))
+	}
+}
+
+func TestSubtaskPercent_StatusCompleted(t *testing.T) {
+	// `subtask update --status COMPLETED` leaves Completed at 0.
+	b := testSubtask(subB, subA, false, 2)
+	b.Status = "COMPLETED"
+	roots := buildSubtaskTree([]models.Subtask{
+		testSubtask(subA, "", false, 1),
+		b,
+		testSubtask(subC, subA, false, 3),
+	})
+	if got := roots[0].percent(); got != 50 {
+		t.Errorf("A percent = %v, want 50 (B completed by status)", got)
+	}
+	if lines := renderSubtaskTree(roots); !strings.Contains(lines[1], "✓") {
+		t.Errorf("B should be marked done:\n%s", strings.Join(lines, "\n"))
+	}
+}
+
+func TestBuildSubtaskTree_OrphansAndCycles(t *testing.T) {
+	missing := "ffffffff-0000-0000-0000-000000000009"
+	subs := []models.Subtask{
+		testSubtask(subA, missing, false, 1),
+		testSubtask(subB, subC, false, 2),
+		testSubtask(subC, subB, false, 3),
+	}
+	roots := buildSubtaskTree(subs)
+	count := 0
+	var walk func([]*subtaskNode)
+	walk = func(ns []*subtaskNode) {
+		for _, n := range ns {
+			count++
+			walk(n.Children)
+		}
+	}
+	walk(roots)
+	if count != 3 {
+		t.Fatalf("every subtask must appear exactly once, got %d", count)
+	}
+	if roots[0].Subtask.ID.String() != subA {
+		t.Errorf("orphan should be promoted to the top level")
+	}
+}
+
+func TestResolveSubtaskRef(t *testing.T) {
+	subs := []models.Subtask{
+		testSubtask(subA, "", false, 1),
+		testSubtask("aaaabbbb-0000-0000-0000-000000000006", "", false, 2),
+	}
+	if s, err := resolveSubtaskRef(subs, "AAAAAAAA"); err != nil || s.ID.String() != subA {
+		t.Fatalf("got %v, %v", s, err)
+	}
+	if _, err := resolveSubtaskRef(subs, "aaaa"); err == nil || !strings.Contains(err.Error(), "ambiguous") {
+		t.Errorf("short prefix should be ambiguous, got %v", err)
+	}
+	if _, err := resolveSubtaskRef(subs, "1234"); err == nil {
+		t.Error("unknown ID should fail")
+	}
+}
+
+func TestIsSubtaskDescendant(t *testing.T) {
+	subs := []models.Subtask{
+		testSubtask(subA, "", false, 1),
+		testSubtask(subB, subA, false, 2),
+		testSubtask(subC, subB, false, 3),
+	}
+	if !isSubtaskDescendant(subs, subA, subC) {
+		t.Error("C is below A")
+	}
+	if isSubtaskDescendant(subs, subC, subA) {
+		t.Error("A is not below C")
+	}
+}
@@ -6,13 +6,17 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/kutbudev/ramorie-cli/internal/activetask"
 	"github.com/kutbudev/ramorie-cli/internal/api"
 	"github.com/kutbudev/ramorie-cli/internal/cli/display"
 	"github.com/kutbudev/ramorie-cli/internal/cli/resolve"
 	"github.com/kutbudev/ramorie-cli/internal/crypto"
 	"github.com/kutbudev/ramorie-cli/internal/encstate"
 	apierrors "github.com/kutbudev/ramorie-cli/internal/errors"
+	"github.com/kutbudev/ramorie-cli/internal/journal"
 	"github.com/kutbudev/ramorie-cli/internal/models"
+	"github.com/kutbudev/ramorie-cli/internal/secrets"
+	"github.com/kutbudev/ramorie-cli/internal/templates"
 	"github.com/urfave/cli/v2"
 )
 
@@ -27,7 +31,9 @@ func NewTaskCommand() *cli.Command {
 			taskCreateCmd(),
 			taskShowCmd(),
 			taskUpdateCmd(),
+			taskEditCmd(),
 			taskStartCmd(),
+			taskFromBranchCmd(),
 			taskStopCmd(),
 			taskCompleteCmd(),
 			taskDeleteCmd(),
@@ -40,6 +46,7 @@ func NewTaskCommand() *cli.Command {
 			taskNotesCmd(),
 			taskLinkCmd(),
 			taskLinksCmd(),
+			taskTemplatesCmd(),
 		},
 	}
 }
@@ -163,7 +170,7 @@ func taskListCmd() *cli.Command {
 func taskCreateCmd() *cli.Command {
 	return &cli.Command{
 		Name:                   "create",
-		Usage:                  "Create a new task",
+		Usage:                  "Create a new task (optionally from a template)",
 		ArgsUsage:              "[title]",
 		UseShortOptionHandling: true,
 		Flags: []cli.Flag{
@@ -171,6 +178,9 @@ func taskCreateCmd() *cli.Command {
 			&cli.StringFlag{Name: "description", Aliases: []string{"d"}, Usage: "Task description"},
 			&cli.StringFlag{Name: "priority", Aliases: []string{"P"}, Usage: "Priority (H, M, L)", Value: "M"},
 			&cli.StringSliceFlag{Name: "tags", Aliases: []string{"t"}, Usage: "Tags (comma-separated or multiple -t flags)"},
+			&cli.StringFlag{Name: "template", Aliases: []string{"T"}, Usage: "Expand a task template from ~/.ramorie/templates or <repo>/.ramorie/templates"},
+			&cli.StringSliceFlag{Name: "var", Usage: "Template variable as key=value (repeatable)"},
+			&cli.BoolFlag{Name: "allow-plaintext", Usage: "Add template subtasks in plaintext under an encrypted task"},
 		},
 		Action: func(c *cli.Context) error {
 			// Rescue a -p/--project the user typed AFTER the title (urfave/cli
@@ -183,14 +193,50 @@ func taskCreateCmd() *cli.Command {
 					posArgs = rest
 				}
 			}
-			if len(posArgs) == 0 {
-				return fmt.Errorf("task title is required. Usage: ramorie task create [--project name] [--priority H] [--tags tag1,tag2] \"Task title\"")
+			title := ""
+			if len(posArgs) > 0 {
+				title = posArgs[0]
 			}
-			title := posArgs[0]
 			description := c.String("description")
 			priority := c.String("priority")
 			tags := c.StringSlice("tags")
 
+			// Template expansion: explicit title/description/priority win,
+			// template tags are merged with -t tags.
+			var subtasks []string
+			if name := c.String("template"); name != "" {
+				tpl, err := templates.Find(name, templates.Dirs())
+				if err != nil {
+					return err
+				}
+				vars, err := templates.ParseVars(c.StringSlice("var"))
+				if err != nil {
+					return err
+				}
+				exp, err := tpl.Expand(vars)
+				if err != nil {
+					return err
+				}
+				if title == "" {
+					title = exp.Title
+				}
+				if description == "" {
+					description = exp.Description
+				}
+				if !c.IsSet("priority") && exp.Priority != "" {
+					priority = exp.Priority
+				}
+				for _, t := range exp.Tags {
+					if !slices.Contains(tags, t) {
+						tags = append(tags, t)
+					}
+				}
+				subtasks = exp.Subtasks
+			}
+			if title == "" {
+				return fmt.Errorf("task title is required. Usage: ramorie task create [--project name] [--priority H] [--tags tag1,tag2] \"Task title\"")
+			}
+
 			client := api.NewClient()
 
 			// Resolve project (name, short id, UUID, or auto-detect when omitted).
@@ -219,7 +265,15 @@ func taskCreateCmd() *cli.Command {
 			// Gate on the SERVER's CURRENT encryption status (encstate) in
 			// addition to the unlocked vault, so disabling encryption in the web
 			// app stops the CLI from encrypting with the old personal key.
-			if encstate.ShouldEncryptPersonal(encstate.FetcherFor(client)) && crypto.IsVaultUnlocked() && !isOrgProject {
+			encrypt := encstate.ShouldEncryptPersonal(encstate.FetcherFor(client)) && crypto.IsVaultUnlocked() && !isOrgProject
+
+			// The subtask API has no encrypted form, so template subtasks
+			// under an encrypted task would be the only plaintext part of it.
+			if encrypt && len(subtasks) > 0 && !c.Bool("allow-plaintext") {
+				return fmt.Errorf("the task will be encrypted but its %d template subtask(s) would be stored in plaintext — pass --allow-plaintext to add them anyway", len(subtasks))
+			}
+
+			if encrypt {
 				// Personal project only — encrypt with personal key
 				encTitle, titleNonce, titleEncrypted, encErr := crypto.EncryptContent(title)
 				if encErr != nil {
@@ -268,9 +322,26 @@ func taskCreateCmd() *cli.Command {
 			}
 
 			fmt.Printf("ID: %s\n", task.ID.String()[:8])
+			journal.Record("task create", taskLabel(task, task.ID.String()), journal.Create(journal.KindTask, task.ID.String()))
 			if len(tags) > 0 {
 				fmt.Printf("Tags: %s\n", strings.Join(tags, ", "))
 			}
+
+			// Template subtasks, always stored as plaintext (see above).
+			if encrypt && len(subtasks) > 0 {
+				fmt.Println(display.Warn.Render("⚠ adding template subtasks in plaintext (--allow-plaintext)"))
+			}
+			created := 0
+			for _, st := range subtasks {
+				if _, err := client.CreateSubtask(task.ID.String(), st); err != nil {
+					fmt.Printf("⚠️  Failed to add subtask %q: %v\n", st, err)
+					continue
+				}
+				created++
+			}
+			if len(subtasks) > 0 {
+				fmt.Printf("Subtasks: %d/%d added\n", created, len(subtasks))
+			}
 			return nil
 		},
 	}
@@ -425,11 +496,14 @@ func taskUpdateCmd() *cli.Command {
 			}
 
 			client := api.NewClient()
+			before, fullID := taskBefore(client, taskID)
 			task, err := client.UpdateTask(taskID, updateData)
 			if err != nil {
 				fmt.Println(apierrors.ParseAPIError(err))
 				return err
 			}
+			journal.Record("task update", taskLabel(before, fullID),
+				journal.Update(journal.KindTask, fullID, journal.TaskSnapshot(before), updateFields(updateData)...))
 
 			fmt.Printf("✅ Task '%s' updated successfully.\n", task.Title)
 			return nil
@@ -450,11 +524,14 @@ func taskStartCmd() *cli.Command {
 			taskID := c.Args().First()
 
 			client := api.NewClient()
-			err := client.StartTask(taskID)
+			before, fullID := taskBefore(client, taskID)
+			err := activetask.Start(client, fullID)
 			if err != nil {
 				fmt.Println(apierrors.ParseAPIError(err))
 				return err
 			}
+			journal.Record("task start", taskLabel(before, fullID),
+				journal.Update(journal.KindTask, fullID, journal.TaskSnapshot(before), "status"))
 
 			shortID := taskID
 			if len(taskID) > 8 {
@@ -481,11 +558,14 @@ func taskCompleteCmd() *cli.Command {
 			taskID := c.Args().First()
 
 			client := api.NewClient()
-			err := client.CompleteTask(taskID)
+			before, fullID := taskBefore(client, taskID)
+			err := activetask.Complete(client, fullID)
 			if err != nil {
 				fmt.Println(apierrors.ParseAPIError(err))
 				return err
 			}
+			journal.Record("task complete", taskLabel(before, fullID),
+				journal.Update(journal.KindTask, fullID, journal.TaskSnapshot(before), "status"))
 
 			shortID := taskID
 			if len(taskID) > 8 {
@@ -511,11 +591,14 @@ func taskStopCmd() *cli.Command {
 			taskID := c.Args().First()
 
 			client := api.NewClient()
-			err := client.StopTask(taskID)
+			before, fullID := taskBefore(client, taskID)
+			err := activetask.Stop(client, fullID)
 			if err != nil {
 				fmt.Println(apierrors.ParseAPIError(err))
 				return err
 			}
+			journal.Record("task stop", taskLabel(before, fullID),
+				journal.Update(journal.KindTask, fullID, journal.TaskSnapshot(before), "status"))
 
 			shortID := taskID
 			if len(taskID) > 8 {
@@ -541,11 +624,14 @@ func taskDeleteCmd() *cli.Command {
 			taskID := c.Args().First()
 
 			client := api.NewClient()
+			before, fullID := taskBefore(client, taskID)
 			err := client.DeleteTask(taskID)
 			if err != nil {
 				fmt.Println(apierrors.ParseAPIError(err))
 				return err
 			}
+			journal.Record("task delete", taskLabel(before, fullID),
+				journal.Delete(journal.KindTask, fullID, journal.TaskSnapshot(before)))
 
 			fmt.Printf("✅ Task %s deleted successfully.\n", taskID[:8])
 			return nil
@@ -634,6 +720,7 @@ func taskDuplicateCmd() *cli.Command {
 			for _, ann := range original.Annotations {
 				_, _ = client.CreateAnnotation(newTask.ID.String(), ann.Content)
 			}
+			journal.Record("task duplicate", taskLabel(newTask, newTask.ID.String()), journal.Create(journal.KindTask, newTask.ID.String()))
 
 			fmt.Printf("✅ Task duplicated successfully!\n")
 			fmt.Printf("Original: %s - %s\n", original.ID.String()[:8], original.Title)
@@ -676,17 +763,21 @@ func taskMoveCmd() *cli.Command {
 				return err
 			}
 
-			// Move each task
+			// Move each task; the whole move is one journal entry.
 			movedCount := 0
+			var ops []journal.Op
 			for _, taskID := range taskIDs {
+				before, fullID := taskBefore(client, taskID)
 				updateData := map[string]interface{}{"project_id": projectID}
 				_, err := client.UpdateTask(taskID, updateData)
 				if err != nil {
 					fmt.Printf("⚠️  Failed to move task %s: %v\n", taskID[:8], err)
 					continue
 				}
+				ops = append(ops, journal.Update(journal.KindTask, fullID, journal.TaskSnapshot(before), "project_id"))
 				movedCount++
 			}
+			journal.Record("task move", fmt.Sprintf("%d task(s) → %s", len(ops), targetProject), ops...)
 
 			fmt.Printf("✅ Moved %d/%d task(s) to project.\n", movedCount, len(taskIDs))
 			return nil
@@ -833,18 +924,16 @@ func taskProgressCmd() *cli.Command {
 		Name:      "progress",
 		Usage:     "Update task progress (0-100)",
 		ArgsUsage: "[task-id] [progress]",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "from-subtasks", Aliases: []string{"auto"}, Usage: "Derive progress from (nested) subtask completion"},
+		},
 		Action: func(c *cli.Context) error {
-			if c.NArg() < 2 {
-				return fmt.Errorf("usage: ramorie task progress <task-id> <progress>")
+			fromSubtasks := c.Bool("from-subtasks")
+			if c.NArg() < 1 || (c.NArg() < 2 && !fromSubtasks) {
+				return fmt.Errorf("usage: ramorie task progress <task-id> <progress> | <task-id> --from-subtasks")
 			}
 
 			taskID := c.Args().Get(0)
-			progressStr := c.Args().Get(1)
-
-			progress, err := strconv.Atoi(progressStr)
-			if err != nil || progress < 0 || progress > 100 {
-				return fmt.Errorf("progress must be a number between 0 and 100")
-			}
 
 			client := api.NewClient()
 
@@ -855,12 +944,33 @@ func taskProgressCmd() *cli.Command {
 				return err
 			}
 
+			var progress int
+			if fromSubtasks {
+				subs, err := client.ListSubtasks(task.ID.String())
+				if err != nil {
+					fmt.Println(apierrors.ParseAPIError(err))
+					return err
+				}
+				if len(subs) == 0 {
+					return fmt.Errorf("task has no subtasks to derive progress from")
+				}
+				progress = subtaskRollup(buildSubtaskTree(subs))
+			} else {
+				progress, err = strconv.Atoi(c.Args().Get(1))
+				if err != nil || progress < 0 || progress > 100 {
+					return fmt.Errorf("progress must be a number between 0 and 100")
+				}
+			}
+
+			before := task
 			updateData := map[string]interface{}{"progress": progress}
 			task, err = client.UpdateTask(task.ID.String(), updateData)
 			if err != nil {
 				fmt.Println(apierrors.ParseAPIError(err))
 				return err
 			}
+			journal.Record("task progress", taskLabel(before, before.ID.String()),
+				journal.Update(journal.KindTask, before.ID.String(), journal.TaskSnapshot(before), "progress"))
 
 			// Visual progress bar
 			filled := progress / 5
@@ -889,19 +999,53 @@ func taskNoteCmd() *cli.Command {
 			taskID := c.Args().Get(0)
 			text := strings.Join(c.Args().Slice()[1:], " ")
 			client := api.NewClient()
-			if _, err := client.CreateAnnotation(taskID, text); err != nil {
+			projectID := ""
+			if task, err := client.GetTask(taskID); err == nil {
+				projectID = task.ProjectID.String()
+			}
+			scan, err := checkSecrets(client, projectID, text)
+			if err != nil {
 				return err
 			}
-			shortID := taskID
-			if len(shortID) > 8 {
-				shortID = shortID[:8]
+			var note *models.Annotation
+			if scan.Encrypt {
+				note, err = createEncryptedNote(client, taskID, scan.Content)
+			} else {
+				note, err = client.CreateAnnotation(taskID, scan.Content)
+			}
+			if err != nil {
+				return err
 			}
-			fmt.Printf("✅ Note added to task %s\n", shortID)
+			journal.Record("task note", shortID(taskID), journal.Create(journal.KindNote, note.ID.String()))
+			fmt.Printf("✅ Note added to task %s\n", shortID(taskID))
 			return nil
 		},
 	}
 }
 
+// createEncryptedNote saves a note the secret scanner wants encrypted. It
+// needs the unlocked vault and a personal project.
+func createEncryptedNote(client *api.Client, taskID, text string) (*models.Annotation, error) {
+	if !crypto.IsVaultUnlocked() {
+		return nil, secrets.ErrNeedsEncryption
+	}
+	task, err := client.GetTask(taskID)
+	if err != nil {
+		return nil, err
+	}
+	if isOrgProjectID(client, task.ProjectID.String()) {
+		return nil, secrets.ErrNeedsEncryption
+	}
+	encrypted, nonce, ok, err := crypto.EncryptContent(text)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, secrets.ErrNeedsEncryption
+	}
+	return client.CreateEncryptedAnnotation(task.ID.String(), encrypted, nonce)
+}
+
 // taskNotesCmd lists annotations on a task.
 func taskNotesCmd() *cli.Command {
 	return &cli.Command{
@@ -955,6 +1099,8 @@ func taskLinkCmd() *cli.Command {
 			if _, err := client.CreateMemoryTaskLink(taskID, memoryID, ""); err != nil {
 				return err
 			}
+			journal.Record("task link", shortID(taskID)+" ↔ "+shortID(memoryID),
+				journal.Create(journal.KindLink, taskID+"/"+memoryID))
 			shortTask := taskID
 			if len(shortTask) > 8 {
 				shortTask = shortTask[:8]
@@ -1017,6 +1163,44 @@ func taskLinksCmd() *cli.Command {
 	}
 }
 
+// taskTemplatesCmd lists the task templates visible from the current directory.
+func taskTemplatesCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "templates",
+		Usage: "List task templates (~/.ramorie/templates, <repo>/.ramorie/templates)",
+		Action: func(c *cli.Context) error {
+			dirs := templates.Dirs()
+			list, err := templates.List(dirs)
+			if err != nil {
+				return err
+			}
+			if len(list) == 0 {
+				fmt.Println(display.Dim.Render("  no templates — add YAML files to " + strings.Join(dirs, " or ")))
+				return nil
+			}
+			cols := []display.Column{
+				{Title: "NAME", Min: 12, Weight: 0},
+				{Title: "P", Min: 3, Weight: 0},
+				{Title: "TITLE", Min: 24, Weight: 3},
+				{Title: "SUBTASKS", Min: 8, Weight: 0},
+				{Title: "SOURCE", Min: 16, Weight: 2},
+			}
+			rows := make([][]string, 0, len(list))
+			for _, t := range list {
+				rows = append(rows, []string{
+					t.Name,
+					display.PriorityBadge(t.Priority),
+					display.SingleLine(t.Title),
+					fmt.Sprintf("%d", len(t.Subtasks)),
+					display.Dim.Render(t.Source),
+				})
+			}
+			fmt.Println(display.NewResponsiveTable(cols, rows))
+			return nil
+		},
+	}
+}
+
 // decryptTaskForCLI decrypts task title and description if encrypted and vault is unlocked.
 // Returns decrypted title and description.
 func decryptTaskForCLI(t *models.Task) (title, description string) {
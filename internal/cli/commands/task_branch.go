@@ -0,0 +1,301 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/kutbudev/ramorie-cli/internal/activetask"
+	"github.com/kutbudev/ramorie-cli/internal/api"
+	"github.com/kutbudev/ramorie-cli/internal/cli/display"
+	"github.com/kutbudev/ramorie-cli/internal/cli/resolve"
+	"github.com/kutbudev/ramorie-cli/internal/config"
+	apierrors "github.com/kutbudev/ramorie-cli/internal/errors"
+	"github.com/kutbudev/ramorie-cli/internal/journal"
+	"github.com/urfave/cli/v2"
+)
+
+// defaultBranchPattern accepts `feat/3f2a-login-rate-limit`,
+// `fix/login-rate-limit` and plain `3f2a-login`: any number of `prefix/`
+// segments, an optional 4–8 char hex short ID, then the title slug.
+const defaultBranchPattern = `^(?:[^/]+/)*(?:(?P<id>[0-9a-fA-F]{4,8})(?:[-_]|$))?(?P<title>.*)$`
+
+// branchesWithoutTask are never matched against tasks.
+var branchesWithoutTask = map[string]bool{
+	"main": true, "master": true, "develop": true, "dev": true, "trunk": true, "HEAD": true,
+}
+
+// minBranchTitleScore is the share of branch-slug words that must appear in
+// a task title for a fuzzy match.
+const minBranchTitleScore = 0.6
+
+// branchRef is what a branch name says about its task.
+type branchRef struct {
+	ID    string // short task ID, lower-cased; "" when absent
+	Title string // slug with separators turned into spaces
+}
+
+// compileBranchPattern validates a user pattern: it must compile and expose
+// at least one of the `id` / `title` named groups.
+func compileBranchPattern(pattern string) (*regexp.Regexp, error) {
+	if strings.TrimSpace(pattern) == "" {
+		pattern = defaultBranchPattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid branch pattern %q: %w", pattern, err)
+	}
+	if re.SubexpIndex("id") < 0 && re.SubexpIndex("title") < 0 {
+		return nil, fmt.Errorf("branch pattern %q needs a (?P<id>…) or (?P<title>…) group", pattern)
+	}
+	return re, nil
+}
+
+// resolveBranchPattern picks the pattern by precedence: explicit flag, then
+// `git config ramorie.branchPattern`, then config.json, then the default.
+func resolveBranchPattern(flag string) string {
+	if flag != "" {
+		return flag
+	}
+	if p, err := gitOutput("config", "--get", "ramorie.branchPattern"); err == nil && p != "" {
+		return p
+	}
+	if cfg, err := config.LoadConfig(); err == nil && cfg.BranchPattern != "" {
+		return cfg.BranchPattern
+	}
+	return defaultBranchPattern
+}
+
+// parseBranch applies re to branch. ok is false for trunk branches and
+// names the pattern does not match.
+func parseBranch(re *regexp.Regexp, branch string) (ref branchRef, ok bool) {
+	branch = strings.TrimSpace(branch)
+	if branch == "" || branchesWithoutTask[branch] {
+		return ref, false
+	}
+	m := re.FindStringSubmatch(branch)
+	if m == nil {
+		return ref, false
+	}
+	if i := re.SubexpIndex("id"); i >= 0 {
+		ref.ID = strings.ToLower(m[i])
+	}
+	if i := re.SubexpIndex("title"); i >= 0 {
+		ref.Title = strings.Join(slugWords(m[i]), " ")
+	}
+	return ref, ref.ID != "" || ref.Title != ""
+}
+
+// slugWords splits a branch slug or task title into lower-case words.
+func slugWords(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r > 127)
+	})
+}
+
+// branchCandidate is a task considered for a fuzzy title match.
+type branchCandidate struct {
+	ID    string
+	Title string
+}
+
+// matchBranchTitle scores each candidate by the share of slug words found in
+// its title. It returns the single best candidate at or above the threshold;
+// a tie at the top score is reported as ambiguous.
+func matchBranchTitle(slug string, cands []branchCandidate) (best *branchCandidate, ambiguous []branchCandidate) {
+	words := slugWords(slug)
+	if len(words) == 0 {
+		return nil, nil
+	}
+	type scored struct {
+		c     branchCandidate
+		score float64
+	}
+	var hits []scored
+	for _, c := range cands {
+		titleWords := map[string]bool{}
+		for _, w := range slugWords(c.Title) {
+			titleWords[w] = true
+		}
+		n := 0
+		for _, w := range words {
+			if titleWords[w] {
+				n++
+			}
+		}
+		score := float64(n) / float64(len(words))
+		if score >= minBranchTitleScore {
+			hits = append(hits, scored{c, score})
+		}
+	}
+	if len(hits) == 0 {
+		return nil, nil
+	}
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].score > hits[j].score })
+	if len(hits) > 1 && hits[1].score == hits[0].score {
+		for _, h := range hits {
+			if h.score == hits[0].score {
+				ambiguous = append(ambiguous, h.c)
+			}
+		}
+		return nil, ambiguous
+	}
+	return &hits[0].c, nil
+}
+
+// taskFromBranchCmd implements `ramorie task from-branch`.
+func taskFromBranchCmd() *cli.Command {
+	return &cli.Command{
+		Name:      "from-branch",
+		Usage:     "Start the task named by the current git branch (stops the previous one)",
+		ArgsUsage: "[branch]",
+		Description: "Resolves a short ID (feat/3f2a-login) or a fuzzy title (fix/login-rate-limit)\n" +
+			"   from the branch name. The pattern is a regexp with named groups `id` and/or\n" +
+			"   `title`, taken from --pattern, `git config ramorie.branchPattern`, or\n" +
+			"   `branch_pattern` in ~/.ramorie/config.json.",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "project", Aliases: []string{"p"}, Usage: "Project for title matching (name | short id | UUID). Optional: auto-detected when omitted."},
+			&cli.StringFlag{Name: "pattern", Usage: "Branch-name regexp with (?P<id>…) and/or (?P<title>…) groups"},
+			&cli.BoolFlag{Name: "dry-run", Aliases: []string{"n"}, Usage: "Only report which task would be started"},
+		},
+		Action: func(c *cli.Context) error {
+			branch := c.Args().First()
+			if branch == "" {
+				b, err := gitOutput("rev-parse", "--abbrev-ref", "HEAD")
+				if err != nil {
+					return fmt.Errorf("not on a git branch — pass the branch name explicitly")
+				}
+				branch = b
+			}
+			m, err := taskForBranch(api.NewClient(), branch, c.String("project"), c.String("pattern"))
+			if err != nil {
+				return err
+			}
+			if c.Bool("dry-run") {
+				fmt.Printf("%s → %s %s\n", branch, display.Dim.Render(m.ID[:8]), m.Title)
+				return nil
+			}
+			return switchActiveTask(api.NewClient(), m.ID, m.Title)
+		},
+	}
+}
+
+// branchTask is the task a branch resolved to.
+type branchTask struct {
+	ID        string
+	Title     string
+	ProjectID string // "" when the task could not be fetched
+	Completed bool
+	ByID      bool // matched by short ID rather than by title
+}
+
+// hookSkipReason says why the post-checkout hook must not start m, or ""
+// when it may. A short-ID match is only trusted inside the checkout's own
+// project: the ID is prefix-matched across every task, so a hex-looking
+// slug word ("facade") can hit a task anywhere.
+func hookSkipReason(m branchTask, checkoutProject string) string {
+	switch {
+	case m.Completed:
+		return "it is completed"
+	case !m.ByID:
+		return ""
+	case checkoutProject == "":
+		return "the checkout's project could not be detected"
+	case m.ProjectID != checkoutProject:
+		return "it belongs to another project"
+	}
+	return ""
+}
+
+// taskForBranch resolves a branch to a task: the short ID first, then a
+// fuzzy title match against open tasks in the project.
+func taskForBranch(client *api.Client, branch, projectArg, patternFlag string) (branchTask, error) {
+	re, err := compileBranchPattern(resolveBranchPattern(patternFlag))
+	if err != nil {
+		return branchTask{}, err
+	}
+	ref, ok := parseBranch(re, branch)
+	if !ok {
+		return branchTask{}, fmt.Errorf("branch %q does not name a task", branch)
+	}
+	if ref.ID != "" {
+		if full, err := resolveTaskRef(client, ref.ID); err == nil {
+			m := branchTask{ID: full, ByID: true}
+			t, err := client.GetTask(full)
+			if err != nil {
+				return m, nil
+			}
+			m.Title, _ = decryptTaskForCLI(t)
+			m.ProjectID = t.ProjectID.String()
+			m.Completed = strings.EqualFold(t.Status, "COMPLETED")
+			return m, nil
+		}
+		// A hex-looking word ("dead-code") is not necessarily an ID — fall
+		// through to the title with the word put back.
+		ref.Title = strings.TrimSpace(ref.ID + " " + ref.Title)
+	}
+	if ref.Title == "" {
+		return branchTask{}, fmt.Errorf("no task matches ram#%s", ref.ID)
+	}
+
+	projectID, err := resolve.AutoResolveProject(projectArg, client)
+	if err != nil {
+		return branchTask{}, err
+	}
+	var cands []branchCandidate
+	for page := 1; ; page++ {
+		items, hasMore, err := client.ListTasksPage(projectID, "", page, 100)
+		if err != nil {
+			return branchTask{}, fmt.Errorf("%s", apierrors.ParseAPIError(err))
+		}
+		for i := range items {
+			if strings.EqualFold(items[i].Status, "COMPLETED") {
+				continue
+			}
+			t, _ := decryptTaskForCLI(&items[i])
+			cands = append(cands, branchCandidate{ID: items[i].ID.String(), Title: t})
+		}
+		if !hasMore {
+			break
+		}
+	}
+	best, ambiguous := matchBranchTitle(ref.Title, cands)
+	if len(ambiguous) > 0 {
+		names := make([]string, 0, len(ambiguous))
+		for _, a := range ambiguous {
+			names = append(names, a.ID[:8]+" "+a.Title)
+		}
+		return branchTask{}, fmt.Errorf("branch %q matches several tasks — put the short ID in the branch name:\n  %s", branch, strings.Join(names, "\n  "))
+	}
+	if best == nil {
+		return branchTask{}, fmt.Errorf("no open task matches branch %q", branch)
+	}
+	return branchTask{ID: best.ID, Title: best.Title, ProjectID: projectID}, nil
+}
+
+// switchActiveTask stops the previously started task (if different) and
+// starts taskID, so new memories auto-link to the right work.
+func switchActiveTask(client *api.Client, taskID, title string) error {
+	var ops []journal.Op
+	if prev := config.LoadActiveTask(); prev != "" && prev != taskID && !strings.HasPrefix(taskID, prev) {
+		before, prevID := taskBefore(client, prev)
+		if err := activetask.Stop(client, prev); err == nil {
+			ops = append(ops, journal.Update(journal.KindTask, prevID, journal.TaskSnapshot(before), "status"))
+			short := prev
+			if len(short) > 8 {
+				short = short[:8]
+			}
+			fmt.Printf("⏸️  Task %s paused.\n", short)
+		}
+	}
+	before, _ := taskBefore(client, taskID)
+	if err := activetask.Start(client, taskID); err != nil {
+		fmt.Println(apierrors.ParseAPIError(err))
+		return err
+	}
+	ops = append(ops, journal.Update(journal.KindTask, taskID, journal.TaskSnapshot(before), "status"))
+	journal.Record("task from-branch", taskLabel(before, taskID), ops...)
+	fmt.Printf("🚀 Task %s is now ACTIVE: %s\n", taskID[:8], title)
+	return nil
+}
@@ -0,0 +1,99 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseBranch_DefaultPattern(t *testing.T) {
+	re, err := compileBranchPattern("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		branch string
+		want   branchRef
+		ok     bool
+	}{
+		{"feat/3f2a-login-rate-limit", branchRef{ID: "3f2a", Title: "login rate limit"}, true},
+		{"user/ada/fix/3F2A_login", branchRef{ID: "3f2a", Title: "login"}, true},
+		{"fix/login-rate-limit", branchRef{Title: "login rate limit"}, true},
+		{"3f2a1b2c", branchRef{ID: "3f2a1b2c"}, true},
+		{"feature/abcd1234deadbeef", branchRef{Title: "abcd1234deadbeef"}, true},
+		{"main", branchRef{}, false},
+		{"HEAD", branchRef{}, false},
+	}
+	for _, tc := range cases {
+		got, ok := parseBranch(re, tc.branch)
+		if ok != tc.ok || got != tc.want {
+			t.Errorf("%s: got %+v ok=%v, want %+v ok=%v", tc.branch, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestParseBranch_CustomPattern(t *testing.T) {
+	// Jira-style team convention: RAM-<id>/<slug>.
+	re, err := compileBranchPattern(`^RAM-(?P<id>[0-9a-f]+)/(?P<title>.+)$`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, ok := parseBranch(re, "RAM-beef/speed-up-search")
+	if !ok || got.ID != "beef" || got.Title != "speed up search" {
+		t.Fatalf("got %+v ok=%v", got, ok)
+	}
+	if _, ok := parseBranch(re, "feat/beef-other"); ok {
+		t.Fatal("non-matching branch should not resolve")
+	}
+}
+
+func TestCompileBranchPattern_Validates(t *testing.T) {
+	if _, err := compileBranchPattern(`(`); err == nil {
+		t.Error("invalid regexp should fail")
+	}
+	if _, err := compileBranchPattern(`^feat/(.+)$`); err == nil || !strings.Contains(err.Error(), "(?P<id>") {
+		t.Errorf("pattern without named groups should fail, got %v", err)
+	}
+}
+
+func TestMatchBranchTitle(t *testing.T) {
+	cands := []branchCandidate{
+		{ID: "aaaaaaaa-1", Title: "Add rate limit to login endpoint"},
+		{ID: "bbbbbbbb-2", Title: "Login page redesign"},
+		{ID: "cccccccc-3", Title: "Rate limit the search API"},
+	}
+	best, amb := matchBranchTitle("login rate limit", cands)
+	if best == nil || best.ID != "aaaaaaaa-1" || amb != nil {
+		t.Fatalf("best=%+v ambiguous=%+v", best, amb)
+	}
+
+	if best, _ := matchBranchTitle("billing export", cands); best != nil {
+		t.Fatalf("unrelated slug should not match, got %+v", best)
+	}
+
+	dupes := append(cands, branchCandidate{ID: "dddddddd-4", Title: "Login rate limit follow-up"})
+	if best, amb := matchBranchTitle("login rate limit", dupes); best != nil || len(amb) != 2 {
+		t.Fatalf("tie should be ambiguous: best=%+v ambiguous=%+v", best, amb)
+	}
+}
+
+func TestHookSkipReason(t *testing.T) {
+	const proj, other = "pppppppp-1", "oooooooo-2"
+	cases := []struct {
+		name     string
+		m        branchTask
+		checkout string
+		skip     bool
+	}{
+		{"id match in project", branchTask{ByID: true, ProjectID: proj}, proj, false},
+		{"completed id match", branchTask{ByID: true, ProjectID: proj, Completed: true}, proj, true},
+		{"id match elsewhere", branchTask{ByID: true, ProjectID: other}, proj, true},
+		{"id match, project unknown", branchTask{ByID: true, ProjectID: proj}, "", true},
+		{"id match, task not fetched", branchTask{ByID: true}, proj, true},
+		{"title match", branchTask{ProjectID: proj}, "", false},
+	}
+	for _, tc := range cases {
+		if got := hookSkipReason(tc.m, tc.checkout); (got != "") != tc.skip {
+			t.Errorf("%s: reason %q, want skip=%v", tc.name, got, tc.skip)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kutbudev/ramorie-cli/internal/cli/display"
+)
+
+// diffOp is one line of a line-based diff.
+type diffOp struct {
+	Kind byte // ' ' unchanged, '-' removed, '+' added
+	Text string
+}
+
+// diffLines computes a minimal line diff of a → b (LCS). Inputs are split on
+// "\n"; a trailing newline does not produce an empty last line.
+func diffLines(a, b string) []diffOp {
+	x, y := splitDiffLines(a), splitDiffLines(b)
+	// lcs[i][j] = LCS length of x[i:] and y[j:].
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	var ops []diffOp
+	i, j := 0, 0
+	for i < len(x) && j < len(y) {
+		switch {
+		case x[i] == y[j]:
+			ops = append(ops, diffOp{' ', x[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, diffOp{'-', x[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{'+', y[j]})
+			j++
+		}
+	}
+	for ; i < len(x); i++ {
+		ops = append(ops, diffOp{'-', x[i]})
+	}
+	for ; j < len(y); j++ {
+		ops = append(ops, diffOp{'+', y[j]})
+	}
+	return ops
+}
+
+func splitDiffLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// diffStats counts added and removed lines.
+func diffStats(ops []diffOp) (added, removed int) {
+	for _, op := range ops {
+		switch op.Kind {
+		case '+':
+			added++
+		case '-':
+			removed++
+		}
+	}
+	return added, removed
+}
+
+// formatUnifiedDiff renders ops as unified-diff hunks with `context` lines
+// around each change. Colored output paints removals red and additions
+// green; uncolored output is plain `diff -u` text. Returns "" when nothing
+// changed.
+func formatUnifiedDiff(ops []diffOp, context int, color bool) string {
+	// Mark the ops that fall inside a hunk.
+	keep := make([]bool, len(ops))
+	changed := false
+	for i, op := range ops {
+		if op.Kind == ' ' {
+			continue
+		}
+		changed = true
+		for k := max(0, i-context); k <= min(len(ops)-1, i+context); k++ {
+			keep[k] = true
+		}
+	}
+	if !changed {
+		return ""
+	}
+
+	var b strings.Builder
+	aLine, bLine := 1, 1
+	for i := 0; i < len(ops); {
+		if !keep[i] {
+			if ops[i].Kind != '+' {
+				aLine++
+			}
+			if ops[i].Kind != '-' {
+				bLine++
+			}
+			i++
+			continue
+		}
+		end := i
+		for end < len(ops) && keep[end] {
+			end++
+		}
+		aCount, bCount := 0, 0
+		for _, op := range ops[i:end] {
+			if op.Kind != '+' {
+				aCount++
+			}
+			if op.Kind != '-' {
+				bCount++
+			}
+		}
+		header := fmt.Sprintf("@@ -%d,%d +%d,%d @@", aLine, aCount, bLine, bCount)
+		if color {
+			header = display.Info.Render(header)
+		}
+		b.WriteString(header + "\n")
+		for _, op := range ops[i:end] {
+			line := string(op.Kind) + op.Text
+			if color {
+				switch op.Kind {
+				case '-':
+					line = display.Err.Render(line)
+				case '+':
+					line = display.Good.Render(line)
+				default:
+					line = display.Dim.Render(line)
+				}
+			}
+			b.WriteString(line + "\n")
+		}
+		aLine += aCount
+		bLine += bCount
+		i = end
+	}
+	return b.String()
+}
@@ -0,0 +1,44 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiffLines(t *testing.T) {
+	ops := diffLines("a\nb\nc\n", "a\nB\nc\nd")
+	var got []string
+	for _, op := range ops {
+		got = append(got, string(op.Kind)+op.Text)
+	}
+	want := []string{" a", "-b", "+B", " c", "+d"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("diffLines = %v, want %v", got, want)
+	}
+	if added, removed := diffStats(ops); added != 2 || removed != 1 {
+		t.Errorf("diffStats = +%d -%d", added, removed)
+	}
+	if ops := diffLines("", "x"); len(ops) != 1 || ops[0].Kind != '+' {
+		t.Errorf("diffLines from empty = %v", ops)
+	}
+}
+
+func TestFormatUnifiedDiff(t *testing.T) {
+	if out := formatUnifiedDiff(diffLines("same\n", "same"), 3, false); out != "" {
+		t.Errorf("unchanged input should render nothing, got %q", out)
+	}
+
+	var a, b []string
+	for i := 1; i <= 10; i++ {
+		a = append(a, string(rune('a'+i-1)))
+	}
+	b = append(b, a...)
+	b[1] = "B"
+	b[8] = "I"
+	out := formatUnifiedDiff(diffLines(strings.Join(a, "\n"), strings.Join(b, "\n")), 1, false)
+	want := "@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n" +
+		"@@ -8,3 +8,3 @@\n h\n-i\n+I\n j\n"
+	if out != want {
+		t.Errorf("formatUnifiedDiff =\n%s\nwant\n%s", out, want)
+	}
+}
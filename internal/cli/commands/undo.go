@@ -0,0 +1,288 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kutbudev/ramorie-cli/internal/api"
+	"github.com/kutbudev/ramorie-cli/internal/cli/display"
+	"github.com/kutbudev/ramorie-cli/internal/journal"
+	"github.com/kutbudev/ramorie-cli/internal/memrev"
+	"github.com/kutbudev/ramorie-cli/internal/models"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/term"
+)
+
+// ---- journal helpers used by the mutating commands --------------------------
+
+// taskBefore fetches a task ahead of a mutation so the change can be
+// journaled. A failed fetch returns (nil, id): the mutation still runs, it
+// just won't be undoable.
+func taskBefore(client *api.Client, id string) (*models.Task, string) {
+	t, err := client.GetTask(id)
+	if err != nil {
+		return nil, id
+	}
+	return t, t.ID.String()
+}
+
+// memoryBefore is taskBefore for memories.
+func memoryBefore(client *api.Client, id string) (*models.Memory, string) {
+	m, err := client.GetMemory(id)
+	if err != nil {
+		return nil, id
+	}
+	return m, m.ID.String()
+}
+
+// subtaskBefore finds subtaskID (full or short) among the task's subtasks.
+func subtaskBefore(client *api.Client, taskID, subtaskID string) (*models.Subtask, string) {
+	subs, err := client.ListSubtasks(taskID)
+	if err != nil {
+		return nil, subtaskID
+	}
+	for i := range subs {
+		if strings.HasPrefix(subs[i].ID.String(), subtaskID) {
+			return &subs[i], subs[i].ID.String()
+		}
+	}
+	return nil, subtaskID
+}
+
+// taskLabel names a task in journal summaries. Encrypted titles stay out of
+// the journal.
+func taskLabel(t *models.Task, id string) string {
+	if t == nil || t.IsEncrypted {
+		return shortID(id)
+	}
+	return shortID(id) + " " + display.Truncate(display.SingleLine(t.Title), 40)
+}
+
+// memoryLabel is taskLabel for memories.
+func memoryLabel(m *models.Memory, id string) string {
+	if m == nil || m.IsEncrypted {
+		return shortID(id)
+	}
+	first, _ := splitFirstLine(m.Content)
+	return shortID(id) + " " + display.Truncate(first, 40)
+}
+
+func shortID(id string) string {
+	if len(id) > 8 {
+		return id[:8]
+	}
+	return id
+}
+
+// updateFields lists the keys of an update payload in a stable order,
+// leaving out derived keys (nonces, hashes) that undo resolves itself.
+func updateFields(updates map[string]interface{}) []string {
+	var fields []string
+	for k := range updates {
+		switch k {
+		case "title_nonce", "description_nonce", "content_nonce", "content_hash", "is_encrypted":
+			continue
+		case "encrypted_title":
+			k = "title"
+		case "encrypted_description":
+			k = "description"
+		case "encrypted_content":
+			k = "content"
+		}
+		fields = append(fields, k)
+	}
+	slices.Sort(fields)
+	return fields
+}
+
+// ---- undo / history ----------------------------------------------------------
+
+// NewUndoCommand reverts journaled changes.
+func NewUndoCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "undo",
+		Usage:     "Revert the last n changes from the local undo journal",
+		ArgsUsage: "[n]",
+		Description: "Replays the inverse of the most recent journaled changes through the API:\n" +
+			"   updates write the previous values back, deletes re-create the item from\n" +
+			"   its snapshot (with a new ID), creates are deleted. Encrypted items are\n" +
+			"   restored from their stored ciphertext. See `ramorie history`.",
+		Flags: []cli.Flag{
+			&cli.IntFlag{Name: "entry", Aliases: []string{"e"}, Usage: "Undo this journal entry (#) instead of the latest"},
+			&cli.BoolFlag{Name: "dry-run", Aliases: []string{"n"}, Usage: "Show what would be reverted"},
+		},
+		Action: func(c *cli.Context) error {
+			n := 1
+			if c.NArg() > 0 {
+				if _, err := fmt.Sscanf(c.Args().First(), "%d", &n); err != nil || n < 1 {
+					return fmt.Errorf("n must be a positive number, got %q", c.Args().First())
+				}
+			}
+			store, err := journal.Open()
+			if err != nil {
+				return err
+			}
+			entries, err := store.Entries()
+			if err != nil {
+				return err
+			}
+			targets, err := undoTargets(entries, n, c.Int("entry"))
+			if err != nil {
+				return err
+			}
+
+			if c.Bool("dry-run") {
+				for _, e := range targets {
+					fmt.Printf("%s %s\n", display.Label.Render(fmt.Sprintf("#%d", e.Seq)), describeEntry(e))
+				}
+				return nil
+			}
+
+			client := api.NewClient()
+			remap := map[string]string{}
+			for _, e := range targets {
+				notes, err := journal.Revert(memrev.Tracked{Client: client, Source: "undo"}, e, remap)
+				for _, note := range notes {
+					fmt.Printf("   %s\n", display.Dim.Render(note))
+				}
+				forgetLocalMemories(createdMemories(e, len(notes), remap)...)
+				if err != nil {
+					fmt.Printf("%s #%d %s: %v\n", display.Err.Render("✗"), e.Seq, e.Command, err)
+					return err
+				}
+				if err := store.MarkUndone(e.Seq, time.Now()); err != nil {
+					return err
+				}
+				fmt.Printf("↩️  Undid #%d %s\n", e.Seq, describeEntry(e))
+			}
+			return nil
+		},
+	}
+}
+
+// createdMemories returns the memories an entry created that reverting it
+// deleted. Revert goes newest op first and stops at a failure, so only the
+// last reverted ops are considered.
+func createdMemories(e journal.Entry, reverted int, remap map[string]string) []string {
+	var ids []string
+	for _, op := range e.Ops[len(e.Ops)-min(reverted, len(e.Ops)):] {
+		if op.Kind != journal.KindMemory || op.Action != journal.ActionCreate {
+			continue
+		}
+		id := op.ID
+		if mapped, ok := remap[id]; ok {
+			id = mapped
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// undoTargets picks the entries to revert: entry seq when given, otherwise
+// the newest n entries that are not undone yet (newest first). Entries that
+// cannot be reverted (see journal.Entry.Reversible) are passed over.
+func undoTargets(entries []journal.Entry, n, seq int) ([]journal.Entry, error) {
+	if seq > 0 {
+		for _, e := range entries {
+			if e.Seq == seq {
+				if e.Undone() {
+					return nil, fmt.Errorf("entry #%d was already undone", seq)
+				}
+				if !e.Reversible() {
+					return nil, fmt.Errorf("entry #%d (%s) cannot be undone", seq, e.Command)
+				}
+				return []journal.Entry{e}, nil
+			}
+		}
+		return nil, fmt.Errorf("journal entry #%d not found — see `ramorie history`", seq)
+	}
+	var out []journal.Entry
+	for i := len(entries) - 1; i >= 0 && len(out) < n; i-- {
+		if !entries[i].Undone() && entries[i].Reversible() {
+			out = append(out, entries[i])
+		}
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("nothing to undo")
+	}
+	return out, nil
+}
+
+// describeEntry renders "task move — 2 task(s) → backend".
+func describeEntry(e journal.Entry) string {
+	s := e.Command
+	if e.Summary != "" {
+		s += " — " + e.Summary
+	}
+	return s
+}
+
+// NewHistoryCommand lists the undo journal.
+func NewHistoryCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "history",
+		Usage: "List journaled changes that `ramorie undo` can revert",
+		Flags: []cli.Flag{
+			&cli.IntFlag{Name: "limit", Aliases: []string{"n"}, Usage: "Show the last N entries", Value: 20},
+			&cli.BoolFlag{Name: "json", Usage: "Output raw JSON (always on when piped)"},
+		},
+		Action: func(c *cli.Context) error {
+			store, err := journal.Open()
+			if err != nil {
+				return err
+			}
+			entries, err := store.Entries()
+			if err != nil {
+				return err
+			}
+			if limit := c.Int("limit"); limit > 0 && len(entries) > limit {
+				entries = entries[len(entries)-limit:]
+			}
+
+			if c.Bool("json") || !term.IsTerminal(int(os.Stdout.Fd())) {
+				if entries == nil {
+					entries = []journal.Entry{}
+				}
+				out, _ := json.MarshalIndent(entries, "", "  ")
+				fmt.Println(string(out))
+				return nil
+			}
+			if len(entries) == 0 {
+				fmt.Println(display.Dim.Render("The undo journal is empty."))
+				return nil
+			}
+
+			rows := make([][]string, 0, len(entries))
+			for _, e := range entries {
+				state := display.Good.Render("undoable")
+				switch {
+				case e.Undone():
+					state = display.Dim.Render("undone " + display.Relative(*e.UndoneAt))
+				case !e.Reversible():
+					state = display.Dim.Render("recorded only")
+				}
+				rows = append(rows, []string{
+					fmt.Sprintf("#%d", e.Seq),
+					display.Relative(e.At),
+					e.Command,
+					display.SingleLine(e.Summary),
+					state,
+				})
+			}
+			fmt.Println(display.Header("🕘 History", fmt.Sprintf("%d entr(ies) · ↓ newest", len(entries))))
+			fmt.Println(display.NewResponsiveTable([]display.Column{
+				{Title: "#", Min: 4, Weight: 0},
+				{Title: "WHEN", Min: 8, Weight: 0},
+				{Title: "COMMAND", Min: 12, Weight: 1},
+				{Title: "SUMMARY", Min: 20, Weight: 4},
+				{Title: "STATE", Min: 10, Weight: 1},
+			}, rows))
+			fmt.Println(display.Dim.Render("ramorie undo [n] reverts the newest entries · --entry <#> a specific one"))
+			return nil
+		},
+	}
+}
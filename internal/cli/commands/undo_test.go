@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kutbudev/ramorie-cli/internal/journal"
+	"github.com/kutbudev/ramorie-cli/internal/models"
+)
+
+func TestUndoTargets(t *testing.T) {
+	now := time.Now()
+	note := []journal.Op{journal.Create(journal.KindNote, "n")}
+	entries := []journal.Entry{{Seq: 1}, {Seq: 2}, {Seq: 3, UndoneAt: &now}, {Seq: 4}, {Seq: 5, Ops: note}}
+
+	got, err := undoTargets(entries, 2, 0)
+	if err != nil || len(got) != 2 || got[0].Seq != 4 || got[1].Seq != 2 {
+		t.Errorf("undo 2 = %+v, %v (want #4 then #2, skipping undone #3 and the note #5)", got, err)
+	}
+	if _, err := undoTargets(entries, 1, 5); err == nil {
+		t.Error("undoing a note should fail")
+	}
+	if got, err := undoTargets(entries, 1, 1); err != nil || len(got) != 1 || got[0].Seq != 1 {
+		t.Errorf("--entry 1 = %+v, %v", got, err)
+	}
+	if _, err := undoTargets(entries, 1, 3); err == nil {
+		t.Error("undoing an undone entry should fail")
+	}
+	if _, err := undoTargets(entries, 1, 9); err == nil {
+		t.Error("unknown entry should fail")
+	}
+	if _, err := undoTargets(nil, 1, 0); err == nil {
+		t.Error("empty journal should report nothing to undo")
+	}
+}
+
+func TestCreatedMemories(t *testing.T) {
+	e := journal.Entry{Ops: []journal.Op{
+		journal.Create(journal.KindMemory, "m1"),
+		journal.Create(journal.KindTask, "t1"),
+		journal.Create(journal.KindMemory, "m2"),
+		journal.Delete(journal.KindMemory, "m3", nil),
+	}}
+	got := createdMemories(e, len(e.Ops), map[string]string{"m2": "m2-new"})
+	if strings.Join(got, ",") != "m1,m2-new" {
+		t.Errorf("createdMemories = %v", got)
+	}
+	// A revert that failed after the two newest ops only deleted m2.
+	if got := createdMemories(e, 2, nil); strings.Join(got, ",") != "m2" {
+		t.Errorf("partial createdMemories = %v", got)
+	}
+}
+
+func TestUpdateFields(t *testing.T) {
+	got := updateFields(map[string]interface{}{
+		"status": "TODO", "encrypted_title": "x", "title_nonce": "n",
+		"is_encrypted": true, "content_hash": "h", "priority": "H",
+	})
+	if strings.Join(got, ",") != "priority,status,title" {
+		t.Errorf("updateFields = %v", got)
+	}
+}
+
+func TestJournalLabelsHideEncryptedText(t *testing.T) {
+	id := uuid.New()
+	task := &models.Task{ID: id, Title: "secret plan", IsEncrypted: true}
+	if got := taskLabel(task, id.String()); got != id.String()[:8] {
+		t.Errorf("encrypted task label = %q", got)
+	}
+	task.IsEncrypted = false
+	if got := taskLabel(task, id.String()); !strings.Contains(got, "secret plan") {
+		t.Errorf("plain task label = %q", got)
+	}
+	mem := &models.Memory{ID: id, Content: "secret\nmore", IsEncrypted: true}
+	if got := memoryLabel(mem, id.String()); strings.Contains(got, "secret") {
+		t.Errorf("encrypted memory label = %q", got)
+	}
+}
@@ -0,0 +1,354 @@
+package display
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ---- charts ----------------------------------------------------------------
+//
+// Small terminal charts for time series (burndown, throughput, activity).
+// Line charts plot on a braille canvas — each cell holds a 2×4 dot grid, so
+// a 60-column chart has 120 horizontal samples. With NO_COLOR set (or
+// ChartOptions.ASCII) everything falls back to plain ASCII glyphs that
+// survive any terminal, log file or copy-paste.
+
+// Series is one named line in a LineChart.
+type Series struct {
+	Name   string
+	Values []float64
+}
+
+// ChartOptions tunes chart rendering. Zero values pick sensible defaults:
+// the terminal width, 10 rows, and ASCII when NO_COLOR is set.
+type ChartOptions struct {
+	Width   int  // total width including the axis; default TerminalWidth()
+	Height  int  // plot rows; default 10
+	ASCII   bool // force ASCII glyphs (also implied by NO_COLOR)
+	NoColor bool // skip ANSI styling (e.g. for markdown code blocks)
+}
+
+func (o ChartOptions) normalized() ChartOptions {
+	if o.Width <= 0 {
+		o.Width = TerminalWidth()
+	}
+	if o.Height <= 0 {
+		o.Height = 10
+	}
+	if os.Getenv("NO_COLOR") != "" {
+		o.ASCII = true
+		o.NoColor = true
+	}
+	return o
+}
+
+// seriesStyles colors successive series; seriesGlyphs tells them apart in
+// ASCII mode.
+var (
+	seriesStyles = []lipgloss.Style{Info, Warn, Good, Err, Title}
+	seriesGlyphs = []rune{'*', 'o', '+', 'x', '#'}
+)
+
+func (o ChartOptions) paint(s lipgloss.Style, text string) string {
+	if o.NoColor {
+		return text
+	}
+	return s.Render(text)
+}
+
+// LineChart plots one or more series against shared x labels. The y axis
+// starts at zero unless a value is negative. Returns "" when there is
+// nothing to plot.
+func LineChart(labels []string, series []Series, opt ChartOptions) string {
+	opt = opt.normalized()
+	n := 0
+	lo, hi := 0.0, math.Inf(-1)
+	for _, s := range series {
+		if len(s.Values) > n {
+			n = len(s.Values)
+		}
+		for _, v := range s.Values {
+			lo = math.Min(lo, v)
+			hi = math.Max(hi, v)
+		}
+	}
+	if n == 0 {
+		return ""
+	}
+	if hi <= lo {
+		hi = lo + 1
+	}
+
+	rows := opt.Height
+	axisLabel := func(r int) string {
+		switch {
+		case r == rows-1:
+			return formatAxis(hi)
+		case r == 0:
+			return formatAxis(lo)
+		case r == rows/2 && rows > 4:
+			return formatAxis(lo + (hi-lo)*float64(r)/float64(rows-1))
+		}
+		return ""
+	}
+	axisW := 0
+	for _, r := range []int{0, rows / 2, rows - 1} {
+		if w := len(axisLabel(r)); w > axisW {
+			axisW = w
+		}
+	}
+	cols := opt.Width - axisW - 3
+	if cols < 10 {
+		cols = 10
+	}
+
+	// Sub-cell resolution: braille gives 2×4 dots per cell, ASCII 1×1.
+	dx, dy := 2, 4
+	if opt.ASCII {
+		dx, dy = 1, 1
+	}
+	c := newCanvas(cols, rows, dx, dy)
+	for si, s := range series {
+		var px, py int
+		for i, v := range s.Values {
+			x := 0
+			if n > 1 {
+				x = int(math.Round(float64(i) * float64(cols*dx-1) / float64(n-1)))
+			}
+			y := int(math.Round((v - lo) / (hi - lo) * float64(rows*dy-1)))
+			if i == 0 {
+				c.set(x, y, si)
+			} else {
+				c.line(px, py, x, y, si)
+			}
+			px, py = x, y
+		}
+	}
+
+	tick := "┤"
+	if opt.ASCII {
+		tick = "|"
+	}
+	var b strings.Builder
+	for r := rows - 1; r >= 0; r-- {
+		b.WriteString(opt.paint(Dim, fmt.Sprintf("%*s %s", axisW, axisLabel(r), tick)))
+		for col := 0; col < cols; col++ {
+			b.WriteString(c.cell(col, r, opt))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(xAxis(labels, axisW, cols, opt))
+	b.WriteString(legend(series, opt))
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// xAxis draws the axis rule plus the first and last labels.
+func xAxis(labels []string, axisW, cols int, opt ChartOptions) string {
+	corner, rule := "└", "─"
+	if opt.ASCII {
+		corner, rule = "+", "-"
+	}
+	var b strings.Builder
+	b.WriteString(opt.paint(Dim, strings.Repeat(" ", axisW+1)+corner+strings.Repeat(rule, cols)))
+	b.WriteString("\n")
+	if len(labels) > 0 {
+		first, last := labels[0], labels[len(labels)-1]
+		gap := cols - len([]rune(first)) - len([]rune(last))
+		line := first
+		if len(labels) > 1 && gap > 0 {
+			line += strings.Repeat(" ", gap) + last
+		}
+		b.WriteString(opt.paint(Dim, strings.Repeat(" ", axisW+2)+line))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func legend(series []Series, opt ChartOptions) string {
+	if len(series) < 2 {
+		return ""
+	}
+	parts := make([]string, 0, len(series))
+	for i, s := range series {
+		mark := "━━"
+		if opt.ASCII {
+			mark = strings.Repeat(string(seriesGlyphs[i%len(seriesGlyphs)]), 2)
+		}
+		parts = append(parts, opt.paint(seriesStyles[i%len(seriesStyles)], mark)+" "+s.Name)
+	}
+	return strings.Join(parts, "   ") + "\n"
+}
+
+// BarChart draws one horizontal bar per label, scaled to the largest value,
+// with the value printed after the bar. Braille is not needed here: eighth
+// blocks give sub-cell precision (ASCII uses '#').
+func BarChart(labels []string, values []float64, opt ChartOptions) string {
+	opt = opt.normalized()
+	if len(values) == 0 {
+		return ""
+	}
+	labelW, valueW := 0, 0
+	peak := 0.0
+	for i, v := range values {
+		if i < len(labels) && len([]rune(labels[i])) > labelW {
+			labelW = len([]rune(labels[i]))
+		}
+		if w := len(formatAxis(v)); w > valueW {
+			valueW = w
+		}
+		peak = math.Max(peak, v)
+	}
+	barW := opt.Width - labelW - valueW - 4
+	if barW < 5 {
+		barW = 5
+	}
+
+	var b strings.Builder
+	for i, v := range values {
+		label := ""
+		if i < len(labels) {
+			label = labels[i]
+		}
+		bar := ""
+		if peak > 0 && v > 0 {
+			bar = barGlyphs(v/peak*float64(barW), opt.ASCII)
+		}
+		pad := barW - len([]rune(bar))
+		fmt.Fprintf(&b, "%s%s %s%s %s\n",
+			strings.Repeat(" ", labelW-len([]rune(label))), opt.paint(Dim, label),
+			opt.paint(seriesStyles[0], bar), strings.Repeat(" ", pad),
+			formatAxis(v))
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func barGlyphs(width float64, ascii bool) string {
+	if ascii {
+		return strings.Repeat("#", int(math.Round(width)))
+	}
+	const eighths = " ▏▎▍▌▋▊▉"
+	full := int(width)
+	out := strings.Repeat("█", full)
+	if frac := int((width - float64(full)) * 8); frac > 0 {
+		out += string([]rune(eighths)[frac])
+	}
+	return out
+}
+
+// Sparkline renders values as block characters scaled to the maximum
+// (ASCII under NO_COLOR).
+func Sparkline(values []float64) string {
+	ticks := []rune("▁▂▃▄▅▆▇█")
+	if os.Getenv("NO_COLOR") != "" {
+		ticks = []rune("_.-=#")
+	}
+	peak := 0.0
+	for _, v := range values {
+		peak = math.Max(peak, v)
+	}
+	var b strings.Builder
+	for _, v := range values {
+		if peak <= 0 || v <= 0 {
+			b.WriteRune(ticks[0])
+			continue
+		}
+		b.WriteRune(ticks[int(v*float64(len(ticks)-1)/peak)])
+	}
+	return b.String()
+}
+
+// formatAxis prints integers without decimals and others with one.
+func formatAxis(v float64) string {
+	if v == math.Trunc(v) && math.Abs(v) < 1e9 {
+		return strconv.FormatInt(int64(v), 10)
+	}
+	return strconv.FormatFloat(v, 'f', 1, 64)
+}
+
+// ---- canvas ----------------------------------------------------------------
+
+// canvas is a dot grid of cols*dx × rows*dy points; y grows upwards.
+type canvas struct {
+	cols, rows, dx, dy int
+	dots               [][]int // [cell row][cell col] → braille bits
+	owner              [][]int // series index that last touched the cell, -1 = empty
+}
+
+func newCanvas(cols, rows, dx, dy int) *canvas {
+	c := &canvas{cols: cols, rows: rows, dx: dx, dy: dy}
+	c.dots = make([][]int, rows)
+	c.owner = make([][]int, rows)
+	for r := range c.dots {
+		c.dots[r] = make([]int, cols)
+		c.owner[r] = make([]int, cols)
+		for i := range c.owner[r] {
+			c.owner[r][i] = -1
+		}
+	}
+	return c
+}
+
+// brailleBits[x][y] is the dot bit for sub-position (x, y) with y counted
+// from the top of the cell.
+var brailleBits = [2][4]int{{0x01, 0x02, 0x04, 0x40}, {0x08, 0x10, 0x20, 0x80}}
+
+func (c *canvas) set(x, y, series int) {
+	if x < 0 || y < 0 || x >= c.cols*c.dx || y >= c.rows*c.dy {
+		return
+	}
+	col, row := x/c.dx, y/c.dy
+	if c.dx == 2 {
+		c.dots[row][col] |= brailleBits[x%2][c.dy-1-y%c.dy]
+	} else {
+		c.dots[row][col] = 1
+	}
+	c.owner[row][col] = series
+}
+
+// line draws from (x0,y0) to (x1,y1) with Bresenham's algorithm.
+func (c *canvas) line(x0, y0, x1, y1, series int) {
+	dx := int(math.Abs(float64(x1 - x0)))
+	dy := -int(math.Abs(float64(y1 - y0)))
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+	err := dx + dy
+	for {
+		c.set(x0, y0, series)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y0 += sy
+		}
+	}
+}
+
+func (c *canvas) cell(col, row int, opt ChartOptions) string {
+	s := c.owner[row][col]
+	if s < 0 {
+		return " "
+	}
+	var ch string
+	if c.dx == 2 {
+		ch = string(rune(0x2800 + c.dots[row][col]))
+	} else {
+		ch = string(seriesGlyphs[s%len(seriesGlyphs)])
+	}
+	return opt.paint(seriesStyles[s%len(seriesStyles)], ch)
+}
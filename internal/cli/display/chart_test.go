@@ -0,0 +1,100 @@
+package display
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestLineChart_BrailleFitsWidth(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	out := stripAnsi(LineChart(
+		[]string{"03-01", "03-02", "03-03", "03-04"},
+		[]Series{{Name: "remaining", Values: []float64{10, 7, 4, 0}}, {Name: "ideal", Values: []float64{10, 6.7, 3.3, 0}}},
+		ChartOptions{Width: 50, Height: 6},
+	))
+	lines := strings.Split(out, "\n")
+	if len(lines) != 6+3 { // plot rows + axis + labels + legend
+		t.Fatalf("got %d lines:\n%s", len(lines), out)
+	}
+	for _, l := range lines {
+		if n := utf8.RuneCountInString(l); n > 50 {
+			t.Errorf("line wider than 50 (%d): %q", n, l)
+		}
+	}
+	if !strings.HasPrefix(lines[0], "10 ┤") || !strings.HasPrefix(lines[5], " 0 ┤") {
+		t.Errorf("y axis labels missing:\n%s", out)
+	}
+	hasBraille := false
+	for _, r := range out {
+		if r > 0x2800 && r <= 0x28ff {
+			hasBraille = true
+		}
+	}
+	if !hasBraille {
+		t.Errorf("expected braille dots:\n%s", out)
+	}
+	if !strings.Contains(lines[7], "03-01") || !strings.Contains(lines[7], "03-04") {
+		t.Errorf("x labels missing: %q", lines[7])
+	}
+	if !strings.Contains(lines[8], "remaining") || !strings.Contains(lines[8], "ideal") {
+		t.Errorf("legend missing: %q", lines[8])
+	}
+}
+
+func TestLineChart_ASCIIUnderNoColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	out := LineChart([]string{"a", "b"}, []Series{{Name: "x", Values: []float64{0, 5}}}, ChartOptions{Width: 30, Height: 4})
+	for _, r := range out {
+		if r > 127 {
+			t.Fatalf("non-ASCII rune %q under NO_COLOR:\n%s", r, out)
+		}
+	}
+	if strings.Contains(out, "\x1b") {
+		t.Fatal("ANSI escapes under NO_COLOR")
+	}
+	if !strings.Contains(out, "*") || !strings.Contains(out, "+---") {
+		t.Errorf("expected ASCII plot and axis:\n%s", out)
+	}
+}
+
+func TestLineChart_Empty(t *testing.T) {
+	if got := LineChart(nil, nil, ChartOptions{}); got != "" {
+		t.Errorf("empty chart = %q", got)
+	}
+}
+
+func TestBarChart_ScalesToMax(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	out := BarChart([]string{"todo", "completed"}, []float64{5, 10}, ChartOptions{Width: 40})
+	lines := strings.Split(out, "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines:\n%s", len(lines), out)
+	}
+	short, long := strings.Count(lines[0], "#"), strings.Count(lines[1], "#")
+	if long == 0 || short*2-long > 1 || long-short*2 > 1 {
+		t.Errorf("bars not proportional (%d vs %d):\n%s", short, long, out)
+	}
+	if !strings.HasPrefix(lines[0], "     todo ") || !strings.HasSuffix(lines[1], " 10") {
+		t.Errorf("labels/values misaligned:\n%s", out)
+	}
+	for _, l := range lines {
+		if len(l) > 40 {
+			t.Errorf("line wider than 40: %q", l)
+		}
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	if got := Sparkline([]float64{0, 1, 2, 4}); got != "▁▂▄█" {
+		t.Errorf("Sparkline = %q", got)
+	}
+	if got := Sparkline([]float64{0, 0}); got != "▁▁" {
+		t.Errorf("all-zero Sparkline = %q", got)
+	}
+	t.Setenv("NO_COLOR", "1")
+	if got := Sparkline([]float64{0, 4}); got != "_#" {
+		t.Errorf("ASCII Sparkline = %q", got)
+	}
+}
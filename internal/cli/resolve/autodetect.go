@@ -42,23 +42,12 @@ func AutoResolveProject(arg string, l ProjectLister) (string, error) {
 		return "", errors.New("no projects found — create one with `ramorie project create <name>`")
 	}
 
-	// 1. Current working directory name.
-	if id := matchByCWD(projects); id != "" {
+	// 1. + 2. Current working directory name, then git remote repo name.
+	if id := matchCheckout(projects); id != "" {
 		config.RememberLastProject(id)
 		return id, nil
 	}
 
-	// 2. Git remote repo name.
-	if repo := detectGitRemoteRepo(); repo != "" {
-		repoNorm := normalizeForMatch(repo)
-		for _, p := range projects {
-			if normalizeForMatch(p.Name) == repoNorm {
-				config.RememberLastProject(p.ID.String())
-				return p.ID.String(), nil
-			}
-		}
-	}
-
 	// 3. Single project — unambiguous.
 	if len(projects) == 1 {
 		id := projects[0].ID.String()
@@ -85,6 +74,34 @@ func AutoResolveProject(arg string, l ProjectLister) (string, error) {
 	)
 }
 
+// DetectProject returns the project of the working directory's checkout —
+// steps 1 and 2 of AutoResolveProject, without the fallbacks — or "" when
+// none matches. Nothing is remembered.
+func DetectProject(l ProjectLister) string {
+	projects, err := l.ListProjects()
+	if err != nil {
+		return ""
+	}
+	return matchCheckout(projects)
+}
+
+// matchCheckout matches the working directory name, then the git remote
+// repo name, against the project names.
+func matchCheckout(projects []models.Project) string {
+	if id := matchByCWD(projects); id != "" {
+		return id
+	}
+	if repo := detectGitRemoteRepo(); repo != "" {
+		repoNorm := normalizeForMatch(repo)
+		for _, p := range projects {
+			if normalizeForMatch(p.Name) == repoNorm {
+				return p.ID.String()
+			}
+		}
+	}
+	return ""
+}
+
 // matchByCWD returns the project UUID whose normalized name equals a path
 // segment of the current working directory, or "" if none match.
 func matchByCWD(projects []models.Project) string {
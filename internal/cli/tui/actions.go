@@ -2,7 +2,10 @@ package tui
 
 import (
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/kutbudev/ramorie-cli/internal/activetask"
 	"github.com/kutbudev/ramorie-cli/internal/api"
+	"github.com/kutbudev/ramorie-cli/internal/journal"
+	"github.com/kutbudev/ramorie-cli/internal/localindex"
 )
 
 // actions.go holds the write-side and recall commands that make the TUI
@@ -11,6 +14,10 @@ import (
 // tea.Cmd that performs one API call off the UI goroutine and reports back
 // through a typed message that rootModel.Update folds in (usually by
 // refreshing the active category).
+//
+// Mutations are recorded in the undo journal like their CLI counterparts so
+// `ramorie undo` can revert them. Journal errors are dropped — the TUI owns
+// the terminal and a stray stderr line would tear the frame.
 
 // actionDoneMsg reports the result of a mutating action. ok=false carries an
 // error; refresh asks rootModel to reload the current category afterwards.
@@ -26,67 +33,102 @@ func actionErr(e error) tea.Msg    { return actionDoneMsg{ok: false, err: e} }
 
 func completeTaskCmd(c *api.Client, id string) tea.Cmd {
 	return func() tea.Msg {
-		if err := c.CompleteTask(id); err != nil {
+		before := taskSnapshot(c, id)
+		if err := activetask.Complete(c, id); err != nil {
 			return actionErr(err)
 		}
+		_ = journal.Add("tui complete", shortJournalID(id), journal.Update(journal.KindTask, id, before, "status"))
 		return actionOK("completed")
 	}
 }
 
 func reopenTaskCmd(c *api.Client, id string) tea.Cmd {
 	return func() tea.Msg {
+		before := taskSnapshot(c, id)
 		if _, err := c.UpdateTask(id, map[string]interface{}{"status": "TODO"}); err != nil {
 			return actionErr(err)
 		}
+		_ = journal.Add("tui reopen", shortJournalID(id), journal.Update(journal.KindTask, id, before, "status"))
 		return actionOK("reopened")
 	}
 }
 
 func startTaskCmd(c *api.Client, id string) tea.Cmd {
 	return func() tea.Msg {
-		if err := c.StartTask(id); err != nil {
+		before := taskSnapshot(c, id)
+		if err := activetask.Start(c, id); err != nil {
 			return actionErr(err)
 		}
+		_ = journal.Add("tui start", shortJournalID(id), journal.Update(journal.KindTask, id, before, "status"))
 		return actionOK("started")
 	}
 }
 
 func deleteTaskCmd(c *api.Client, id string) tea.Cmd {
 	return func() tea.Msg {
+		before := taskSnapshot(c, id)
 		if err := c.DeleteTask(id); err != nil {
 			return actionErr(err)
 		}
+		_ = journal.Add("tui delete", shortJournalID(id), journal.Delete(journal.KindTask, id, before))
 		return actionOK("deleted")
 	}
 }
 
 func deleteMemoryCmd(c *api.Client, id string) tea.Cmd {
 	return func() tea.Msg {
+		var before map[string]interface{}
+		if m, err := c.GetMemory(id); err == nil {
+			before = journal.MemorySnapshot(m)
+		}
 		if err := c.DeleteMemory(id); err != nil {
 			return actionErr(err)
 		}
+		_ = journal.Add("tui forget", shortJournalID(id), journal.Delete(journal.KindMemory, id, before))
+		_ = localindex.Forget(id)
 		return actionOK("deleted")
 	}
 }
 
 func createTaskCmd(c *api.Client, projectID, title string) tea.Cmd {
 	return func() tea.Msg {
-		if _, err := c.CreateTask(projectID, title, "", "M"); err != nil {
+		t, err := c.CreateTask(projectID, title, "", "M")
+		if err != nil {
 			return actionErr(err)
 		}
+		_ = journal.Add("tui create", shortJournalID(t.ID.String()), journal.Create(journal.KindTask, t.ID.String()))
 		return actionOK("task created")
 	}
 }
 
 func createMemoryCmd(c *api.Client, projectID, content string) tea.Cmd {
 	return func() tea.Msg {
-		if _, err := c.CreateMemoryWithType(projectID, content, "general"); err != nil {
+		m, err := c.CreateMemoryWithType(projectID, content, "general")
+		if err != nil {
 			return actionErr(err)
 		}
+		_ = journal.Add("tui remember", shortJournalID(m.ID.String()), journal.Create(journal.KindMemory, m.ID.String()))
 		return actionOK("memory created")
 	}
 }
 
+// taskSnapshot fetches the journal snapshot of a task; nil when the fetch
+// fails (the action still runs, it just can't be undone).
+func taskSnapshot(c *api.Client, id string) map[string]interface{} {
+	t, err := c.GetTask(id)
+	if err != nil {
+		return nil
+	}
+	return journal.TaskSnapshot(t)
+}
+
+func shortJournalID(id string) string {
+	if len(id) > 8 {
+		return id[:8]
+	}
+	return id
+}
+
 // recallLoadedMsg carries hybrid-recall results back to the list pane.
 type recallLoadedMsg struct {
 	term  string
@@ -94,16 +136,12 @@ type recallLoadedMsg struct {
 	err   error
 }
 
-func recallCmd(c *api.Client, term, projectID string) tea.Cmd {
+func recallCmd(c *api.Client, opts api.FindMemoriesOptions) tea.Cmd {
 	return func() tea.Msg {
-		resp, err := c.FindMemories(api.FindMemoriesOptions{
-			Term:    term,
-			Project: projectID,
-			Limit:   30,
-		})
+		resp, err := c.FindMemories(opts)
 		if err != nil {
-			return recallLoadedMsg{term: term, err: err}
+			return recallLoadedMsg{term: opts.Term, err: err}
 		}
-		return recallLoadedMsg{term: term, items: resp.Items}
+		return recallLoadedMsg{term: opts.Term, items: resp.Items}
 	}
 }
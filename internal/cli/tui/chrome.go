@@ -172,6 +172,8 @@ func categoryIcon(c Category) string {
 		return icon("cat_kanban")
 	case CatProfile:
 		return icon("cat_profile")
+	case CatSaved:
+		return icon("cat_saved")
 	case CatSearch:
 		return icon("cat_search")
 	}
@@ -6,7 +6,9 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/kutbudev/ramorie-cli/internal/api"
 	"github.com/kutbudev/ramorie-cli/internal/crypto"
+	"github.com/kutbudev/ramorie-cli/internal/kanban"
 	"github.com/kutbudev/ramorie-cli/internal/models"
+	"github.com/kutbudev/ramorie-cli/internal/searches"
 )
 
 // ---- Messages -------------------------------------------------------------
@@ -89,14 +91,18 @@ type activityLoadedMsg struct {
 	err   error
 }
 
-// kanbanLoadedMsg is fired after the three parallel ListTasks calls (one per
-// status bucket) complete.
+// savedSearchesLoadedMsg carries the searches saved with `find --save`.
+type savedSearchesLoadedMsg struct {
+	items []searches.Search
+	err   error
+}
+
+// kanbanLoadedMsg is fired after the project's board definition and its
+// tasks (one ListTasks call per status the board uses) are loaded.
 type kanbanLoadedMsg struct {
-	projectID  string
-	todo       []models.Task
-	inProgress []models.Task
-	completed  []models.Task
-	err        error
+	projectID string
+	layout    kanban.Layout
+	err       error
 }
 
 // profileLoadedMsg is fired after the 5-way parallel fan-out for the Profile
@@ -328,42 +334,27 @@ func loadActivity(c *api.Client) tea.Cmd {
 	}
 }
 
-// loadKanban fans out three parallel ListTasks calls (one per status bucket)
-// for the given project.
+// loadSavedSearches reads the local saved-search store.
+func loadSavedSearches() tea.Cmd {
+	return func() tea.Msg {
+		items, err := searches.All()
+		return savedSearchesLoadedMsg{items: items, err: err}
+	}
+}
+
+// loadKanban fetches the project's board definition, then fans out one
+// ListTasks call per status the board draws from.
 func loadKanban(c *api.Client, projectID string) tea.Cmd {
 	return func() tea.Msg {
 		if projectID == "" {
 			return kanbanLoadedMsg{projectID: "", err: nil}
 		}
-		var (
-			wg   sync.WaitGroup
-			todo []models.Task
-			ip   []models.Task
-			done []models.Task
-			err1 error
-			err2 error
-			err3 error
-		)
-		wg.Add(3)
-		go func() { defer wg.Done(); todo, err1 = c.ListTasks(projectID, "TODO") }()
-		go func() { defer wg.Done(); ip, err2 = c.ListTasks(projectID, "IN_PROGRESS") }()
-		go func() { defer wg.Done(); done, err3 = c.ListTasks(projectID, "COMPLETED") }()
-		wg.Wait()
-		// Surface the first non-nil error.
-		err := err1
-		if err == nil {
-			err = err2
-		}
-		if err == nil {
-			err = err3
-		}
-		return kanbanLoadedMsg{
-			projectID:  projectID,
-			todo:       todo,
-			inProgress: ip,
-			completed:  done,
-			err:        err,
+		board, err := kanban.BoardFor(c, projectID)
+		if err != nil {
+			return kanbanLoadedMsg{projectID: projectID, err: err}
 		}
+		layout, err := kanban.Load(c, projectID, board)
+		return kanbanLoadedMsg{projectID: projectID, layout: layout, err: err}
 	}
 }
 
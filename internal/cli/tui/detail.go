@@ -4,12 +4,15 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/kutbudev/ramorie-cli/internal/api"
 	"github.com/kutbudev/ramorie-cli/internal/cli/display"
+	"github.com/kutbudev/ramorie-cli/internal/kanban"
 	"github.com/kutbudev/ramorie-cli/internal/models"
+	"github.com/kutbudev/ramorie-cli/internal/searches"
 )
 
 // Markdown-safe glyph helpers — used in detail render functions instead of
@@ -539,8 +542,9 @@ func renderOrgDetail(
 	return strings.TrimRight(b.String(), "\n")
 }
 
-// renderActivityDetail expands a single activity feed item.
-func renderActivityDetail(item models.ActivityItem) string {
+// renderActivityDetail expands a single activity feed item, followed by a
+// per-day chart of the whole loaded feed.
+func renderActivityDetail(item models.ActivityItem, feed []models.ActivityItem, width int) string {
 	var b strings.Builder
 	fmt.Fprintf(&b, "%s `%s`\n\n", mdTypeBadge(item.EntityType), item.EntityID.String())
 	fmt.Fprintf(&b, "**Timestamp:** %s (%s)\n",
@@ -553,60 +557,84 @@ func renderActivityDetail(item models.ActivityItem) string {
 	b.WriteString("\n")
 	mdSection(&b, "Summary")
 	b.WriteString(item.Summary)
+	if labels, counts := activityPerDay(feed); len(counts) > 1 {
+		b.WriteString("\n\n")
+		mdSection(&b, "Activity per day")
+		// Fenced so glamour keeps the bars verbatim; no ANSI inside.
+		b.WriteString("```\n")
+		b.WriteString(display.BarChart(labels, counts, display.ChartOptions{Width: max(width-8, 30), NoColor: true}))
+		b.WriteString("\n```\n")
+	}
 	return b.String()
 }
 
-// renderKanbanDetail draws a 3-column board inside the detail pane.
-// Mirrors the logic of internal/cli/commands/kanban.renderBoard but writes to
-// a string and uses the pane's pixel width instead of terminal width.
-//
-// NOTE: Output is NOT markdown — caller should use setRawContent.
-func renderKanbanDetail(width int, todo, inProgress, completed []models.Task) string {
-	cols := [3]struct {
-		title string
-		tasks []models.Task
-	}{
-		{"📝 TODO", todo},
-		{"🚀 IN PROGRESS", inProgress},
-		{"✅ COMPLETED", completed},
+// renderSavedSearchDetail shows a saved search's term and options. Enter
+// on the row runs it.
+func renderSavedSearchDetail(s searches.Search) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "**@%s**\n\n", s.Name)
+	fmt.Fprintf(&b, "**Term:** %s\n", s.Term)
+	if summary := s.Summary(); summary != "" {
+		fmt.Fprintf(&b, "**Options:** %s\n", summary)
+	}
+	fmt.Fprintf(&b, "**Saved:** %s (%s)\n\n", s.UpdatedAt.Format("2006-01-02 15:04"), display.Relative(s.UpdatedAt))
+	b.WriteString("_enter runs it · `ramorie find @" + s.Name + "` on the command line_\n")
+	return b.String()
+}
+
+// activityPerDay buckets the feed by local calendar day, oldest first,
+// including empty days between the first and last event.
+func activityPerDay(feed []models.ActivityItem) ([]string, []float64) {
+	if len(feed) == 0 {
+		return nil, nil
+	}
+	day := func(t time.Time) time.Time {
+		y, m, d := t.Local().Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+	}
+	counts := map[time.Time]int{}
+	first, last := day(feed[0].Timestamp), day(feed[0].Timestamp)
+	for _, it := range feed {
+		d := day(it.Timestamp)
+		counts[d]++
+		if d.Before(first) {
+			first = d
+		}
+		if d.After(last) {
+			last = d
+		}
+	}
+	var labels []string
+	var values []float64
+	for d := first; !d.After(last); d = d.AddDate(0, 0, 1) {
+		labels = append(labels, d.Format("Mon 01-02"))
+		values = append(values, float64(counts[d]))
 	}
+	return labels, values
+}
 
+// renderKanbanDetail draws the project's board inside the detail pane using
+// the same definition and grid renderer as `ramorie kanban`, sized to the
+// pane's width instead of the terminal's.
+//
+// NOTE: Output is NOT markdown — caller should use setRawContent.
+func renderKanbanDetail(width int, layout kanban.Layout) string {
 	if width < 60 {
 		width = 60
 	}
-	colWidth := (width - 8) / 3
-	if colWidth < 18 {
-		colWidth = 18
+	if len(layout.Board.Columns) == 0 {
+		layout = kanban.Default().Place(nil)
 	}
 
 	var b strings.Builder
 	b.WriteString(display.Title.Render("🗂  Kanban"))
 	b.WriteString(" ")
-	b.WriteString(display.Dim.Render(fmt.Sprintf("%d todo · %d in progress · %d done",
-		len(todo), len(inProgress), len(completed))))
+	b.WriteString(display.Dim.Render(layout.Summary()))
 	b.WriteString("\n\n")
-
-	b.WriteString(fmt.Sprintf(" %s | %s | %s\n",
-		padRightTUI(display.Dim.Render(cols[0].title), colWidth),
-		padRightTUI(display.Dim.Render(cols[1].title), colWidth),
-		padRightTUI(display.Dim.Render(cols[2].title), colWidth)))
-	b.WriteString(fmt.Sprintf(" %s-+-%s-+-%s\n",
-		strings.Repeat("─", colWidth),
-		strings.Repeat("─", colWidth),
-		strings.Repeat("─", colWidth)))
-
-	rows := maxLenTUI(cols[0].tasks, cols[1].tasks, cols[2].tasks)
-	if rows == 0 {
-		b.WriteString(" ")
-		b.WriteString(display.Dim.Render("(no tasks in this project yet)"))
-		return b.String()
-	}
-	for i := 0; i < rows; i++ {
-		b.WriteString(fmt.Sprintf(" %s | %s | %s\n",
-			padRightTUI(kanbanCell(cols[0].tasks, i, colWidth), colWidth),
-			padRightTUI(kanbanCell(cols[1].tasks, i, colWidth), colWidth),
-			padRightTUI(kanbanCell(cols[2].tasks, i, colWidth), colWidth)))
-	}
+	b.WriteString(kanban.Render(layout, width, func(t *models.Task) string {
+		title, _ := decryptTask(t)
+		return title
+	}))
 	return strings.TrimRight(b.String(), "\n")
 }
 
@@ -866,35 +894,7 @@ func formatCountMap(m map[string]int) string {
 	return "{" + strings.Join(parts, ", ") + "}"
 }
 
-// kanbanCell formats one cell in the kanban grid.
-func kanbanCell(tasks []models.Task, i, width int) string {
-	if i >= len(tasks) {
-		return ""
-	}
-	t := tasks[i]
-	title, _ := decryptTask(&t)
-	title = display.SingleLine(title)
-	titleBudget := width - 13
-	if titleBudget < 8 {
-		titleBudget = 8
-	}
-	return fmt.Sprintf("%s %s %s",
-		display.PriorityBadge(t.Priority),
-		display.Dim.Render(t.ID.String()[:8]),
-		display.Truncate(title, titleBudget))
-}
-
-// padRightTUI pads to visible width. Local copy to avoid pulling in the
-// commands package (which would create a tui→commands→tui import cycle once
-// the kanban command is updated to share helpers).
-func padRightTUI(s string, n int) string {
-	visible := stripANSITUI(s)
-	if len(visible) >= n {
-		return s
-	}
-	return s + strings.Repeat(" ", n-len(visible))
-}
-
+// stripANSITUI removes ANSI escapes so tests can compare visible text.
 func stripANSITUI(s string) string {
 	var b strings.Builder
 	inEsc := false
@@ -910,14 +910,3 @@ func stripANSITUI(s string) string {
 	}
 	return b.String()
 }
-
-func maxLenTUI(a, b, c []models.Task) int {
-	m := len(a)
-	if len(b) > m {
-		m = len(b)
-	}
-	if len(c) > m {
-		m = len(c)
-	}
-	return m
-}
@@ -41,6 +41,7 @@ var glyphs = map[string]glyph{
 	"cat_kanban":   {nf(0xf009), "▦"}, // th-large
 	"cat_profile":  {nf(0xf007), "⊙"}, // user
 	"cat_search":   {nf(0xf002), "⌕"}, // search
+	"cat_saved":    {nf(0xf02e), "⚑"}, // bookmark
 
 	// Memory / activity types (plain fallback is the bracketed label).
 	"type_decision":   {nf(0xf0e3), "[decision]"},   // gavel
@@ -31,7 +31,7 @@ func helpOverlay(_ keyMap, width, height int) string {
 		{"t", "theme"}, {"A", "accent"}, {"I", "icons"},
 	}}
 	global := group{"Global", []kv{
-		{"1-6", "jump category"}, {"?", "help"}, {"q", "quit"},
+		{"1-7", "jump category"}, {"?", "help"}, {"q", "quit"},
 	}}
 
 	renderGroup := func(g group) string {
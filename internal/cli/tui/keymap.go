@@ -38,6 +38,7 @@ type keyMap struct {
 	Cat4        key.Binding
 	Cat5        key.Binding
 	Cat6        key.Binding
+	Cat7        key.Binding
 }
 
 func defaultKeyMap() keyMap {
@@ -75,5 +76,6 @@ func defaultKeyMap() keyMap {
 		Cat4:        key.NewBinding(key.WithKeys("4"), key.WithHelp("4", "orgs")),
 		Cat5:        key.NewBinding(key.WithKeys("5"), key.WithHelp("5", "activity")),
 		Cat6:        key.NewBinding(key.WithKeys("6"), key.WithHelp("6", "profile")),
+		Cat7:        key.NewBinding(key.WithKeys("7"), key.WithHelp("7", "saved")),
 	}
 }
@@ -10,7 +10,9 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/kutbudev/ramorie-cli/internal/api"
 	"github.com/kutbudev/ramorie-cli/internal/cli/display"
+	"github.com/kutbudev/ramorie-cli/internal/kanban"
 	"github.com/kutbudev/ramorie-cli/internal/models"
+	"github.com/kutbudev/ramorie-cli/internal/searches"
 )
 
 // listItem wraps any entity for bubbles/list. The row is laid out by
@@ -377,6 +379,30 @@ func (l *listModel) setActivity(events []models.ActivityItem) {
 	l.applyItems(items)
 }
 
+// setSavedSearches renders one row per saved search: its name in the ID
+// column and the term as the title. Enter runs it.
+func (l *listModel) setSavedSearches(saved []searches.Search) {
+	rows := make([]list.Item, 0, len(saved))
+	for i := range saved {
+		s := saved[i]
+		badge, st := typeBadgeParts("saved")
+		rows = append(rows, listItem{
+			id:         s.Name,
+			title:      display.SingleLine(s.Term),
+			badge:      badge,
+			badgeStyle: st,
+			rel:        display.Relative(s.UpdatedAt),
+			filter:     s.Name + " " + s.Term,
+			raw:        s,
+		})
+	}
+	if len(rows) == 0 {
+		l.setPlaceholder("no saved searches — ramorie find <term> --save <name>")
+		return
+	}
+	l.applyItems(rows)
+}
+
 // setSearchResults renders hybrid-recall hits. Each row's badge is the hit
 // type (memory/task/decision/…) and the raw entity is the api.FindItem so the
 // detail pane can dispatch by type on selection.
@@ -414,16 +440,20 @@ func itemKind(it api.FindItem) string {
 	return it.Type
 }
 
-// setKanbanSummary renders three compact rows showing bucket counts. The
-// detail pane carries the actual three-column board.
-func (l *listModel) setKanbanSummary(todo, inProgress, completed int) {
-	items := []list.Item{
-		listItem{id: "kanban-todo", title: fmt.Sprintf("%s  %d tasks",
-			display.Dim.Render("📝 TODO        "), todo)},
-		listItem{id: "kanban-ip", title: fmt.Sprintf("%s  %d tasks",
-			display.Warn.Render("🚀 IN PROGRESS "), inProgress)},
-		listItem{id: "kanban-done", title: fmt.Sprintf("%s  %d tasks",
-			display.Good.Render("✅ COMPLETED   "), completed)},
+// setKanbanSummary renders one compact row per board column showing its
+// count (and WIP limit). The detail pane carries the actual board.
+func (l *listModel) setKanbanSummary(layout kanban.Layout) {
+	items := make([]list.Item, 0, len(layout.Board.Columns))
+	for i, col := range layout.Board.Columns {
+		label := display.Dim.Render(col.Title)
+		count := fmt.Sprintf("%d tasks", layout.Counts[i])
+		if col.WIP > 0 {
+			count = fmt.Sprintf("%d/%d tasks", layout.Counts[i], col.WIP)
+		}
+		if layout.OverLimit(i) {
+			label = display.Err.Render("⚠ " + col.Title)
+		}
+		items = append(items, listItem{id: fmt.Sprintf("kanban-%d", i), title: label + "  " + count})
 	}
 	l.applyItems(items)
 }
@@ -12,7 +12,9 @@ import (
 	"github.com/kutbudev/ramorie-cli/internal/api"
 	"github.com/kutbudev/ramorie-cli/internal/cli/display"
 	"github.com/kutbudev/ramorie-cli/internal/config"
+	"github.com/kutbudev/ramorie-cli/internal/kanban"
 	"github.com/kutbudev/ramorie-cli/internal/models"
+	"github.com/kutbudev/ramorie-cli/internal/searches"
 )
 
 // clearStatusMsg fires after a delay to wipe the transient status message
@@ -76,11 +78,12 @@ type rootModel struct {
 	// fetches when the cursor doesn't move to a new item.
 	lastSelectedID string
 
-	// kanban buckets cached so the detail view can re-render without a
-	// re-fetch when geometry changes.
-	kanbanTodo       []models.Task
-	kanbanInProgress []models.Task
-	kanbanCompleted  []models.Task
+	// activity feed cached for the per-day chart in the detail pane.
+	activityFeed []models.ActivityItem
+
+	// kanban board (definition + placed tasks) cached so the detail view
+	// can re-render without a re-fetch when geometry changes.
+	kanbanLayout kanban.Layout
 
 	// profile bundle cached.
 	profile *profileLoadedMsg
@@ -112,12 +115,16 @@ type rootModel struct {
 	caps          terminalCaps
 
 	// Interactive overlay state (create / recall prompt, delete confirm).
-	overlay        overlayKind
-	input          textinput.Model
-	promptIntent   promptIntent
-	confirmVerb    string  // e.g. "Delete task abc123?"
-	confirmCmd     tea.Cmd // action to run if the user confirms
-	lastRecallTerm string  // last recall query, so post-action refresh re-runs it
+	overlay      overlayKind
+	input        textinput.Model
+	promptIntent promptIntent
+	confirmVerb  string                   // e.g. "Delete task abc123?"
+	confirmCmd   tea.Cmd                  // action to run if the user confirms
+	lastRecall   *api.FindMemoriesOptions // last recall query, so post-action refresh re-runs it
+
+	// initialRecall, when set, replaces the first category load with a
+	// recall frame (RunOptions.Recall).
+	initialRecall *Recall
 
 	// Resize debounce: every WindowSizeMsg bumps resizeGen and schedules a
 	// resizeSettleMsg with that generation. Only the matching settle fires
@@ -282,10 +289,46 @@ func (m *rootModel) loadForCategory() tea.Cmd {
 	case CatProfile:
 		m.list.setProfileMode()
 		return loadProfile(m.client)
+	case CatSaved:
+		return loadSavedSearches()
 	}
 	return nil
 }
 
+// openRecall shows a set of find hits in a recall frame on top of the
+// sidebar's category. The category itself is loaded once the frame is
+// popped.
+func (m *rootModel) openRecall(r Recall) tea.Cmd {
+	cat := m.sidebar.selected()
+	m.list.resetStack(cat, cat.Label())
+	m.loadedCat = -1
+	m.list.pushFrame(CatSearch, "Search: "+display.Truncate(r.Options.Term, 24), "")
+	opts := r.Options
+	m.lastRecall = &opts
+	m.list.setSearchResults(r.Items)
+	for i, it := range m.list.list.Items() {
+		if li, ok := it.(listItem); ok && li.id == r.SelectID {
+			m.list.list.Select(i)
+			break
+		}
+	}
+	m.lastSelectedID = ""
+	m.focus = paneList
+	m.applyFocus()
+	return m.loadDetailForSelection()
+}
+
+// popListFrame leaves a drill-down frame. A base category that was never
+// loaded (the TUI opened on a recall frame) is loaded now.
+func (m *rootModel) popListFrame() tea.Cmd {
+	m.list.popFrame()
+	m.lastSelectedID = ""
+	if m.list.depth() == 1 && m.loadedCat != m.sidebar.selected() {
+		return m.loadForCategory()
+	}
+	return m.loadDetailForSelection()
+}
+
 // maybeFetchNextPage triggers an append-mode fetch when the cursor reaches
 // the bottom guard band of a paginated list AND another page is available.
 // Returns nil when no fetch should happen (kept simple — only Tasks and
@@ -346,18 +389,22 @@ func (m *rootModel) loadDetailForSelection() tea.Cmd {
 		if item, ok := sel.raw.(models.ActivityItem); ok {
 			it := item
 			m.yankActivity = &it
-			return m.detail.setContent(renderActivityDetail(item))
+			return m.detail.setContent(renderActivityDetail(item, m.activityFeed, m.detail.width))
 		}
 		return nil
 	case CatKanban:
 		// Always render the same board regardless of which row is selected.
-		m.detail.setRawContent(renderKanbanDetail(
-			m.detail.width, m.kanbanTodo, m.kanbanInProgress, m.kanbanCompleted,
-		))
+		m.detail.setRawContent(renderKanbanDetail(m.detail.width, m.kanbanLayout))
 		return nil
 	case CatProfile:
 		// Already populated by profileLoadedMsg.
 		return nil
+	case CatSaved:
+		// Inline render — the definition is already loaded.
+		if s, ok := sel.raw.(searches.Search); ok {
+			return m.detail.setContent(renderSavedSearchDetail(s))
+		}
+		return nil
 	case CatSearch:
 		// Recall hit — dispatch the detail fetch by the hit's entity type.
 		if it, ok := sel.raw.(api.FindItem); ok {
@@ -391,6 +438,19 @@ func (m *rootModel) handleEnterOnList() (bool, tea.Cmd) {
 			m.lastSelectedID = ""
 			return true, loadProjects(m.client, org.ID)
 		}
+	case CatSaved:
+		// Run the saved search in a pushed recall frame.
+		if s, ok := sel.raw.(searches.Search); ok {
+			opts := s.Options()
+			if opts.Project == "" {
+				opts.Project = m.projectID
+			}
+			m.list.pushFrame(CatSearch, "@"+display.Truncate(s.Name, 24), "")
+			m.lastSelectedID = ""
+			m.lastRecall = &opts
+			m.statusMsg = "searching…"
+			return true, recallCmd(m.client, opts)
+		}
 	case CatProjects:
 		// Projects sidebar shows project settings only — no task/memory drill.
 		// To browse a project's content, the user switches to Tasks or
@@ -411,15 +471,16 @@ func (m rootModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Re-render kanban board on resize so its column widths follow.
 		// (Cheap — string formatting only, no glamour.)
 		if m.list.cat == CatKanban && m.detail.content != "" {
-			m.detail.setRawContent(renderKanbanDetail(
-				m.detail.width, m.kanbanTodo, m.kanbanInProgress, m.kanbanCompleted,
-			))
+			m.detail.setRawContent(renderKanbanDetail(m.detail.width, m.kanbanLayout))
 		}
 		if first {
 			// First sized frame: kick the initial categorical load AND
 			// schedule an initial reflow tick (in case lastContent
 			// arrives before the next resize).
 			m.resizeGen++
+			if m.initialRecall != nil {
+				return m, tea.Batch(m.openRecall(*m.initialRecall), resizeDebounce(m.resizeGen))
+			}
 			return m, tea.Batch(m.loadForCategory(), resizeDebounce(m.resizeGen))
 		}
 		// Debounce the heavy markdown reflow until the resize burst settles.
@@ -540,6 +601,8 @@ func (m rootModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, m.activateCategoryIndex(4)
 		case key.Matches(msg, m.keys.Cat6):
 			return m, m.activateCategoryIndex(5)
+		case key.Matches(msg, m.keys.Cat7):
+			return m, m.activateCategoryIndex(6)
 
 		case key.Matches(msg, m.keys.Tab):
 			// Cycle focus forward.
@@ -558,9 +621,7 @@ func (m rootModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case key.Matches(msg, m.keys.PrevTab):
 			if m.focus == paneList && m.list.depth() > 1 {
-				m.list.popFrame()
-				m.lastSelectedID = ""
-				return m, m.loadDetailForSelection()
+				return m, m.popListFrame()
 			}
 			m.focus = (m.focus + 2) % 3
 			m.applyFocus()
@@ -601,9 +662,7 @@ func (m rootModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case key.Matches(msg, m.keys.Back):
 			// Esc: pop drill-down frame first if we're in one.
 			if m.focus == paneList && m.list.depth() > 1 {
-				m.list.popFrame()
-				m.lastSelectedID = ""
-				return m, m.loadDetailForSelection()
+				return m, m.popListFrame()
 			}
 			if m.focus > paneSidebar {
 				m.focus--
@@ -640,9 +699,7 @@ func (m rootModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case paneList:
 			if key.Matches(msg, m.keys.Left) {
 				if m.list.depth() > 1 {
-					m.list.popFrame()
-					m.lastSelectedID = ""
-					return m, m.loadDetailForSelection()
+					return m, m.popListFrame()
 				}
 				m.focus = paneSidebar
 				m.applyFocus()
@@ -738,11 +795,20 @@ func (m rootModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.list.setOrgs(msg.items)
 		return m, m.loadDetailForSelection()
 
+	case savedSearchesLoadedMsg:
+		if msg.err != nil {
+			m.list.setError(msg.err)
+			return m, nil
+		}
+		m.list.setSavedSearches(msg.items)
+		return m, m.loadDetailForSelection()
+
 	case activityLoadedMsg:
 		if msg.err != nil {
 			m.list.setError(msg.err)
 			return m, nil
 		}
+		m.activityFeed = msg.items
 		m.list.setActivity(msg.items)
 		return m, m.loadDetailForSelection()
 
@@ -751,13 +817,9 @@ func (m rootModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.list.setError(msg.err)
 			return m, nil
 		}
-		m.kanbanTodo = msg.todo
-		m.kanbanInProgress = msg.inProgress
-		m.kanbanCompleted = msg.completed
-		m.list.setKanbanSummary(len(msg.todo), len(msg.inProgress), len(msg.completed))
-		m.detail.setRawContent(renderKanbanDetail(
-			m.detail.width, m.kanbanTodo, m.kanbanInProgress, m.kanbanCompleted,
-		))
+		m.kanbanLayout = msg.layout
+		m.list.setKanbanSummary(msg.layout)
+		m.detail.setRawContent(renderKanbanDetail(m.detail.width, m.kanbanLayout))
 		return m, nil
 
 	case profileLoadedMsg:
@@ -850,10 +912,10 @@ func (m rootModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds := []tea.Cmd{clearStatusAfter(3 * time.Second)}
 		if msg.refresh {
 			m.lastSelectedID = ""
-			if m.list.cat == CatSearch && m.lastRecallTerm != "" {
+			if m.list.cat == CatSearch && m.lastRecall != nil {
 				// Stay in the recall frame: re-run the query instead of
 				// resetting the stack back to a base category.
-				cmds = append(cmds, recallCmd(m.client, m.lastRecallTerm, m.projectID))
+				cmds = append(cmds, recallCmd(m.client, *m.lastRecall))
 			} else {
 				cmds = append(cmds, m.loadForCategory())
 			}
@@ -982,7 +1044,7 @@ func (m rootModel) footerHints() []string {
 	case paneSidebar:
 		return []string{
 			keyHint("↑↓", "choose"), keyHint("↵", "open"),
-			keyHint("1-6", "jump"), keyHint("s", "recall"),
+			keyHint("1-7", "jump"), keyHint("s", "recall"),
 			keyHint("?", "help"), keyHint("q", "quit"),
 		}
 	case paneList:
@@ -1038,6 +1100,8 @@ func detailTitleFor(c Category) string {
 		return "Kanban"
 	case CatProfile:
 		return "Profile"
+	case CatSaved:
+		return "Saved search"
 	}
 	return "Detail"
 }
@@ -1127,9 +1191,9 @@ func (m rootModel) submitPrompt() (tea.Model, tea.Cmd) {
 		m.applyFocus()
 		m.list.pushFrame(CatSearch, "Search: "+display.Truncate(val, 24), "")
 		m.lastSelectedID = ""
-		m.lastRecallTerm = val
+		m.lastRecall = &api.FindMemoriesOptions{Term: val, Project: m.projectID, Limit: 30}
 		m.statusMsg = "searching…"
-		return m, recallCmd(m.client, val, m.projectID)
+		return m, recallCmd(m.client, *m.lastRecall)
 	case promptCreateTask:
 		m.statusMsg = "creating task…"
 		return m, createTaskCmd(m.client, m.projectID, val)
@@ -0,0 +1,442 @@
+// Package tui — picker.go: the `ramorie find --pick` hit picker. A single
+// fuzzy-filtered list with an optional preview pane, built from the same
+// panes, badges and footer as the 3-pane navigator.
+package tui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/kutbudev/ramorie-cli/internal/api"
+	"github.com/kutbudev/ramorie-cli/internal/cli/display"
+	"github.com/kutbudev/ramorie-cli/internal/config"
+	"golang.org/x/term"
+)
+
+// PickAction is how the picker was left.
+type PickAction int
+
+const (
+	PickCancel PickAction = iota // esc / ctrl+c — nothing picked
+	PickAccept                   // enter — print the picked hits
+	PickOpen                     // ctrl+o — open the hit in the TUI
+)
+
+// PickOptions configures Pick.
+type PickOptions struct {
+	Term   string      // shown in the title
+	Client *api.Client // fetches full bodies for preview and copy; nil = previews only
+	Output io.Writer   // where the picker draws; nil = stdout
+}
+
+// PickResult is the outcome of Pick. Items holds the marked hits in result
+// order, or the hit under the cursor when none were marked. For PickOpen,
+// Cursor is the hit to open.
+type PickResult struct {
+	Action PickAction
+	Items  []api.FindItem
+	Cursor string
+}
+
+// Pick lets the user choose among find hits. Blocks until the picker is
+// left.
+func Pick(items []api.FindItem, opts PickOptions) (PickResult, error) {
+	cfg, _ := config.LoadConfig()
+	pal := resolveAccent(configAccent(cfg))
+	display.SetAccent(pal.Accent, pal.Bright)
+	setNerdFont(resolveNerdFont("", cfg))
+
+	out := opts.Output
+	if out == nil {
+		out = os.Stdout
+	}
+	progOpts := []tea.ProgramOption{tea.WithAltScreen(), tea.WithOutput(out)}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		progOpts = append(progOpts, tea.WithInputTTY())
+	}
+	p := tea.NewProgram(newPicker(items, opts), progOpts...)
+	final, err := p.Run()
+	if err != nil {
+		return PickResult{}, fmt.Errorf("picker: %w", err)
+	}
+	return final.(pickerModel).result, nil
+}
+
+// pickerModel is the picker's Bubble Tea model.
+type pickerModel struct {
+	client  *api.Client
+	term    string
+	all     []api.FindItem
+	targets []string // fuzzy-match text per hit
+	visible []int    // indexes into all, best match first
+	cursor  int      // index into visible
+	offset  int      // first visible row drawn
+	marked  map[string]bool
+	input   textinput.Model
+	preview bool
+	bodies  map[string]string // full bodies by hit ID, fetched lazily
+	status  string
+	width   int
+	height  int
+	result  PickResult
+}
+
+// pickBodyMsg carries a hit's full body.
+type pickBodyMsg struct {
+	id   string
+	body string
+	err  error
+}
+
+// pickCopiedMsg reports a clipboard write.
+type pickCopiedMsg struct {
+	chars int
+	err   error
+}
+
+func newPicker(items []api.FindItem, opts PickOptions) pickerModel {
+	ti := textinput.New()
+	ti.Prompt = "› "
+	ti.Placeholder = "type to filter…"
+	ti.CharLimit = 256
+	ti.Focus()
+	m := pickerModel{
+		client:  opts.Client,
+		term:    opts.Term,
+		all:     items,
+		marked:  map[string]bool{},
+		input:   ti,
+		preview: true,
+		bodies:  map[string]string{},
+	}
+	for _, it := range items {
+		m.targets = append(m.targets, strings.Join([]string{it.Title, it.Preview, itemKind(it), it.Project, it.ID}, " "))
+	}
+	m.refilter()
+	return m
+}
+
+// refilter recomputes the visible hits from the filter text. An empty filter
+// keeps the search's ranking.
+func (m *pickerModel) refilter() {
+	q := strings.TrimSpace(m.input.Value())
+	m.visible = m.visible[:0]
+	if q == "" {
+		for i := range m.all {
+			m.visible = append(m.visible, i)
+		}
+	} else {
+		for _, r := range list.DefaultFilter(q, m.targets) {
+			m.visible = append(m.visible, r.Index)
+		}
+	}
+	m.cursor, m.offset = 0, 0
+}
+
+func (m pickerModel) current() (api.FindItem, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.visible) {
+		return api.FindItem{}, false
+	}
+	return m.all[m.visible[m.cursor]], true
+}
+
+func (m pickerModel) Init() tea.Cmd {
+	return tea.Batch(textinput.Blink, m.fetchCurrent())
+}
+
+func (m pickerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		return m, nil
+
+	case pickBodyMsg:
+		if msg.err == nil {
+			m.bodies[msg.id] = msg.body
+		}
+		return m, nil
+
+	case pickCopiedMsg:
+		if msg.err != nil {
+			m.status = "✗ " + msg.err.Error()
+		} else {
+			m.status = fmt.Sprintf("✓ copied %d chars", msg.chars)
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		m.status = ""
+		switch msg.String() {
+		case "ctrl+c", "esc":
+			m.result = PickResult{Action: PickCancel}
+			return m, tea.Quit
+		case "enter":
+			picked := m.picked()
+			if len(picked) == 0 {
+				return m, nil
+			}
+			m.result = PickResult{Action: PickAccept, Items: picked}
+			return m, tea.Quit
+		case "ctrl+o":
+			it, ok := m.current()
+			if !ok {
+				return m, nil
+			}
+			m.result = PickResult{Action: PickOpen, Items: m.picked(), Cursor: it.ID}
+			return m, tea.Quit
+		case "tab":
+			if it, ok := m.current(); ok {
+				m.marked[it.ID] = !m.marked[it.ID]
+				if !m.marked[it.ID] {
+					delete(m.marked, it.ID)
+				}
+			}
+			return m, m.move(1)
+		case "up", "ctrl+k":
+			return m, m.move(-1)
+		case "down", "ctrl+j":
+			return m, m.move(1)
+		case "pgup":
+			return m, m.move(-m.listRows())
+		case "pgdown":
+			return m, m.move(m.listRows())
+		case "ctrl+t":
+			m.preview = !m.preview
+			return m, m.fetchCurrent()
+		case "ctrl+y":
+			it, ok := m.current()
+			if !ok {
+				return m, nil
+			}
+			return m, m.copyBody(it)
+		}
+		before := m.input.Value()
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		if m.input.Value() != before {
+			m.refilter()
+			return m, tea.Batch(cmd, m.fetchCurrent())
+		}
+		return m, cmd
+	}
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// picked returns the marked hits in result order, or the hit under the
+// cursor when nothing is marked.
+func (m pickerModel) picked() []api.FindItem {
+	var out []api.FindItem
+	for _, it := range m.all {
+		if m.marked[it.ID] {
+			out = append(out, it)
+		}
+	}
+	if len(out) == 0 {
+		if it, ok := m.current(); ok {
+			out = append(out, it)
+		}
+	}
+	return out
+}
+
+func (m *pickerModel) move(delta int) tea.Cmd {
+	if len(m.visible) == 0 {
+		return nil
+	}
+	m.cursor = min(max(m.cursor+delta, 0), len(m.visible)-1)
+	rows := m.listRows()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	} else if m.cursor >= m.offset+rows {
+		m.offset = m.cursor - rows + 1
+	}
+	return m.fetchCurrent()
+}
+
+// fetchCurrent loads the full body of the hit under the cursor for the
+// preview pane, once per hit.
+func (m pickerModel) fetchCurrent() tea.Cmd {
+	it, ok := m.current()
+	if !ok || !m.preview || m.client == nil {
+		return nil
+	}
+	if _, done := m.bodies[it.ID]; done {
+		return nil
+	}
+	c := m.client
+	return func() tea.Msg {
+		body, err := hitBody(c, it)
+		return pickBodyMsg{id: it.ID, body: body, err: err}
+	}
+}
+
+// copyBody writes a hit's full body to the clipboard.
+func (m pickerModel) copyBody(it api.FindItem) tea.Cmd {
+	body, ok := m.bodies[it.ID]
+	c := m.client
+	return func() tea.Msg {
+		if !ok {
+			var err error
+			if body, err = hitBody(c, it); err != nil {
+				return pickCopiedMsg{err: err}
+			}
+		}
+		if err := clipboard.WriteAll(body); err != nil {
+			return pickCopiedMsg{err: fmt.Errorf("clipboard not available — install xclip/xsel/pbcopy: %w", err)}
+		}
+		return pickCopiedMsg{chars: len(body)}
+	}
+}
+
+// hitBody fetches and decrypts a hit's full text. Without a client the
+// search preview stands in.
+func hitBody(c *api.Client, it api.FindItem) (string, error) {
+	if c == nil {
+		return it.Preview, nil
+	}
+	if strings.EqualFold(it.Type, "task") {
+		t, err := c.GetTask(it.ID)
+		if err != nil {
+			return "", err
+		}
+		title, desc := decryptTask(t)
+		return strings.TrimSpace(title + "\n\n" + desc), nil
+	}
+	mem, err := c.GetMemory(it.ID)
+	if err != nil {
+		return "", err
+	}
+	return decryptMemoryContent(mem), nil
+}
+
+// listRows is the number of hit rows that fit: the pane minus its borders
+// and the filter line.
+func (m pickerModel) listRows() int {
+	return maxInt(m.height-1-2-1, 1)
+}
+
+func (m pickerModel) View() string {
+	if m.width == 0 {
+		return "loading…"
+	}
+	h := maxInt(m.height-1, 3) // footer row
+	listW := m.width
+	previewW := 0
+	if m.preview && m.width >= 80 {
+		listW = m.width * 2 / 5
+		previewW = m.width - listW
+	}
+
+	title := "Find"
+	if m.term != "" {
+		title = "Find: " + m.term
+	}
+	count := fmt.Sprintf("%d/%d", len(m.visible), len(m.all))
+	if len(m.marked) > 0 {
+		count += fmt.Sprintf(" · %d marked", len(m.marked))
+	}
+	panes := titledPane(title, count, m.listView(maxInt(listW-2, 1)), listW, h, true)
+	if previewW > 0 {
+		panes = lipgloss.JoinHorizontal(lipgloss.Top, panes,
+			titledPane("Preview", "", m.previewView(maxInt(previewW-4, 1), h-2), previewW, h, false))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, panes, m.footer())
+}
+
+func (m pickerModel) listView(innerW int) string {
+	m.input.Width = maxInt(innerW-3, 1)
+	lines := []string{m.input.View()}
+	rows := m.listRows()
+	for i := m.offset; i < len(m.visible) && i < m.offset+rows; i++ {
+		lines = append(lines, m.rowView(m.all[m.visible[i]], i == m.cursor, innerW))
+	}
+	if len(m.visible) == 0 {
+		lines = append(lines, display.Dim.Render(" (no matches)"))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// rowView lays a hit out like the navigator's list rows: mark, badge, short
+// ID, title. The cursor row gets the selection bar, built from plain text.
+func (m pickerModel) rowView(it api.FindItem, selected bool, innerW int) string {
+	mark := " "
+	if m.marked[it.ID] {
+		mark = "●"
+	}
+	badge, st := typeBadgeParts(itemKind(it))
+	title := display.SingleLine(it.Title)
+	if title == "" {
+		title = display.SingleLine(it.Preview)
+	}
+	idStr := shortID(it.ID)
+	used := 2 + lipgloss.Width(badge) + 1 + len(idStr) + 2
+	title = display.Truncate(title, maxInt(innerW-used-1, 1))
+	if selected {
+		plain := mark + " " + badge + " " + idStr + "  " + title
+		return display.SelRowStyle.Width(innerW).MaxWidth(innerW).Render(plain)
+	}
+	line := display.Warn.Render(mark) + " " + st.Render(badge) + " " + display.Dim.Render(idStr) + "  " + title
+	return lipgloss.NewStyle().Width(innerW).MaxWidth(innerW).Render(line)
+}
+
+func (m pickerModel) previewView(innerW, innerH int) string {
+	it, ok := m.current()
+	if !ok {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render(display.Truncate(display.SingleLine(it.Title), innerW)))
+	b.WriteString("\n")
+	meta := []string{itemKind(it), it.ID}
+	if it.Project != "" {
+		meta = append(meta, it.Project)
+	}
+	meta = append(meta, fmt.Sprintf("score %.2f", it.Score), display.Relative(it.CreatedAt))
+	b.WriteString(display.Dim.Render(display.Truncate(strings.Join(meta, " · "), innerW)))
+	b.WriteString("\n")
+	if it.Stale {
+		reason := "possibly stale"
+		if it.StaleReason != "" {
+			reason += ": " + it.StaleReason
+		}
+		b.WriteString(display.Warn.Render(display.Truncate("⚠ "+reason, innerW)))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	body, ok := m.bodies[it.ID]
+	if !ok {
+		body = it.Preview
+	}
+	wrapped := lipgloss.NewStyle().Width(innerW).Render(body)
+	lines := strings.Split(b.String()+wrapped, "\n")
+	if len(lines) > innerH {
+		lines = lines[:innerH]
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m pickerModel) footer() string {
+	hints := []string{
+		keyHint("↑↓", "move"), keyHint("tab", "mark"),
+		keyHintAccent("↵", "pick", display.ColorInfo),
+		keyHint("ctrl+t", "preview"), keyHint("ctrl+y", "copy"), keyHint("ctrl+o", "open in ui"),
+		keyHint("esc", "cancel"),
+	}
+	status := ""
+	if m.status != "" {
+		status = lipgloss.NewStyle().
+			Foreground(display.ColorGood).
+			Background(display.ColorFooterBg).
+			Render(m.status)
+	}
+	return renderFooter(maxInt(m.width, 1), display.FooterSeg.Render("pick"), hints, status)
+}
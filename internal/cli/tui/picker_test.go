@@ -0,0 +1,60 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/kutbudev/ramorie-cli/internal/api"
+)
+
+func pickerKeys(m pickerModel, keys ...tea.KeyMsg) pickerModel {
+	for _, k := range keys {
+		next, _ := m.Update(k)
+		m = next.(pickerModel)
+	}
+	return m
+}
+
+func TestPickerFiltersMarksAndAccepts(t *testing.T) {
+	items := []api.FindItem{
+		{ID: "a", Type: "memory", Title: "deploy runbook"},
+		{ID: "b", Type: "memory", Title: "postgres decisions"},
+		{ID: "c", Type: "task", Title: "deploy to railway"},
+	}
+	m := newPicker(items, PickOptions{Term: "deploy"})
+	m.width, m.height = 100, 20
+
+	m = pickerKeys(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("dep")})
+	if len(m.visible) != 2 {
+		t.Fatalf("filter kept %d hits, want 2", len(m.visible))
+	}
+
+	tab := tea.KeyMsg{Type: tea.KeyTab}
+	m = pickerKeys(m, tab, tab)
+	if len(m.marked) != 2 {
+		t.Fatalf("marked = %v", m.marked)
+	}
+
+	m = pickerKeys(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.result.Action != PickAccept || len(m.result.Items) != 2 || m.result.Items[0].ID != "a" || m.result.Items[1].ID != "c" {
+		t.Fatalf("result = %+v", m.result)
+	}
+	if m.View() == "" {
+		t.Fatal("empty view")
+	}
+}
+
+func TestPickerEnterTakesCursorWithoutMarks(t *testing.T) {
+	items := []api.FindItem{{ID: "a", Title: "one"}, {ID: "b", Title: "two"}}
+	m := newPicker(items, PickOptions{})
+	m = pickerKeys(m, tea.KeyMsg{Type: tea.KeyDown}, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.result.Action != PickAccept || len(m.result.Items) != 1 || m.result.Items[0].ID != "b" {
+		t.Fatalf("result = %+v", m.result)
+	}
+
+	m = newPicker(items, PickOptions{})
+	m = pickerKeys(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.result.Action != PickCancel {
+		t.Fatalf("esc result = %+v", m.result)
+	}
+}
@@ -18,6 +18,7 @@ const (
 	CatActivity
 	CatKanban
 	CatProfile
+	CatSaved  // saved searches (`ramorie find --save`)
 	CatSearch // recall results — never shown in the sidebar; pushed as a frame
 )
 
@@ -38,6 +39,8 @@ func (c Category) Label() string {
 		return "Kanban"
 	case CatProfile:
 		return "Profile"
+	case CatSaved:
+		return "Saved"
 	case CatSearch:
 		return "Search"
 	}
@@ -50,7 +53,7 @@ func (c Category) Label() string {
 // keep, easy to re-enable) but no longer appear in the sidebar.
 var allCategories = []Category{
 	CatTasks, CatMemories, CatProjects, CatOrganizations,
-	CatActivity, CatProfile,
+	CatActivity, CatProfile, CatSaved,
 }
 
 // sidebarModel holds focus on a category list. Pure rendering — input dispatch
@@ -18,6 +18,17 @@ import (
 type RunOptions struct {
 	Accent string // "auto"|"brand"|ANSI index|hex; "" => config/auto
 	Icons  string // "nerd"|"unicode"|"auto"; "" => env/config/off
+
+	// Recall opens the TUI on a recall frame holding these hits instead of
+	// the Tasks list (`find --pick`, ctrl+o). Esc goes back to Tasks.
+	Recall *Recall
+}
+
+// Recall is a set of find hits to open the TUI on.
+type Recall struct {
+	Options  api.FindMemoriesOptions // the query, re-run on refresh
+	Items    []api.FindItem
+	SelectID string // hit to put the cursor on
 }
 
 // Run starts the TUI. Blocks until the user quits.
@@ -38,6 +49,7 @@ func Run(opts RunOptions) error {
 	m := newRootModel(client)
 	m.accentSpec = accentSpec
 	m.accentGlamour = pal.Glamour
+	m.initialRecall = opts.Recall
 
 	p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion())
 	if _, err := p.Run(); err != nil {
@@ -1,9 +1,13 @@
 package config
 
 import (
+	"context"
 	"encoding/json"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strings"
+	"time"
 )
 
 const (
@@ -38,6 +42,49 @@ type Config struct {
 	// auto-detect the project when -p is omitted, so users rarely have to
 	// remember or retype project identifiers.
 	LastProjectID string `json:"last_project_id,omitempty"`
+
+	// ActiveTaskID is the task last started from this machine, so switching
+	// branches can stop it before starting the next one. The server tracks
+	// the active task too but offers no way to read it back.
+	ActiveTaskID string `json:"active_task_id,omitempty"`
+	// BranchPattern is the regexp `task from-branch` applies to branch names.
+	// Named groups `id` (task short ID) and `title` (fuzzy title) are used;
+	// empty means the built-in default. `git config ramorie.branchPattern`
+	// overrides it per repository.
+	BranchPattern string `json:"branch_pattern,omitempty"`
+}
+
+// LoadActiveTask returns the remembered active task UUID, or "" if none.
+func LoadActiveTask() string {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return ""
+	}
+	return cfg.ActiveTaskID
+}
+
+// RememberActiveTask persists taskID as the active task (best effort). An
+// empty taskID clears it.
+func RememberActiveTask(taskID string) {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return
+	}
+	if cfg.ActiveTaskID == taskID {
+		return
+	}
+	cfg.ActiveTaskID = taskID
+	_ = SaveConfig(cfg)
+}
+
+// ForgetActiveTask clears the remembered active task if it is taskID (full
+// UUID or short prefix).
+func ForgetActiveTask(taskID string) {
+	active := LoadActiveTask()
+	if active == "" || taskID == "" || (!strings.HasPrefix(active, taskID) && !strings.HasPrefix(taskID, active)) {
+		return
+	}
+	RememberActiveTask("")
 }
 
 // LoadLastProject returns the remembered project UUID, or "" if none/unreadable.
@@ -142,3 +189,50 @@ func SaveConfig(cfg *Config) error {
 
 	return os.WriteFile(path, data, 0644)
 }
+
+// RepoRoot returns the git toplevel of the working directory, or the working
+// directory itself outside a checkout.
+func RepoRoot() string {
+	return RepoRootAt("")
+}
+
+// RepoRootAt returns the git toplevel of dir, or dir itself outside a
+// checkout. An empty dir means the working directory.
+func RepoRootAt(dir string) string {
+	if dir == "" {
+		dir, _ = os.Getwd()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "git", "rev-parse", "--show-toplevel")
+	cmd.Dir = dir
+	if out, err := cmd.Output(); err == nil {
+		if root := strings.TrimSpace(string(out)); root != "" {
+			return root
+		}
+	}
+	return dir
+}
+
+// LayeredFiles returns the user-level (~/.ramorie/<name>) and project-level
+// (<repo>/.ramorie/<name>) paths of a config file, in the order they apply.
+// Either is "" when it cannot be located; project is "" when it is the same
+// file as user.
+func LayeredFiles(name string) (user, project string) {
+	return LayeredFilesAt(name, "")
+}
+
+// LayeredFilesAt is LayeredFiles for the checkout containing dir rather than
+// the working directory.
+func LayeredFilesAt(name, dir string) (user, project string) {
+	if home, err := os.UserHomeDir(); err == nil {
+		user = filepath.Join(home, configDirNew, name)
+	}
+	if root := RepoRootAt(dir); root != "" {
+		project = filepath.Join(root, configDirNew, name)
+		if project == user {
+			project = ""
+		}
+	}
+	return user, project
+}
@@ -0,0 +1,143 @@
+// Package gitlink remembers what the git hooks already did, so they stay
+// idempotent.
+//
+// The post-commit hook adds a note to every task a commit references as
+// ram#<id>. `git commit --amend` and `git rebase` run the hook again for
+// rewritten commits; the ledger keys each link by the commit SHA and by
+// the commit's author identity and author date, which a rewrite keeps, so
+// a rewritten commit is not noted twice. The file also caches resolved
+// ram#<id> prefixes, which otherwise cost a walk over every task.
+//
+// State lives in ~/.ramorie/git-links.json (mode 0600). Links older than
+// MaxAge are dropped on save.
+package gitlink
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/kutbudev/ramorie-cli/internal/statefile"
+)
+
+// MaxAge is how long a commit link is remembered.
+const MaxAge = 180 * 24 * time.Hour
+
+// Link records that a commit was noted on a task, and whether it also
+// completed it.
+type Link struct {
+	TaskID string    `json:"task_id"`
+	Closed bool      `json:"closed,omitempty"`
+	At     time.Time `json:"at"`
+}
+
+// State is the content of the ledger file.
+type State struct {
+	// Commits maps a commit key (see Keys) to the links made for it.
+	Commits map[string][]Link `json:"commits"`
+	// Refs maps a short ram#<id> reference to the full task ID.
+	Refs map[string]string `json:"refs"`
+}
+
+// Keys returns the ledger keys of a commit: its SHA and, when known, its
+// author identity and author date, which survive an amend or rebase.
+func Keys(sha, authorEmail string, authorTime int64) []string {
+	keys := []string{sha}
+	if authorEmail != "" && authorTime > 0 {
+		keys = append(keys, fmt.Sprintf("author:%s@%d", authorEmail, authorTime))
+	}
+	return keys
+}
+
+// Linked reports whether any of keys was already noted on taskID and
+// whether that link completed the task.
+func (st *State) Linked(keys []string, taskID string) (noted, closed bool) {
+	for _, k := range keys {
+		for _, l := range st.Commits[k] {
+			if l.TaskID == taskID {
+				noted = true
+				closed = closed || l.Closed
+			}
+		}
+	}
+	return noted, closed
+}
+
+// Record stores a link under every key.
+func (st *State) Record(keys []string, taskID string, closed bool, now time.Time) {
+	for _, k := range keys {
+		links := st.Commits[k]
+		found := false
+		for i := range links {
+			if links[i].TaskID == taskID {
+				links[i].Closed = links[i].Closed || closed
+				links[i].At = now
+				found = true
+			}
+		}
+		if !found {
+			links = append(links, Link{TaskID: taskID, Closed: closed, At: now})
+		}
+		st.Commits[k] = links
+	}
+}
+
+// prune drops links older than MaxAge.
+func (st *State) prune(now time.Time) {
+	for k, links := range st.Commits {
+		kept := links[:0]
+		for _, l := range links {
+			if now.Sub(l.At) < MaxAge {
+				kept = append(kept, l)
+			}
+		}
+		if len(kept) == 0 {
+			delete(st.Commits, k)
+		} else {
+			st.Commits[k] = kept
+		}
+	}
+}
+
+// Store is the ledger file.
+type Store struct {
+	Path string
+}
+
+// Open returns the default store in ~/.ramorie/git-links.json.
+func Open() (*Store, error) {
+	path, err := statefile.Path("git-links.json")
+	if err != nil {
+		return nil, err
+	}
+	return &Store{Path: path}, nil
+}
+
+// Load reads the ledger. A missing file is an empty ledger.
+func (s *Store) Load() (*State, error) {
+	st := &State{Commits: map[string][]Link{}, Refs: map[string]string{}}
+	data, err := os.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		return st, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, st); err != nil {
+		return nil, fmt.Errorf("corrupt git link file %s: %w", s.Path, err)
+	}
+	if st.Commits == nil {
+		st.Commits = map[string][]Link{}
+	}
+	if st.Refs == nil {
+		st.Refs = map[string]string{}
+	}
+	return st, nil
+}
+
+// Save prunes old links and writes the ledger.
+func (s *Store) Save(st *State) error {
+	st.prune(time.Now())
+	return statefile.WriteJSON(s.Path, st)
+}
@@ -0,0 +1,63 @@
+package gitlink
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRewrittenCommitIsLinkedOnce(t *testing.T) {
+	s := &Store{Path: filepath.Join(t.TempDir(), "git-links.json")}
+	st, err := s.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	original := Keys("aaa111", "ada@example.com", 1700000000)
+	st.Record(original, "task-1", false, now)
+	st.Refs["3f2a"] = "task-1"
+	if err := s.Save(st); err != nil {
+		t.Fatal(err)
+	}
+
+	st, err = s.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// An amend keeps the author and author date but changes the SHA.
+	amended := Keys("bbb222", "ada@example.com", 1700000000)
+	if noted, closed := st.Linked(amended, "task-1"); !noted || closed {
+		t.Errorf("amended commit: noted=%v closed=%v", noted, closed)
+	}
+	if noted, _ := st.Linked(amended, "task-2"); noted {
+		t.Error("another task counted as linked")
+	}
+	if noted, _ := st.Linked(Keys("ccc333", "ada@example.com", 1700000500), "task-1"); noted {
+		t.Error("a different commit counted as linked")
+	}
+	if st.Refs["3f2a"] != "task-1" {
+		t.Errorf("refs = %v", st.Refs)
+	}
+
+	st.Record(amended, "task-1", true, now)
+	if _, closed := st.Linked(original, "task-1"); !closed {
+		t.Error("close not recorded under the shared author key")
+	}
+}
+
+func TestSavePrunesOldLinks(t *testing.T) {
+	s := &Store{Path: filepath.Join(t.TempDir(), "git-links.json")}
+	st, _ := s.Load()
+	st.Record([]string{"old"}, "task-1", false, time.Now().Add(-MaxAge-time.Hour))
+	st.Record([]string{"new"}, "task-1", false, time.Now())
+	if err := s.Save(st); err != nil {
+		t.Fatal(err)
+	}
+	st, _ = s.Load()
+	if _, ok := st.Commits["old"]; ok {
+		t.Error("old link kept")
+	}
+	if _, ok := st.Commits["new"]; !ok {
+		t.Error("recent link dropped")
+	}
+}
@@ -0,0 +1,386 @@
+// Package gitmem turns git commits into memories.
+//
+// `remember --from-commit <sha|range>` and `remember --since <rev|date>`
+// read commits with git and build one memory per commit from its message,
+// author, diffstat and touched paths. The type comes from the conventional
+// commit prefix (fix: → bug_fix) or, without one, from the memory-type
+// classifier; the top-level directories the commit touched become tags.
+//
+// Imported commits are recorded in ~/.ramorie/commits.json (mode 0600) by
+// project and SHA, so running the same import into the same project skips
+// them. An entry whose memory has since been deleted or undone is dropped
+// and the commit imported again.
+package gitmem
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kutbudev/ramorie-cli/internal/memtype"
+	"github.com/kutbudev/ramorie-cli/internal/review"
+	"github.com/kutbudev/ramorie-cli/internal/statefile"
+)
+
+// File is one path a commit touched.
+type File struct {
+	Path    string `json:"path"`
+	Added   int    `json:"added"`
+	Deleted int    `json:"deleted"`
+	Binary  bool   `json:"binary,omitempty"`
+}
+
+// Commit is a commit as read from git.
+type Commit struct {
+	SHA     string    `json:"sha"`
+	Author  string    `json:"author"`
+	Email   string    `json:"email"`
+	Date    time.Time `json:"date"`
+	Subject string    `json:"subject"`
+	Body    string    `json:"body,omitempty"`
+	Files   []File    `json:"files,omitempty"`
+}
+
+// Short is the abbreviated SHA.
+func (c Commit) Short() string {
+	if len(c.SHA) > 12 {
+		return c.SHA[:12]
+	}
+	return c.SHA
+}
+
+// Message is the full commit message.
+func (c Commit) Message() string {
+	if c.Body == "" {
+		return c.Subject
+	}
+	return c.Subject + "\n\n" + c.Body
+}
+
+// Stat totals the lines added and deleted.
+func (c Commit) Stat() (added, deleted int) {
+	for _, f := range c.Files {
+		added += f.Added
+		deleted += f.Deleted
+	}
+	return added, deleted
+}
+
+// Select finds the commits to import. fromCommit is a single commit or an
+// "a..b" range; since is a revision (everything after it up to HEAD), a
+// date git understands ("2026-01-01", "2 weeks ago") or an interval such as
+// 30d. Ranges are oldest first and leave out merge commits.
+func Select(dir, fromCommit, since string) ([]Commit, error) {
+	fromCommit, since = strings.TrimSpace(fromCommit), strings.TrimSpace(since)
+	switch {
+	case fromCommit != "" && since != "":
+		return nil, fmt.Errorf("use either --from-commit or --since, not both")
+	case strings.Contains(fromCommit, ".."):
+		return Log(dir, "--reverse", "--no-merges", fromCommit)
+	case fromCommit != "":
+		sha, err := git(dir, "rev-parse", "--verify", "--quiet", fromCommit+"^{commit}")
+		if err != nil {
+			return nil, fmt.Errorf("unknown commit %q", fromCommit)
+		}
+		return Log(dir, "-1", sha)
+	case since == "":
+		return nil, fmt.Errorf("a commit, range or --since is required")
+	}
+	if _, err := git(dir, "rev-parse", "--verify", "--quiet", since+"^{commit}"); err == nil {
+		return Log(dir, "--reverse", "--no-merges", since+"..HEAD")
+	}
+	if days, err := review.ParseInterval(since); err == nil {
+		since = time.Now().AddDate(0, 0, -days).Format("2006-01-02")
+	}
+	return Log(dir, "--reverse", "--no-merges", "--since="+since, "HEAD")
+}
+
+// Field and record separators of the log format.
+const (
+	fieldSep  = "\x1f"
+	recordSep = "\x1e"
+)
+
+// Log runs git log with args and reads each commit's touched files.
+func Log(dir string, args ...string) ([]Commit, error) {
+	format := "--format=" + strings.Join([]string{"%H", "%an", "%ae", "%aI", "%s", "%b"}, "%x1f") + "%x1e"
+	out, err := git(dir, append([]string{"log", "--no-color", format}, args...)...)
+	if err != nil {
+		return nil, fmt.Errorf("git log: %w", err)
+	}
+	var commits []Commit
+	for _, rec := range strings.Split(out, recordSep) {
+		f := strings.Split(strings.TrimLeft(rec, "\n"), fieldSep)
+		if len(f) != 6 {
+			continue
+		}
+		date, _ := time.Parse(time.RFC3339, f[3])
+		c := Commit{SHA: f[0], Author: f[1], Email: f[2], Date: date, Subject: f[4], Body: strings.TrimSpace(f[5])}
+		numstat, err := git(dir, "diff-tree", "--no-commit-id", "--numstat", "-r", "--root", "-m", "--first-parent", c.SHA)
+		if err != nil {
+			return nil, fmt.Errorf("git diff-tree %s: %w", c.Short(), err)
+		}
+		c.Files = ParseNumstat(numstat)
+		commits = append(commits, c)
+	}
+	return commits, nil
+}
+
+// ParseNumstat reads `git diff-tree --numstat` output. Renames are listed
+// under their new path.
+func ParseNumstat(out string) []File {
+	var files []File
+	for _, line := range strings.Split(out, "\n") {
+		parts := strings.SplitN(line, "\t", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		f := File{Path: renamedPath(parts[2])}
+		if parts[0] == "-" {
+			f.Binary = true
+		} else {
+			f.Added, _ = strconv.Atoi(parts[0])
+			f.Deleted, _ = strconv.Atoi(parts[1])
+		}
+		files = append(files, f)
+	}
+	return files
+}
+
+// renamedPath resolves numstat's rename notations, "old => new" and
+// "dir/{old => new}/file", to the new path.
+func renamedPath(p string) string {
+	if i := strings.Index(p, "{"); i >= 0 {
+		if j := strings.Index(p[i:], "}"); j > 0 {
+			inner := p[i+1 : i+j]
+			if k := strings.Index(inner, " => "); k >= 0 {
+				p = p[:i] + inner[k+4:] + p[i+j+1:]
+				return strings.ReplaceAll(p, "//", "/")
+			}
+		}
+	}
+	if k := strings.Index(p, " => "); k >= 0 {
+		return p[k+4:]
+	}
+	return p
+}
+
+// prefixTypes maps conventional commit types to memory types. Types not
+// listed fall back to the classifier.
+var prefixTypes = map[string]string{
+	"fix":    memtype.BugFix,
+	"bugfix": memtype.BugFix,
+	"hotfix": memtype.BugFix,
+	"revert": memtype.Decision,
+	"docs":   memtype.Reference,
+}
+
+var prefixRe = regexp.MustCompile(`^([A-Za-z]+)(?:\([^)]*\))?!?:\s`)
+
+// Type infers a commit's memory type and says why.
+func Type(c Commit) (memoryType, reason string) {
+	if m := prefixRe.FindStringSubmatch(c.Subject); m != nil {
+		if t, ok := prefixTypes[strings.ToLower(m[1])]; ok {
+			return t, fmt.Sprintf("%q prefix", strings.ToLower(m[1])+":")
+		}
+	}
+	if strings.HasPrefix(c.Subject, `Revert "`) {
+		return memtype.Decision, "revert commit"
+	}
+	r := memtype.Default().Classify(c.Message())
+	return r.Type, "classifier"
+}
+
+// Tags returns the top-level directories a commit touched, most files
+// first, at most max. Files at the repository root add no tag.
+func Tags(c Commit, max int) []string {
+	count := map[string]int{}
+	for _, f := range c.Files {
+		i := strings.Index(f.Path, "/")
+		if i <= 0 {
+			continue
+		}
+		if dir := strings.TrimLeft(f.Path[:i], "."); dir != "" {
+			count[dir]++
+		}
+	}
+	tags := make([]string, 0, len(count))
+	for d := range count {
+		tags = append(tags, d)
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if count[tags[i]] != count[tags[j]] {
+			return count[tags[i]] > count[tags[j]]
+		}
+		return tags[i] < tags[j]
+	})
+	if max > 0 && len(tags) > max {
+		tags = tags[:max]
+	}
+	return tags
+}
+
+// maxListedFiles caps the touched paths written into a memory.
+const maxListedFiles = 15
+
+// Content renders the memory text for a commit: the message, then who made
+// it and when, the diffstat and the touched paths.
+func Content(c Commit) string {
+	var b strings.Builder
+	b.WriteString(c.Message())
+	fmt.Fprintf(&b, "\n\nCommit %s by %s", c.Short(), c.Author)
+	if c.Email != "" {
+		fmt.Fprintf(&b, " <%s>", c.Email)
+	}
+	if !c.Date.IsZero() {
+		fmt.Fprintf(&b, ", %s", c.Date.Format("2006-01-02"))
+	}
+	added, deleted := c.Stat()
+	noun := "files"
+	if len(c.Files) == 1 {
+		noun = "file"
+	}
+	fmt.Fprintf(&b, "\n%d %s changed, +%d −%d", len(c.Files), noun, added, deleted)
+	for i, f := range c.Files {
+		if i == maxListedFiles {
+			fmt.Fprintf(&b, "\n- … and %d more", len(c.Files)-maxListedFiles)
+			break
+		}
+		if f.Binary {
+			fmt.Fprintf(&b, "\n- %s (binary)", f.Path)
+			continue
+		}
+		fmt.Fprintf(&b, "\n- %s (+%d −%d)", f.Path, f.Added, f.Deleted)
+	}
+	return b.String()
+}
+
+// Squashable reports whether a commit only exists to be squashed into
+// another (fixup!/squash!/amend!); batch imports skip those.
+func Squashable(c Commit) bool {
+	for _, p := range []string{"fixup! ", "squash! ", "amend! "} {
+		if strings.HasPrefix(c.Subject, p) {
+			return true
+		}
+	}
+	return false
+}
+
+func git(dir string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, "git", append([]string{"-C", dir}, args...)...).Output()
+	if err != nil {
+		if exit, ok := err.(*exec.ExitError); ok && len(exit.Stderr) > 0 {
+			return "", fmt.Errorf("%s", strings.TrimSpace(string(exit.Stderr)))
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// Import records that a commit became a memory.
+type Import struct {
+	MemoryID string    `json:"memory_id"`
+	Repo     string    `json:"repo,omitempty"`
+	Imported time.Time `json:"imported"`
+}
+
+// Store is the imported-commits file: project ID → commit SHA → import.
+type Store struct {
+	Path string
+}
+
+// Open returns the default store in ~/.ramorie/commits.json.
+func Open() (*Store, error) {
+	path, err := statefile.Path("commits.json")
+	if err != nil {
+		return nil, err
+	}
+	return &Store{Path: path}, nil
+}
+
+// Load returns every import by project ID, then commit SHA.
+func (s *Store) Load() (map[string]map[string]Import, error) {
+	out := map[string]map[string]Import{}
+	data, err := os.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		return out, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("corrupt commit import file %s: %w", s.Path, err)
+	}
+	return out, nil
+}
+
+// For returns a project's imports by commit SHA.
+func (s *Store) For(projectID string) (map[string]Import, error) {
+	all, err := s.Load()
+	if err != nil {
+		return nil, err
+	}
+	if all[projectID] == nil {
+		return map[string]Import{}, nil
+	}
+	return all[projectID], nil
+}
+
+// Put records one import into a project.
+func (s *Store) Put(projectID, sha string, imp Import) error {
+	all, err := s.Load()
+	if err != nil {
+		return err
+	}
+	if all[projectID] == nil {
+		all[projectID] = map[string]Import{}
+	}
+	all[projectID][sha] = imp
+	return s.write(all)
+}
+
+// Forget drops the entries, in any project, whose memory is one of
+// memoryIDs, so those commits can be imported again. It returns how many
+// were dropped.
+func (s *Store) Forget(memoryIDs ...string) (int, error) {
+	if len(memoryIDs) == 0 {
+		return 0, nil
+	}
+	gone := map[string]bool{}
+	for _, id := range memoryIDs {
+		gone[id] = true
+	}
+	all, err := s.Load()
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for project, imports := range all {
+		for sha, imp := range imports {
+			if gone[imp.MemoryID] {
+				delete(imports, sha)
+				n++
+			}
+		}
+		if len(imports) == 0 {
+			delete(all, project)
+		}
+	}
+	if n == 0 {
+		return 0, nil
+	}
+	return n, s.write(all)
+}
+
+func (s *Store) write(all map[string]map[string]Import) error {
+	return statefile.WriteJSON(s.Path, all)
+}
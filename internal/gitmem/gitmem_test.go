@@ -0,0 +1,189 @@
+package gitmem
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newRepo creates a repository with a tagged first commit and three more.
+func newRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Jane Doe", "GIT_AUTHOR_EMAIL=jane@example.com",
+			"GIT_COMMITTER_NAME=Jane Doe", "GIT_COMMITTER_EMAIL=jane@example.com",
+			"GIT_AUTHOR_DATE=2026-03-02T10:00:00Z", "GIT_COMMITTER_DATE=2026-03-02T10:00:00Z")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v %s", args, err, out)
+		}
+	}
+	commit := func(msg string, files map[string]string) {
+		t.Helper()
+		for p, body := range files {
+			full := filepath.Join(dir, p)
+			os.MkdirAll(filepath.Dir(full), 0o755)
+			if err := os.WriteFile(full, []byte(body), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		run("add", "-A")
+		run("commit", "-q", "-m", msg)
+	}
+	run("init", "-q")
+	commit("chore: initial", map[string]string{"README.md": "hi\n"})
+	run("tag", "v1.2.0")
+	commit("fix(api): retry idempotent requests on 502\n\nThe gateway drops requests during deploys. Refs ram#3f2a9c10.",
+		map[string]string{"internal/api/client.go": "a\nb\n", "internal/api/retry.go": "c\n", "cmd/main.go": "d\n"})
+	commit("feat: we decided to move sessions to Redis", map[string]string{"internal/session/store.go": "e\n"})
+	commit("fixup! feat: we decided to move sessions to Redis", map[string]string{"internal/session/store.go": "f\n"})
+	return dir
+}
+
+func TestSelect(t *testing.T) {
+	dir := newRepo(t)
+
+	commits, err := Select(dir, "", "v1.2.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(commits) != 3 || !strings.HasPrefix(commits[0].Subject, "fix(api)") {
+		t.Fatalf("since v1.2.0 = %+v", commits)
+	}
+	fix := commits[0]
+	if fix.Author != "Jane Doe" || fix.Email != "jane@example.com" || !fix.Date.Equal(time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("author/date = %q %q %v", fix.Author, fix.Email, fix.Date)
+	}
+	if !strings.Contains(fix.Body, "ram#3f2a9c10") || len(fix.Files) != 3 {
+		t.Errorf("body %q files %+v", fix.Body, fix.Files)
+	}
+	if added, deleted := fix.Stat(); added != 4 || deleted != 0 {
+		t.Errorf("stat = +%d -%d", added, deleted)
+	}
+
+	one, err := Select(dir, "HEAD~1", "")
+	if err != nil || len(one) != 1 || !strings.HasPrefix(one[0].Subject, "feat:") {
+		t.Errorf("single = %+v %v", one, err)
+	}
+	rng, err := Select(dir, "v1.2.0..HEAD~1", "")
+	if err != nil || len(rng) != 2 {
+		t.Errorf("range = %+v %v", rng, err)
+	}
+	if _, err := Select(dir, "nope", ""); err == nil {
+		t.Error("unknown commit should fail")
+	}
+	if _, err := Select(dir, "HEAD", "v1.2.0"); err == nil {
+		t.Error("both modes should fail")
+	}
+	root, err := Select(dir, "v1.2.0", "")
+	if err != nil || len(root) != 1 || len(root[0].Files) != 1 || root[0].Files[0].Path != "README.md" {
+		t.Errorf("root commit = %+v %v", root, err)
+	}
+}
+
+func TestTypeTagsContent(t *testing.T) {
+	c := Commit{
+		SHA:     "3f2a9c10d2b4e6f8a0b1c2d3e4f5a6b7c8d9e0f1",
+		Author:  "Jane Doe",
+		Subject: "fix(api): retry idempotent requests on 502",
+		Body:    "The gateway drops requests during deploys.",
+		Files: []File{
+			{Path: "internal/api/client.go", Added: 40, Deleted: 10},
+			{Path: "internal/api/retry.go", Added: 5},
+			{Path: "cmd/main.go", Added: 1, Deleted: 1},
+			{Path: ".github/workflows/ci.yml", Added: 2},
+			{Path: "go.sum", Added: 3},
+			{Path: "logo.png", Binary: true},
+		},
+	}
+	if typ, reason := Type(c); typ != "bug_fix" || reason != `"fix:" prefix` {
+		t.Errorf("Type = %s (%s)", typ, reason)
+	}
+	if got := strings.Join(Tags(c, 5), ","); got != "internal,cmd,github" {
+		t.Errorf("Tags = %s", got)
+	}
+	if got := Tags(c, 1); len(got) != 1 || got[0] != "internal" {
+		t.Errorf("Tags max 1 = %v", got)
+	}
+	content := Content(c)
+	for _, want := range []string{
+		"fix(api): retry idempotent requests on 502\n\nThe gateway drops",
+		"Commit 3f2a9c10d2b4 by Jane Doe",
+		"6 files changed, +51 −11",
+		"- internal/api/client.go (+40 −10)",
+		"- logo.png (binary)",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("content missing %q:\n%s", want, content)
+		}
+	}
+
+	for subject, want := range map[string]string{
+		"docs: explain the cache":              "reference",
+		`Revert "feat: drop the v1 endpoint"`:  "decision",
+		"hotfix!: stop double-charging":        "bug_fix",
+		"feat: we decided to use Redis":        "decision",
+		"chore: bump deps":                     "general",
+		"Root cause was a missing nonce check": "bug_fix",
+	} {
+		if got, _ := Type(Commit{Subject: subject}); got != want {
+			t.Errorf("Type(%q) = %s, want %s", subject, got, want)
+		}
+	}
+}
+
+func TestParseNumstat(t *testing.T) {
+	files := ParseNumstat("3\t1\tinternal/a.go\n-\t-\tlogo.png\n0\t0\tpkg/{old => new}/x.go\n2\t2\tdocs/a.md => guide/a.md\n")
+	want := []File{
+		{Path: "internal/a.go", Added: 3, Deleted: 1},
+		{Path: "logo.png", Binary: true},
+		{Path: "pkg/new/x.go"},
+		{Path: "guide/a.md", Added: 2, Deleted: 2},
+	}
+	if len(files) != len(want) {
+		t.Fatalf("files = %+v", files)
+	}
+	for i := range want {
+		if files[i] != want[i] {
+			t.Errorf("file %d = %+v, want %+v", i, files[i], want[i])
+		}
+	}
+	if renamedPath("src/{a => }/b.go") != "src/b.go" {
+		t.Errorf("empty rename side = %q", renamedPath("src/{a => }/b.go"))
+	}
+}
+
+func TestSquashableAndStore(t *testing.T) {
+	if !Squashable(Commit{Subject: "fixup! feat: x"}) || Squashable(Commit{Subject: "fix: x"}) {
+		t.Error("Squashable")
+	}
+	s := &Store{Path: filepath.Join(t.TempDir(), "commits.json")}
+	if err := s.Put("p1", "abc", Import{MemoryID: "m1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Put("p2", "def", Import{MemoryID: "m2"}); err != nil {
+		t.Fatal(err)
+	}
+	p1, err := s.For("p1")
+	if err != nil || p1["abc"].MemoryID != "m1" || len(p1) != 1 {
+		t.Errorf("For(p1) = %v %v", p1, err)
+	}
+	if p3, _ := s.For("p3"); len(p3) != 0 {
+		t.Errorf("another project sees imports: %v", p3)
+	}
+	if n, err := s.Forget("m1", "m9"); n != 1 || err != nil {
+		t.Errorf("Forget = %d %v", n, err)
+	}
+	if all, _ := s.Load(); len(all) != 1 || all["p2"]["def"].MemoryID != "m2" {
+		t.Errorf("after Forget: %v", all)
+	}
+}
@@ -0,0 +1,283 @@
+package hooks
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Git hook events. Unlike the agent events above these name the hook file
+// git executes (.git/hooks/<event>), so they double as file names.
+const (
+	// GitCommitMsg runs after the message is written, before the commit is
+	// recorded; $1 is the message file.
+	GitCommitMsg HookEvent = "commit-msg"
+	// GitPostCommit runs once the commit exists, so HEAD is the new SHA.
+	GitPostCommit HookEvent = "post-commit"
+	// GitPostCheckout runs after `git checkout`/`git switch`; $3 is 1 for a
+	// branch checkout and 0 for a file checkout.
+	GitPostCheckout HookEvent = "post-checkout"
+)
+
+// GitEntries is the canonical set of git hooks `ramorie setup git-hooks`
+// installs. Every command ends in `|| true` — a Ramorie outage or a locked
+// vault must never block a commit.
+func GitEntries() []HookEntry {
+	return []HookEntry{
+		{
+			Event:   GitCommitMsg,
+			Command: ramorieExe() + ` hook git commit-msg "$1" || true`,
+			ID:      "ramorie-protocol-git-commit-msg-v1",
+		},
+		{
+			Event:   GitPostCommit,
+			Command: ramorieExe() + " hook git post-commit || true",
+			ID:      "ramorie-protocol-git-post-commit-v1",
+		},
+		{
+			// Branch switch → start the task the branch names.
+			Event:   GitPostCheckout,
+			Command: ramorieExe() + ` hook git post-checkout "$1" "$2" "$3" || true`,
+			ID:      "ramorie-protocol-git-post-checkout-v1",
+		},
+	}
+}
+
+func ramorieExe() string {
+	if exe, err := os.Executable(); err == nil && strings.TrimSpace(exe) != "" {
+		return shellQuote(exe)
+	}
+	return "ramorie"
+}
+
+// GitInstaller manages Ramorie blocks inside a repository's hook scripts.
+// Each entry lives in its own marker-delimited block so foreign hook code
+// (husky, pre-commit, hand-written scripts) in the same file survives
+// install and uninstall untouched:
+//
+//	# >>> ramorie:managed id=ramorie-protocol-git-post-commit-v1 >>>
+//	'/usr/local/bin/ramorie' hook git post-commit || true
+//	# <<< ramorie:managed id=ramorie-protocol-git-post-commit-v1 <<<
+//
+// Blocks are inserted right after the shebang so an early `exit 0` in the
+// user's script cannot skip them.
+type GitInstaller struct {
+	hooksDir string // override for tests; empty = `git rev-parse --git-path hooks`
+}
+
+// NewGitInstaller constructs an installer for the repository containing the
+// current working directory. core.hooksPath is honoured.
+func NewGitInstaller() *GitInstaller { return &GitInstaller{} }
+
+// NewGitInstallerAt constructs an installer writing into an explicit hooks
+// directory.
+func NewGitInstallerAt(hooksDir string) *GitInstaller {
+	return &GitInstaller{hooksDir: hooksDir}
+}
+
+// Name implements Installer.
+func (g *GitInstaller) Name() string { return "git" }
+
+// SettingsPath returns the hooks directory the scripts are written to.
+func (g *GitInstaller) SettingsPath() string {
+	if g.hooksDir != "" {
+		return g.hooksDir
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, "git", "rev-parse", "--git-path", "hooks").Output()
+	if err != nil {
+		return ""
+	}
+	dir := strings.TrimSpace(string(out))
+	if dir == "" {
+		return ""
+	}
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	return dir
+}
+
+// Detect reports whether we are inside a git repository.
+func (g *GitInstaller) Detect() bool {
+	return g.SettingsPath() != ""
+}
+
+// Install upserts one managed block per entry into .git/hooks/<event>,
+// creating the script (and making it executable) when missing.
+func (g *GitInstaller) Install(entries []HookEntry) error {
+	dir := g.SettingsPath()
+	if dir == "" {
+		return fmt.Errorf("git: not inside a git repository")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	for _, e := range entries {
+		path := filepath.Join(dir, string(e.Event))
+		existing, err := readHookScript(path)
+		if err != nil {
+			return err
+		}
+		updated := upsertGitBlock(existing, e)
+		if err := os.WriteFile(path, []byte(updated), 0o755); err != nil {
+			return err
+		}
+		// WriteFile keeps the mode of an existing file; force +x.
+		if err := os.Chmod(path, 0o755); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Uninstall removes the blocks whose ID is in ids from every hook script.
+// A script left with nothing but a shebang was ours alone and is deleted.
+func (g *GitInstaller) Uninstall(ids []string) error {
+	dir := g.SettingsPath()
+	if dir == "" {
+		return fmt.Errorf("git: not inside a git repository")
+	}
+	scripts, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	for _, s := range scripts {
+		if s.IsDir() || strings.HasSuffix(s.Name(), ".sample") {
+			continue
+		}
+		path := filepath.Join(dir, s.Name())
+		existing, err := readHookScript(path)
+		if err != nil {
+			return err
+		}
+		updated := existing
+		for _, id := range ids {
+			updated = removeGitBlock(updated, id)
+		}
+		if updated == existing {
+			continue
+		}
+		if isEmptyHookScript(updated) {
+			if err := os.Remove(path); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := os.WriteFile(path, []byte(updated), 0o755); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Status returns the managed blocks currently present, sorted by event.
+func (g *GitInstaller) Status() ([]HookEntry, error) {
+	dir := g.SettingsPath()
+	if dir == "" {
+		return nil, nil
+	}
+	scripts, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var out []HookEntry
+	for _, s := range scripts {
+		if s.IsDir() || strings.HasSuffix(s.Name(), ".sample") {
+			continue
+		}
+		content, err := readHookScript(filepath.Join(dir, s.Name()))
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range gitBlockRegexp.FindAllStringSubmatch(content, -1) {
+			if m[1] != m[3] || !IsRamorieID(m[1]) {
+				continue
+			}
+			out = append(out, HookEntry{Event: HookEvent(s.Name()), Command: strings.TrimSpace(m[2]), ID: m[1]})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Event < out[j].Event })
+	return out, nil
+}
+
+// gitBlockRegexp captures (id, body, closing id) of each managed block.
+var gitBlockRegexp = regexp.MustCompile(`(?s)# >>> ramorie:managed id=(\S+) >>>\n(.*?)# <<< ramorie:managed id=(\S+) <<<\n?`)
+
+func gitBlock(e HookEntry) string {
+	return fmt.Sprintf("# >>> ramorie:managed id=%s >>>\n%s\n# <<< ramorie:managed id=%s <<<\n", e.ID, strings.TrimSpace(e.Command), e.ID)
+}
+
+// upsertGitBlock replaces the block carrying e.ID or inserts a new one after
+// the shebang (adding `#!/bin/sh` to a new script).
+func upsertGitBlock(existing string, e HookEntry) string {
+	block := gitBlock(e)
+	replaced := false
+	out := gitBlockRegexp.ReplaceAllStringFunc(existing, func(m string) string {
+		if gitBlockRegexp.FindStringSubmatch(m)[1] != e.ID {
+			return m
+		}
+		if replaced {
+			return "" // collapse accidental duplicates
+		}
+		replaced = true
+		return block
+	})
+	if replaced {
+		return out
+	}
+	if strings.TrimSpace(existing) == "" {
+		return "#!/bin/sh\n" + block
+	}
+	if strings.HasPrefix(existing, "#!") {
+		nl := strings.IndexByte(existing, '\n')
+		if nl < 0 {
+			return existing + "\n" + block
+		}
+		return existing[:nl+1] + block + existing[nl+1:]
+	}
+	return block + existing
+}
+
+func removeGitBlock(existing, id string) string {
+	return gitBlockRegexp.ReplaceAllStringFunc(existing, func(m string) string {
+		if gitBlockRegexp.FindStringSubmatch(m)[1] == id {
+			return ""
+		}
+		return m
+	})
+}
+
+func isEmptyHookScript(s string) bool {
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#!") {
+			return false
+		}
+	}
+	return true
+}
+
+func readHookScript(path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return string(b), nil
+}
@@ -0,0 +1,92 @@
+package hooks
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestGitInstaller_IdempotentAndPreservesForeignHooks installs three times
+// into a hooks dir that already has a user-written post-commit script and
+// checks the user's code survives, our block appears once, and it sits
+// before any early exit.
+func TestGitInstaller_IdempotentAndPreservesForeignHooks(t *testing.T) {
+	dir := t.TempDir()
+	foreign := "#!/bin/bash\necho user-hook\nexit 0\n"
+	if err := os.WriteFile(filepath.Join(dir, "post-commit"), []byte(foreign), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	inst := NewGitInstallerAt(dir)
+	for i := 0; i < 3; i++ {
+		if err := inst.Install(GitEntries()); err != nil {
+			t.Fatalf("install round %d: %v", i, err)
+		}
+	}
+
+	got, err := os.ReadFile(filepath.Join(dir, "post-commit"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := string(got)
+	if strings.Count(s, "ramorie:managed id=ramorie-protocol-git-post-commit-v1 >>>") != 1 {
+		t.Fatalf("expected exactly one managed block:\n%s", s)
+	}
+	if !strings.HasPrefix(s, "#!/bin/bash\n# >>> ramorie:managed") {
+		t.Fatalf("block should follow the shebang:\n%s", s)
+	}
+	if !strings.HasSuffix(s, "echo user-hook\nexit 0\n") {
+		t.Fatalf("foreign hook body lost:\n%s", s)
+	}
+	if fi, err := os.Stat(filepath.Join(dir, "post-commit")); err != nil || fi.Mode().Perm()&0o100 == 0 {
+		t.Fatalf("post-commit must be executable: %v %v", fi.Mode(), err)
+	}
+
+	msg, err := os.ReadFile(filepath.Join(dir, "commit-msg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(msg), "#!/bin/sh\n") || !strings.Contains(string(msg), `hook git commit-msg "$1" || true`) {
+		t.Fatalf("new commit-msg script malformed:\n%s", msg)
+	}
+
+	entries, err := inst.Status()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if missing, stale := DiffEntries(GitEntries(), entries); len(missing) != 0 || len(stale) != 0 {
+		t.Fatalf("status should match canonical entries: missing=%v stale=%v", missing, stale)
+	}
+}
+
+func TestGitInstaller_UninstallRemovesOnlyOurs(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "post-commit"), []byte("#!/bin/sh\necho keep\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	inst := NewGitInstallerAt(dir)
+	if err := inst.Install(GitEntries()); err != nil {
+		t.Fatal(err)
+	}
+	ids := []string{}
+	for _, e := range GitEntries() {
+		ids = append(ids, e.ID)
+	}
+	if err := inst.Uninstall(ids); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "commit-msg")); !os.IsNotExist(err) {
+		t.Fatalf("commit-msg was created by us and should be removed, stat err=%v", err)
+	}
+	got, err := os.ReadFile(filepath.Join(dir, "post-commit"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "#!/bin/sh\necho keep\n" {
+		t.Fatalf("foreign script not restored exactly:\n%q", got)
+	}
+	if entries, _ := inst.Status(); len(entries) != 0 {
+		t.Fatalf("status after uninstall = %v", entries)
+	}
+}
@@ -0,0 +1,396 @@
+// Package journal keeps a local, append-only record of the mutations the CLI
+// and TUI make (task updates, moves, deletes, memory forgets, …) so they can
+// be reverted with `ramorie undo`.
+//
+// Every entry stores the state BEFORE the change as a field snapshot taken
+// from the API model. Snapshots of encrypted items carry only the ciphertext
+// fields (encrypted_title + title_nonce, encrypted_content + content_nonce,
+// …) — plaintext of vault items never reaches the journal file, and undo
+// writes the ciphertext straight back.
+//
+// The journal lives in ~/.ramorie/journal.jsonl (one JSON entry per line,
+// mode 0600) and keeps the newest MaxEntries entries. Writers take
+// journal.jsonl.lock first, since the MCP server, the CLI and git hooks
+// append to it concurrently.
+package journal
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/kutbudev/ramorie-cli/internal/api"
+	"github.com/kutbudev/ramorie-cli/internal/models"
+	"github.com/kutbudev/ramorie-cli/internal/statefile"
+)
+
+// MaxEntries caps the journal; older entries are dropped on append.
+const MaxEntries = 500
+
+// Entity kinds.
+const (
+	KindTask    = "task"
+	KindMemory  = "memory"
+	KindSubtask = "subtask"
+	KindProject = "project"
+	KindContext = "context"
+	KindPack    = "context pack"
+	// Notes, memory links and organizations have no delete endpoint, so
+	// their creation is recorded for history but cannot be undone.
+	KindNote         = "note"
+	KindLink         = "link"
+	KindOrganization = "organization"
+)
+
+// irreversible lists the kinds whose ops cannot be reverted.
+var irreversible = map[string]bool{KindNote: true, KindLink: true, KindOrganization: true}
+
+// Actions. The inverse of create is delete, of delete a re-create from the
+// snapshot, and of update a write of the snapshot's values for Fields.
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+// Op is one mutation of one entity.
+type Op struct {
+	Kind   string `json:"kind"`
+	Action string `json:"action"`
+	ID     string `json:"id"`
+	// Fields lists the updated fields (ActionUpdate). Only names are kept —
+	// the new values may be plaintext of an encrypted item.
+	Fields []string `json:"fields,omitempty"`
+	// Before is the entity snapshot taken before the change (see
+	// TaskSnapshot, MemorySnapshot, SubtaskSnapshot). Nil for creates.
+	Before map[string]interface{} `json:"before,omitempty"`
+}
+
+// Entry is one journaled command.
+type Entry struct {
+	Seq      int        `json:"seq"`
+	At       time.Time  `json:"at"`
+	Command  string     `json:"command"` // e.g. "task move", "tui delete"
+	Summary  string     `json:"summary,omitempty"`
+	Ops      []Op       `json:"ops"`
+	UndoneAt *time.Time `json:"undone_at,omitempty"`
+}
+
+// Undone reports whether the entry has been reverted.
+func (e Entry) Undone() bool { return e.UndoneAt != nil }
+
+// Reversible reports whether Revert can replay the inverse of every op.
+func (e Entry) Reversible() bool {
+	for _, op := range e.Ops {
+		if irreversible[op.Kind] {
+			return false
+		}
+	}
+	return true
+}
+
+// Store is a journal file. The zero value is unusable; use Open or set Path.
+type Store struct {
+	Path string
+}
+
+// Open returns the default store in ~/.ramorie/journal.jsonl.
+func Open() (*Store, error) {
+	path, err := statefile.Path("journal.jsonl")
+	if err != nil {
+		return nil, err
+	}
+	return &Store{Path: path}, nil
+}
+
+// Entries returns all entries, oldest first. A missing file is an empty
+// journal; unreadable lines are skipped.
+func (s *Store) Entries() ([]Entry, error) {
+	data, err := os.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var entries []Entry
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for sc.Scan() {
+		var e Entry
+		if json.Unmarshal(sc.Bytes(), &e) == nil && e.Seq > 0 {
+			entries = append(entries, e)
+		}
+	}
+	return entries, sc.Err()
+}
+
+// Append assigns the next sequence number and timestamp to e and stores it,
+// trimming the journal to MaxEntries. Entries without ops are ignored.
+func (s *Store) Append(e Entry) (Entry, error) {
+	if len(e.Ops) == 0 {
+		return e, nil
+	}
+	unlock, err := statefile.Lock(s.Path)
+	if err != nil {
+		return e, err
+	}
+	defer unlock()
+	entries, err := s.Entries()
+	if err != nil {
+		return e, err
+	}
+	e.Seq = 1
+	if n := len(entries); n > 0 {
+		e.Seq = entries[n-1].Seq + 1
+	}
+	if e.At.IsZero() {
+		e.At = time.Now()
+	}
+	entries = append(entries, e)
+	if len(entries) > MaxEntries {
+		entries = entries[len(entries)-MaxEntries:]
+	}
+	return e, s.write(entries)
+}
+
+// MarkUndone flags entry seq as reverted at the given time.
+func (s *Store) MarkUndone(seq int, at time.Time) error {
+	unlock, err := statefile.Lock(s.Path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	entries, err := s.Entries()
+	if err != nil {
+		return err
+	}
+	for i := range entries {
+		if entries[i].Seq == seq {
+			entries[i].UndoneAt = &at
+			return s.write(entries)
+		}
+	}
+	return fmt.Errorf("journal entry #%d not found", seq)
+}
+
+// write replaces the file atomically (temp file + rename).
+func (s *Store) write(entries []Entry) error {
+	var b bytes.Buffer
+	for _, e := range entries {
+		line, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		b.Write(line)
+		b.WriteByte('\n')
+	}
+	return statefile.WriteFile(s.Path, b.Bytes())
+}
+
+// Add appends an entry to the default journal.
+func Add(command, summary string, ops ...Op) error {
+	if len(ops) == 0 {
+		return nil
+	}
+	s, err := Open()
+	if err != nil {
+		return err
+	}
+	_, err = s.Append(Entry{Command: command, Summary: summary, Ops: ops})
+	return err
+}
+
+// Record is Add for CLI commands. Journaling is best-effort: a failure is
+// reported on stderr but never fails the command that made the change.
+func Record(command, summary string, ops ...Op) {
+	if err := Add(command, summary, ops...); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: could not write undo journal: %v\n", err)
+	}
+}
+
+// ---- snapshots -------------------------------------------------------------
+
+// TaskSnapshot captures the restorable fields of t. Encrypted tasks keep
+// their ciphertext and nonces instead of title and description.
+func TaskSnapshot(t *models.Task) map[string]interface{} {
+	if t == nil {
+		return nil
+	}
+	s := map[string]interface{}{
+		"project_id": t.ProjectID.String(),
+		"status":     t.Status,
+		"priority":   t.Priority,
+		"progress":   t.Progress,
+		"tags":       tagStrings(t.Tags),
+	}
+	if t.IsEncrypted {
+		s["is_encrypted"] = true
+		s["encrypted_title"] = t.EncryptedTitle
+		s["title_nonce"] = t.TitleNonce
+		s["encrypted_description"] = t.EncryptedDescription
+		s["description_nonce"] = t.DescriptionNonce
+		if t.EncryptionScope != "" {
+			s["encryption_scope"] = t.EncryptionScope
+			s["encryption_org_id"] = t.EncryptionOrgID
+		}
+	} else {
+		s["title"] = t.Title
+		s["description"] = t.Description
+	}
+	return s
+}
+
+// MemorySnapshot captures the restorable fields of m. Encrypted memories
+// keep their ciphertext and nonce instead of the content.
+func MemorySnapshot(m *models.Memory) map[string]interface{} {
+	if m == nil {
+		return nil
+	}
+	s := map[string]interface{}{
+		"project_id": m.ProjectID.String(),
+		"type":       m.Type,
+		"tags":       tagStrings(m.Tags),
+		// Skill fields are always present so undoing a conversion to a skill
+		// clears them again.
+		"trigger":    "",
+		"steps":      []string{},
+		"validation": "",
+	}
+	if m.Trigger != nil {
+		s["trigger"] = *m.Trigger
+	}
+	if len(m.Steps) > 0 {
+		s["steps"] = m.Steps
+	}
+	if m.Validation != nil {
+		s["validation"] = *m.Validation
+	}
+	// Access lists are always present so undoing a share clears them again;
+	// an empty visibility is left to the backend's default.
+	if m.Visibility != "" {
+		s["visibility"] = m.Visibility
+	}
+	s["readers"] = append([]string{}, m.Readers...)
+	s["writers"] = append([]string{}, m.Writers...)
+	if m.IsEncrypted {
+		s["is_encrypted"] = true
+		s["encrypted_content"] = m.EncryptedContent
+		s["content_nonce"] = m.ContentNonce
+		if m.ContentHash != "" {
+			s["content_hash"] = m.ContentHash
+		}
+		if m.EncryptionScope != "" {
+			s["encryption_scope"] = m.EncryptionScope
+			s["encryption_org_id"] = m.EncryptionOrgID
+		}
+	} else {
+		s["content"] = m.Content
+	}
+	return s
+}
+
+// SubtaskSnapshot captures the restorable fields of st.
+func SubtaskSnapshot(st *models.Subtask) map[string]interface{} {
+	if st == nil {
+		return nil
+	}
+	s := map[string]interface{}{
+		"task_id":     st.TaskID.String(),
+		"description": st.Description,
+		"completed":   st.Completed,
+		"status":      st.Status,
+		"priority":    st.Priority,
+		// "" = top level, so undoing a move can go back to the root.
+		"parent_subtask_id": "",
+	}
+	if st.ParentSubtaskID != nil {
+		s["parent_subtask_id"] = st.ParentSubtaskID.String()
+	}
+	return s
+}
+
+// ProjectSnapshot captures the restorable fields of p. Re-creating a
+// deleted project restores the project itself, not its tasks or memories.
+func ProjectSnapshot(p *models.Project) map[string]interface{} {
+	if p == nil {
+		return nil
+	}
+	cfg := p.Configuration
+	if cfg == nil {
+		cfg = map[string]interface{}{}
+	}
+	return map[string]interface{}{
+		"name":                p.Name,
+		"description":         p.Description,
+		"configuration":       cfg,
+		"encryption_required": p.EncryptionRequired,
+	}
+}
+
+// ContextSnapshot captures the restorable fields of c.
+func ContextSnapshot(c *models.Context) map[string]interface{} {
+	if c == nil {
+		return nil
+	}
+	s := map[string]interface{}{"name": c.Name, "description": ""}
+	if c.Description != nil {
+		s["description"] = *c.Description
+	}
+	return s
+}
+
+// PackSnapshot captures the restorable fields of a context pack. Its
+// members are not restored with it.
+func PackSnapshot(p *api.ContextPack) map[string]interface{} {
+	if p == nil {
+		return nil
+	}
+	s := map[string]interface{}{
+		"name":        p.Name,
+		"type":        p.Type,
+		"status":      p.Status,
+		"tags":        append([]string{}, p.Tags...),
+		"description": "",
+	}
+	if p.Description != nil {
+		s["description"] = *p.Description
+	}
+	return s
+}
+
+// Update builds an update op for fields of an entity whose prior state is
+// before.
+func Update(kind, id string, before map[string]interface{}, fields ...string) Op {
+	return Op{Kind: kind, Action: ActionUpdate, ID: id, Fields: fields, Before: before}
+}
+
+// Delete builds a delete op; before is needed to re-create the entity.
+func Delete(kind, id string, before map[string]interface{}) Op {
+	return Op{Kind: kind, Action: ActionDelete, ID: id, Before: before}
+}
+
+// Create builds a create op, undone by deleting id.
+func Create(kind, id string) Op {
+	return Op{Kind: kind, Action: ActionCreate, ID: id}
+}
+
+// tagStrings flattens the backend's loosely typed tags.
+func tagStrings(tags interface{}) []string {
+	switch v := tags.(type) {
+	case []string:
+		return v
+	case []interface{}:
+		out := make([]string, 0, len(v))
+		for _, t := range v {
+			if s, ok := t.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return []string{}
+}
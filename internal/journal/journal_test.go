@@ -0,0 +1,340 @@
+package journal
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kutbudev/ramorie-cli/internal/api"
+	"github.com/kutbudev/ramorie-cli/internal/models"
+)
+
+func testStore(t *testing.T) *Store {
+	t.Helper()
+	return &Store{Path: filepath.Join(t.TempDir(), "nested", "journal.jsonl")}
+}
+
+func TestStoreAppendAndMarkUndone(t *testing.T) {
+	s := testStore(t)
+	if entries, err := s.Entries(); err != nil || len(entries) != 0 {
+		t.Fatalf("empty journal: %v, %v", entries, err)
+	}
+	if e, err := s.Append(Entry{Command: "noop"}); err != nil || e.Seq != 0 {
+		t.Fatalf("entries without ops must be skipped: %+v, %v", e, err)
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := s.Append(Entry{Command: "task delete", Ops: []Op{Create(KindTask, "t")}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	info, err := os.Stat(s.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("journal mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	if err := s.MarkUndone(2, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.MarkUndone(9, time.Now()); err == nil {
+		t.Error("MarkUndone of a missing entry should fail")
+	}
+	entries, _ := s.Entries()
+	if len(entries) != 3 || entries[2].Seq != 3 || !entries[1].Undone() || entries[0].Undone() {
+		t.Errorf("entries = %+v", entries)
+	}
+}
+
+func TestStoreConcurrentAppends(t *testing.T) {
+	path := testStore(t).Path
+	const writers = 20
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// A Store per writer, like separate processes sharing the file.
+			s := &Store{Path: path}
+			if _, err := s.Append(Entry{Command: "memory forget", Ops: []Op{Create(KindMemory, "m")}}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	entries, err := (&Store{Path: path}).Entries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != writers {
+		t.Fatalf("got %d entries, want %d", len(entries), writers)
+	}
+	for i, e := range entries {
+		if e.Seq != i+1 {
+			t.Errorf("entry %d has seq %d", i, e.Seq)
+		}
+	}
+}
+
+func TestStoreTrimsToMaxEntries(t *testing.T) {
+	s := testStore(t)
+	var b strings.Builder
+	for i := 1; i <= MaxEntries; i++ {
+		line, _ := json.Marshal(Entry{Seq: i, Command: "x", Ops: []Op{Create(KindTask, "t")}})
+		b.Write(line)
+		b.WriteByte('\n')
+	}
+	os.MkdirAll(filepath.Dir(s.Path), 0o700)
+	os.WriteFile(s.Path, []byte(b.String()+"not json\n"), 0o600)
+
+	e, err := s.Append(Entry{Command: "y", Ops: []Op{Create(KindTask, "t")}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries, _ := s.Entries()
+	if e.Seq != MaxEntries+1 || len(entries) != MaxEntries || entries[0].Seq != 2 {
+		t.Errorf("seq=%d len=%d first=%d", e.Seq, len(entries), entries[0].Seq)
+	}
+}
+
+func TestSnapshotsKeepCiphertextOnly(t *testing.T) {
+	task := &models.Task{
+		ID: uuid.New(), ProjectID: uuid.New(), Title: "secret title", Description: "secret body",
+		Status: "TODO", Priority: "H", Tags: []interface{}{"a"},
+		IsEncrypted: true, EncryptedTitle: "CT", TitleNonce: "N1", EncryptedDescription: "CD", DescriptionNonce: "N2",
+	}
+	mem := &models.Memory{ID: uuid.New(), Content: "secret memory", Type: "decision",
+		IsEncrypted: true, EncryptedContent: "CM", ContentNonce: "N3"}
+
+	raw, _ := json.Marshal([]map[string]interface{}{TaskSnapshot(task), MemorySnapshot(mem)})
+	if strings.Contains(string(raw), "secret") {
+		t.Fatalf("plaintext leaked into snapshot: %s", raw)
+	}
+	if !strings.Contains(string(raw), `"encrypted_title":"CT"`) || !strings.Contains(string(raw), `"encrypted_content":"CM"`) {
+		t.Errorf("ciphertext missing: %s", raw)
+	}
+	if TaskSnapshot(nil) != nil || MemorySnapshot(nil) != nil || SubtaskSnapshot(nil) != nil {
+		t.Error("nil models should give nil snapshots")
+	}
+}
+
+func TestUpdatePayload(t *testing.T) {
+	enc := map[string]interface{}{"is_encrypted": true, "encrypted_title": "CT", "title_nonce": "N", "status": "TODO"}
+	p, skipped := UpdatePayload(enc, []string{"title", "status", "progress"})
+	if p["encrypted_title"] != "CT" || p["title_nonce"] != "N" || p["status"] != "TODO" || p["title"] != nil {
+		t.Errorf("payload = %v", p)
+	}
+	if len(skipped) != 1 || skipped[0] != "progress" {
+		t.Errorf("skipped = %v", skipped)
+	}
+
+	plain := map[string]interface{}{"title": "T", "project_id": "p1"}
+	p, _ = UpdatePayload(plain, []string{"title", "project_id"})
+	if p["title"] != "T" || p["project_id"] != "p1" || p["is_encrypted"] != nil {
+		t.Errorf("plain payload = %v", p)
+	}
+}
+
+// fakeClient records calls and hands out fresh IDs for creates.
+type fakeClient struct {
+	calls []string
+	last  map[string]interface{}
+}
+
+func (f *fakeClient) log(s string) { f.calls = append(f.calls, s) }
+
+func (f *fakeClient) CreateTask(projectID, title, description, priority string, tags ...string) (*models.Task, error) {
+	f.log("CreateTask " + title)
+	return &models.Task{ID: uuid.MustParse("11111111-0000-0000-0000-000000000000")}, nil
+}
+func (f *fakeClient) CreateEncryptedTask(projectID, encryptedTitle, titleNonce, encryptedDesc, descNonce, priority string, tags ...string) (*models.Task, error) {
+	f.log("CreateEncryptedTask " + encryptedTitle + "/" + titleNonce)
+	return &models.Task{ID: uuid.MustParse("22222222-0000-0000-0000-000000000000")}, nil
+}
+func (f *fakeClient) UpdateTask(id string, data map[string]interface{}) (*models.Task, error) {
+	f.log("UpdateTask " + id[:8])
+	f.last = data
+	return &models.Task{}, nil
+}
+func (f *fakeClient) DeleteTask(id string) error { f.log("DeleteTask " + id); return nil }
+func (f *fakeClient) CreateMemoryWithOptions(opts api.CreateMemoryOptions) (*models.Memory, error) {
+	f.log("CreateMemory " + opts.Content + " scope=" + opts.Scope)
+	return &models.Memory{ID: uuid.New()}, nil
+}
+func (f *fakeClient) CreateEncryptedMemoryWithOptions(opts api.CreateEncryptedMemoryOptions) (*models.Memory, error) {
+	f.log("CreateEncryptedMemory " + opts.EncryptedContent + " hash=" + opts.ContentHash)
+	return &models.Memory{ID: uuid.New()}, nil
+}
+func (f *fakeClient) UpdateMemory(id string, updates map[string]interface{}) (*models.Memory, error) {
+	f.log("UpdateMemory " + id)
+	f.last = updates
+	return &models.Memory{}, nil
+}
+func (f *fakeClient) DeleteMemory(id string) error { f.log("DeleteMemory " + id); return nil }
+func (f *fakeClient) CreateSubtask(taskID, description string) (*models.Subtask, error) {
+	f.log("CreateSubtask " + description)
+	return &models.Subtask{ID: uuid.New()}, nil
+}
+func (f *fakeClient) CreateChildSubtask(taskID, parentSubtaskID, description string) (*models.Subtask, error) {
+	f.log("CreateChildSubtask " + parentSubtaskID + " " + description)
+	return &models.Subtask{ID: uuid.New()}, nil
+}
+func (f *fakeClient) UpdateSubtask(subtaskID string, req api.UpdateSubtaskRequest) (*models.Subtask, error) {
+	f.log("UpdateSubtask " + subtaskID)
+	return &models.Subtask{}, nil
+}
+func (f *fakeClient) MoveSubtask(subtaskID, parentSubtaskID string) (*models.Subtask, error) {
+	f.log("MoveSubtask " + subtaskID + " → " + parentSubtaskID)
+	return &models.Subtask{}, nil
+}
+func (f *fakeClient) DeleteSubtask(subtaskID string) error {
+	f.log("DeleteSubtask " + subtaskID)
+	return nil
+}
+func (f *fakeClient) CreateProject(name, description string) (*models.Project, error) {
+	f.log("CreateProject " + name)
+	return &models.Project{ID: uuid.MustParse("33333333-0000-0000-0000-000000000000")}, nil
+}
+func (f *fakeClient) UpdateProject(id string, data map[string]interface{}) (*models.Project, error) {
+	f.log("UpdateProject " + id[:8])
+	f.last = data
+	return &models.Project{}, nil
+}
+func (f *fakeClient) DeleteProject(id string) error { f.log("DeleteProject " + id); return nil }
+func (f *fakeClient) CreateContext(name, description string) (*models.Context, error) {
+	f.log("CreateContext " + name)
+	return &models.Context{ID: uuid.New()}, nil
+}
+func (f *fakeClient) DeleteContext(id string) error { f.log("DeleteContext " + id); return nil }
+func (f *fakeClient) CreateContextPack(name, packType, description, status string, tags []string) (*api.ContextPack, error) {
+	f.log("CreateContextPack " + name + " " + packType)
+	return &api.ContextPack{ID: uuid.NewString()}, nil
+}
+func (f *fakeClient) UpdateContextPack(id string, updates map[string]interface{}) (*api.ContextPack, error) {
+	f.log("UpdateContextPack " + id)
+	f.last = updates
+	return &api.ContextPack{}, nil
+}
+func (f *fakeClient) DeleteContextPack(id string) error { f.log("DeleteContextPack " + id); return nil }
+
+func TestRevertOrderAndRemap(t *testing.T) {
+	old := "aaaaaaaa-0000-0000-0000-000000000000"
+	deleted := Entry{Seq: 2, Command: "task delete", Ops: []Op{
+		Delete(KindTask, old, map[string]interface{}{
+			"project_id": "p", "status": "IN_PROGRESS", "priority": "H",
+			"is_encrypted": true, "encrypted_title": "CT", "title_nonce": "N",
+		}),
+	}}
+	updated := Entry{Seq: 1, Command: "task update", Ops: []Op{
+		Update(KindTask, old, map[string]interface{}{"priority": "L"}, "priority"),
+	}}
+
+	f := &fakeClient{}
+	remap := map[string]string{}
+	for _, e := range []Entry{deleted, updated} {
+		if _, err := Revert(f, e, remap); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := []string{
+		"CreateEncryptedTask CT/N",
+		"UpdateTask 22222222", // status fix-up after re-create
+		"UpdateTask 22222222", // the older update follows the new ID
+	}
+	if strings.Join(f.calls, "|") != strings.Join(want, "|") {
+		t.Errorf("calls = %v, want %v", f.calls, want)
+	}
+	if f.last["priority"] != "L" {
+		t.Errorf("last payload = %v", f.last)
+	}
+}
+
+func TestRevertCreatesAndMissingSnapshot(t *testing.T) {
+	f := &fakeClient{}
+	e := Entry{Ops: []Op{Create(KindTask, "t1"), Create(KindMemory, "m1"), Create(KindSubtask, "s1")}}
+	notes, err := Revert(f, e, map[string]string{})
+	if err != nil || len(notes) != 3 {
+		t.Fatalf("notes=%v err=%v", notes, err)
+	}
+	if strings.Join(f.calls, "|") != "DeleteSubtask s1|DeleteMemory m1|DeleteTask t1" {
+		t.Errorf("ops must be reverted newest first: %v", f.calls)
+	}
+
+	if _, err := Revert(f, Entry{Ops: []Op{Delete(KindMemory, "m2", nil)}}, map[string]string{}); err == nil {
+		t.Error("a delete without snapshot cannot be undone")
+	}
+}
+
+func TestRevertSubtaskMoveAndGlobalMemory(t *testing.T) {
+	f := &fakeClient{}
+	sub := SubtaskSnapshot(&models.Subtask{ID: uuid.New(), TaskID: uuid.New(), Description: "d"})
+	if _, err := Revert(f, Entry{Ops: []Op{Update(KindSubtask, "s1", sub, "parent_subtask_id")}}, map[string]string{}); err != nil {
+		t.Fatal(err)
+	}
+	mem := MemorySnapshot(&models.Memory{Content: "hello", Type: "general"})
+	if _, err := Revert(f, Entry{Ops: []Op{Delete(KindMemory, "m1", mem)}}, map[string]string{}); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"MoveSubtask s1 → ", "CreateMemory hello scope=personal"}
+	if strings.Join(f.calls, "|") != strings.Join(want, "|") {
+		t.Errorf("calls = %v, want %v", f.calls, want)
+	}
+}
+
+func TestRevertEncryptedMemoryDeleteKeepsAccess(t *testing.T) {
+	f := &fakeClient{}
+	mem := MemorySnapshot(&models.Memory{
+		ProjectID: uuid.New(), Type: "decision", IsEncrypted: true,
+		EncryptedContent: "CT", ContentNonce: "N", ContentHash: "H",
+		Visibility: "private", Readers: []string{"u1"}, Writers: []string{"u2"},
+		EncryptionScope: "organization", EncryptionOrgID: "o1",
+	})
+	// Snapshots come back from the journal file as decoded JSON.
+	raw, _ := json.Marshal(mem)
+	var decoded map[string]interface{}
+	_ = json.Unmarshal(raw, &decoded)
+	if _, err := Revert(f, Entry{Ops: []Op{Delete(KindMemory, "m1", decoded)}}, map[string]string{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(f.calls) != 2 || f.calls[0] != "CreateEncryptedMemory CT hash=H" || !strings.HasPrefix(f.calls[1], "UpdateMemory ") {
+		t.Fatalf("calls = %v", f.calls)
+	}
+	if f.last["visibility"] != "private" || f.last["encryption_scope"] != "organization" ||
+		strings.Join(stringSlice(f.last["readers"]), ",") != "u1" || strings.Join(stringSlice(f.last["writers"]), ",") != "u2" {
+		t.Errorf("follow-up update = %v", f.last)
+	}
+}
+
+func TestRevertProjectsAndIrreversibleKinds(t *testing.T) {
+	f := &fakeClient{}
+	deleted := Entry{Ops: []Op{Delete(KindProject, "p-old", map[string]interface{}{
+		"name": "api", "description": "d", "encryption_required": true,
+		"configuration": map[string]interface{}{"kanban": "x"},
+	})}}
+	if _, err := Revert(f, deleted, map[string]string{}); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(f.calls, "|") != "CreateProject api|UpdateProject 33333333" {
+		t.Errorf("calls = %v", f.calls)
+	}
+	if f.last["encryption_required"] != true || f.last["configuration"] == nil {
+		t.Errorf("fix-up payload = %v", f.last)
+	}
+
+	noted := Entry{Ops: []Op{Create(KindNote, "n1")}}
+	if noted.Reversible() {
+		t.Error("note creation reported as reversible")
+	}
+	if _, err := Revert(f, noted, map[string]string{}); err == nil {
+		t.Error("reverting a note should fail")
+	}
+	if !deleted.Reversible() {
+		t.Error("project delete reported as irreversible")
+	}
+}
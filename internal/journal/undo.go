@@ -0,0 +1,401 @@
+package journal
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/kutbudev/ramorie-cli/internal/api"
+	"github.com/kutbudev/ramorie-cli/internal/models"
+)
+
+// Client is the subset of *api.Client that Revert replays through. It is an
+// interface so undo is testable without network.
+type Client interface {
+	CreateTask(projectID, title, description, priority string, tags ...string) (*models.Task, error)
+	CreateEncryptedTask(projectID, encryptedTitle, titleNonce, encryptedDesc, descNonce, priority string, tags ...string) (*models.Task, error)
+	UpdateTask(id string, data map[string]interface{}) (*models.Task, error)
+	DeleteTask(id string) error
+	CreateMemoryWithOptions(opts api.CreateMemoryOptions) (*models.Memory, error)
+	CreateEncryptedMemoryWithOptions(opts api.CreateEncryptedMemoryOptions) (*models.Memory, error)
+	UpdateMemory(id string, updates map[string]interface{}) (*models.Memory, error)
+	DeleteMemory(id string) error
+	CreateSubtask(taskID, description string) (*models.Subtask, error)
+	CreateChildSubtask(taskID, parentSubtaskID, description string) (*models.Subtask, error)
+	UpdateSubtask(subtaskID string, req api.UpdateSubtaskRequest) (*models.Subtask, error)
+	MoveSubtask(subtaskID, parentSubtaskID string) (*models.Subtask, error)
+	DeleteSubtask(subtaskID string) error
+	CreateProject(name, description string) (*models.Project, error)
+	UpdateProject(id string, data map[string]interface{}) (*models.Project, error)
+	DeleteProject(id string) error
+	CreateContext(name, description string) (*models.Context, error)
+	DeleteContext(id string) error
+	CreateContextPack(name, packType, description, status string, tags []string) (*api.ContextPack, error)
+	UpdateContextPack(id string, updates map[string]interface{}) (*api.ContextPack, error)
+	DeleteContextPack(id string) error
+}
+
+var _ Client = (*api.Client)(nil)
+
+// cipherFields maps a plaintext field to the ciphertext fields that replace
+// it on encrypted snapshots.
+var cipherFields = map[string][]string{
+	"title":       {"encrypted_title", "title_nonce"},
+	"description": {"encrypted_description", "description_nonce"},
+	"content":     {"encrypted_content", "content_nonce"},
+}
+
+// Revert replays the inverse of every op in e, newest op first, and returns
+// human-readable notes about what was restored. Re-created entities get new
+// IDs; remap (old → new) is consulted and extended so that reverting several
+// entries in a row follows an entity across a delete + restore. It stops at
+// the first failing op.
+func Revert(c Client, e Entry, remap map[string]string) ([]string, error) {
+	var notes []string
+	for i := len(e.Ops) - 1; i >= 0; i-- {
+		op := e.Ops[i]
+		if id, ok := remap[op.ID]; ok {
+			op.ID = id
+		}
+		note, err := revertOp(c, op, remap)
+		if err != nil {
+			return notes, fmt.Errorf("%s %s %s: %w", op.Action, op.Kind, short(op.ID), err)
+		}
+		notes = append(notes, note)
+	}
+	return notes, nil
+}
+
+func revertOp(c Client, op Op, remap map[string]string) (string, error) {
+	if irreversible[op.Kind] {
+		return "", fmt.Errorf("a %s cannot be removed through the API", op.Kind)
+	}
+	switch op.Action {
+	case ActionCreate:
+		var err error
+		switch op.Kind {
+		case KindTask:
+			err = c.DeleteTask(op.ID)
+		case KindMemory:
+			err = c.DeleteMemory(op.ID)
+		case KindSubtask:
+			err = c.DeleteSubtask(op.ID)
+		case KindProject:
+			err = c.DeleteProject(op.ID)
+		case KindContext:
+			err = c.DeleteContext(op.ID)
+		case KindPack:
+			err = c.DeleteContextPack(op.ID)
+		default:
+			return "", fmt.Errorf("unknown kind %q", op.Kind)
+		}
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("deleted %s %s", op.Kind, short(op.ID)), nil
+
+	case ActionUpdate:
+		if op.Before == nil {
+			return "", fmt.Errorf("no snapshot was recorded")
+		}
+		payload, skipped := UpdatePayload(op.Before, op.Fields)
+		if len(payload) == 0 {
+			return "", fmt.Errorf("none of the fields (%s) can be restored", strings.Join(op.Fields, ", "))
+		}
+		var err error
+		switch op.Kind {
+		case KindTask:
+			_, err = c.UpdateTask(op.ID, payload)
+		case KindMemory:
+			_, err = c.UpdateMemory(op.ID, payload)
+		case KindSubtask:
+			if parent, ok := payload["parent_subtask_id"].(string); ok {
+				if remapped, ok := remap[parent]; ok {
+					parent = remapped
+				}
+				_, err = c.MoveSubtask(op.ID, parent)
+				delete(payload, "parent_subtask_id")
+			}
+			if req := subtaskRequest(payload); err == nil && req != (api.UpdateSubtaskRequest{}) {
+				_, err = c.UpdateSubtask(op.ID, req)
+			}
+		case KindProject:
+			_, err = c.UpdateProject(op.ID, payload)
+		case KindPack:
+			_, err = c.UpdateContextPack(op.ID, payload)
+		default:
+			return "", fmt.Errorf("unknown kind %q", op.Kind)
+		}
+		if err != nil {
+			return "", err
+		}
+		note := fmt.Sprintf("restored %s of %s %s", strings.Join(restoredFields(op.Fields, skipped), ", "), op.Kind, short(op.ID))
+		if len(skipped) > 0 {
+			note += fmt.Sprintf(" (cannot restore %s)", strings.Join(skipped, ", "))
+		}
+		return note, nil
+
+	case ActionDelete:
+		if op.Before == nil {
+			return "", fmt.Errorf("no snapshot was recorded")
+		}
+		newID, err := recreate(c, op.Kind, op.Before)
+		if err != nil {
+			return "", err
+		}
+		remap[op.ID] = newID
+		note := fmt.Sprintf("re-created %s %s as %s", op.Kind, short(op.ID), short(newID))
+		switch op.Kind {
+		case KindProject:
+			note += " (its tasks and memories are not restored)"
+		case KindPack:
+			note += " (its members are not restored)"
+		}
+		return note, nil
+	}
+	return "", fmt.Errorf("unknown action %q", op.Action)
+}
+
+// UpdatePayload selects the snapshot values for fields. On encrypted
+// snapshots title/description/content resolve to their ciphertext and nonce.
+// Fields the snapshot does not hold (e.g. progress) are returned as skipped.
+func UpdatePayload(before map[string]interface{}, fields []string) (payload map[string]interface{}, skipped []string) {
+	payload = map[string]interface{}{}
+	encrypted, _ := before["is_encrypted"].(bool)
+	for _, f := range fields {
+		if enc, ok := cipherFields[f]; ok && encrypted {
+			f = enc[0]
+		}
+		if pair := cipherPair(f); pair != nil {
+			if _, ok := before[pair[0]]; ok {
+				payload[pair[0]] = before[pair[0]]
+				payload[pair[1]] = before[pair[1]]
+				payload["is_encrypted"] = true
+				continue
+			}
+		}
+		if v, ok := before[f]; ok {
+			payload[f] = v
+			continue
+		}
+		skipped = append(skipped, f)
+	}
+	return payload, skipped
+}
+
+// cipherPair returns {ciphertext, nonce} when f names either half.
+func cipherPair(f string) []string {
+	for _, pair := range cipherFields {
+		if f == pair[0] || f == pair[1] {
+			return pair
+		}
+	}
+	return nil
+}
+
+func restoredFields(fields, skipped []string) []string {
+	var out []string
+	for _, f := range fields {
+		if !containsString(skipped, f) {
+			out = append(out, f)
+		}
+	}
+	if len(out) == 0 {
+		return []string{"fields"}
+	}
+	return out
+}
+
+// recreate restores a deleted entity from its snapshot and returns the new ID.
+func recreate(c Client, kind string, s map[string]interface{}) (string, error) {
+	str := func(k string) string { v, _ := s[k].(string); return v }
+	encrypted, _ := s["is_encrypted"].(bool)
+	tags := stringSlice(s["tags"])
+
+	switch kind {
+	case KindTask:
+		var t *models.Task
+		var err error
+		if encrypted {
+			t, err = c.CreateEncryptedTask(str("project_id"), str("encrypted_title"), str("title_nonce"),
+				str("encrypted_description"), str("description_nonce"), str("priority"), tags...)
+		} else {
+			t, err = c.CreateTask(str("project_id"), str("title"), str("description"), str("priority"), tags...)
+		}
+		if err != nil {
+			return "", err
+		}
+		// Creation always starts at TODO and in the personal scope.
+		fix := map[string]interface{}{}
+		if st := str("status"); st != "" && st != "TODO" {
+			fix["status"] = st
+		}
+		if scope := str("encryption_scope"); scope != "" {
+			fix["encryption_scope"] = scope
+			fix["encryption_org_id"] = str("encryption_org_id")
+		}
+		if len(fix) > 0 {
+			if _, err := c.UpdateTask(t.ID.String(), fix); err != nil {
+				return t.ID.String(), err
+			}
+		}
+		return t.ID.String(), nil
+
+	case KindMemory:
+		scope := ""
+		if str("project_id") == uuid.Nil.String() {
+			scope = "personal"
+		}
+		var m *models.Memory
+		var err error
+		if encrypted {
+			m, err = c.CreateEncryptedMemoryWithOptions(api.CreateEncryptedMemoryOptions{
+				ProjectID: str("project_id"), EncryptedContent: str("encrypted_content"), ContentNonce: str("content_nonce"),
+				ContentHash: str("content_hash"), Type: str("type"), Tags: tags, Trigger: str("trigger"), Steps: stringSlice(s["steps"]),
+				Validation: str("validation"), Scope: scope,
+			})
+		} else {
+			m, err = c.CreateMemoryWithOptions(api.CreateMemoryOptions{
+				ProjectID: str("project_id"), Content: str("content"),
+				Type: str("type"), Tags: tags, Trigger: str("trigger"), Steps: stringSlice(s["steps"]),
+				Validation: str("validation"), Scope: scope,
+				Visibility: str("visibility"), Readers: stringSlice(s["readers"]), Writers: stringSlice(s["writers"]),
+			})
+		}
+		if err != nil {
+			return "", err
+		}
+		// The encrypted create takes neither the access lists nor the scope.
+		fix := map[string]interface{}{}
+		if encrypted {
+			if v := str("visibility"); v != "" {
+				fix["visibility"] = v
+			}
+			if r := stringSlice(s["readers"]); len(r) > 0 {
+				fix["readers"] = r
+			}
+			if w := stringSlice(s["writers"]); len(w) > 0 {
+				fix["writers"] = w
+			}
+		}
+		if scope := str("encryption_scope"); scope != "" {
+			fix["encryption_scope"] = scope
+			fix["encryption_org_id"] = str("encryption_org_id")
+		}
+		if len(fix) > 0 {
+			if _, err := c.UpdateMemory(m.ID.String(), fix); err != nil {
+				return m.ID.String(), err
+			}
+		}
+		return m.ID.String(), nil
+
+	case KindSubtask:
+		var st *models.Subtask
+		var err error
+		if parent := str("parent_subtask_id"); parent != "" {
+			st, err = c.CreateChildSubtask(str("task_id"), parent, str("description"))
+		} else {
+			st, err = c.CreateSubtask(str("task_id"), str("description"))
+		}
+		if err != nil {
+			return "", err
+		}
+		req := subtaskRequest(map[string]interface{}{"status": s["status"], "priority": s["priority"], "completed": s["completed"]})
+		if req.Status != nil || req.Priority != nil || (req.Completed != nil && *req.Completed != 0) {
+			if _, err := c.UpdateSubtask(st.ID.String(), req); err != nil {
+				return st.ID.String(), err
+			}
+		}
+		return st.ID.String(), nil
+
+	case KindProject:
+		p, err := c.CreateProject(str("name"), str("description"))
+		if err != nil {
+			return "", err
+		}
+		fix := map[string]interface{}{}
+		if cfg, ok := s["configuration"].(map[string]interface{}); ok && len(cfg) > 0 {
+			fix["configuration"] = cfg
+		}
+		if required, _ := s["encryption_required"].(bool); required {
+			fix["encryption_required"] = true
+		}
+		if len(fix) > 0 {
+			if _, err := c.UpdateProject(p.ID.String(), fix); err != nil {
+				return p.ID.String(), err
+			}
+		}
+		return p.ID.String(), nil
+
+	case KindContext:
+		ctx, err := c.CreateContext(str("name"), str("description"))
+		if err != nil {
+			return "", err
+		}
+		return ctx.ID.String(), nil
+
+	case KindPack:
+		p, err := c.CreateContextPack(str("name"), str("type"), str("description"), str("status"), tags)
+		if err != nil {
+			return "", err
+		}
+		return p.ID, nil
+	}
+	return "", fmt.Errorf("unknown kind %q", kind)
+}
+
+// subtaskRequest converts a snapshot payload into the typed subtask update.
+// Empty strings are left out.
+func subtaskRequest(p map[string]interface{}) api.UpdateSubtaskRequest {
+	var req api.UpdateSubtaskRequest
+	if v, ok := p["description"].(string); ok && v != "" {
+		req.Description = &v
+	}
+	if v, ok := p["status"].(string); ok && v != "" {
+		req.Status = &v
+	}
+	if v, ok := p["priority"].(string); ok && v != "" {
+		req.Priority = &v
+	}
+	switch v := p["completed"].(type) {
+	case int:
+		req.Completed = &v
+	case float64: // after a JSON round trip
+		n := int(v)
+		req.Completed = &n
+	}
+	return req
+}
+
+// stringSlice accepts []string or the []interface{} a JSON round trip yields.
+func stringSlice(v interface{}) []string {
+	switch s := v.(type) {
+	case []string:
+		return s
+	case []interface{}:
+		out := make([]string, 0, len(s))
+		for _, x := range s {
+			if str, ok := x.(string); ok {
+				out = append(out, str)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, x := range list {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
+
+func short(id string) string {
+	if len(id) > 8 {
+		return id[:8]
+	}
+	return id
+}
@@ -0,0 +1,340 @@
+// Package kanban holds the board definition shared by `ramorie kanban` and
+// the TUI kanban pane. A board lives in the project's configuration JSON
+// under the "kanban" key; projects without one get the classic three
+// columns.
+//
+// Example (project configuration):
+//
+//	{
+//	  "kanban": {
+//	    "columns": [
+//	      {"title": "Todo",   "status": "TODO"},
+//	      {"title": "Doing",  "status": "IN_PROGRESS", "wip": 3},
+//	      {"title": "Review", "status": "IN_PROGRESS", "tag": "review", "wip": 2},
+//	      {"title": "Done",   "status": "COMPLETED"}
+//	    ],
+//	    "swimlanes": "priority"
+//	  }
+//	}
+//
+// A column matches a task by status and, when set, by tag. Tag columns win
+// over plain columns of the same status, so a task tagged "review" lands in
+// Review rather than Doing.
+package kanban
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kutbudev/ramorie-cli/internal/models"
+)
+
+// ConfigKey is the project-configuration key holding the board.
+const ConfigKey = "kanban"
+
+// Swimlane modes.
+const (
+	LanesNone     = ""
+	LanesPriority = "priority"
+	LanesTag      = "tag"
+)
+
+// validStatuses are the task statuses the backend knows about.
+var validStatuses = map[string]bool{"TODO": true, "IN_PROGRESS": true, "COMPLETED": true}
+
+// Column is one board column.
+type Column struct {
+	Title  string `json:"title"`
+	Status string `json:"status"`
+	Tag    string `json:"tag,omitempty"`
+	WIP    int    `json:"wip,omitempty"` // 0 = unlimited
+}
+
+// Board is a full board definition.
+type Board struct {
+	Columns   []Column `json:"columns"`
+	Swimlanes string   `json:"swimlanes,omitempty"` // "", "priority" or "tag"
+	LaneTags  []string `json:"lane_tags,omitempty"` // lane order for tag swimlanes
+}
+
+// Default is the board used when the project defines none.
+func Default() Board {
+	return Board{Columns: []Column{
+		{Title: "📝 TODO", Status: "TODO"},
+		{Title: "🚀 IN PROGRESS", Status: "IN_PROGRESS"},
+		{Title: "✅ COMPLETED", Status: "COMPLETED"},
+	}}
+}
+
+// FromConfiguration reads the board from a project's configuration map. A
+// missing key yields Default; a present but invalid board is an error so a
+// typo does not silently fall back.
+func FromConfiguration(cfg map[string]interface{}) (Board, error) {
+	raw, ok := cfg[ConfigKey]
+	if !ok || raw == nil {
+		return Default(), nil
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return Board{}, err
+	}
+	return Parse(data)
+}
+
+// Parse decodes and validates a board from JSON.
+func Parse(data []byte) (Board, error) {
+	var b Board
+	if err := json.Unmarshal(data, &b); err != nil {
+		return Board{}, fmt.Errorf("invalid kanban board: %w", err)
+	}
+	b.normalize()
+	if err := b.Validate(); err != nil {
+		return Board{}, err
+	}
+	return b, nil
+}
+
+func (b *Board) normalize() {
+	for i := range b.Columns {
+		c := &b.Columns[i]
+		c.Status = strings.ToUpper(strings.TrimSpace(c.Status))
+		c.Tag = strings.TrimSpace(c.Tag)
+		if strings.TrimSpace(c.Title) == "" {
+			c.Title = c.Status
+			if c.Tag != "" {
+				c.Title += " · " + c.Tag
+			}
+		}
+	}
+	b.Swimlanes = strings.ToLower(strings.TrimSpace(b.Swimlanes))
+}
+
+// Validate reports the first problem with the definition.
+func (b Board) Validate() error {
+	if len(b.Columns) == 0 {
+		return fmt.Errorf("kanban board needs at least one column")
+	}
+	for i, c := range b.Columns {
+		if !validStatuses[c.Status] {
+			return fmt.Errorf("kanban column %d (%q): status must be TODO, IN_PROGRESS or COMPLETED", i+1, c.Title)
+		}
+		if c.WIP < 0 {
+			return fmt.Errorf("kanban column %d (%q): wip must be >= 0", i+1, c.Title)
+		}
+	}
+	switch b.Swimlanes {
+	case LanesNone, LanesPriority, LanesTag:
+	default:
+		return fmt.Errorf("kanban swimlanes must be \"priority\" or \"tag\", got %q", b.Swimlanes)
+	}
+	return nil
+}
+
+// Statuses lists the distinct statuses the board's columns draw from, in
+// column order — the set of ListTasks calls a caller has to make.
+func (b Board) Statuses() []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, c := range b.Columns {
+		if !seen[c.Status] {
+			seen[c.Status] = true
+			out = append(out, c.Status)
+		}
+	}
+	return out
+}
+
+// Lane is one horizontal band of the board; Cells[i] holds the tasks in
+// column i.
+type Lane struct {
+	Title string
+	Cells [][]models.Task
+}
+
+// Layout is a board with tasks placed into it.
+type Layout struct {
+	Board    Board
+	Lanes    []Lane
+	Counts   []int // tasks per column across all lanes
+	Unplaced int   // tasks no column accepts
+}
+
+// Total is the number of placed tasks.
+func (l Layout) Total() int {
+	n := 0
+	for _, c := range l.Counts {
+		n += c
+	}
+	return n
+}
+
+// OverLimit reports whether column i exceeds its WIP limit.
+func (l Layout) OverLimit(i int) bool {
+	w := l.Board.Columns[i].WIP
+	return w > 0 && l.Counts[i] > w
+}
+
+// Header is column i's title with its count, plus the limit when one is set
+// ("Review 3/2").
+func (l Layout) Header(i int) string {
+	c := l.Board.Columns[i]
+	if c.WIP > 0 {
+		return fmt.Sprintf("%s %d/%d", c.Title, l.Counts[i], c.WIP)
+	}
+	return fmt.Sprintf("%s %d", c.Title, l.Counts[i])
+}
+
+// Place sorts tasks into columns and swimlanes. Empty lanes are dropped;
+// a board without swimlanes always has exactly one lane.
+func (b Board) Place(tasks []models.Task) Layout {
+	l := Layout{Board: b, Counts: make([]int, len(b.Columns))}
+	laneIndex := map[string]int{}
+	for _, title := range b.laneOrder(tasks) {
+		laneIndex[title] = len(l.Lanes)
+		l.Lanes = append(l.Lanes, Lane{Title: title, Cells: make([][]models.Task, len(b.Columns))})
+	}
+	for _, t := range tasks {
+		col := b.columnFor(t)
+		if col < 0 {
+			l.Unplaced++
+			continue
+		}
+		lane := &l.Lanes[laneIndex[b.laneFor(t)]]
+		lane.Cells[col] = append(lane.Cells[col], t)
+		l.Counts[col]++
+	}
+	kept := l.Lanes[:0]
+	for _, lane := range l.Lanes {
+		for _, cell := range lane.Cells {
+			if len(cell) > 0 {
+				kept = append(kept, lane)
+				break
+			}
+		}
+	}
+	if len(kept) == 0 && len(l.Lanes) > 0 {
+		kept = append(kept, Lane{Cells: make([][]models.Task, len(b.Columns))})
+	}
+	l.Lanes = kept
+	return l
+}
+
+// columnFor returns the index of the column accepting t, or -1. A matching
+// tag column beats a plain column with the same status.
+func (b Board) columnFor(t models.Task) int {
+	status := strings.ToUpper(t.Status)
+	tags := TaskTags(t.Tags)
+	plain := -1
+	for i, c := range b.Columns {
+		if c.Status != status {
+			continue
+		}
+		if c.Tag == "" {
+			if plain < 0 {
+				plain = i
+			}
+			continue
+		}
+		if hasTag(tags, c.Tag) {
+			return i
+		}
+	}
+	return plain
+}
+
+// priorityLanes are the lane titles for priority swimlanes, high first.
+var priorityLanes = []struct{ code, title string }{
+	{"H", "High"}, {"M", "Medium"}, {"L", "Low"}, {"", "No priority"},
+}
+
+// otherLane collects tasks matching none of the tag lanes.
+const otherLane = "Other"
+
+func (b Board) laneOrder(tasks []models.Task) []string {
+	switch b.Swimlanes {
+	case LanesPriority:
+		out := make([]string, 0, len(priorityLanes))
+		for _, p := range priorityLanes {
+			out = append(out, p.title)
+		}
+		return out
+	case LanesTag:
+		lanes := b.LaneTags
+		if len(lanes) == 0 {
+			seen := map[string]bool{}
+			for _, t := range tasks {
+				for _, tag := range TaskTags(t.Tags) {
+					key := strings.ToLower(tag)
+					if !seen[key] {
+						seen[key] = true
+						lanes = append(lanes, key)
+					}
+				}
+			}
+			sort.Strings(lanes)
+		}
+		return append(append([]string{}, lanes...), otherLane)
+	}
+	return []string{""}
+}
+
+func (b Board) laneFor(t models.Task) string {
+	switch b.Swimlanes {
+	case LanesPriority:
+		p := strings.ToUpper(strings.TrimSpace(t.Priority))
+		for _, l := range priorityLanes[:3] {
+			if l.code == p {
+				return l.title
+			}
+		}
+		return priorityLanes[3].title
+	case LanesTag:
+		tags := TaskTags(t.Tags)
+		if len(b.LaneTags) > 0 {
+			for _, lane := range b.LaneTags {
+				if hasTag(tags, lane) {
+					return lane
+				}
+			}
+			return otherLane
+		}
+		if len(tags) == 0 {
+			return otherLane
+		}
+		sorted := make([]string, len(tags))
+		for i, tag := range tags {
+			sorted[i] = strings.ToLower(tag)
+		}
+		sort.Strings(sorted)
+		return sorted[0]
+	}
+	return ""
+}
+
+// TaskTags converts the backend's loosely typed tags into strings.
+func TaskTags(tags interface{}) []string {
+	switch v := tags.(type) {
+	case []string:
+		return v
+	case []interface{}:
+		out := make([]string, 0, len(v))
+		for _, t := range v {
+			if s, ok := t.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+func hasTag(tags []string, want string) bool {
+	for _, t := range tags {
+		if strings.EqualFold(t, want) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,159 @@
+package kanban
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kutbudev/ramorie-cli/internal/models"
+)
+
+func task(title, status, priority string, tags ...string) models.Task {
+	t := models.Task{ID: uuid.New(), Title: title, Status: status, Priority: priority}
+	if len(tags) > 0 {
+		raw := make([]interface{}, len(tags))
+		for i, tag := range tags {
+			raw[i] = tag
+		}
+		t.Tags = raw
+	}
+	return t
+}
+
+const reviewBoard = `{
+  "columns": [
+    {"title": "Todo",   "status": "todo"},
+    {"title": "Doing",  "status": "IN_PROGRESS", "wip": 1},
+    {"title": "Review", "status": "IN_PROGRESS", "tag": "review", "wip": 2},
+    {"status": "COMPLETED"}
+  ]
+}`
+
+func TestParse_NormalizesAndValidates(t *testing.T) {
+	b, err := Parse([]byte(reviewBoard))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Columns[0].Status != "TODO" || b.Columns[3].Title != "COMPLETED" {
+		t.Errorf("not normalized: %+v", b.Columns)
+	}
+	if got := strings.Join(b.Statuses(), ","); got != "TODO,IN_PROGRESS,COMPLETED" {
+		t.Errorf("Statuses = %s", got)
+	}
+
+	bad := []string{
+		`{"columns": []}`,
+		`{"columns": [{"title": "X", "status": "BLOCKED"}]}`,
+		`{"columns": [{"status": "TODO", "wip": -1}]}`,
+		`{"columns": [{"status": "TODO"}], "swimlanes": "assignee"}`,
+		`not json`,
+	}
+	for _, in := range bad {
+		if _, err := Parse([]byte(in)); err == nil {
+			t.Errorf("%s: expected an error", in)
+		}
+	}
+}
+
+func TestFromConfiguration_DefaultsWhenMissing(t *testing.T) {
+	b, err := FromConfiguration(map[string]interface{}{"other": 1})
+	if err != nil || len(b.Columns) != 3 || b.Columns[1].Status != "IN_PROGRESS" {
+		t.Fatalf("got %+v, %v", b, err)
+	}
+	cfg := map[string]interface{}{ConfigKey: map[string]interface{}{
+		"columns": []interface{}{map[string]interface{}{"title": "Only", "status": "TODO"}},
+	}}
+	if b, err := FromConfiguration(cfg); err != nil || len(b.Columns) != 1 {
+		t.Fatalf("got %+v, %v", b, err)
+	}
+}
+
+func TestPlace_TagColumnsAndWIP(t *testing.T) {
+	b, _ := Parse([]byte(reviewBoard))
+	l := b.Place([]models.Task{
+		task("a", "TODO", "H"),
+		task("b", "IN_PROGRESS", "M"),
+		task("c", "IN_PROGRESS", "M", "Review"),
+		task("d", "IN_PROGRESS", "L"),
+		task("e", "COMPLETED", "L", "review"),
+	})
+	want := []int{1, 2, 1, 1}
+	for i, n := range want {
+		if l.Counts[i] != n {
+			t.Fatalf("Counts = %v, want %v", l.Counts, want)
+		}
+	}
+	if !l.OverLimit(1) || l.OverLimit(2) {
+		t.Errorf("Doing (2/1) should be over its limit, Review (1/2) not")
+	}
+	if got := l.Header(2); got != "Review 1/2" {
+		t.Errorf("Header = %q", got)
+	}
+	if len(l.Lanes) != 1 {
+		t.Errorf("no swimlanes should give one lane, got %d", len(l.Lanes))
+	}
+}
+
+func TestPlace_UnplacedWhenNoColumnAccepts(t *testing.T) {
+	b, _ := Parse([]byte(`{"columns": [{"status": "IN_PROGRESS", "tag": "review"}]}`))
+	l := b.Place([]models.Task{task("a", "IN_PROGRESS", "M"), task("b", "TODO", "M")})
+	if l.Unplaced != 2 || l.Total() != 0 {
+		t.Fatalf("Unplaced=%d Total=%d", l.Unplaced, l.Total())
+	}
+}
+
+func TestPlace_Swimlanes(t *testing.T) {
+	tasks := []models.Task{
+		task("a", "TODO", "L", "backend"),
+		task("b", "TODO", "H", "frontend"),
+		task("c", "IN_PROGRESS", "H"),
+	}
+
+	b := Default()
+	b.Swimlanes = LanesPriority
+	l := b.Place(tasks)
+	if len(l.Lanes) != 2 || l.Lanes[0].Title != "High" || l.Lanes[1].Title != "Low" {
+		t.Fatalf("priority lanes: %+v", laneTitles(l))
+	}
+	if len(l.Lanes[0].Cells[0]) != 1 || len(l.Lanes[0].Cells[1]) != 1 {
+		t.Errorf("High lane should hold one todo and one in-progress task")
+	}
+
+	b.Swimlanes = LanesTag
+	l = b.Place(tasks)
+	if got := strings.Join(laneTitles(l), ","); got != "backend,frontend,Other" {
+		t.Errorf("derived tag lanes = %s", got)
+	}
+
+	b.LaneTags = []string{"frontend"}
+	l = b.Place(tasks)
+	if got := strings.Join(laneTitles(l), ","); got != "frontend,Other" {
+		t.Errorf("fixed tag lanes = %s", got)
+	}
+}
+
+func TestRender_HighlightsOverLimit(t *testing.T) {
+	b, _ := Parse([]byte(reviewBoard))
+	b.Swimlanes = LanesPriority
+	l := b.Place([]models.Task{
+		task("first", "IN_PROGRESS", "H"),
+		task("second", "IN_PROGRESS", "L"),
+	})
+	out := Render(l, 120, func(t *models.Task) string { return t.Title })
+	for _, want := range []string{"⚠ Doing 2/1", "▸ High", "▸ Low", "first", "second"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("render missing %q:\n%s", want, out)
+		}
+	}
+	if got := l.Summary(); got != "0 todo · 2 doing · 0 review · 0 completed" {
+		t.Errorf("Summary = %q", got)
+	}
+}
+
+func laneTitles(l Layout) []string {
+	out := make([]string, len(l.Lanes))
+	for i, lane := range l.Lanes {
+		out[i] = lane.Title
+	}
+	return out
+}
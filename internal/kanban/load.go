@@ -0,0 +1,43 @@
+package kanban
+
+import (
+	"sync"
+
+	"github.com/kutbudev/ramorie-cli/internal/api"
+	"github.com/kutbudev/ramorie-cli/internal/models"
+)
+
+// BoardFor fetches the project and returns its board definition.
+func BoardFor(c *api.Client, projectID string) (Board, error) {
+	p, err := c.GetProject(projectID)
+	if err != nil {
+		return Board{}, err
+	}
+	return FromConfiguration(p.Configuration)
+}
+
+// Load fetches the tasks for every status the board uses (in parallel, one
+// ListTasks call per status) and places them.
+func Load(c *api.Client, projectID string, b Board) (Layout, error) {
+	statuses := b.Statuses()
+	results := make([][]models.Task, len(statuses))
+	errs := make([]error, len(statuses))
+	var wg sync.WaitGroup
+	for i, s := range statuses {
+		wg.Add(1)
+		go func(i int, status string) {
+			defer wg.Done()
+			results[i], errs[i] = c.ListTasks(projectID, status)
+		}(i, s)
+	}
+	wg.Wait()
+	var tasks []models.Task
+	for i := range statuses {
+		// Surface the first non-nil error.
+		if errs[i] != nil {
+			return Layout{}, errs[i]
+		}
+		tasks = append(tasks, results[i]...)
+	}
+	return b.Place(tasks), nil
+}
@@ -0,0 +1,122 @@
+package kanban
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/kutbudev/ramorie-cli/internal/cli/display"
+	"github.com/kutbudev/ramorie-cli/internal/models"
+)
+
+// Column width bounds for Render.
+const (
+	minColWidth = 18
+	maxColWidth = 42
+)
+
+// TitleFunc returns the display title of a task; callers pass their own
+// decrypting helper so this package stays free of vault state.
+type TitleFunc func(t *models.Task) string
+
+// Summary is the one-line count shown next to the board title
+// ("3 todo · 2 doing · 5 done").
+func (l Layout) Summary() string {
+	parts := make([]string, 0, len(l.Counts)+1)
+	for i, c := range l.Board.Columns {
+		parts = append(parts, fmt.Sprintf("%d %s", l.Counts[i], strings.ToLower(plainTitle(c.Title))))
+	}
+	if l.Unplaced > 0 {
+		parts = append(parts, fmt.Sprintf("%d unplaced", l.Unplaced))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// Render draws the grid (column headers, swimlanes and task cells) to fit
+// width. Columns over their WIP limit get a highlighted header.
+func Render(l Layout, width int, title TitleFunc) string {
+	n := len(l.Board.Columns)
+	colWidth := (width - 1 - 3*(n-1)) / n
+	if colWidth < minColWidth {
+		colWidth = minColWidth
+	}
+	if colWidth > maxColWidth {
+		colWidth = maxColWidth
+	}
+
+	var b strings.Builder
+	headers := make([]string, n)
+	rules := make([]string, n)
+	for i := range l.Board.Columns {
+		h := display.Dim.Render(display.Truncate(l.Header(i), colWidth))
+		if l.OverLimit(i) {
+			h = display.Err.Render(display.Truncate("⚠ "+l.Header(i), colWidth))
+		}
+		headers[i] = pad(h, colWidth)
+		rules[i] = strings.Repeat("─", colWidth)
+	}
+	b.WriteString(" " + strings.Join(headers, " | ") + "\n")
+	b.WriteString(" " + strings.Join(rules, "-+-") + "\n")
+
+	if l.Total() == 0 {
+		b.WriteString(" " + display.Dim.Render("(no tasks in this project yet)") + "\n")
+		return b.String()
+	}
+
+	lanes := l.Board.Swimlanes != LanesNone
+	for li, lane := range l.Lanes {
+		if lanes {
+			if li > 0 {
+				b.WriteString("\n")
+			}
+			b.WriteString(" " + display.Label.Render("▸ "+lane.Title) + "\n")
+		}
+		rows := 0
+		for _, cell := range lane.Cells {
+			if len(cell) > rows {
+				rows = len(cell)
+			}
+		}
+		for r := 0; r < rows; r++ {
+			cells := make([]string, n)
+			for i, cell := range lane.Cells {
+				cells[i] = pad(renderCell(cell, r, colWidth, title), colWidth)
+			}
+			b.WriteString(" " + strings.Join(cells, " | ") + "\n")
+		}
+	}
+	return b.String()
+}
+
+func renderCell(tasks []models.Task, i, width int, title TitleFunc) string {
+	if i >= len(tasks) {
+		return ""
+	}
+	t := tasks[i]
+	text := display.SingleLine(title(&t))
+	budget := width - 13 // 3 (badge) + 1 + 8 (id) + 1 = 13
+	if budget < 8 {
+		budget = 8
+	}
+	return fmt.Sprintf("%s %s %s",
+		display.PriorityBadge(t.Priority),
+		display.Dim.Render(t.ID.String()[:8]),
+		display.Truncate(text, budget))
+}
+
+// pad right-pads a (possibly styled) string to visible width n.
+func pad(s string, n int) string {
+	if w := lipgloss.Width(s); w < n {
+		return s + strings.Repeat(" ", n-w)
+	}
+	return s
+}
+
+// plainTitle drops a leading emoji so "🚀 IN PROGRESS" reads "in progress"
+// in summaries.
+func plainTitle(s string) string {
+	if i := strings.IndexByte(s, ' '); i > 0 && s[0] >= 0x80 {
+		return strings.TrimSpace(s[i+1:])
+	}
+	return s
+}
@@ -0,0 +1,542 @@
+// Package localindex keeps a local BM25 index of memories so `find` and the
+// hooks still answer when the backend is down or slow.
+//
+// Memories enter the index when the CLI creates or fetches them (remember,
+// memory list, memory get) and on `ramorie index rebuild`. Plain memories
+// are stored as they came from the server. Memories the server holds
+// encrypted are indexed from their decrypted content, and that part of the
+// index is sealed with the vault key: it is never written in the clear and
+// is only searchable while the vault is unlocked.
+//
+// The index lives in ~/.ramorie/index.json (mode 0600).
+package localindex
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/kutbudev/ramorie-cli/internal/api"
+	"github.com/kutbudev/ramorie-cli/internal/crypto"
+	"github.com/kutbudev/ramorie-cli/internal/models"
+	"github.com/kutbudev/ramorie-cli/internal/similarity"
+	"github.com/kutbudev/ramorie-cli/internal/statefile"
+)
+
+// RankingMode is the FindMeta.RankingMode of responses served locally.
+const RankingMode = "local_fallback"
+
+// BM25 parameters, the usual defaults.
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+const fileVersion = 1
+
+// Doc is one indexed memory. Terms holds the term frequencies of its
+// title, content, tags and skill fields.
+type Doc struct {
+	ID        string         `json:"id"`
+	Type      string         `json:"type,omitempty"`
+	Title     string         `json:"title"`
+	Preview   string         `json:"preview,omitempty"`
+	Project   string         `json:"project,omitempty"`
+	ProjectID string         `json:"project_id,omitempty"`
+	Tags      []string       `json:"tags,omitempty"`
+	CreatedAt time.Time      `json:"created_at"`
+	IndexedAt time.Time      `json:"indexed_at"`
+	Length    int            `json:"length"`
+	Terms     map[string]int `json:"terms"`
+}
+
+// file is the on-disk layout. Sealed is the JSON of the encrypted
+// memories' docs, encrypted with the vault key. Removed lists memories
+// deleted while the vault was locked; they are dropped from the sealed
+// part the next time it is opened.
+type file struct {
+	Version   int            `json:"version"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	Docs      map[string]Doc `json:"docs"`
+	Sealed    string         `json:"sealed,omitempty"`
+	Nonce     string         `json:"nonce,omitempty"`
+	Removed   []string       `json:"removed,omitempty"`
+}
+
+// Index is a loaded local index. Key is the vault key, or nil while the
+// vault is locked; a locked index keeps the sealed part as it found it.
+type Index struct {
+	Path string
+	Key  []byte
+
+	docs         map[string]Doc
+	sealed       map[string]Doc
+	sealedRaw    string
+	sealedNonce  string
+	sealedLocked bool
+	removed      map[string]bool
+	updatedAt    time.Time
+}
+
+// DefaultPath returns ~/.ramorie/index.json.
+func DefaultPath() (string, error) {
+	return statefile.Path("index.json")
+}
+
+// Open loads the default index with the vault key, when the vault is
+// unlocked.
+func Open() (*Index, error) {
+	path, err := DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return Load(path, vaultKey())
+}
+
+// Empty returns an empty default index, for a rebuild over an index that
+// no longer opens.
+func Empty() (*Index, error) {
+	path, err := DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return &Index{Path: path, Key: vaultKey(), docs: map[string]Doc{}, sealed: map[string]Doc{}, removed: map[string]bool{}}, nil
+}
+
+func vaultKey() []byte {
+	if !crypto.IsVaultUnlocked() {
+		return nil
+	}
+	key, err := crypto.GetSymmetricKey()
+	if err != nil {
+		return nil
+	}
+	return key
+}
+
+// Load reads the index at path. A missing file is an empty index. The
+// sealed part is opened when key is given; a key that does not open it
+// is an error, so a stale index is never silently merged.
+func Load(path string, key []byte) (*Index, error) {
+	ix := &Index{Path: path, Key: key, docs: map[string]Doc{}, sealed: map[string]Doc{}, removed: map[string]bool{}}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return ix, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var f file
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("corrupt local index %s: %w (run `ramorie index rebuild`)", path, err)
+	}
+	if f.Docs != nil {
+		ix.docs = f.Docs
+	}
+	ix.updatedAt = f.UpdatedAt
+	if f.Sealed == "" {
+		return ix, nil
+	}
+	if key == nil {
+		ix.sealedRaw, ix.sealedNonce, ix.sealedLocked = f.Sealed, f.Nonce, true
+		for _, id := range f.Removed {
+			ix.removed[id] = true
+		}
+		return ix, nil
+	}
+	plain, err := crypto.DecryptFromBase64(f.Sealed, f.Nonce, key)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open the encrypted part of %s with this vault key (run `ramorie index rebuild`)", path)
+	}
+	if err := json.Unmarshal([]byte(plain), &ix.sealed); err != nil {
+		return nil, fmt.Errorf("corrupt encrypted local index %s: %w", path, err)
+	}
+	for _, id := range f.Removed {
+		delete(ix.sealed, id)
+	}
+	return ix, nil
+}
+
+// Locked reports whether the index has an encrypted part that the
+// current key cannot search.
+func (ix *Index) Locked() bool { return ix.sealedLocked }
+
+// Len returns the number of plain and sealed docs. The sealed count is 0
+// while locked.
+func (ix *Index) Len() (plain, sealed int) { return len(ix.docs), len(ix.sealed) }
+
+// UpdatedAt is when the index was last saved.
+func (ix *Index) UpdatedAt() time.Time { return ix.updatedAt }
+
+// Add indexes a memory and reports whether it was indexed. Encrypted
+// memories are decrypted with the vault key and go to the sealed part;
+// without the key, or for content this key cannot open (organization
+// scope), they are skipped.
+func (ix *Index) Add(m *models.Memory) bool {
+	if m == nil {
+		return false
+	}
+	id := m.ID.String()
+	content := m.Content
+	if m.IsEncrypted {
+		if ix.Key == nil || ix.sealedLocked || m.EncryptedContent == "" {
+			return false
+		}
+		plain, err := crypto.DecryptFromBase64(m.EncryptedContent, m.ContentNonce, ix.Key)
+		if err != nil {
+			return false
+		}
+		content = plain
+	}
+	d := newDoc(m, content)
+	if m.IsEncrypted {
+		delete(ix.docs, id)
+		ix.sealed[id] = d
+	} else {
+		delete(ix.sealed, id)
+		ix.docs[id] = d
+	}
+	return true
+}
+
+// Remove drops a memory from the index. A locked sealed part cannot be
+// changed, so the memory is recorded as removed and dropped from it once
+// the vault is unlocked.
+func (ix *Index) Remove(id string) {
+	delete(ix.docs, id)
+	delete(ix.sealed, id)
+	if ix.sealedLocked {
+		ix.removed[id] = true
+	}
+}
+
+// Reset empties the index before a rebuild. A locked sealed part is kept,
+// since the memories in it cannot be re-indexed without the key.
+func (ix *Index) Reset() {
+	ix.docs = map[string]Doc{}
+	if !ix.sealedLocked {
+		ix.sealed = map[string]Doc{}
+	}
+}
+
+// Save writes the index atomically with mode 0600.
+func (ix *Index) Save() error {
+	f := file{Version: fileVersion, UpdatedAt: time.Now(), Docs: ix.docs}
+	switch {
+	case ix.sealedLocked:
+		f.Sealed, f.Nonce = ix.sealedRaw, ix.sealedNonce
+		for id := range ix.removed {
+			f.Removed = append(f.Removed, id)
+		}
+		sort.Strings(f.Removed)
+	case len(ix.sealed) > 0:
+		if ix.Key == nil {
+			return fmt.Errorf("vault is locked; cannot write the encrypted local index")
+		}
+		raw, err := json.Marshal(ix.sealed)
+		if err != nil {
+			return err
+		}
+		ct, nonce, err := crypto.EncryptToBase64(string(raw), ix.Key)
+		if err != nil {
+			return fmt.Errorf("encrypt local index: %w", err)
+		}
+		f.Sealed, f.Nonce = ct, nonce
+	}
+	data, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+	if err := statefile.WriteFile(ix.Path, data); err != nil {
+		return err
+	}
+	ix.updatedAt = f.UpdatedAt
+	return nil
+}
+
+// Hit is a search result.
+type Hit struct {
+	Doc   Doc
+	Score float64
+}
+
+// Search ranks the searchable docs against opts.Term with BM25 and
+// applies the Project, Types, Tags and Limit filters of opts. An explicit
+// Project matches a project name or (prefix of a) project ID; ProjectHint
+// scopes the search only when some doc is in a project of that name.
+func (ix *Index) Search(opts api.FindMemoriesOptions) []Hit {
+	corpus := make([]Doc, 0, len(ix.docs)+len(ix.sealed))
+	for _, d := range ix.docs {
+		corpus = append(corpus, d)
+	}
+	for _, d := range ix.sealed {
+		corpus = append(corpus, d)
+	}
+	if len(corpus) == 0 {
+		return nil
+	}
+
+	query := unique(similarity.Tokens(opts.Term, true))
+	if len(query) == 0 {
+		return nil
+	}
+
+	// Corpus statistics over everything searchable, so a filter does not
+	// change how rare a term is.
+	df := map[string]int{}
+	total := 0
+	for _, d := range corpus {
+		total += d.Length
+		for _, t := range query {
+			if d.Terms[t] > 0 {
+				df[t]++
+			}
+		}
+	}
+	n := float64(len(corpus))
+	avgLen := float64(total) / n
+	if avgLen == 0 {
+		avgLen = 1
+	}
+
+	project := strings.TrimSpace(opts.Project)
+	if project == "" && opts.ProjectHint != "" && hasProject(corpus, opts.ProjectHint) {
+		project = opts.ProjectHint
+	}
+
+	var hits []Hit
+	for _, d := range corpus {
+		if project != "" && !inProject(d, project) {
+			continue
+		}
+		if len(opts.Types) > 0 && !containsFold(opts.Types, d.Type) {
+			continue
+		}
+		if !hasAllTags(d.Tags, opts.Tags) {
+			continue
+		}
+		score := 0.0
+		for _, t := range query {
+			tf := float64(d.Terms[t])
+			if tf == 0 {
+				continue
+			}
+			idf := math.Log(1 + (n-float64(df[t])+0.5)/(float64(df[t])+0.5))
+			norm := tf + bm25K1*(1-bm25B+bm25B*float64(d.Length)/avgLen)
+			score += idf * tf * (bm25K1 + 1) / norm
+		}
+		if score <= 0 || score < opts.MinScore {
+			continue
+		}
+		hits = append(hits, Hit{Doc: d, Score: score})
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].Doc.CreatedAt.After(hits[j].Doc.CreatedAt)
+	})
+	if opts.Limit > 0 && len(hits) > opts.Limit {
+		hits = hits[:opts.Limit]
+	}
+	return hits
+}
+
+// Find answers a find request from the index, shaped like the backend's
+// response. Every item is marked LocalFallback and Meta.RankingMode is
+// RankingMode; reason is reported as Meta.FallbackReason.
+func (ix *Index) Find(opts api.FindMemoriesOptions, reason string) *api.FindResponse {
+	hits := ix.Search(opts)
+	resp := &api.FindResponse{Items: make([]api.FindItem, 0, len(hits))}
+	now := time.Now()
+	for _, h := range hits {
+		d := h.Doc
+		it := api.FindItem{
+			ID:            d.ID,
+			Type:          d.Type,
+			Title:         d.Title,
+			Preview:       d.Preview,
+			Score:         h.Score,
+			Breakdown:     map[string]float64{"bm25": h.Score},
+			Project:       d.Project,
+			CreatedAt:     d.CreatedAt,
+			LocalFallback: true,
+		}
+		if d.ProjectID != "" {
+			pid := d.ProjectID
+			it.ProjectID = &pid
+		}
+		if !d.CreatedAt.IsZero() {
+			it.AgeDays = int(now.Sub(d.CreatedAt).Hours() / 24)
+		}
+		resp.Items = append(resp.Items, it)
+	}
+	resp.Meta = api.FindMeta{
+		Total:          len(resp.Items),
+		Returned:       len(resp.Items),
+		AppliedScope:   opts.Project,
+		RankingMode:    RankingMode,
+		FallbackReason: reason,
+	}
+	return resp
+}
+
+// Record adds memories to the default index. It is called wherever the
+// CLI fetches memories and is best effort: the caller already has what it
+// asked for, so errors are returned only for logging.
+func Record(memories ...models.Memory) error {
+	if len(memories) == 0 {
+		return nil
+	}
+	ix, err := Open()
+	if err != nil {
+		return err
+	}
+	changed := false
+	for i := range memories {
+		if ix.Add(&memories[i]) {
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return ix.Save()
+}
+
+// Forget removes memories from the default index, e.g. after a delete.
+func Forget(ids ...string) error {
+	ix, err := Open()
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		ix.Remove(id)
+	}
+	return ix.Save()
+}
+
+func newDoc(m *models.Memory, content string) Doc {
+	tags := tagStrings(m.Tags)
+	text := []string{content}
+	text = append(text, tags...)
+	if m.Trigger != nil {
+		text = append(text, *m.Trigger)
+	}
+	text = append(text, m.Steps...)
+	if m.Validation != nil {
+		text = append(text, *m.Validation)
+	}
+	tokens := similarity.Tokens(strings.Join(text, "\n"), true)
+	terms := make(map[string]int, len(tokens))
+	for _, t := range tokens {
+		terms[t]++
+	}
+	d := Doc{
+		ID:        m.ID.String(),
+		Type:      m.Type,
+		Title:     clip(titleLine(content), 80),
+		Preview:   clip(strings.Join(strings.Fields(content), " "), 200),
+		Tags:      tags,
+		CreatedAt: m.CreatedAt,
+		IndexedAt: time.Now(),
+		Length:    len(tokens),
+		Terms:     terms,
+	}
+	if m.ProjectID != uuid.Nil {
+		d.ProjectID = m.ProjectID.String()
+	}
+	if m.Project != nil {
+		d.Project = m.Project.Name
+	}
+	return d
+}
+
+// titleLine is the first non-empty line of content without markdown
+// heading marks.
+func titleLine(content string) string {
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "#"))
+		if line != "" {
+			return line
+		}
+	}
+	return ""
+}
+
+func clip(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	r := []rune(s)
+	return strings.TrimSpace(string(r[:n-1])) + "…"
+}
+
+func tagStrings(tags interface{}) []string {
+	switch v := tags.(type) {
+	case []string:
+		return v
+	case []interface{}:
+		out := make([]string, 0, len(v))
+		for _, t := range v {
+			if s, ok := t.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+func unique(tokens []string) []string {
+	seen := map[string]bool{}
+	out := tokens[:0:0]
+	for _, t := range tokens {
+		if !seen[t] {
+			seen[t] = true
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+func inProject(d Doc, project string) bool {
+	p := strings.ToLower(project)
+	if strings.EqualFold(d.Project, project) {
+		return true
+	}
+	return d.ProjectID != "" && strings.HasPrefix(strings.ToLower(d.ProjectID), p)
+}
+
+func hasProject(corpus []Doc, name string) bool {
+	for _, d := range corpus {
+		if strings.EqualFold(d.Project, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasAllTags(have, want []string) bool {
+	for _, w := range want {
+		if !containsFold(have, w) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
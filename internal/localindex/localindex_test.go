@@ -0,0 +1,192 @@
+package localindex
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kutbudev/ramorie-cli/internal/api"
+	"github.com/kutbudev/ramorie-cli/internal/crypto"
+	"github.com/kutbudev/ramorie-cli/internal/models"
+)
+
+func memory(content, typ, project string) models.Memory {
+	return models.Memory{
+		ID:      uuid.New(),
+		Content: content,
+		Type:    typ,
+		Project: &models.Project{Name: project},
+	}
+}
+
+func TestSearch_RanksByBM25AndFilters(t *testing.T) {
+	ix, err := Load(filepath.Join(t.TempDir(), "index.json"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	deploy := memory("Deploy runbook\nRun the deploy script, then check the deploy dashboard.", "skill", "api")
+	other := memory("Use tabs in Makefiles; deploy is unrelated here.", "preference", "api")
+	elsewhere := memory("Deploy runbook for the web app", "skill", "web")
+	unrelated := memory("Postgres connection pooling notes", "reference", "api")
+	for _, m := range []models.Memory{deploy, other, elsewhere, unrelated} {
+		m := m
+		if !ix.Add(&m) {
+			t.Fatalf("Add(%q) = false", m.Content)
+		}
+	}
+
+	hits := ix.Search(api.FindMemoriesOptions{Term: "deploy runbook", Project: "api"})
+	if len(hits) != 2 {
+		t.Fatalf("got %d hits, want 2: %+v", len(hits), hits)
+	}
+	if hits[0].Doc.ID != deploy.ID.String() {
+		t.Errorf("top hit = %q, want the runbook", hits[0].Doc.Title)
+	}
+	if hits[0].Doc.Title != "Deploy runbook" {
+		t.Errorf("title = %q", hits[0].Doc.Title)
+	}
+
+	hits = ix.Search(api.FindMemoriesOptions{Term: "deploy", Types: []string{"preference"}})
+	if len(hits) != 1 || hits[0].Doc.ID != other.ID.String() {
+		t.Errorf("type filter: %+v", hits)
+	}
+
+	// A project hint that matches no indexed project does not scope.
+	hits = ix.Search(api.FindMemoriesOptions{Term: "runbook", ProjectHint: "somewhere-else"})
+	if len(hits) != 2 {
+		t.Errorf("unknown hint scoped the search: %d hits", len(hits))
+	}
+	hits = ix.Search(api.FindMemoriesOptions{Term: "runbook", ProjectHint: "web"})
+	if len(hits) != 1 || hits[0].Doc.ID != elsewhere.ID.String() {
+		t.Errorf("known hint did not scope: %+v", hits)
+	}
+}
+
+func TestFind_MarksLocalFallback(t *testing.T) {
+	ix, _ := Load(filepath.Join(t.TempDir(), "index.json"), nil)
+	m := memory("Prefer table-driven tests", "preference", "api")
+	ix.Add(&m)
+	resp := ix.Find(api.FindMemoriesOptions{Term: "table tests"}, "backend_unreachable")
+	if len(resp.Items) != 1 || !resp.Items[0].LocalFallback {
+		t.Fatalf("items = %+v", resp.Items)
+	}
+	if resp.Items[0].Type != "preference" {
+		t.Errorf("type = %q", resp.Items[0].Type)
+	}
+	if resp.Meta.RankingMode != RankingMode || resp.Meta.FallbackReason != "backend_unreachable" {
+		t.Errorf("meta = %+v", resp.Meta)
+	}
+}
+
+func TestEncryptedMemoriesAreSealed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "index.json")
+	key := make([]byte, 32)
+	for i := range key {
+		key[i] = byte(i)
+	}
+	ct, nonce, err := crypto.EncryptToBase64("the launch code is swordfish", key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret := models.Memory{ID: uuid.New(), Content: "[Encrypted]", IsEncrypted: true, EncryptedContent: ct, ContentNonce: nonce}
+	plain := memory("swordfish is also a film", "general", "")
+
+	locked, _ := Load(path, nil)
+	if locked.Add(&secret) {
+		t.Fatal("encrypted memory indexed without a key")
+	}
+
+	ix, _ := Load(path, key)
+	if !ix.Add(&secret) || !ix.Add(&plain) {
+		t.Fatal("Add failed")
+	}
+	if err := ix.Save(); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "launch") {
+		t.Fatal("decrypted content written in the clear")
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0o600 {
+		t.Errorf("mode = %v", info.Mode().Perm())
+	}
+
+	reopened, err := Load(path, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hits := reopened.Search(api.FindMemoriesOptions{Term: "launch swordfish"}); len(hits) != 2 || hits[0].Doc.ID != secret.ID.String() {
+		t.Errorf("unlocked search = %+v", hits)
+	}
+
+	// Locked: only plain docs are searchable, and saving keeps the sealed
+	// part for the next unlock.
+	locked, err = Load(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !locked.Locked() {
+		t.Error("Locked() = false")
+	}
+	if hits := locked.Search(api.FindMemoriesOptions{Term: "swordfish"}); len(hits) != 1 || hits[0].Doc.ID != plain.ID.String() {
+		t.Errorf("locked search = %+v", hits)
+	}
+	locked.Reset()
+	if err := locked.Save(); err != nil {
+		t.Fatal(err)
+	}
+	reopened, err = Load(path, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p, s := reopened.Len(); p != 0 || s != 1 {
+		t.Errorf("after locked reset: plain %d sealed %d", p, s)
+	}
+
+	if _, err := Load(path, make([]byte, 32)); err == nil {
+		t.Error("wrong key opened the sealed part")
+	}
+}
+
+func TestRemoveWhileLockedDropsSealedDocOnUnlock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "index.json")
+	key := make([]byte, 32)
+	ct, nonce, err := crypto.EncryptToBase64("deleted while locked", key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret := models.Memory{ID: uuid.New(), Content: "[Encrypted]", IsEncrypted: true, EncryptedContent: ct, ContentNonce: nonce}
+	ix, _ := Load(path, key)
+	ix.Add(&secret)
+	if err := ix.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	locked, _ := Load(path, nil)
+	locked.Remove(secret.ID.String())
+	if err := locked.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	unlocked, err := Load(path, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, s := unlocked.Len(); s != 0 {
+		t.Errorf("sealed docs after unlock = %d, want the removed memory gone", s)
+	}
+	if hits := unlocked.Search(api.FindMemoriesOptions{Term: "deleted"}); len(hits) != 0 {
+		t.Errorf("removed memory still found: %+v", hits)
+	}
+	if err := unlocked.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(path); strings.Contains(string(data), `"removed"`) {
+		t.Error("tombstones kept after the sealed part was rewritten")
+	}
+}
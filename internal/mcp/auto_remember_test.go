@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	"github.com/google/uuid"
+	"github.com/kutbudev/ramorie-cli/internal/localindex"
 )
 
 // TestProtocolReminderForOp locks the per-op reminder strings so future edits
@@ -63,7 +64,7 @@ func TestAutoRemember_TypeInference(t *testing.T) {
 		{"random observation about the build", MemoryTypeGeneral},
 	}
 	for _, c := range cases {
-		if got := inferAutoRememberType(c.content); got != c.want {
+		if got := inferAutoRememberType(c.content, ""); got != c.want {
 			t.Errorf("inferAutoRememberType(%q) = %q, want %q", c.content, got, c.want)
 		}
 	}
@@ -316,6 +317,7 @@ func TestAutoRemember_Jaccard_BelowThreshold_Creates(t *testing.T) {
 // with the new memory id + auto-detected type.
 func TestAutoRemember_NoMatch_Creates(t *testing.T) {
 	withInitializedSession(t)
+	t.Setenv("HOME", t.TempDir())
 
 	projectID := uuid.New()
 	newID := uuid.New()
@@ -394,6 +396,14 @@ func TestAutoRemember_NoMatch_Creates(t *testing.T) {
 	if meta == nil || meta["protocol_reminder"] == nil {
 		t.Errorf("envelope._meta.protocol_reminder must be set; envelope=%+v", envelope)
 	}
+	// The new memory reaches the offline search index.
+	ix, err := localindex.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plain, _ := ix.Len(); plain != 1 {
+		t.Errorf("local index holds %d memories, want the created one", plain)
+	}
 }
 
 // TestAutoRemember_SemanticDuplicate_MatchesViaFind verifies the v7.0.2 semantic
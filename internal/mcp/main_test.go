@@ -0,0 +1,22 @@
+package mcp
+
+import (
+	"fmt"
+	"os"
+	"testing"
+)
+
+// TestMain points $HOME at a scratch directory: handlers keep local state
+// (search index, journal, revisions) under ~/.ramorie, which tests must not
+// touch. Tests that need a specific home still t.Setenv their own.
+func TestMain(m *testing.M) {
+	home, err := os.MkdirTemp("", "ramorie-mcp-test")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Setenv("HOME", home)
+	code := m.Run()
+	os.RemoveAll(home)
+	os.Exit(code)
+}
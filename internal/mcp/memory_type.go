@@ -1,137 +1,76 @@
 package mcp
 
 import (
-	"regexp"
-	"strings"
+	"sync"
+
+	"github.com/kutbudev/ramorie-cli/internal/memtype"
 )
 
 // Memory type constants
 const (
-	MemoryTypeGeneral    = "general"
-	MemoryTypeDecision   = "decision"
-	MemoryTypeBugFix     = "bug_fix"
-	MemoryTypePreference = "preference"
-	MemoryTypePattern    = "pattern"
-	MemoryTypeReference  = "reference"
-	MemoryTypeSkill      = "skill" // Procedural memory: trigger/steps/validation runbook
+	MemoryTypeGeneral    = memtype.General
+	MemoryTypeDecision   = memtype.Decision
+	MemoryTypeBugFix     = memtype.BugFix
+	MemoryTypePreference = memtype.Preference
+	MemoryTypePattern    = memtype.Pattern
+	MemoryTypeReference  = memtype.Reference
+	MemoryTypeSkill      = memtype.Skill // Procedural memory: trigger/steps/validation runbook
 )
 
-// weightedKeyword pairs a lowercase substring trigger with a confidence weight.
-// Stronger, less-ambiguous phrases carry more weight so the scorer can resolve
-// content that matches several buckets (e.g. "decided to always use yarn" hits
-// BOTH a decision keyword and a preference keyword) by picking the bucket with
-// the highest total signal rather than whichever list happened to be checked
-// first.
-type weightedKeyword struct {
-	kw     string
-	weight int
+// DetectMemoryType returns the most appropriate memory type for content. It
+// scores every type's weighted keywords — from the built-in language packs
+// plus the user's and project's classifier.yaml — and picks the strongest
+// signal rather than the first list that matched, so "decided to always use
+// yarn" routes to preference. See package memtype.
+func DetectMemoryType(content string) string {
+	return memtype.Default().Classify(content).Type
 }
 
-// memoryTypeKeywords maps each type to its weighted trigger phrases. Reference
-// is handled separately because it is URL-gated (see DetectMemoryType).
-//
-// De-overlap notes:
-//   - "best practice is" (preference, weight 2) deliberately outweighs the bare
-//     "best practice" (pattern, weight 1) so an imperative rule phrased as a
-//     best practice routes to preference, not pattern.
-//   - imperative rule phrases ("always use", "never use", "should always") carry
-//     the highest preference weight so durable user rules win over a co-occurring
-//     decision verb ("decided to always use yarn" → preference).
-var memoryTypeKeywords = map[string][]weightedKeyword{
-	MemoryTypeDecision: {
-		{"decision:", 3}, {"architectural decision", 3}, {"we chose", 3}, {"we decided", 3},
-		{"adr", 2}, {"decided", 2}, {"chose", 2}, {"chosen", 2}, {"selected", 2},
-		{"instead of", 2}, {"opted for", 2}, {"went with", 2}, {"we will use", 2},
-		{"after considering", 2},
-	},
-	MemoryTypeBugFix: {
-		{"root cause", 3}, {"the problem was", 3}, {"bug:", 3}, {"hotfix", 3},
-		{"fix:", 2}, {"fixed", 2}, {"solved", 2}, {"resolved", 2}, {"error:", 2},
-		{"issue:", 2}, {"patched", 2}, {"workaround", 2}, {"debugging", 2},
-	},
-	MemoryTypePreference: {
-		{"always use", 3}, {"never use", 3}, {"should always", 3}, {"should never", 3},
-		{"prefer", 2}, {"i like", 2}, {"we like", 2}, {"always", 2}, {"never", 2},
-		{"convention:", 2}, {"standard:", 2}, {"rule:", 2}, {"best practice is", 2},
-		{"recommended", 1},
-	},
-	MemoryTypePattern: {
-		{"design pattern", 3}, {"architecture pattern", 3}, {"implementation pattern", 3},
-		{"coding pattern", 3}, {"pattern:", 3}, {"template:", 2}, {"boilerplate", 2},
-		{"structure:", 2}, {"best practice", 1},
-	},
-	MemoryTypeSkill: {
-		{"runbook", 3}, {"skill:", 3}, {"step by step", 2}, {"step-by-step", 2},
-		{"steps:", 2}, {"how to", 2}, {"how-to", 2}, {"recipe", 2}, {"procedure", 2},
-	},
+// DetectMemoryTypeFor is DetectMemoryType for a memory of projectID. The
+// MCP server is long-lived and may save memories for several projects, but
+// the only checkout it knows is its working directory, so that directory's
+// classifier.yaml applies only to the project detected from it (see
+// inCwdCheckout); memories of other projects use the user-level file alone.
+func DetectMemoryTypeFor(content, projectID string) string {
+	if inCwdCheckout(projectID) {
+		return DetectMemoryType(content)
+	}
+	return memtype.UserOnly().Classify(content).Type
 }
 
-// referenceKeywords only count when the content also contains a URL — see
-// DetectMemoryType. Keeping reference URL-gated avoids classifying prose that
-// merely mentions "docs" or "guide" as a reference.
-var referenceKeywords = []weightedKeyword{
-	{"documentation", 2}, {"docs", 2}, {"reference", 2}, {"link:", 2}, {"see:", 2},
-	{"url:", 2}, {"source:", 2}, {"article", 2}, {"tutorial", 2}, {"guide", 2},
-}
+var (
+	cwdProjectMu sync.Mutex
+	cwdProjectID string
+	cwdDetected  bool
+)
 
-// memoryTypePriority is the deterministic tie-break order applied when two
-// buckets score equally. The first type in this slice with the maximum score
-// wins (the scorer replaces only on a strictly-greater score).
-var memoryTypePriority = []string{
-	MemoryTypeDecision,
-	MemoryTypeBugFix,
-	MemoryTypePreference,
-	MemoryTypeSkill,
-	MemoryTypePattern,
-	MemoryTypeReference,
+// setCwdProject records the project detectCwdProject matched to the working
+// directory ("" for none).
+func setCwdProject(projectID string) {
+	cwdProjectMu.Lock()
+	defer cwdProjectMu.Unlock()
+	cwdProjectID, cwdDetected = projectID, true
 }
 
-var urlPattern = regexp.MustCompile(`https?://[^\s]+`)
-
-// DetectMemoryType analyzes content and returns the most appropriate memory
-// type by SCORING every bucket and selecting the strongest signal — not by
-// returning the first list that matched. This fixes keyword shadowing where an
-// early bucket (decision) would swallow content that more strongly belongs to a
-// later bucket (preference), e.g. "decided to always use yarn".
-func DetectMemoryType(content string) string {
-	lowerContent := strings.ToLower(content)
-	hasURL := urlPattern.MatchString(content)
-
-	scores := make(map[string]int, len(memoryTypeKeywords)+1)
-	for memType, keywords := range memoryTypeKeywords {
-		for _, k := range keywords {
-			if strings.Contains(lowerContent, k.kw) {
-				scores[memType] += k.weight
-			}
-		}
+// inCwdCheckout reports whether the working directory's project-level config
+// files apply to projectID: it has no project, or it is the project
+// detected from the working directory. Detection runs once if session_start
+// has not done it yet.
+func inCwdCheckout(projectID string) bool {
+	if projectID == "" {
+		return true
 	}
-
-	// Reference is URL-gated: only score it when the content carries a link.
-	if hasURL {
-		for _, k := range referenceKeywords {
-			if strings.Contains(lowerContent, k.kw) {
-				scores[MemoryTypeReference] += k.weight
-			}
+	cwdProjectMu.Lock()
+	detected := cwdDetected
+	cwdProjectMu.Unlock()
+	if !detected && apiClient != nil {
+		if _, _, err := detectCwdProject(apiClient); err != nil {
+			return false
 		}
-		// A short snippet that is mostly a URL is almost certainly a reference,
-		// even without an explicit "docs"/"guide" keyword.
-		if len(strings.Fields(content)) < 10 {
-			scores[MemoryTypeReference] += 2
-		}
-	}
-
-	best := MemoryTypeGeneral
-	bestScore := 0
-	for _, memType := range memoryTypePriority {
-		if scores[memType] > bestScore {
-			bestScore = scores[memType]
-			best = memType
-		}
-	}
-	if bestScore == 0 {
-		return MemoryTypeGeneral
 	}
-	return best
+	cwdProjectMu.Lock()
+	defer cwdProjectMu.Unlock()
+	return projectID == cwdProjectID
 }
 
 // ValidMemoryTypes returns all valid memory type values
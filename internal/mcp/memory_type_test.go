@@ -66,3 +66,20 @@ func TestDetectMemoryType_StrongestSignalWins(t *testing.T) {
 		t.Errorf("expected decision to outscore bug_fix, got %q", got)
 	}
 }
+
+// TestInCwdCheckout: the working directory's project-level config only
+// applies to the project detected from it.
+func TestInCwdCheckout(t *testing.T) {
+	setCwdProject("p1")
+	t.Cleanup(func() {
+		cwdProjectMu.Lock()
+		cwdProjectID, cwdDetected = "", false
+		cwdProjectMu.Unlock()
+	})
+	if !inCwdCheckout("p1") || !inCwdCheckout("") {
+		t.Error("the detected project and no project should use the working directory")
+	}
+	if inCwdCheckout("p2") {
+		t.Error("another project must not use the working directory's config")
+	}
+}
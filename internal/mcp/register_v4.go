@@ -82,6 +82,14 @@ OPTIONAL:
 
 REQUIRED: content, project (name or ID)
 OPTIONAL: force (bool) — skip similarity check and save anyway
+OPTIONAL: review_in ("90d", "6m") — resurface in "ramorie review" for re-confirmation
+OPTIONAL: expires ("2026-12-31" or "90d") — stop injecting this memory after that date
+OPTIONAL: anchors (["internal/api/client.go:120-160"]) — link to code; find flags the
+  memory "stale" once those lines change
+
+🔒 SECRETS: API keys, tokens, passwords and private keys are redacted before saving
+(or the write is blocked / saved encrypted, per ~/.ramorie/secrets.yaml). The result
+lists them under "redacted_secrets"; store where a credential lives, not its value.
 
 Type is auto-detected from content words:
 - "decided X" / "chose X"  → decision
@@ -195,7 +203,10 @@ REQUIRED: action (list|get|create|start|complete|stop|progress|note|move)
 Actions:
 - list: List tasks. Requires: project
 - get: Get task details. Requires: taskId
-- create: Create task. Requires: project, description. Optional: priority (L/M/H)
+- create: Create task. Requires: project, description. Optional: priority (L/M/H),
+  template (name from ~/.ramorie/templates or <repo>/.ramorie/templates) + vars ({key: value}).
+  With a template, description is optional and appended to the template body; subtasks are created too.
+  Under an encrypted task, template subtasks are skipped unless allow_plaintext: true (they are stored in plaintext).
 - start: Start working on task. Requires: taskId
 - complete: Mark task complete. Requires: taskId
 - stop: Stop working on task. Requires: taskId
@@ -206,6 +217,7 @@ Actions:
 Examples:
 - task(action: "list", project: "my-project")
 - task(action: "create", project: "my-project", description: "Add login", priority: "H")
+- task(action: "create", project: "my-project", template: "release", vars: {"version": "1.4.0"})
 - task(action: "start", taskId: "uuid")`,
 		Annotations: &mcp.ToolAnnotations{
 			Title:         "Task",
@@ -223,14 +235,20 @@ Examples:
 		Name: "memory",
 		Description: `🟡 COMMON | Get memory details with related entities, or generate a skill from a goal.
 
-REQUIRED: action (list|get) — OR set goal to trigger skill generation (action not needed).
+REQUIRED: action (list|get|share|acl) — OR set goal to trigger skill generation (action not needed).
 
 Actions:
 - list: Browse a project's raw memory list — newest-first, UNRANKED (no semantic scoring).
   Requires: project. Optional:
     - term: contains-filter (substring browse — NOT search). Use find() for topic/semantic retrieval.
     - limit
+    - visibility: only memories with this visibility (private|project|organization)
 - get: Get memory details with related decisions, tasks, and memories. Requires: memoryId
+- acl: Show visibility, readers, writers and encryption scope. Requires: memoryId
+- share: Change who can see or edit a memory. Requires: memoryId and at least one of
+    visibility (private|project|organization), readers, writers, revoke
+    (emails of organization members or user IDs). Memories encrypted with a
+    personal key cannot be shared; org-encrypted ones only inside that org.
 
 Skill Generation (when goal is set):
 - Provide goal (string): what the skill should teach.
@@ -242,6 +260,7 @@ Skill Generation (when goal is set):
 Examples:
 - memory(action: "list", project: "my-project")
 - memory(action: "get", memoryId: "uuid")
+- memory(action: "share", memoryId: "uuid", visibility: "organization", readers: ["alice@x.com"])
 - memory(goal: "add OAuth2 login", project: "my-project", auto_context: true)
 - memory(goal: "deploy with Railway", project: "my-project")`,
 		Annotations: &mcp.ToolAnnotations{
@@ -6,6 +6,7 @@ func TestEffectiveRememberMemoryType_StructuredFieldsPromoteToSkill(t *testing.T
 	got := effectiveRememberMemoryType(
 		"fixed iOS build linker issue",
 		"",
+		"",
 		"before:ios-build",
 		[]string{"add -lswiftCompatibility56"},
 		"Archive succeeds",
@@ -18,6 +19,7 @@ func TestEffectiveRememberMemoryType_StructuredFieldsPromoteToSkill(t *testing.T
 func TestEffectiveRememberMemoryType_StructuredFieldsOverrideExplicitNonSkill(t *testing.T) {
 	got := effectiveRememberMemoryType(
 		"bug_fix with reusable build procedure",
+		"",
 		MemoryTypeBugFix,
 		"",
 		[]string{"run pod install", "archive"},
@@ -29,7 +31,7 @@ func TestEffectiveRememberMemoryType_StructuredFieldsOverrideExplicitNonSkill(t
 }
 
 func TestEffectiveRememberMemoryType_NoStructuredFieldsKeepsDetection(t *testing.T) {
-	got := effectiveRememberMemoryType("root cause was a nil project id; fixed parser", "", "", nil, "")
+	got := effectiveRememberMemoryType("root cause was a nil project id; fixed parser", "", "", "", nil, "")
 	if got != MemoryTypeBugFix {
 		t.Fatalf("type = %q, want %q", got, MemoryTypeBugFix)
 	}
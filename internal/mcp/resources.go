@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/kutbudev/ramorie-cli/internal/api"
+	"github.com/kutbudev/ramorie-cli/internal/searches"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
@@ -79,6 +80,32 @@ func registerResources(server *mcp.Server) {
 		Description: "Assembled, token-budgeted view of a context pack ready for agent context.",
 		MIMEType:    "application/xml",
 	}, handleContextPackAssembledResource)
+
+	// Saved searches (`ramorie find --save`): the list, one concrete
+	// resource per search known at startup so clients can list them, and
+	// the template for searches saved later.
+	server.AddResource(&mcp.Resource{
+		URI:         "ramorie://searches",
+		Name:        "searches",
+		Description: "Saved find searches with their term and options",
+		MIMEType:    "application/json",
+	}, handleSearchesResource)
+	if saved, err := searches.All(); err == nil {
+		for _, s := range saved {
+			server.AddResource(&mcp.Resource{
+				URI:         "ramorie://search/" + s.Name,
+				Name:        "search-" + s.Name,
+				Description: fmt.Sprintf("Saved search @%s: %q", s.Name, s.Term),
+				MIMEType:    "application/json",
+			}, handleSearchResource)
+		}
+	}
+	server.AddResourceTemplate(&mcp.ResourceTemplate{
+		URITemplate: "ramorie://search/{name}",
+		Name:        "search",
+		Description: "Results of a saved search, re-run on every read",
+		MIMEType:    "application/json",
+	}, handleSearchResource)
 }
 
 // extractIDFromURI extracts the {id} portion from a resource URI
@@ -303,3 +330,58 @@ func handleContextPackAssembledResource(_ context.Context, req *mcp.ReadResource
 		}},
 	}, nil
 }
+
+func handleSearchesResource(_ context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	saved, err := searches.All()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load saved searches: %w", err)
+	}
+
+	data, err := json.MarshalIndent(saved, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal saved searches: %w", err)
+	}
+
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{{
+			URI:      req.Params.URI,
+			MIMEType: "application/json",
+			Text:     string(data),
+		}},
+	}, nil
+}
+
+// handleSearchResource re-runs a saved search and returns its definition
+// together with the current hits.
+func handleSearchResource(_ context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	name := extractIDFromURI(req.Params.URI, "ramorie://search/", "")
+	if name == "" {
+		return nil, mcp.ResourceNotFoundError(req.Params.URI)
+	}
+
+	search, err := searches.Lookup(name)
+	if err != nil {
+		return nil, mcp.ResourceNotFoundError(req.Params.URI)
+	}
+
+	resp, err := apiClient.FindMemories(search.Options())
+	if err != nil {
+		return nil, fmt.Errorf("failed to run saved search @%s: %w", search.Name, err)
+	}
+
+	data, err := json.MarshalIndent(map[string]interface{}{
+		"search":  search,
+		"results": resp,
+	}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal search results: %w", err)
+	}
+
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{{
+			URI:      req.Params.URI,
+			MIMEType: "application/json",
+			Text:     string(data),
+		}},
+	}, nil
+}
@@ -0,0 +1,114 @@
+package mcp
+
+import (
+	"fmt"
+
+	"github.com/kutbudev/ramorie-cli/internal/crypto"
+	"github.com/kutbudev/ramorie-cli/internal/encstate"
+	"github.com/kutbudev/ramorie-cli/internal/secrets"
+)
+
+// rememberSecrets collects the secret scanner's verdict on one write.
+type rememberSecrets struct {
+	// Scanner checks the fields; nil means the working directory's scanner.
+	Scanner  *secrets.Scanner
+	Redacted []redactedSecret
+	// Encrypt is set when a finding's action is encrypt: the memory is saved
+	// encrypted even where encryption is otherwise off.
+	Encrypt bool
+}
+
+// redactedSecret is a redacted finding and the input field it was in.
+type redactedSecret struct {
+	Field string `json:"field"`
+	secrets.Finding
+}
+
+// scan checks one field and returns the text to save. Blocked secrets are
+// an error. Fields that are stored in plain text even on encrypted memories
+// (steps, validation) are not encryptable: secrets there whose action is
+// encrypt are redacted instead.
+func (s *rememberSecrets) scan(field, text string, encryptable bool) (string, error) {
+	if text == "" {
+		return text, nil
+	}
+	r := s.scanner().Check(text)
+	if err := r.Err(); err != nil {
+		return "", fmt.Errorf("%s: %w", field, err)
+	}
+	redact := r.Redacted()
+	if r.Encrypt {
+		if encryptable {
+			s.Encrypt = true
+		} else {
+			redact = r.Findings
+		}
+	}
+	for _, f := range redact {
+		s.Redacted = append(s.Redacted, redactedSecret{Field: field, Finding: f})
+	}
+	return secrets.RedactFindings(text, redact), nil
+}
+
+// query returns scanned text in the form it may leave the machine as a
+// search term. Secrets kept for encryption are still in text, and a search
+// term is never encrypted, so they are redacted here as well.
+func (s *rememberSecrets) query(text string) string {
+	if !s.Encrypt {
+		return text
+	}
+	return secrets.RedactFindings(text, s.scanner().Check(text).Findings)
+}
+
+func (s *rememberSecrets) scanner() *secrets.Scanner {
+	if s.Scanner == nil {
+		return secrets.Default()
+	}
+	return s.Scanner
+}
+
+// secretsFor returns the rememberSecrets of a write to projectID, scanning
+// with the config files that apply to it: the working directory's
+// secrets.yaml only for the project detected from it (see inCwdCheckout),
+// the user-level file alone for any other.
+func secretsFor(projectID string) *rememberSecrets {
+	if inCwdCheckout(projectID) {
+		return &rememberSecrets{Scanner: secrets.Default()}
+	}
+	return &rememberSecrets{Scanner: secrets.UserOnly()}
+}
+
+// annotate reports redactions in a tool result so the agent knows the saved
+// text differs from what it sent.
+func (s *rememberSecrets) annotate(result map[string]interface{}) {
+	if len(s.Redacted) > 0 {
+		findings := make([]secrets.Finding, len(s.Redacted))
+		for i, r := range s.Redacted {
+			findings[i] = r.Finding
+		}
+		result["redacted_secrets"] = s.Redacted
+		result["_secrets_warning"] = fmt.Sprintf("🔒 Redacted %d secret(s) before saving: %s. "+
+			"Never store credentials in memories; reference where they live instead (e.g. \"token is in 1Password: deploy-bot\").",
+			len(s.Redacted), secrets.Summary(findings))
+	}
+	if s.Encrypt {
+		result["encrypted_for_secrets"] = true
+	}
+}
+
+// encryptPersonal decides whether a write is encrypted with the personal
+// vault key. Org projects skip personal encryption; forced (the scanner
+// found secrets to encrypt) overrides a disabled encryption setting but
+// still needs the unlocked vault and a personal project.
+func encryptPersonal(orgID string, forced bool) (bool, error) {
+	if orgID == "" && encstate.ShouldEncryptPersonal(encstate.FetcherFor(apiClient)) && crypto.IsVaultUnlocked() {
+		return true, nil
+	}
+	if !forced {
+		return false, nil
+	}
+	if orgID != "" || !crypto.IsVaultUnlocked() {
+		return false, secrets.ErrNeedsEncryption
+	}
+	return true, nil
+}
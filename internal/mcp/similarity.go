@@ -1,11 +1,11 @@
 package mcp
 
 import (
-	"regexp"
 	"sort"
 	"strings"
 
 	"github.com/kutbudev/ramorie-cli/internal/models"
+	"github.com/kutbudev/ramorie-cli/internal/similarity"
 )
 
 // SimilarityThreshold is the minimum similarity score to consider memories similar
@@ -22,77 +22,60 @@ type SimilarMemoryResult struct {
 	Similarity  float64 `json:"similarity"`
 }
 
-// tokenize splits text into lowercase words, removing punctuation
+// tokenize splits text into a set of lowercase words. Words are letters and
+// digits in any script (see similarity.Tokens), so non-English memories no
+// longer tokenize to nothing.
 func tokenize(text string) map[string]struct{} {
-	// Convert to lowercase
-	text = strings.ToLower(text)
-
-	// Remove punctuation and special characters, keep alphanumeric and spaces
-	reg := regexp.MustCompile(`[^a-z0-9\s]`)
-	text = reg.ReplaceAllString(text, " ")
-
-	// Split by whitespace
-	words := strings.Fields(text)
-
-	// Create set of unique words
-	wordSet := make(map[string]struct{})
-	for _, word := range words {
-		if len(word) > 1 { // Skip single-character words
-			wordSet[word] = struct{}{}
-		}
-	}
-
-	return wordSet
+	return similarity.Set(similarity.Tokens(text, false))
 }
 
 // JaccardSimilarity calculates the Jaccard similarity coefficient between two texts
 // Returns a value between 0 (no overlap) and 1 (identical)
 func JaccardSimilarity(a, b string) float64 {
-	setA := tokenize(a)
-	setB := tokenize(b)
-
-	if len(setA) == 0 || len(setB) == 0 {
-		return 0
-	}
-
-	// Calculate intersection
-	intersection := 0
-	for word := range setA {
-		if _, exists := setB[word]; exists {
-			intersection++
-		}
-	}
-
-	// Calculate union (|A| + |B| - |A ∩ B|)
-	union := len(setA) + len(setB) - intersection
-
-	if union == 0 {
-		return 0
-	}
-
-	return float64(intersection) / float64(union)
+	return similarity.Jaccard(tokenize(a), tokenize(b))
 }
 
-// CheckSimilarMemories finds memories similar to the given content
+// similarityIndexMin is the corpus size from which CheckSimilarMemories uses
+// a MinHash/LSH index instead of comparing against every memory.
+const similarityIndexMin = 256
+
+// CheckSimilarMemories finds memories similar to the given content using
+// similarity.Default (word + character-shingle similarity, identifier-aware).
 // Returns a slice of similar memories sorted by similarity (highest first)
 func CheckSimilarMemories(memories []models.Memory, content string, threshold float64) []SimilarMemoryResult {
-	var similar []SimilarMemoryResult
-
+	var readable []models.Memory
 	for _, memory := range memories {
 		// Skip encrypted memories we can't read
-		memoryContent := memory.Content
 		if memory.IsEncrypted && memory.Content == "[Encrypted]" {
 			continue
 		}
+		readable = append(readable, memory)
+	}
 
-		similarity := JaccardSimilarity(content, memoryContent)
-		if similarity >= threshold {
-			similar = append(similar, SimilarMemoryResult{
-				MemoryID:    memory.ID.String(),
-				Content:     truncateContent(memoryContent, 200),
-				FullContent: memoryContent,
-				Similarity:  similarity,
-			})
+	var similar []SimilarMemoryResult
+	add := func(memory models.Memory, score float64) {
+		similar = append(similar, SimilarMemoryResult{
+			MemoryID:    memory.ID.String(),
+			Content:     truncateContent(memory.Content, 200),
+			FullContent: memory.Content,
+			Similarity:  score,
+		})
+	}
+	if len(readable) >= similarityIndexMin {
+		ix := similarity.NewIndex(similarity.Default)
+		byID := make(map[string]models.Memory, len(readable))
+		for _, memory := range readable {
+			ix.Add(memory.ID.String(), memory.Content)
+			byID[memory.ID.String()] = memory
+		}
+		for _, m := range ix.Query(content, threshold) {
+			add(byID[m.ID], m.Score)
+		}
+	} else {
+		for _, memory := range readable {
+			if score := similarity.Default.Score(content, memory.Content); score >= threshold {
+				add(memory, score)
+			}
 		}
 	}
 
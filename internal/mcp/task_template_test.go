@@ -0,0 +1,117 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// TestHandleTaskCreate_TemplateCreatesTaskAndSubtasks drives task(action=create,
+// template=...) end to end against a stub backend: the template is read from
+// $HOME/.ramorie/templates, expanded with vars, and each subtask is POSTed
+// under the new task.
+func TestHandleTaskCreate_TemplateCreatesTaskAndSubtasks(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	tplDir := filepath.Join(home, ".ramorie", "templates")
+	if err := os.MkdirAll(tplDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	tpl := "title: \"Release {{version}}\"\npriority: H\ntags: [release]\nsubtasks:\n  - \"Tag v{{version}}\"\n  - \"Announce {{version}}\"\n"
+	if err := os.WriteFile(filepath.Join(tplDir, "release.yaml"), []byte(tpl), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	projectID := uuid.New()
+	taskID := uuid.New()
+	var (
+		mu       sync.Mutex
+		taskBody map[string]any
+		subtasks []string
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/projects"):
+			stubProjectsEndpoint([]stubProject{{ID: projectID, Name: "Release Train"}})(w, r)
+		case r.Method == http.MethodPost && r.URL.Path == "/tasks":
+			b, _ := io.ReadAll(r.Body)
+			mu.Lock()
+			_ = json.Unmarshal(b, &taskBody)
+			mu.Unlock()
+			_, _ = w.Write([]byte(`{"id":"` + taskID.String() + `","project_id":"` + projectID.String() + `","title":"Release 1.4.0","status":"TODO","priority":"H"}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/tasks/"+taskID.String()+"/subtasks":
+			var req map[string]string
+			b, _ := io.ReadAll(r.Body)
+			_ = json.Unmarshal(b, &req)
+			mu.Lock()
+			subtasks = append(subtasks, req["description"])
+			mu.Unlock()
+			_, _ = w.Write([]byte(`{"id":"` + uuid.New().String() + `","task_id":"` + taskID.String() + `","description":"` + req["description"] + `"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+	installTestAPIClient(t, ts)
+	withInitializedSession(t)
+
+	res, _, err := handleTaskCreate(context.Background(), UnifiedTaskInput{
+		Action:   "create",
+		Project:  "Release Train",
+		Template: "release",
+		Vars:     map[string]string{"version": "1.4.0"},
+	})
+	if err != nil {
+		t.Fatalf("handleTaskCreate: %v", err)
+	}
+	got := decodeToolResult(t, res)
+
+	if taskBody["title"] != "Release 1.4.0" {
+		t.Errorf("task title = %v, want expanded template title", taskBody["title"])
+	}
+	if taskBody["priority"] != "H" {
+		t.Errorf("priority = %v, want template priority H", taskBody["priority"])
+	}
+	if tags, _ := taskBody["tags"].([]any); len(tags) != 1 || tags[0] != "release" {
+		t.Errorf("tags = %v, want [release]", taskBody["tags"])
+	}
+	want := []string{"Tag v1.4.0", "Announce 1.4.0"}
+	if strings.Join(subtasks, "|") != strings.Join(want, "|") {
+		t.Errorf("subtasks = %v, want %v", subtasks, want)
+	}
+	if subs, _ := got["subtasks"].([]any); len(subs) != 2 {
+		t.Errorf("result should list 2 created subtasks; payload=%v", got)
+	}
+}
+
+func TestHandleTaskCreate_TemplateMissingVar(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	tplDir := filepath.Join(home, ".ramorie", "templates")
+	if err := os.MkdirAll(tplDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tplDir, "release.yaml"), []byte("title: \"Release {{version}}\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	withInitializedSession(t)
+
+	_, _, err := handleTaskCreate(context.Background(), UnifiedTaskInput{
+		Action:   "create",
+		Project:  "anything",
+		Template: "release",
+	})
+	if err == nil || !strings.Contains(err.Error(), "version") {
+		t.Fatalf("expected missing-variable error, got %v", err)
+	}
+}
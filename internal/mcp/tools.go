@@ -13,11 +13,16 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/kutbudev/ramorie-cli/internal/anchor"
 	"github.com/kutbudev/ramorie-cli/internal/api"
 	"github.com/kutbudev/ramorie-cli/internal/config"
 	"github.com/kutbudev/ramorie-cli/internal/crypto"
 	"github.com/kutbudev/ramorie-cli/internal/encstate"
+	"github.com/kutbudev/ramorie-cli/internal/localindex"
+	"github.com/kutbudev/ramorie-cli/internal/memrev"
 	"github.com/kutbudev/ramorie-cli/internal/models"
+	"github.com/kutbudev/ramorie-cli/internal/review"
+	"github.com/kutbudev/ramorie-cli/internal/secrets"
 	"github.com/kutbudev/ramorie-cli/internal/version"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
@@ -211,6 +216,14 @@ OPTIONAL: scope ("personal") / global (true) - mark this as a GLOBAL memory that
   without approval"). Global memories are user-private and injected at every
   session start. (Legacy: a "scope:global" tag also marks a memory global.)
 OPTIONAL runbook fields: trigger, steps, validation
+OPTIONAL: review_in ("90d", "6m") - resurface in "ramorie review" for re-confirmation
+OPTIONAL: expires ("2026-12-31" or "90d") - stop injecting this memory after that date
+OPTIONAL: anchors (["internal/api/client.go:120-160"]) - link to code; find flags the
+  memory "stale" once those lines change
+
+🔒 SECRETS: API keys, tokens, passwords and private keys are redacted before saving
+(or the write is blocked / saved encrypted, per ~/.ramorie/secrets.yaml). The result
+lists them under "redacted_secrets"; store where a credential lives, not its value.
 
 ⚠️ DUPLICATE PREVENTION: By default, remember() checks for similar existing memories.
 If similar content exists (>80% match), you'll get a warning with the existing memory.
@@ -941,7 +954,7 @@ var setupAgentNonPreferencePrefixes = []string{
 }
 
 // detectCwdProject scans cwd path segments against project names, returns the first match.
-// Also sets session last-project on match.
+// Also sets session last-project on match and records the result for inCwdCheckout.
 //
 // Matching rules (tightest-first to avoid false positives):
 //  1. Exact normalized equality: normalizeForMatch(segment) == normalizeForMatch(project.Name)
@@ -981,10 +994,12 @@ func detectCwdProject(client *api.Client) (*models.Project, string, error) {
 			// "ramorie" (project) matching "ramoriefrontend" (segment).
 			if segmentNorm == projectNorm {
 				SetSessionLastProject(p.ID)
+				setCwdProject(p.ID.String())
 				return p, cwd, nil
 			}
 		}
 	}
+	setCwdProject("")
 	return nil, cwd, nil
 }
 
@@ -1601,6 +1616,62 @@ type RememberInput struct {
 	// "scope:global" tag also triggers this. Empty = default project-scoped.
 	Scope  string `json:"scope,omitempty"`  // OPTIONAL - "personal"/"global" → cross-project user memory
 	Global bool   `json:"global,omitempty"` // OPTIONAL - alias for scope="personal"
+
+	// Review scheduling. ReviewIn ("90d", "6m") brings the memory back in
+	// `ramorie review` for re-confirmation; Expires (a date or an interval)
+	// drops it from hook injections after that point and is sent to the
+	// backend as valid_until.
+	ReviewIn string `json:"review_in,omitempty"` // OPTIONAL - e.g. "90d", "12w", "6m"
+	Expires  string `json:"expires,omitempty"`   // OPTIONAL - "2026-12-31" or an interval
+
+	// Anchors link the memory to code ("path/to/file.go:120-160", optionally
+	// "#Symbol"); relative paths are taken from the server's working
+	// directory. find marks the memory possibly stale once that code changes.
+	Anchors []string `json:"anchors,omitempty"` // OPTIONAL
+}
+
+// scheduleRememberReview stores the review schedule of a new memory and
+// adds it to the tool result.
+func scheduleRememberReview(result map[string]interface{}, memoryID string, days int, until *time.Time) {
+	if days <= 0 && until == nil {
+		return
+	}
+	if err := review.Set(memoryID, days, until); err != nil {
+		result["_review_warning"] = "review schedule not saved: " + err.Error()
+		return
+	}
+	if days > 0 {
+		result["review_due"] = time.Now().AddDate(0, 0, days).Format("2006-01-02")
+	}
+	if until != nil {
+		result["expires"] = until.Format(time.RFC3339)
+	}
+}
+
+// recordMemory adds a memory the server just stored to the local search
+// index, so the offline fallback finds it. Failures only cost fallback
+// coverage, so they are ignored.
+func recordMemory(memory *models.Memory) {
+	if memory != nil {
+		_ = localindex.Record(*memory)
+	}
+}
+
+// saveRememberAnchors stores a new memory's code anchors and adds them to
+// the tool result.
+func saveRememberAnchors(result map[string]interface{}, memory *models.Memory, anchors []anchor.Anchor) {
+	if len(anchors) == 0 {
+		return
+	}
+	if err := anchor.Add(memrev.Tracked{Client: apiClient, Source: "mcp"}, memory, anchors); err != nil {
+		result["_anchor_warning"] = "code anchors not saved: " + err.Error()
+		return
+	}
+	specs := make([]string, len(anchors))
+	for i, a := range anchors {
+		specs[i] = a.String()
+	}
+	result["anchors"] = specs
 }
 
 // resolveMemoryScope maps the user-facing "global memory" signals to the
@@ -1628,8 +1699,14 @@ func resolveMemoryScope(scope string, global bool, tags []string) string {
 	return ""
 }
 
+// rememberSimilarityThreshold is the score at which remember() warns about
+// an existing memory. It stays at 0.80 rather than the hygiene report's
+// similarity.DuplicateThreshold: a warning on every save is noise, and the
+// lower bound is tuned on too small a corpus to put in front of agents.
+const rememberSimilarityThreshold = 0.80
+
 // checkForSimilarMemories checks if similar memories already exist in the project
-// Uses existing CheckSimilarMemories function from similarity.go with 80% threshold
+// Uses CheckSimilarMemories from similarity.go with the 80% threshold
 // Returns a list of similar memories
 func checkForSimilarMemories(client *api.Client, projectID, content string) ([]SimilarMemoryResult, error) {
 	// Search existing memories using the API
@@ -1638,8 +1715,7 @@ func checkForSimilarMemories(client *api.Client, projectID, content string) ([]S
 		return nil, err // Don't block on errors, just skip check
 	}
 
-	// Use existing CheckSimilarMemories function with 80% threshold
-	similar := CheckSimilarMemories(memories, content, 0.80)
+	similar := CheckSimilarMemories(memories, content, rememberSimilarityThreshold)
 
 	return similar, nil
 }
@@ -1675,40 +1751,66 @@ func handleRemember(ctx context.Context, req *mcp.CallToolRequest, input Remembe
 		return nil, nil, errors.New(errMsg)
 	}
 
+	// Resolve project (auto-detect if empty). Which secrets.yaml applies
+	// depends on it; resolving sends no content to the backend.
+	projectID, orgID, err := resolveProjectWithOrg(apiClient, input.Project)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Scan for secrets before the content reaches any backend call — the
+	// duplicate check and task promotion below included.
+	found := secretsFor(projectID)
+	if content, err = found.scan("content", content, true); err != nil {
+		return nil, nil, err
+	}
+	if input.Validation, err = found.scan("validation", input.Validation, false); err != nil {
+		return nil, nil, err
+	}
+	for i, step := range input.Steps {
+		if input.Steps[i], err = found.scan(fmt.Sprintf("steps[%d]", i), step, false); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	reviewDays, expires, err := review.Parse(input.ReviewIn, input.Expires, time.Now())
+	if err != nil {
+		return nil, nil, err
+	}
+	cwd, _ := os.Getwd()
+	anchors, err := anchor.ResolveAll(input.Anchors, cwd)
+	if err != nil {
+		return nil, nil, err
+	}
+	validUntil := ""
+	if expires != nil {
+		validUntil = expires.UTC().Format(time.RFC3339)
+	}
+
 	// DUPLICATE PREVENTION: Check for similar memories unless force=true
 	if !input.Force {
-		// First resolve the project to get the ID
-		tempProjectID, _, err := resolveProjectWithOrg(apiClient, input.Project)
-		if err == nil {
-			similarMemories, err := checkForSimilarMemories(apiClient, tempProjectID, content)
-			if err == nil && len(similarMemories) > 0 {
-				// Found similar memories - return warning with existing memories
-				return mustTextResult(map[string]interface{}{
-					"status":            "similar_exists",
-					"similar_memories":  similarMemories,
-					"requested_content": truncateContent(content, 200),
-					"_message": fmt.Sprintf("⚠️ Found %d similar memory(ies) already saved. To avoid duplicates:\n"+
-						"1. Use the existing memory if it covers the same knowledge, OR\n"+
-						"2. Call remember(..., force=true) to save anyway", len(similarMemories)),
-					"_action": "Either skip saving (duplicate) or use force=true to save anyway",
-					"_hint":   "💡 Best practice: Always call find(term) BEFORE remember() to check existing knowledge",
-					"_meta":   map[string]interface{}{"protocol_reminder": protocolReminderForOp("remember")},
-				}), nil, nil
-			}
+		similarMemories, err := checkForSimilarMemories(apiClient, projectID, content)
+		if err == nil && len(similarMemories) > 0 {
+			// Found similar memories - return warning with existing memories
+			return mustTextResult(map[string]interface{}{
+				"status":            "similar_exists",
+				"similar_memories":  similarMemories,
+				"requested_content": truncateContent(content, 200),
+				"_message": fmt.Sprintf("⚠️ Found %d similar memory(ies) already saved. To avoid duplicates:\n"+
+					"1. Use the existing memory if it covers the same knowledge, OR\n"+
+					"2. Call remember(..., force=true) to save anyway", len(similarMemories)),
+				"_action": "Either skip saving (duplicate) or use force=true to save anyway",
+				"_hint":   "💡 Best practice: Always call find(term) BEFORE remember() to check existing knowledge",
+				"_meta":   map[string]interface{}{"protocol_reminder": protocolReminderForOp("remember")},
+			}), nil, nil
 		}
-		// If project resolution or similarity check fails, proceed anyway
+		// If the similarity check fails, proceed anyway
 	}
 
 	// Check if this should be a task instead
 	if shouldBeTask(content) {
 		taskDesc := extractTaskDescription(content)
 
-		// Resolve project
-		projectID, orgID, err := resolveProjectWithOrg(apiClient, input.Project)
-		if err != nil {
-			return nil, nil, err
-		}
-
 		// Get agent metadata from current session
 		session := GetCurrentSession()
 		var meta *api.AgentMetadata
@@ -1723,20 +1825,29 @@ func handleRemember(ctx context.Context, req *mcp.CallToolRequest, input Remembe
 
 		// Personal project only — encrypt with personal key (org projects skip
 		// encryption). Gate on the server's CURRENT encryption status.
-		if orgID == "" && encstate.ShouldEncryptPersonal(encstate.FetcherFor(apiClient)) && crypto.IsVaultUnlocked() {
+		encrypt, err := encryptPersonal(orgID, found.Encrypt)
+		if err != nil {
+			return nil, nil, err
+		}
+		if encrypt {
 			encryptedDesc, nonce, isEncrypted, err := crypto.EncryptContent(taskDesc)
 			if err == nil && isEncrypted {
 				task, err := apiClient.CreateEncryptedTaskWithMeta(projectID, encryptedDesc, nonce, "M", meta)
 				if err != nil {
 					return nil, nil, err
 				}
-				return mustTextResult(map[string]interface{}{
+				result := map[string]interface{}{
 					"action":    "task_created",
 					"message":   "📋 Created task instead of memory (detected TODO)",
 					"task":      task,
 					"encrypted": true,
 					"_meta":     map[string]interface{}{"protocol_reminder": protocolReminderForOp("task")},
-				}), nil, nil
+				}
+				found.annotate(result)
+				return mustTextResult(result), nil, nil
+			}
+			if found.Encrypt {
+				return nil, nil, secrets.ErrNeedsEncryption
 			}
 		}
 
@@ -1746,21 +1857,17 @@ func handleRemember(ctx context.Context, req *mcp.CallToolRequest, input Remembe
 			return nil, nil, err
 		}
 
-		return mustTextResult(map[string]interface{}{
+		result := map[string]interface{}{
 			"action":  "task_created",
 			"message": "📋 Created task instead of memory (detected TODO)",
 			"task":    task,
 			"_meta":   map[string]interface{}{"protocol_reminder": protocolReminderForOp("task")},
-		}), nil, nil
-	}
-
-	// Resolve project (auto-detect if empty)
-	projectID, orgID, err := resolveProjectWithOrg(apiClient, input.Project)
-	if err != nil {
-		return nil, nil, err
+		}
+		found.annotate(result)
+		return mustTextResult(result), nil, nil
 	}
 
-	memoryType := effectiveRememberMemoryType(content, input.Type, input.Trigger, input.Steps, input.Validation)
+	memoryType := effectiveRememberMemoryType(content, projectID, input.Type, input.Trigger, input.Steps, input.Validation)
 
 	// GLOBAL memory signal → backend scope="personal" (cross-project,
 	// user-private). Empty preserves default project-scoped behavior.
@@ -1770,7 +1877,11 @@ func handleRemember(ctx context.Context, req *mcp.CallToolRequest, input Remembe
 	// Personal project only — encrypt with personal key (org projects skip
 	// encryption). Gate on the server's CURRENT encryption status (encstate)
 	// instead of the stale local flag.
-	if orgID == "" && encstate.ShouldEncryptPersonal(encstate.FetcherFor(apiClient)) && crypto.IsVaultUnlocked() {
+	encrypt, err := encryptPersonal(orgID, found.Encrypt)
+	if err != nil {
+		return nil, nil, err
+	}
+	if encrypt {
 		contentHash := crypto.ComputeContentHash(content)
 
 		encryptedContent, nonce, isEncrypted, err := crypto.EncryptContent(content)
@@ -1786,11 +1897,12 @@ func handleRemember(ctx context.Context, req *mcp.CallToolRequest, input Remembe
 				Steps:            input.Steps,
 				Validation:       input.Validation,
 				Scope:            memoryScope,
+				ValidUntil:       validUntil,
 			})
 			if err != nil {
 				return nil, nil, err
 			}
-			return mustTextResult(map[string]interface{}{
+			result := map[string]interface{}{
 				"action":        "memory_saved",
 				"message":       fmt.Sprintf("💾 Remembered as %s (encrypted)", memoryType),
 				"memory":        memory,
@@ -1799,7 +1911,15 @@ func handleRemember(ctx context.Context, req *mcp.CallToolRequest, input Remembe
 				"encrypted":     true,
 				"project_id":    projectID,
 				"_meta":         map[string]interface{}{"protocol_reminder": protocolReminderForOp("remember")},
-			}), nil, nil
+			}
+			recordMemory(memory)
+			scheduleRememberReview(result, memory.ID.String(), reviewDays, expires)
+			saveRememberAnchors(result, memory, anchors)
+			found.annotate(result)
+			return mustTextResult(result), nil, nil
+		}
+		if found.Encrypt {
+			return nil, nil, secrets.ErrNeedsEncryption
 		}
 	}
 
@@ -1816,6 +1936,7 @@ func handleRemember(ctx context.Context, req *mcp.CallToolRequest, input Remembe
 		Steps:      input.Steps,
 		Validation: input.Validation,
 		Scope:      memoryScope,
+		ValidUntil: validUntil,
 	})
 	if err != nil {
 		return nil, nil, err
@@ -1842,13 +1963,17 @@ func handleRemember(ctx context.Context, req *mcp.CallToolRequest, input Remembe
 		result["recent_in_project"] = resp.RecentInProject
 		result["_hint_recent"] = "💡 This project saw another memory in the last 10 minutes — these may be part of the same work unit."
 	}
+	recordMemory(&resp.Memory)
+	scheduleRememberReview(result, resp.Memory.ID.String(), reviewDays, expires)
+	saveRememberAnchors(result, &resp.Memory, anchors)
+	found.annotate(result)
 	return mustTextResult(result), nil, nil
 }
 
-func effectiveRememberMemoryType(content, explicitType, trigger string, steps []string, validation string) string {
+func effectiveRememberMemoryType(content, projectID, explicitType, trigger string, steps []string, validation string) string {
 	memoryType := strings.TrimSpace(explicitType)
 	if memoryType == "" {
-		memoryType = DetectMemoryType(content)
+		memoryType = DetectMemoryTypeFor(content, projectID)
 	}
 	hasSkillFields := strings.TrimSpace(trigger) != "" || len(steps) > 0 || strings.TrimSpace(validation) != ""
 	if hasSkillFields {
@@ -3665,6 +3790,9 @@ func handleFind(ctx context.Context, req *mcp.CallToolRequest, input FindInput)
 	if err != nil {
 		return nil, nil, err
 	}
+	if resp != nil {
+		markAnchorDrift(resp.Items)
+	}
 
 	// PR10 — inject protocol reminder into _meta so the agent sees the
 	// next-required-action nudge on every find result. We re-marshal through a
@@ -3674,6 +3802,30 @@ func handleFind(ctx context.Context, req *mcp.CallToolRequest, input FindInput)
 	return mustTextResult(wrapped), nil, nil
 }
 
+// markAnchorDrift flags find results whose anchored code changed since they
+// were remembered, so the agent re-verifies them before acting.
+func markAnchorDrift(items []api.FindItem) {
+	if len(items) == 0 {
+		return
+	}
+	ids := make([]string, len(items))
+	for i, it := range items {
+		ids[i] = it.ID
+	}
+	drift := anchor.Drift(ids)
+	for i := range items {
+		reason, ok := drift[items[i].ID]
+		if !ok {
+			continue
+		}
+		items[i].Stale = true
+		if items[i].StaleReason != "" {
+			reason = items[i].StaleReason + "; " + reason
+		}
+		items[i].StaleReason = reason
+	}
+}
+
 // withProtocolReminder rewrites slot 0 of a CallToolResult so the JSON
 // payload carries `_meta.protocol_reminder`. If slot 0 isn't valid JSON we
 // leave the result untouched — multi-content responses (e.g. load_skill's
@@ -4506,10 +4658,11 @@ func handleUpdateSkill(ctx context.Context, req *mcp.CallToolRequest, input Upda
 	}
 
 	// Use memory update endpoint (skills are stored as memories with type=skill)
-	updated, err := apiClient.UpdateMemory(input.SkillID, updates)
+	updated, err := memrev.Update(apiClient, input.SkillID, updates, "mcp")
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to update skill: %w", err)
 	}
+	recordMemory(updated)
 
 	return mustTextResult(map[string]interface{}{
 		"action":   "skill_updated",
@@ -4805,13 +4958,12 @@ func protocolReminderForOp(op string) string {
 	}
 }
 
-// autoRememberSimilarityThreshold is the Jaccard 0.60 — captures meaningful
-// duplicates (~60% word overlap). Lower than 0.85 (too strict for prod content
-// — PR10 v7.0.0 smoke test showed 0.7693 same-topic memories slipping through),
-// higher than 0.40 (false positives on incidental word overlap). The check runs
-// via CheckSimilarMemories → JaccardSimilarity (similarity.go), NOT cosine —
-// Jaccard is word-set intersection over distinct lowercase tokens, so 0.60 is
-// notably stricter than the same score on cosine would be. Above this threshold
+// autoRememberSimilarityThreshold is 0.60 — captures meaningful duplicates.
+// Lower than 0.85 (too strict for prod content — PR10 v7.0.0 smoke test
+// showed 0.7693 same-topic memories slipping through). The check runs via CheckSimilarMemories →
+// similarity.Default (the better of word-set and character-shingle Jaccard),
+// NOT cosine, so 0.60 is notably stricter than the same score on cosine
+// would be. Above this threshold
 // auto_remember reports `matched_existing` and skips creation; below, it
 // creates a new memory. Callers wanting unconditional save should use
 // remember() directly.
@@ -4826,12 +4978,12 @@ const autoRememberSimilarityThreshold = 0.60
 // margin without re-tuning if rerank weights drift.
 const autoRememberFindScoreThreshold = 0.75
 
-// inferAutoRememberType is a thin wrapper over DetectMemoryType. It mirrors the
+// inferAutoRememberType is a thin wrapper over DetectMemoryTypeFor. It mirrors the
 // auto-detection rules documented in the auto_remember tool description so
 // callers/tests have a single reference point. Kept separate so we can add
 // auto_remember-specific heuristics later without touching DetectMemoryType.
-func inferAutoRememberType(content string) string {
-	return DetectMemoryType(content)
+func inferAutoRememberType(content, projectID string) string {
+	return DetectMemoryTypeFor(content, projectID)
 }
 
 // AutoRememberInput is the MCP tool input for auto_remember. Project is
@@ -4847,7 +4999,7 @@ type AutoRememberInput struct {
 // handleAutoRemember is the atomic find()+remember() entry point. It exists so
 // agents don't have to chain two calls (and inevitably skip one). Flow:
 //  1. Resolve project (explicit → cwd auto-detect).
-//  2. Run a similarity check via existing checkForSimilarMemories (Jaccard).
+//  2. Run a local similarity check via CheckSimilarMemories (similarity.go).
 //     The 0.60 threshold (autoRememberSimilarityThreshold) catches meaningful
 //     same-topic duplicates that 0.85 missed in prod (PR10 v7.0.0 smoke test).
 //  3. If a near-duplicate exists → return action="matched_existing" with the
@@ -4884,18 +5036,27 @@ func handleAutoRemember(ctx context.Context, req *mcp.CallToolRequest, input Aut
 			projectIdent = detected.Name
 		}
 	}
-	projectID, _, err := resolveProjectWithOrg(apiClient, projectIdent)
+	projectID, orgID, err := resolveProjectWithOrg(apiClient, projectIdent)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	// Secrets are scanned, with the project's config, before the content
+	// reaches any backend call: the semantic dedupe below sends it to the
+	// find pipeline, redacted in full via found.query because secrets kept
+	// for encryption are still in content.
+	found := secretsFor(projectID)
+	if content, err = found.scan("content", content, true); err != nil {
+		return nil, nil, err
+	}
+
 	// PR10 v7.0.2: Semantic dedupe via find pipeline before local Jaccard.
 	// Catches paraphrased duplicates that token-set Jaccard misses
 	// (smoke test: 0.867 cosine match for same-topic memory missed by Jaccard 0.0).
 	// FastMode skips HyDE expansion — rerank+cosine alone is enough for dedupe,
 	// saves 5-10s cold latency.
 	findResp, findErr := apiClient.FindMemories(api.FindMemoriesOptions{
-		Term:     content,
+		Term:     found.query(content),
 		Project:  projectIdent,
 		Limit:    1,
 		FastMode: true,
@@ -4904,7 +5065,7 @@ func handleAutoRemember(ctx context.Context, req *mcp.CallToolRequest, input Aut
 		top := findResp.Items[0]
 		memType := input.TypeOverride
 		if memType == "" {
-			memType = inferAutoRememberType(content)
+			memType = inferAutoRememberType(content, projectID)
 		}
 		envelope := map[string]interface{}{
 			"action":       "matched_existing",
@@ -4934,7 +5095,7 @@ func handleAutoRemember(ctx context.Context, req *mcp.CallToolRequest, input Aut
 		}, nil, nil
 	}
 
-	// Fallback: Jaccard local check (handles no_indexed_corpus / find unavailable).
+	// Fallback: local similarity check (handles no_indexed_corpus / find unavailable).
 	// auto_remember-specific 0.60 threshold via similarity.go. auto_remember is the
 	// only path that *blocks* on similarity — handleRemember only warns.
 	memories, listErr := apiClient.ListMemories(projectID, "")
@@ -4944,7 +5105,7 @@ func handleAutoRemember(ctx context.Context, req *mcp.CallToolRequest, input Aut
 			top := similar[0]
 			memType := input.TypeOverride
 			if memType == "" {
-				memType = inferAutoRememberType(content)
+				memType = inferAutoRememberType(content, projectID)
 			}
 			envelope := map[string]interface{}{
 				"action":       "matched_existing",
@@ -4983,22 +5144,54 @@ func handleAutoRemember(ctx context.Context, req *mcp.CallToolRequest, input Aut
 	memType := input.TypeOverride
 	autoDetected := false
 	if memType == "" {
-		memType = inferAutoRememberType(content)
+		memType = inferAutoRememberType(content, projectID)
 		autoDetected = true
 	}
 
-	resp, err := apiClient.CreateMemoryWithOptionsFull(api.CreateMemoryOptions{
-		ProjectID: projectID,
-		Content:   content,
-		Type:      memType,
-	})
-	if err != nil {
-		return nil, nil, err
+	// auto_remember saves in plain text unless the scanner found secrets
+	// whose action is encrypt.
+	var memoryID string
+	var similar []api.SimilarMemory
+	if found.Encrypt {
+		if _, err := encryptPersonal(orgID, true); err != nil {
+			return nil, nil, err
+		}
+		encrypted, nonce, ok, err := crypto.EncryptContent(content)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !ok {
+			return nil, nil, secrets.ErrNeedsEncryption
+		}
+		memory, err := apiClient.CreateEncryptedMemoryWithOptions(api.CreateEncryptedMemoryOptions{
+			ProjectID:        projectID,
+			EncryptedContent: encrypted,
+			ContentNonce:     nonce,
+			ContentHash:      crypto.ComputeContentHash(content),
+			Type:             memType,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		recordMemory(memory)
+		memoryID = memory.ID.String()
+	} else {
+		resp, err := apiClient.CreateMemoryWithOptionsFull(api.CreateMemoryOptions{
+			ProjectID: projectID,
+			Content:   content,
+			Type:      memType,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		recordMemory(&resp.Memory)
+		memoryID = resp.Memory.ID.String()
+		similar = resp.SimilarMemories
 	}
 
 	envelope := map[string]interface{}{
 		"action":        "created",
-		"memory_id":     resp.Memory.ID.String(),
+		"memory_id":     memoryID,
 		"type":          memType,
 		"auto_detected": autoDetected,
 		"project_id":    projectID,
@@ -5007,16 +5200,17 @@ func handleAutoRemember(ctx context.Context, req *mcp.CallToolRequest, input Aut
 			"protocol_reminder": protocolReminderForOp("auto_remember_created"),
 		},
 	}
-	if len(resp.SimilarMemories) > 0 {
-		envelope["similar_memories"] = resp.SimilarMemories
+	if len(similar) > 0 {
+		envelope["similar_memories"] = similar
 	}
+	found.annotate(envelope)
 	envelopeJSON, err := json.MarshalIndent(envelope, "", "  ")
 	if err != nil {
 		return nil, nil, fmt.Errorf("marshal auto_remember envelope: %w", err)
 	}
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
-			&mcp.TextContent{Text: fmt.Sprintf("✓ Saved as %s: %s", memType, resp.Memory.ID.String())},
+			&mcp.TextContent{Text: fmt.Sprintf("✓ Saved as %s: %s", memType, memoryID)},
 			&mcp.TextContent{Text: string(envelopeJSON)},
 		},
 	}, nil, nil
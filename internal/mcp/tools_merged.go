@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/kutbudev/ramorie-cli/internal/activetask"
 	"github.com/kutbudev/ramorie-cli/internal/api"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
@@ -36,19 +37,19 @@ func handleManageTask(ctx context.Context, req *mcp.CallToolRequest, input Manag
 
 	switch action {
 	case "start":
-		if err := apiClient.StartTask(taskID); err != nil {
+		if err := activetask.Start(apiClient, taskID); err != nil {
 			return nil, nil, err
 		}
 		return mustTextResult(map[string]interface{}{"ok": true, "message": "Task started. Memories will now auto-link to this task."}), nil, nil
 
 	case "complete":
-		if err := apiClient.CompleteTask(taskID); err != nil {
+		if err := activetask.Complete(apiClient, taskID); err != nil {
 			return nil, nil, err
 		}
 		return mustTextResult(map[string]interface{}{"ok": true, "message": "Task completed."}), nil, nil
 
 	case "stop":
-		if err := apiClient.StopTask(taskID); err != nil {
+		if err := activetask.Stop(apiClient, taskID); err != nil {
 			return nil, nil, err
 		}
 		return mustTextResult(map[string]interface{}{"ok": true, "message": "Task stopped. Active task cleared."}), nil, nil
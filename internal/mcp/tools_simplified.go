@@ -7,10 +7,16 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/kutbudev/ramorie-cli/internal/acl"
+	"github.com/kutbudev/ramorie-cli/internal/activetask"
 	"github.com/kutbudev/ramorie-cli/internal/api"
 	"github.com/kutbudev/ramorie-cli/internal/crypto"
 	"github.com/kutbudev/ramorie-cli/internal/encstate"
+	"github.com/kutbudev/ramorie-cli/internal/journal"
+	"github.com/kutbudev/ramorie-cli/internal/memrev"
 	"github.com/kutbudev/ramorie-cli/internal/models"
+	"github.com/kutbudev/ramorie-cli/internal/secrets"
+	"github.com/kutbudev/ramorie-cli/internal/templates"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
@@ -33,6 +39,11 @@ type UnifiedTaskInput struct {
 	ProjectID    string  `json:"projectId,omitempty"`     // For move (target project)
 	Limit        float64 `json:"limit,omitempty"`
 	Cursor       string  `json:"cursor,omitempty"`
+	// Template expansion for create (see internal/templates).
+	Template string            `json:"template,omitempty"` // For create: template name
+	Vars     map[string]string `json:"vars,omitempty"`     // For create: template variables
+	// AllowPlaintext opts in to plaintext template subtasks under an encrypted task.
+	AllowPlaintext bool `json:"allow_plaintext,omitempty"` // For create with template
 }
 
 func handleUnifiedTask(ctx context.Context, req *mcp.CallToolRequest, input UnifiedTaskInput) (*mcp.CallToolResult, interface{}, error) {
@@ -188,6 +199,24 @@ func handleTaskCreate(ctx context.Context, input UnifiedTaskInput) (*mcp.CallToo
 	}
 
 	description := strings.TrimSpace(input.Description)
+	var expanded *templates.Expanded
+	if name := strings.TrimSpace(input.Template); name != "" {
+		tpl, err := templates.Find(name, templates.Dirs())
+		if err != nil {
+			return nil, nil, err
+		}
+		expanded, err = tpl.Expand(input.Vars)
+		if err != nil {
+			return nil, nil, err
+		}
+		// Template title is the first line; any caller description is
+		// appended to the template body as extra context.
+		body := expanded.Description
+		if description != "" {
+			body = strings.TrimSpace(body + "\n\n" + description)
+		}
+		description = strings.TrimSpace(expanded.Title + "\n" + body)
+	}
 	if description == "" {
 		return nil, nil, errors.New("description is required for create action")
 	}
@@ -196,7 +225,15 @@ func handleTaskCreate(ctx context.Context, input UnifiedTaskInput) (*mcp.CallToo
 		return nil, nil, errors.New("'project' parameter is REQUIRED for create action. Use list_projects to see available projects.")
 	}
 
-	priority := normalizePriority(input.Priority)
+	priorityArg := input.Priority
+	var tags []string
+	if expanded != nil {
+		if strings.TrimSpace(priorityArg) == "" {
+			priorityArg = expanded.Priority
+		}
+		tags = expanded.Tags
+	}
+	priority := normalizePriority(priorityArg)
 	projectID, orgID, err := resolveProjectWithOrg(apiClient, input.Project)
 	if err != nil {
 		return nil, nil, err
@@ -220,31 +257,65 @@ func handleTaskCreate(ctx context.Context, input UnifiedTaskInput) (*mcp.CallToo
 	if orgID == "" && encstate.ShouldEncryptPersonal(encstate.FetcherFor(apiClient)) && crypto.IsVaultUnlocked() {
 		encryptedDesc, nonce, isEncrypted, err := crypto.EncryptContent(description)
 		if err == nil && isEncrypted {
-			task, err := apiClient.CreateEncryptedTaskWithMeta(projectID, encryptedDesc, nonce, priority, meta)
+			task, err := apiClient.CreateEncryptedTaskWithMeta(projectID, encryptedDesc, nonce, priority, meta, tags...)
 			if err != nil {
 				return nil, nil, err
 			}
-			return mustTextResult(map[string]interface{}{
+			result := map[string]interface{}{
 				"action":    "created",
 				"task":      task,
 				"encrypted": true,
 				"_message":  "Task created (encrypted)",
-			}), nil, nil
+			}
+			// The subtask API has no encrypted form, so template subtasks
+			// would be the only plaintext part of the task — opt-in only.
+			if expanded != nil && len(expanded.Subtasks) > 0 && !input.AllowPlaintext {
+				result["subtasks_skipped"] = expanded.Subtasks
+				result["_message"] = fmt.Sprintf("Task created (encrypted); %d template subtask(s) skipped because they would be stored in plaintext — pass allow_plaintext=true to add them", len(expanded.Subtasks))
+				return mustTextResult(result), nil, nil
+			}
+			addTemplateSubtasks(result, task, expanded)
+			return mustTextResult(result), nil, nil
 		}
 	}
 
 	// Split description into title (first sentence/line) and body
 	title, body := splitTitleDescription(description)
-	task, err := apiClient.CreateTaskWithMeta(projectID, title, body, priority, meta)
+	task, err := apiClient.CreateTaskWithMeta(projectID, title, body, priority, meta, tags...)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	return mustTextResult(map[string]interface{}{
+	result := map[string]interface{}{
 		"action":   "created",
 		"task":     task,
 		"_message": "Task created successfully",
-	}), nil, nil
+	}
+	addTemplateSubtasks(result, task, expanded)
+	return mustTextResult(result), nil, nil
+}
+
+// addTemplateSubtasks creates the expanded template's subtasks under task and
+// records the outcome on result. Subtask failures are reported, not fatal —
+// the parent task already exists at this point.
+func addTemplateSubtasks(result map[string]interface{}, task *models.Task, expanded *templates.Expanded) {
+	if expanded == nil || task == nil || len(expanded.Subtasks) == 0 {
+		return
+	}
+	created := make([]*models.Subtask, 0, len(expanded.Subtasks))
+	var failed []string
+	for _, st := range expanded.Subtasks {
+		sub, err := apiClient.CreateSubtask(task.ID.String(), st)
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", st, err))
+			continue
+		}
+		created = append(created, sub)
+	}
+	result["subtasks"] = created
+	if len(failed) > 0 {
+		result["subtask_errors"] = failed
+	}
 }
 
 func handleTaskStart(ctx context.Context, input UnifiedTaskInput) (*mcp.CallToolResult, interface{}, error) {
@@ -252,7 +323,7 @@ func handleTaskStart(ctx context.Context, input UnifiedTaskInput) (*mcp.CallTool
 	if taskID == "" {
 		return nil, nil, errors.New("taskId is required for start action")
 	}
-	if err := apiClient.StartTask(taskID); err != nil {
+	if err := activetask.Start(apiClient, taskID); err != nil {
 		return nil, nil, err
 	}
 	return mustTextResult(map[string]interface{}{"ok": true, "message": "Task started. Memories will now auto-link."}), nil, nil
@@ -263,7 +334,7 @@ func handleTaskComplete(ctx context.Context, input UnifiedTaskInput) (*mcp.CallT
 	if taskID == "" {
 		return nil, nil, errors.New("taskId is required for complete action")
 	}
-	if err := apiClient.CompleteTask(taskID); err != nil {
+	if err := activetask.Complete(apiClient, taskID); err != nil {
 		return nil, nil, err
 	}
 	return mustTextResult(map[string]interface{}{"ok": true, "message": "Task completed."}), nil, nil
@@ -274,7 +345,7 @@ func handleTaskStop(ctx context.Context, input UnifiedTaskInput) (*mcp.CallToolR
 	if taskID == "" {
 		return nil, nil, errors.New("taskId is required for stop action")
 	}
-	if err := apiClient.StopTask(taskID); err != nil {
+	if err := activetask.Stop(apiClient, taskID); err != nil {
 		return nil, nil, err
 	}
 	return mustTextResult(map[string]interface{}{"ok": true, "message": "Task stopped."}), nil, nil
@@ -307,23 +378,68 @@ func handleTaskNote(ctx context.Context, input UnifiedTaskInput) (*mcp.CallToolR
 		return nil, nil, errors.New("taskId and description (note content) are required for note action")
 	}
 
+	// The task's project decides which secrets.yaml applies and whether the
+	// personal key may encrypt the note.
+	task, err := apiClient.GetTask(taskID)
+	if err != nil {
+		return nil, nil, err
+	}
+	projectID := task.ProjectID.String()
+	orgID, err := projectOrgID(projectID)
+	if err != nil {
+		return nil, nil, err
+	}
+	found := secretsFor(projectID)
+	if note, err = found.scan("description", note, true); err != nil {
+		return nil, nil, err
+	}
+
 	// Gate on the server's CURRENT encryption status, not the stale local flag.
-	if encstate.ShouldEncryptPersonal(encstate.FetcherFor(apiClient)) && crypto.IsVaultUnlocked() {
+	encrypt, err := encryptPersonal(orgID, found.Encrypt)
+	if err != nil {
+		return nil, nil, err
+	}
+	var annotation *models.Annotation
+	encrypted := false
+	if encrypt {
 		encryptedNote, nonce, isEncrypted, err := crypto.EncryptContent(note)
 		if err == nil && isEncrypted {
-			annotation, err := apiClient.CreateEncryptedAnnotation(taskID, encryptedNote, nonce)
-			if err != nil {
+			if annotation, err = apiClient.CreateEncryptedAnnotation(taskID, encryptedNote, nonce); err != nil {
 				return nil, nil, err
 			}
-			return mustTextResult(annotation), nil, nil
+			encrypted = true
+		} else if found.Encrypt {
+			return nil, nil, secrets.ErrNeedsEncryption
+		}
+	}
+	if annotation == nil {
+		if annotation, err = apiClient.CreateAnnotation(taskID, note); err != nil {
+			return nil, nil, err
 		}
 	}
+	result := map[string]interface{}{
+		"ok":        true,
+		"message":   "Note added",
+		"note":      annotation,
+		"encrypted": encrypted,
+	}
+	found.annotate(result)
+	return mustTextResult(result), nil, nil
+}
 
-	annotation, err := apiClient.CreateAnnotation(taskID, note)
+// projectOrgID returns the organization of projectID, "" for a personal
+// project.
+func projectOrgID(projectID string) (string, error) {
+	projects, err := apiClient.ListProjects("")
 	if err != nil {
-		return nil, nil, err
+		return "", fmt.Errorf("failed to list projects: %w", err)
 	}
-	return mustTextResult(annotation), nil, nil
+	for _, p := range projects {
+		if p.ID.String() == projectID && p.OrganizationID != nil {
+			return p.OrganizationID.String(), nil
+		}
+	}
+	return "", nil
 }
 
 func handleTaskMove(ctx context.Context, input UnifiedTaskInput) (*mcp.CallToolResult, interface{}, error) {
@@ -360,14 +476,18 @@ func handleTaskMove(ctx context.Context, input UnifiedTaskInput) (*mcp.CallToolR
 // --- unified_memory: list/get/generate_skill ---
 
 type UnifiedMemoryInput struct {
-	Action      string  `json:"action,omitempty"`   // list, get, generate_skill (omitted when goal is set)
-	Project     string  `json:"project,omitempty"`  // For list, generate_skill
-	MemoryID    string  `json:"memoryId,omitempty"` // For get
-	Term        string  `json:"term,omitempty"`     // For list: substring (contains) filter — NOT search; use find() for topics
-	Limit       float64 `json:"limit,omitempty"`
-	Cursor      string  `json:"cursor,omitempty"`
-	Goal        string  `json:"goal,omitempty"`         // For generate_skill: what skill to create
-	AutoContext bool    `json:"auto_context,omitempty"` // For generate_skill: fetch suggested context automatically
+	Action      string   `json:"action,omitempty"`   // list, get, share, acl, generate_skill (omitted when goal is set)
+	Project     string   `json:"project,omitempty"`  // For list, generate_skill
+	MemoryID    string   `json:"memoryId,omitempty"` // For get, share, acl
+	Term        string   `json:"term,omitempty"`     // For list: substring (contains) filter — NOT search; use find() for topics
+	Limit       float64  `json:"limit,omitempty"`
+	Cursor      string   `json:"cursor,omitempty"`
+	Goal        string   `json:"goal,omitempty"`         // For generate_skill: what skill to create
+	AutoContext bool     `json:"auto_context,omitempty"` // For generate_skill: fetch suggested context automatically
+	Visibility  string   `json:"visibility,omitempty"`   // For list: filter; for share: private, project or organization
+	Readers     []string `json:"readers,omitempty"`      // For share: grant read access (emails or user IDs)
+	Writers     []string `json:"writers,omitempty"`      // For share: grant write access (emails or user IDs)
+	Revoke      []string `json:"revoke,omitempty"`       // For share: remove read and write access
 }
 
 // serializeSkillMarkdown formats frontmatter + body into a markdown string
@@ -424,6 +544,20 @@ func dispatchUnifiedMemoryAction(action string, input UnifiedMemoryInput) (*mcp.
 			return nil, nil, err
 		}
 
+		visibility, err := acl.ParseVisibility(input.Visibility)
+		if err != nil {
+			return nil, nil, err
+		}
+		if visibility != "" {
+			filtered := memories[:0]
+			for _, m := range memories {
+				if strings.EqualFold(m.Visibility, visibility) {
+					filtered = append(filtered, m)
+				}
+			}
+			memories = filtered
+		}
+
 		// `term` is a plain substring (contains) filter over decrypted content —
 		// NOT a search query. The JSON key stays "term" for wire compatibility,
 		// but it never invokes ranking/HyDE/rerank. Use find() for that.
@@ -455,6 +589,9 @@ func dispatchUnifiedMemoryAction(action string, input UnifiedMemoryInput) (*mcp.
 				"content":    decryptedContent,
 				"created_at": m.CreatedAt,
 			}
+			if m.Visibility != "" {
+				memMap["visibility"] = m.Visibility
+			}
 			if m.Project != nil {
 				memMap["project"] = map[string]interface{}{
 					"id":   m.Project.ID.String(),
@@ -494,6 +631,20 @@ func dispatchUnifiedMemoryAction(action string, input UnifiedMemoryInput) (*mcp.
 
 		return mustTextResult(result), nil, nil
 
+	case "acl":
+		memoryID := strings.TrimSpace(input.MemoryID)
+		if memoryID == "" {
+			return nil, nil, errors.New("memoryId is required for acl action")
+		}
+		memory, err := apiClient.GetMemory(memoryID)
+		if err != nil {
+			return nil, nil, err
+		}
+		return mustTextResult(acl.Of(memory)), nil, nil
+
+	case "share":
+		return handleMemoryShare(input)
+
 	case "create":
 		// memory(action:create) was a thin remember() clone that bypassed
 		// duplicate detection and auto-typing. Removed — route writes through
@@ -501,8 +652,91 @@ func dispatchUnifiedMemoryAction(action string, input UnifiedMemoryInput) (*mcp.
 		return nil, nil, errors.New("memory(action:create) has been removed. Use remember(content, project) instead — it runs duplicate detection and auto-detects the memory type")
 
 	default:
-		return nil, nil, fmt.Errorf("invalid action '%s'. Must be: list or get, or set goal to generate a skill", action)
+		return nil, nil, fmt.Errorf("invalid action '%s'. Must be: list, get, share or acl, or set goal to generate a skill", action)
+	}
+}
+
+// journalMemoryLabel names a memory in undo journal summaries, as the CLI
+// does: the short ID and first line, or only the ID when encrypted.
+func journalMemoryLabel(m *models.Memory) string {
+	label := m.ID.String()[:8]
+	if m.IsEncrypted {
+		return label
+	}
+	first, _, _ := strings.Cut(strings.TrimSpace(m.Content), "\n")
+	if r := []rune(first); len(r) > 40 {
+		first = string(r[:39]) + "…"
+	}
+	return label + " " + first
+}
+
+// handleMemoryShare changes a memory's visibility and access lists. Users
+// are emails of organization members or user IDs; acl.Check refuses
+// sharing memories the new audience could not decrypt.
+func handleMemoryShare(input UnifiedMemoryInput) (*mcp.CallToolResult, interface{}, error) {
+	memoryID := strings.TrimSpace(input.MemoryID)
+	if memoryID == "" {
+		return nil, nil, errors.New("memoryId is required for share action")
+	}
+	visibility, err := acl.ParseVisibility(input.Visibility)
+	if err != nil {
+		return nil, nil, err
+	}
+	memory, err := apiClient.GetMemory(memoryID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	orgID := ""
+	if memory.Project != nil && memory.Project.OrganizationID != nil {
+		orgID = memory.Project.OrganizationID.String()
+	} else if projects, err := apiClient.ListProjects(); err == nil {
+		for _, p := range projects {
+			if p.ID == memory.ProjectID && p.OrganizationID != nil {
+				orgID = p.OrganizationID.String()
+				break
+			}
+		}
+	}
+	var members []api.OrganizationMember
+	if orgID != "" {
+		members, _ = apiClient.GetOrganizationMembers(orgID)
+	}
+
+	change := acl.Change{Visibility: visibility}
+	if change.Readers, err = acl.ResolveUsers(input.Readers, orgID, members); err != nil {
+		return nil, nil, err
+	}
+	if change.Writers, err = acl.ResolveUsers(input.Writers, orgID, members); err != nil {
+		return nil, nil, err
+	}
+	if change.Revoke, err = acl.ResolveUsers(input.Revoke, "", members); err != nil {
+		return nil, nil, err
+	}
+	if change.Empty() {
+		return nil, nil, errors.New("share needs visibility, readers, writers or revoke")
+	}
+	if err := acl.Check(memory, orgID, change); err != nil {
+		return nil, nil, err
+	}
+
+	before := acl.Of(memory)
+	next := acl.Apply(before, change)
+	updates := acl.Updates(before, next)
+	if len(updates) == 0 {
+		return mustTextResult(map[string]interface{}{"ok": true, "changed": false, "acl": before}), nil, nil
+	}
+	id := memory.ID.String()
+	updated, err := memrev.Update(apiClient, id, updates, "mcp")
+	if err != nil {
+		return nil, nil, err
+	}
+	journal.Record("mcp memory share", journalMemoryLabel(memory),
+		journal.Update(journal.KindMemory, id, journal.MemorySnapshot(memory), acl.Fields(updates)...))
+	if updated != nil && updated.ID == memory.ID {
+		next = acl.Of(updated)
 	}
+	return mustTextResult(map[string]interface{}{"ok": true, "changed": true, "acl": next}), nil, nil
 }
 
 // handleMemoryGenerateSkill runs the skill-generation chain:
@@ -554,6 +788,7 @@ func handleMemoryGenerateSkill(ctx context.Context, input UnifiedMemoryInput) (*
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to save skill memory: %w", err)
 	}
+	recordMemory(saved)
 
 	result := map[string]interface{}{
 		"id":         saved.ID.String(),
@@ -0,0 +1,264 @@
+// Package memrev keeps prior versions of memories.
+//
+// The backend's PUT /memories/{id} overwrites a memory in place, so when an
+// agent rewrites a preference the old wording is gone. Every memory update
+// the CLI, MCP server or TUI makes goes through Update (or a Tracked
+// client), which snapshots the current version into a local revision log
+// before writing. When the backend exposes GET /memories/{id}/revisions,
+// History prefers it.
+//
+// Revisions store the memory as the API returned it. For encrypted memories
+// that is the ciphertext + nonce — the plaintext is never written to disk —
+// so reading an old encrypted revision needs the unlocked vault just like
+// the live memory does.
+//
+// Local revisions live in ~/.ramorie/revisions/<memory-id>.jsonl (mode
+// 0600), newest MaxRevisions per memory.
+package memrev
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/kutbudev/ramorie-cli/internal/api"
+	"github.com/kutbudev/ramorie-cli/internal/models"
+	"github.com/kutbudev/ramorie-cli/internal/statefile"
+)
+
+// MaxRevisions caps the local history per memory.
+const MaxRevisions = 50
+
+// Revision is one prior version of a memory. Rev counts from 1 (oldest).
+type Revision struct {
+	Rev    int           `json:"revision"`
+	At     time.Time     `json:"created_at"`
+	Source string        `json:"source,omitempty"` // cli, mcp, tui, undo, revert, backend
+	Memory models.Memory `json:"memory"`
+}
+
+// Store is a directory of per-memory revision logs.
+type Store struct {
+	Dir string
+}
+
+// Open returns the default store in ~/.ramorie/revisions.
+func Open() (*Store, error) {
+	path, err := statefile.Path("revisions")
+	if err != nil {
+		return nil, err
+	}
+	return &Store{Dir: path}, nil
+}
+
+func (s *Store) path(memoryID string) string {
+	return filepath.Join(s.Dir, filepath.Base(memoryID)+".jsonl")
+}
+
+// List returns the local revisions of a memory, oldest first.
+func (s *Store) List(memoryID string) ([]Revision, error) {
+	data, err := os.ReadFile(s.path(memoryID))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var revs []Revision
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for sc.Scan() {
+		var r Revision
+		if json.Unmarshal(sc.Bytes(), &r) == nil && r.Rev > 0 {
+			revs = append(revs, r)
+		}
+	}
+	return revs, sc.Err()
+}
+
+// Capture stores m as the next revision of its memory. It is a no-op when
+// m is identical to the newest stored revision. Plaintext of encrypted
+// memories is dropped before writing.
+func (s *Store) Capture(m *models.Memory, source string) (Revision, error) {
+	snap := snapshot(m)
+	id := m.ID.String()
+	revs, err := s.List(id)
+	if err != nil {
+		return Revision{}, err
+	}
+	if n := len(revs); n > 0 && sameVersion(revs[n-1].Memory, snap) {
+		return revs[n-1], nil
+	}
+	r := Revision{Rev: 1, At: time.Now(), Source: source, Memory: snap}
+	if n := len(revs); n > 0 {
+		r.Rev = revs[n-1].Rev + 1
+	}
+	revs = append(revs, r)
+	if len(revs) > MaxRevisions {
+		revs = revs[len(revs)-MaxRevisions:]
+	}
+	return r, s.write(id, revs)
+}
+
+func (s *Store) write(memoryID string, revs []Revision) error {
+	var b bytes.Buffer
+	for _, r := range revs {
+		line, err := json.Marshal(r)
+		if err != nil {
+			return err
+		}
+		b.Write(line)
+		b.WriteByte('\n')
+	}
+	return statefile.WriteFile(s.path(memoryID), b.Bytes())
+}
+
+// snapshot copies the versioned fields of m; encrypted memories keep only
+// their ciphertext.
+func snapshot(m *models.Memory) models.Memory {
+	snap := models.Memory{
+		ID:         m.ID,
+		ProjectID:  m.ProjectID,
+		Content:    m.Content,
+		Tags:       m.Tags,
+		Type:       m.Type,
+		Trigger:    m.Trigger,
+		Steps:      m.Steps,
+		Validation: m.Validation,
+		UpdatedAt:  m.UpdatedAt,
+	}
+	if m.IsEncrypted {
+		snap.Content = ""
+		snap.IsEncrypted = true
+		snap.EncryptedContent = m.EncryptedContent
+		snap.ContentNonce = m.ContentNonce
+		snap.EncryptionScope = m.EncryptionScope
+		snap.EncryptionOrgID = m.EncryptionOrgID
+	}
+	return snap
+}
+
+func sameVersion(a, b models.Memory) bool {
+	ta, _ := json.Marshal(a.Tags)
+	tb, _ := json.Marshal(b.Tags)
+	return a.Content == b.Content && a.EncryptedContent == b.EncryptedContent &&
+		a.Type == b.Type && bytes.Equal(ta, tb) &&
+		derefString(a.Trigger) == derefString(b.Trigger) &&
+		derefString(a.Validation) == derefString(b.Validation) &&
+		slices.Equal(a.Steps, b.Steps)
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// ---- API integration --------------------------------------------------------
+
+// Client is the subset of *api.Client revisions need.
+type Client interface {
+	GetMemory(id string) (*models.Memory, error)
+	UpdateMemory(id string, updates map[string]interface{}) (*models.Memory, error)
+	ListMemoryRevisions(id string) ([]api.MemoryRevision, error)
+}
+
+// Update records the memory's current version and then applies updates.
+// Recording is best-effort: if the memory cannot be fetched or the log not
+// written, the update still goes through.
+func Update(c Client, id string, updates map[string]interface{}, source string) (*models.Memory, error) {
+	if cur, err := c.GetMemory(id); err == nil {
+		id = cur.ID.String()
+		if s, err := Open(); err == nil {
+			_, _ = s.Capture(cur, source)
+		}
+	}
+	return c.UpdateMemory(id, updates)
+}
+
+// Tracked wraps an API client so UpdateMemory records revisions. Pass it
+// wherever an *api.Client-shaped dependency updates memories (e.g. undo).
+type Tracked struct {
+	*api.Client
+	Source string
+}
+
+// UpdateMemory records the current version, then updates.
+func (t Tracked) UpdateMemory(id string, updates map[string]interface{}) (*models.Memory, error) {
+	return Update(t.Client, id, updates, t.Source)
+}
+
+// History returns the revisions of a memory, oldest first, from the backend
+// when it supports revisions and has any, otherwise from the local log.
+// from reports which ("backend" or "local").
+func History(c Client, id string) (revs []Revision, from string, err error) {
+	if remote, rerr := c.ListMemoryRevisions(id); rerr == nil && len(remote) > 0 {
+		for _, r := range remote {
+			revs = append(revs, Revision{Rev: r.Revision, At: r.CreatedAt, Source: "backend", Memory: r.Memory})
+		}
+		slices.SortFunc(revs, func(a, b Revision) int { return a.Rev - b.Rev })
+		return revs, "backend", nil
+	}
+	s, err := Open()
+	if err != nil {
+		return nil, "", err
+	}
+	revs, err = s.List(id)
+	return revs, "local", err
+}
+
+// Find returns revision rev from revs.
+func Find(revs []Revision, rev int) (Revision, error) {
+	for _, r := range revs {
+		if r.Rev == rev {
+			return r, nil
+		}
+	}
+	return Revision{}, fmt.Errorf("revision %d not found", rev)
+}
+
+// RestorePayload builds the update that writes r back: content (or its
+// ciphertext + nonce), type, tags and the skill fields.
+func RestorePayload(r Revision) map[string]interface{} {
+	m := r.Memory
+	p := map[string]interface{}{
+		"type":       m.Type,
+		"tags":       tagList(m.Tags),
+		"trigger":    derefString(m.Trigger),
+		"steps":      m.Steps,
+		"validation": derefString(m.Validation),
+	}
+	if m.Steps == nil {
+		p["steps"] = []string{}
+	}
+	if m.IsEncrypted {
+		p["encrypted_content"] = m.EncryptedContent
+		p["content_nonce"] = m.ContentNonce
+		p["is_encrypted"] = true
+	} else {
+		p["content"] = m.Content
+	}
+	return p
+}
+
+func tagList(tags interface{}) []string {
+	switch v := tags.(type) {
+	case []string:
+		return v
+	case []interface{}:
+		out := make([]string, 0, len(v))
+		for _, t := range v {
+			if s, ok := t.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return []string{}
+}
@@ -0,0 +1,107 @@
+package memrev
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kutbudev/ramorie-cli/internal/api"
+	"github.com/kutbudev/ramorie-cli/internal/models"
+)
+
+func TestCaptureDedupesAndTrims(t *testing.T) {
+	s := &Store{Dir: t.TempDir()}
+	m := &models.Memory{ID: uuid.New(), Content: "v1", Type: "preference", Tags: []interface{}{"a"}}
+
+	r1, err := s.Capture(m, "cli")
+	if err != nil || r1.Rev != 1 {
+		t.Fatalf("first capture = %+v, %v", r1, err)
+	}
+	if r, _ := s.Capture(m, "cli"); r.Rev != 1 {
+		t.Errorf("identical version should not add a revision, got r%d", r.Rev)
+	}
+	for i := 0; i < MaxRevisions+5; i++ {
+		m.Content = "v" + string(rune('a'+i%26)) + strings.Repeat("x", i)
+		if _, err := s.Capture(m, "mcp"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	revs, err := s.List(m.ID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(revs) != MaxRevisions || revs[len(revs)-1].Rev != MaxRevisions+6 {
+		t.Errorf("len=%d last=r%d", len(revs), revs[len(revs)-1].Rev)
+	}
+	info, _ := os.Stat(s.path(m.ID.String()))
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("revision log mode = %v", info.Mode().Perm())
+	}
+}
+
+func TestCaptureDropsPlaintextOfEncryptedMemories(t *testing.T) {
+	s := &Store{Dir: t.TempDir()}
+	m := &models.Memory{ID: uuid.New(), Content: "secret", IsEncrypted: true, EncryptedContent: "CT", ContentNonce: "N"}
+	if _, err := s.Capture(m, "cli"); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(s.path(m.ID.String()))
+	if strings.Contains(string(data), "secret") || !strings.Contains(string(data), `"encrypted_content":"CT"`) {
+		t.Errorf("log = %s", data)
+	}
+
+	p := RestorePayload(Revision{Memory: snapshot(m)})
+	if p["encrypted_content"] != "CT" || p["content_nonce"] != "N" || p["content"] != nil {
+		t.Errorf("restore payload = %v", p)
+	}
+}
+
+type fakeClient struct {
+	mem     *models.Memory
+	remote  []api.MemoryRevision
+	updated map[string]interface{}
+}
+
+func (f *fakeClient) GetMemory(id string) (*models.Memory, error) { return f.mem, nil }
+func (f *fakeClient) UpdateMemory(id string, u map[string]interface{}) (*models.Memory, error) {
+	f.updated = u
+	return f.mem, nil
+}
+func (f *fakeClient) ListMemoryRevisions(id string) ([]api.MemoryRevision, error) {
+	if f.remote == nil {
+		return nil, errors.New("API request failed with status 404: not found")
+	}
+	return f.remote, nil
+}
+
+func TestUpdateRecordsAndHistoryFallsBack(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	f := &fakeClient{mem: &models.Memory{ID: uuid.New(), Content: "old wording", Type: "preference"}}
+	id := f.mem.ID.String()
+
+	if _, err := Update(f, id[:8], map[string]interface{}{"content": "new"}, "cli"); err != nil {
+		t.Fatal(err)
+	}
+	if f.updated["content"] != "new" {
+		t.Errorf("update not applied: %v", f.updated)
+	}
+	revs, from, err := History(f, id)
+	if err != nil || from != "local" || len(revs) != 1 || revs[0].Memory.Content != "old wording" || revs[0].Source != "cli" {
+		t.Fatalf("local history = %+v, %q, %v", revs, from, err)
+	}
+
+	f.remote = []api.MemoryRevision{
+		{Revision: 2, CreatedAt: time.Now(), Memory: models.Memory{Content: "b"}},
+		{Revision: 1, CreatedAt: time.Now(), Memory: models.Memory{Content: "a"}},
+	}
+	revs, from, _ = History(f, id)
+	if from != "backend" || len(revs) != 2 || revs[0].Rev != 1 {
+		t.Errorf("backend history = %+v, %q", revs, from)
+	}
+	if _, err := Find(revs, 3); err == nil {
+		t.Error("Find of a missing revision should fail")
+	}
+}
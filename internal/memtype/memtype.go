@@ -0,0 +1,481 @@
+// Package memtype classifies memory content into a memory type (decision,
+// bug_fix, preference, …) by scoring weighted keywords.
+//
+// Keywords come from built-in language packs (packs/*.yaml: en, tr, de) and
+// can be extended, reweighted or dropped from two config files:
+//
+//   - ~/.ramorie/classifier.yaml          — user-level
+//   - <repo>/.ramorie/classifier.yaml     — project-level, checked in
+//
+// The project file is applied after the user file. Example:
+//
+//	languages: [en, tr]        # packs to load; default: every built-in pack
+//	priority: [preference, decision, bug_fix, skill, pattern, reference]
+//	keywords:
+//	  decision:
+//	    "rfc:": 3              # add a phrase
+//	    selected: 0            # weight 0 drops a pack phrase
+//	  bug_fix:
+//	    "hata*": 2             # reweight a pack phrase
+//
+// A phrase matches case-insensitively as whole words; a trailing * turns its
+// last word into a stem, so "düzelt*" also matches "düzelttim" and
+// "düzeltildi" while "immer" stays clear of "immersive". Every type's matched
+// weights are summed and the highest total wins; ties go to the type listed
+// first in priority. Reference phrases only count when the content carries a
+// URL, so prose that merely mentions "docs" stays general.
+package memtype
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/kutbudev/ramorie-cli/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// Memory types the classifier can return.
+const (
+	General    = "general"
+	Decision   = "decision"
+	BugFix     = "bug_fix"
+	Preference = "preference"
+	Pattern    = "pattern"
+	Reference  = "reference"
+	Skill      = "skill"
+)
+
+// DefaultPriority is the built-in tie-break order.
+var DefaultPriority = []string{Decision, BugFix, Preference, Skill, Pattern, Reference}
+
+// ConfigFile is the classifier config's file name in ~/.ramorie and
+// <repo>/.ramorie.
+const ConfigFile = "classifier.yaml"
+
+//go:embed packs/*.yaml
+var packFS embed.FS
+
+// Config is a classifier config file. Built-in packs use the same format.
+type Config struct {
+	Languages []string                  `yaml:"languages"`
+	Priority  []string                  `yaml:"priority"`
+	Keywords  map[string]map[string]int `yaml:"keywords"`
+
+	// Source is the file the config was loaded from. Not part of the YAML.
+	Source string `yaml:"-"`
+}
+
+// Keyword is one weighted trigger phrase.
+type Keyword struct {
+	Phrase string `json:"phrase"`
+	Weight int    `json:"weight"`
+	Source string `json:"source"` // pack language or config file
+}
+
+// Classifier scores content against weighted keywords.
+type Classifier struct {
+	Languages []string
+	Priority  []string
+	Sources   []string // config files applied, in order
+
+	keywords map[string][]Keyword
+}
+
+// Languages returns the built-in pack names, sorted.
+func Languages() []string {
+	entries, _ := packFS.ReadDir("packs")
+	var out []string
+	for _, e := range entries {
+		out = append(out, strings.TrimSuffix(e.Name(), ".yaml"))
+	}
+	sort.Strings(out)
+	return out
+}
+
+func loadPack(lang string) (Config, error) {
+	var cfg Config
+	data, err := packFS.ReadFile("packs/" + lang + ".yaml")
+	if err != nil {
+		return cfg, fmt.Errorf("unknown language pack %q (built-in: %s)", lang, strings.Join(Languages(), ", "))
+	}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("language pack %s: %w", lang, err)
+	}
+	return cfg, nil
+}
+
+// ParseConfig decodes and validates a config file.
+func ParseConfig(data []byte) (Config, error) {
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return cfg, err
+	}
+	for t, phrases := range cfg.Keywords {
+		if !isType(t) {
+			return cfg, fmt.Errorf("unknown memory type %q in keywords", t)
+		}
+		for p, w := range phrases {
+			if strings.TrimSpace(p) == "" {
+				return cfg, fmt.Errorf("empty phrase under %s", t)
+			}
+			if w < 0 {
+				return cfg, fmt.Errorf("%s: weight of %q must not be negative", t, p)
+			}
+		}
+	}
+	seen := map[string]bool{}
+	for _, t := range cfg.Priority {
+		if !isType(t) {
+			return cfg, fmt.Errorf("unknown memory type %q in priority", t)
+		}
+		if seen[t] {
+			return cfg, fmt.Errorf("%s is listed twice in priority", t)
+		}
+		seen[t] = true
+	}
+	return cfg, nil
+}
+
+func isType(t string) bool {
+	for _, p := range DefaultPriority {
+		if p == t {
+			return true
+		}
+	}
+	return false
+}
+
+// New builds a classifier from the built-in packs and the given configs,
+// applied in order.
+func New(configs ...Config) (*Classifier, error) {
+	c := &Classifier{Languages: Languages(), Priority: DefaultPriority}
+	for _, cfg := range configs {
+		if cfg.Languages != nil {
+			c.Languages = normalizeLanguages(cfg.Languages)
+		}
+		if len(cfg.Priority) > 0 {
+			c.Priority = completePriority(cfg.Priority)
+		}
+	}
+
+	byType := map[string]map[string]Keyword{}
+	set := func(t, phrase string, weight int, source string) {
+		phrase = foldCase(strings.TrimSpace(phrase))
+		if byType[t] == nil {
+			byType[t] = map[string]Keyword{}
+		}
+		if weight == 0 {
+			delete(byType[t], phrase)
+			return
+		}
+		byType[t][phrase] = Keyword{Phrase: phrase, Weight: weight, Source: source}
+	}
+	for _, lang := range c.Languages {
+		pack, err := loadPack(lang)
+		if err != nil {
+			return nil, err
+		}
+		for t, phrases := range pack.Keywords {
+			for p, w := range phrases {
+				set(t, p, w, lang)
+			}
+		}
+	}
+	for _, cfg := range configs {
+		for t, phrases := range cfg.Keywords {
+			for p, w := range phrases {
+				set(t, p, w, cfg.Source)
+			}
+		}
+		if cfg.Source != "" {
+			c.Sources = append(c.Sources, cfg.Source)
+		}
+	}
+
+	c.keywords = make(map[string][]Keyword, len(byType))
+	for t, m := range byType {
+		list := make([]Keyword, 0, len(m))
+		for _, k := range m {
+			list = append(list, k)
+		}
+		sort.Slice(list, func(i, j int) bool {
+			if list[i].Weight != list[j].Weight {
+				return list[i].Weight > list[j].Weight
+			}
+			return list[i].Phrase < list[j].Phrase
+		})
+		c.keywords[t] = list
+	}
+	return c, nil
+}
+
+func normalizeLanguages(langs []string) []string {
+	out := make([]string, 0, len(langs))
+	seen := map[string]bool{}
+	for _, l := range langs {
+		l = strings.ToLower(strings.TrimSpace(l))
+		if l != "" && !seen[l] {
+			seen[l] = true
+			out = append(out, l)
+		}
+	}
+	return out
+}
+
+// completePriority appends the types a config's priority leaves out, in
+// default order, so every type can still win.
+func completePriority(p []string) []string {
+	out := append([]string(nil), p...)
+	for _, t := range DefaultPriority {
+		found := false
+		for _, have := range p {
+			if have == t {
+				found = true
+				break
+			}
+		}
+		if !found {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// Keywords returns the effective keywords of memoryType, strongest first.
+func (c *Classifier) Keywords(memoryType string) []Keyword {
+	return c.keywords[memoryType]
+}
+
+// Match is one keyword found in the content.
+type Match struct {
+	Type string `json:"type"`
+	Keyword
+	Counted bool `json:"counted"` // false for reference phrases without a URL
+}
+
+// Result is a classification with its score breakdown.
+type Result struct {
+	Type    string         `json:"type"`
+	Scores  map[string]int `json:"scores"`
+	Matches []Match        `json:"matches"`
+	HasURL  bool           `json:"has_url"`
+	// ShortURLBonus is set when a short, link-carrying snippet got the extra
+	// reference weight.
+	ShortURLBonus bool   `json:"short_url_bonus,omitempty"`
+	Reason        string `json:"reason"`
+}
+
+// shortURLBonus is added to reference for a snippet under shortURLWords
+// words that carries a link: it is almost certainly a reference even
+// without a "docs"/"guide" keyword.
+const (
+	shortURLBonus = 2
+	shortURLWords = 10
+)
+
+var urlPattern = regexp.MustCompile(`https?://[^\s]+`)
+
+// Classify scores content and returns the winning type with its breakdown.
+func (c *Classifier) Classify(content string) Result {
+	text := foldCase(content)
+	r := Result{Scores: map[string]int{}, HasURL: urlPattern.MatchString(content)}
+	for _, t := range c.Priority {
+		for _, k := range c.keywords[t] {
+			if !containsPhrase(text, k.Phrase) {
+				continue
+			}
+			counted := t != Reference || r.HasURL
+			r.Matches = append(r.Matches, Match{Type: t, Keyword: k, Counted: counted})
+			if counted {
+				r.Scores[t] += k.Weight
+			}
+		}
+	}
+	if r.HasURL && len(strings.Fields(content)) < shortURLWords {
+		r.Scores[Reference] += shortURLBonus
+		r.ShortURLBonus = true
+	}
+
+	r.Type = General
+	best := 0
+	for _, t := range c.Priority {
+		if r.Scores[t] > best {
+			best, r.Type = r.Scores[t], t
+		}
+	}
+	r.Reason = c.reason(r, best)
+	return r
+}
+
+func (c *Classifier) reason(r Result, best int) string {
+	if best == 0 {
+		return "no keyword matched"
+	}
+	var tied []string
+	for _, t := range c.Priority {
+		if r.Scores[t] == best {
+			tied = append(tied, t)
+		}
+	}
+	if len(tied) > 1 {
+		return fmt.Sprintf("%s tied at %d with %s; %s comes first in priority",
+			r.Type, best, strings.Join(tied[1:], ", "), r.Type)
+	}
+	return fmt.Sprintf("%s has the highest score (%d)", r.Type, best)
+}
+
+// foldCase lowercases text. Turkish dotted capital İ folds to a plain i
+// rather than i plus a combining dot.
+func foldCase(s string) string {
+	return strings.ToLower(strings.ReplaceAll(s, "İ", "i"))
+}
+
+// containsPhrase reports whether phrase occurs in text as whole words. A
+// trailing * lets the last word continue, matching any word it starts.
+func containsPhrase(text, phrase string) bool {
+	stem := strings.HasSuffix(phrase, "*")
+	phrase = strings.TrimSuffix(phrase, "*")
+	if phrase == "" {
+		return false
+	}
+	last, _ := utf8.DecodeLastRuneInString(phrase)
+	for off := 0; off <= len(text)-len(phrase); {
+		i := strings.Index(text[off:], phrase)
+		if i < 0 {
+			return false
+		}
+		i += off
+		end := i + len(phrase)
+		before, _ := utf8.DecodeLastRuneInString(text[:i])
+		after, _ := utf8.DecodeRuneInString(text[end:])
+		startOK := i == 0 || !isWordRune(before)
+		endOK := stem || end == len(text) || !isWordRune(last) || !isWordRune(after)
+		if startOK && endOK {
+			return true
+		}
+		_, size := utf8.DecodeRuneInString(text[i:])
+		off = i + size
+	}
+	return false
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// Paths returns the config files in the order they apply: the user file,
+// then the project file. Either may be "" when it cannot be located.
+func Paths() (user, project string) {
+	return config.LayeredFiles(ConfigFile)
+}
+
+// PathsAt is Paths for the checkout containing dir.
+func PathsAt(dir string) (user, project string) {
+	return config.LayeredFilesAt(ConfigFile, dir)
+}
+
+// LoadFiles builds a classifier from the given config files, skipping ones
+// that do not exist.
+func LoadFiles(paths ...string) (*Classifier, error) {
+	var configs []Config
+	for _, p := range paths {
+		if p == "" {
+			continue
+		}
+		data, err := os.ReadFile(p)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		cfg, err := ParseConfig(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", p, err)
+		}
+		cfg.Source = p
+		configs = append(configs, cfg)
+	}
+	return New(configs...)
+}
+
+// Load builds the classifier from the user and project config files.
+func Load() (*Classifier, error) {
+	user, project := Paths()
+	return LoadFiles(user, project)
+}
+
+// cached is a loaded classifier and the modification times of the config
+// files it was built from.
+type cached struct {
+	c      *Classifier
+	stamps [2]time.Time
+}
+
+var (
+	cacheMu sync.Mutex
+	cache   = map[[2]string]cached{}
+)
+
+// Default returns the classifier for the working directory; see ForDir.
+func Default() *Classifier {
+	return ForDir("")
+}
+
+// ForDir returns the classifier for the checkout containing dir (the working
+// directory when dir is ""), built from the user and that project's config
+// files. Classifiers are cached per config pair and reloaded when either file
+// changes, so a long-lived process such as the MCP server follows edits and
+// serves several projects. A broken config falls back to the built-in packs
+// so saving a memory never fails over it; `ramorie memory classify` reports
+// the error.
+func ForDir(dir string) *Classifier {
+	user, project := PathsAt(dir)
+	return forFiles(user, project)
+}
+
+// UserOnly returns the classifier built from the user config file alone,
+// for content whose project checkout is not known.
+func UserOnly() *Classifier {
+	user, _ := Paths()
+	return forFiles(user, "")
+}
+
+func forFiles(user, project string) *Classifier {
+	key := [2]string{user, project}
+	stamps := [2]time.Time{modTime(user), modTime(project)}
+
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+	if e, ok := cache[key]; ok && e.stamps == stamps {
+		return e.c
+	}
+	c, err := LoadFiles(user, project)
+	if err != nil {
+		c, _ = New()
+	}
+	cache[key] = cached{c: c, stamps: stamps}
+	return c
+}
+
+// modTime returns path's modification time, or the zero time when it is
+// unset or missing.
+func modTime(path string) time.Time {
+	if path == "" {
+		return time.Time{}
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
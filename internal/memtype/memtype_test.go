@@ -0,0 +1,168 @@
+package memtype
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuiltinPacks(t *testing.T) {
+	if got := strings.Join(Languages(), ","); got != "de,en,tr" {
+		t.Fatalf("packs = %s", got)
+	}
+	c, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := map[string]string{
+		// Turkish
+		"Redis yerine Postgres kullanmaya karar verdik":            Decision,
+		"Kök neden eksik nonce idi, parser düzeltildi":             BugFix,
+		"Her zaman yarn kullan, asla npm kullanma":                 Preference,
+		"Sertifika yenileme adım adım: önce anahtarı üret":         Skill,
+		"İstemci tarafında tasarım deseni: hexagonal architecture": Pattern,
+		// German
+		"Wir haben uns für Postgres statt MongoDB entschieden":       Decision,
+		"Die Ursache war ein fehlender Nonce, jetzt behoben":         BugFix,
+		"Wir bevorzugen kebab-case und verwenden immer yarn":         Preference,
+		"Anleitung: Schritt für Schritt den Worker deployen":         Skill,
+		"Dokumentation zum Deployment: https://example.com/handbuch": Reference,
+		// Neither
+		"Der Build lief heute langsam": General,
+	}
+	for text, want := range cases {
+		if got := c.Classify(text); got.Type != want {
+			t.Errorf("Classify(%q) = %s (%v), want %s", text, got.Type, got.Scores, want)
+		}
+	}
+}
+
+func TestPhrasesMatchWholeWords(t *testing.T) {
+	c, _ := New()
+	// "immer" must not fire inside "immersive", nor "statt" inside "Werkstatt".
+	if r := c.Classify("an immersive Werkstatt demo"); r.Type != General {
+		t.Errorf("partial-word match: %+v", r.Matches)
+	}
+	for _, tc := range []struct {
+		text, phrase string
+		want         bool
+	}{
+		{"ben düzelttim", "düzelt*", true},
+		{"x-prefer", "prefer", true},
+		{"bug: fixed", "bug:", true},
+		{"preferred", "prefer", false},
+		{"preferred", "prefer*", true},
+		{"fixedprefer", "prefer*", false},
+	} {
+		if got := containsPhrase(tc.text, tc.phrase); got != tc.want {
+			t.Errorf("containsPhrase(%q, %q) = %v", tc.text, tc.phrase, got)
+		}
+	}
+	if foldCase("İSTANBUL") != "istanbul" {
+		t.Errorf("fold = %q", foldCase("İSTANBUL"))
+	}
+}
+
+func TestReferenceIsURLGated(t *testing.T) {
+	c, _ := New()
+	r := c.Classify("check the documentation for details")
+	if r.Type != General || len(r.Matches) != 1 || r.Matches[0].Counted {
+		t.Errorf("without URL: %+v", r)
+	}
+	r = c.Classify("see: https://example.com/docs")
+	if r.Type != Reference || !r.ShortURLBonus {
+		t.Errorf("with URL: %+v", r)
+	}
+}
+
+func TestConfigOverrides(t *testing.T) {
+	dir := t.TempDir()
+	user := filepath.Join(dir, "user.yaml")
+	project := filepath.Join(dir, "project.yaml")
+	write := func(p, body string) {
+		if err := os.WriteFile(p, []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(user, "languages: [en]\nkeywords:\n  decision:\n    \"rfc:\": 3\n")
+	write(project, "priority: [preference]\nkeywords:\n  decision:\n    decided: 0\n")
+
+	c, err := LoadFiles(user, project, filepath.Join(dir, "missing.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(c.Languages, ",") != "en" || len(c.Sources) != 2 {
+		t.Errorf("languages %v sources %v", c.Languages, c.Sources)
+	}
+	if got := c.Classify("rfc: move to gRPC").Type; got != Decision {
+		t.Errorf("added phrase: %s", got)
+	}
+	if got := c.Classify("it was decided").Type; got != General {
+		t.Errorf("dropped phrase still matches: %s", got)
+	}
+	if got := c.Classify("Redis yerine karar verdik").Type; got != General {
+		t.Errorf("tr pack should be off: %s", got)
+	}
+	// "chose" (decision 2) ties "prefer" (preference 2): priority decides.
+	r := c.Classify("chose what we prefer")
+	if r.Type != Preference || !strings.Contains(r.Reason, "priority") {
+		t.Errorf("tie = %+v", r)
+	}
+	if c.Priority[0] != Preference || len(c.Priority) != len(DefaultPriority) {
+		t.Errorf("priority = %v", c.Priority)
+	}
+}
+
+func TestForDirPerProject(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	a, b := t.TempDir(), t.TempDir()
+	write := func(dir, body string) {
+		p := filepath.Join(dir, ".ramorie", ConfigFile)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(a, "keywords:\n  decision:\n    \"rfc:\": 3\n")
+
+	if got := ForDir(a).Classify("rfc: move to gRPC").Type; got != Decision {
+		t.Errorf("project a: %s", got)
+	}
+	if got := ForDir(b).Classify("rfc: move to gRPC").Type; got != General {
+		t.Errorf("project b picked up a's config: %s", got)
+	}
+	if got := UserOnly().Classify("rfc: move to gRPC").Type; got != General {
+		t.Errorf("UserOnly picked up a project config: %s", got)
+	}
+
+	// An edited config is picked up without restarting the process.
+	write(b, "keywords:\n  bug_fix:\n    \"rfc:\": 3\n")
+	future := time.Now().Add(time.Minute)
+	if err := os.Chtimes(filepath.Join(b, ".ramorie", ConfigFile), future, future); err != nil {
+		t.Fatal(err)
+	}
+	if got := ForDir(b).Classify("rfc: move to gRPC").Type; got != BugFix {
+		t.Errorf("project b after edit: %s", got)
+	}
+}
+
+func TestParseConfigRejectsMistakes(t *testing.T) {
+	for _, bad := range []string{
+		"keywords:\n  decisions:\n    x: 1\n",
+		"keywords:\n  decision:\n    x: -1\n",
+		"priority: [decision, decision]\n",
+		"priority: [todo]\n",
+		"keywords: [a\n",
+	} {
+		if _, err := ParseConfig([]byte(bad)); err == nil {
+			t.Errorf("ParseConfig(%q) should fail", bad)
+		}
+	}
+	if _, err := New(Config{Languages: []string{"fr"}}); err == nil {
+		t.Error("unknown language pack should fail")
+	}
+}
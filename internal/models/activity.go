@@ -15,4 +15,8 @@ type ActivityItem struct {
 	ProjectID  *uuid.UUID `json:"project_id,omitempty"`
 	Summary    string     `json:"summary"`
 	Timestamp  time.Time  `json:"timestamp"`
+	// EventType and Status are the structured form of Summary (e.g.
+	// "task_completed", "COMPLETED"). Older backends leave them empty.
+	EventType string `json:"event_type,omitempty"`
+	Status    string `json:"status,omitempty"`
 }
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -41,6 +42,7 @@ type Task struct {
 	Description string       `json:"description"`
 	Status      string       `json:"status"`   // TODO, IN_PROGRESS, COMPLETED
 	Priority    string       `json:"priority"` // L, M, H
+	Progress    int          `json:"progress"` // 0-100
 	Tags        interface{}  `json:"tags"`     // Can be array or object from backend
 	Annotations []Annotation `json:"annotations"`
 	Project     *Project     `json:"project,omitempty"`
@@ -71,6 +73,10 @@ type Memory struct {
 	LastAccessedAt *time.Time  `json:"last_accessed_at,omitempty"`
 	// Memory categorization
 	Type string `json:"type"` // general, decision, bug_fix, preference, pattern, reference, skill
+	// Lifecycle is active (or empty), superseded or archived; ValidUntil is
+	// the expiry set with remember --expires.
+	Lifecycle  string     `json:"lifecycle,omitempty"`
+	ValidUntil *time.Time `json:"valid_until,omitempty"`
 	// Importance scoring
 	Importance  *float64 `json:"importance,omitempty"`
 	AccessCount int      `json:"access_count"`
@@ -78,6 +84,8 @@ type Memory struct {
 	Trigger    *string  `json:"trigger,omitempty"`    // Conditions when this skill should be activated
 	Steps      []string `json:"steps,omitempty"`      // Array of steps to follow
 	Validation *string  `json:"validation,omitempty"` // How to verify the skill was applied
+	// Code anchors (see package anchor), as saved on the memory
+	Anchors json.RawMessage `json:"anchors,omitempty"`
 	// Access control fields
 	Visibility string   `json:"visibility,omitempty"` // private, project, organization, public
 	Readers    []string `json:"readers,omitempty"`    // User IDs with read access
@@ -85,6 +93,7 @@ type Memory struct {
 	// Encryption fields for zero-knowledge encryption
 	EncryptedContent string `json:"encrypted_content,omitempty"`
 	ContentNonce     string `json:"content_nonce,omitempty"`
+	ContentHash      string `json:"content_hash,omitempty"` // SHA-256 of the plaintext, for duplicate detection
 	IsEncrypted      bool   `json:"is_encrypted"`
 	// Encryption scope (personal vs organization)
 	EncryptionScope string `json:"encryption_scope,omitempty"`
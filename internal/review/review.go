@@ -0,0 +1,242 @@
+// Package review schedules memories for re-confirmation and expiry.
+//
+// Preferences and decisions go stale silently. `remember --review-in 90d`
+// (or the MCP remember tool's review_in) puts a memory on a review
+// schedule; `ramorie review` surfaces the ones that are due. Confirming a
+// memory pushes its next review out by a growing interval, like spaced
+// repetition: 90d, 180d, 360d, … capped at MaxIntervalDays. Editing it
+// restarts the interval it had.
+//
+// `--expires 2026-12-31` marks a memory as no longer valid after that date.
+// The expiry is also sent to the backend as valid_until; the local copy lets
+// hooks drop expired memories even when the search response does not carry
+// it.
+//
+// Schedules live in ~/.ramorie/reviews.json (mode 0600), keyed by memory ID.
+package review
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kutbudev/ramorie-cli/internal/statefile"
+)
+
+// Growth is the factor a confirmed memory's interval grows by.
+const Growth = 2.0
+
+// MaxIntervalDays caps the review interval at two years.
+const MaxIntervalDays = 730
+
+// Schedule is a memory's review state.
+type Schedule struct {
+	MemoryID     string     `json:"memory_id"`
+	IntervalDays int        `json:"interval_days,omitempty"` // 0: no review schedule, expiry only
+	Due          time.Time  `json:"due,omitempty"`
+	Reviews      int        `json:"reviews,omitempty"`
+	LastReviewed *time.Time `json:"last_reviewed,omitempty"`
+	Expires      *time.Time `json:"expires,omitempty"`
+}
+
+// New returns a schedule for memoryID due intervalDays from now. A zero
+// interval leaves only the expiry.
+func New(memoryID string, intervalDays int, expires *time.Time, now time.Time) Schedule {
+	s := Schedule{MemoryID: memoryID, IntervalDays: intervalDays, Expires: expires}
+	if intervalDays > 0 {
+		s.Due = now.AddDate(0, 0, intervalDays)
+	}
+	return s
+}
+
+// IsDue reports whether the memory needs a review at now.
+func (s Schedule) IsDue(now time.Time) bool {
+	return s.IntervalDays > 0 && !now.Before(s.Due)
+}
+
+// IsExpired reports whether the memory's expiry has passed.
+func (s Schedule) IsExpired(now time.Time) bool {
+	return s.Expires != nil && now.After(*s.Expires)
+}
+
+// Confirm records that the memory is still right: the interval grows by
+// Growth and the next review is that far from now.
+func (s Schedule) Confirm(now time.Time) Schedule {
+	if s.IntervalDays <= 0 {
+		return s
+	}
+	s.IntervalDays = min(int(float64(s.IntervalDays)*Growth+0.5), MaxIntervalDays)
+	return s.reviewed(now)
+}
+
+// Edited records that the memory was corrected: the interval stays, since
+// the new wording has not been confirmed yet.
+func (s Schedule) Edited(now time.Time) Schedule {
+	if s.IntervalDays <= 0 {
+		return s
+	}
+	return s.reviewed(now)
+}
+
+func (s Schedule) reviewed(now time.Time) Schedule {
+	s.Reviews++
+	s.LastReviewed = &now
+	s.Due = now.AddDate(0, 0, s.IntervalDays)
+	return s
+}
+
+// ParseInterval parses a review interval such as 90d, 12w, 6m or 1y into
+// days. A bare number is days.
+func ParseInterval(s string) (int, error) {
+	orig := s
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return 0, fmt.Errorf("empty interval")
+	}
+	unit := 1
+	switch s[len(s)-1] {
+	case 'd':
+		s = s[:len(s)-1]
+	case 'w':
+		unit, s = 7, s[:len(s)-1]
+	case 'm':
+		unit, s = 30, s[:len(s)-1]
+	case 'y':
+		unit, s = 365, s[:len(s)-1]
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid interval %q (use e.g. 30d, 12w, 6m, 1y)", orig)
+	}
+	return n * unit, nil
+}
+
+// ParseExpiry parses an expiry as a date (2026-12-31, end of that day in
+// local time), an RFC 3339 timestamp, or an interval from now (90d).
+func ParseExpiry(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if t, err := time.ParseInLocation("2006-01-02", s, now.Location()); err == nil {
+		return t.Add(24*time.Hour - time.Second), nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	if days, err := ParseInterval(s); err == nil {
+		return now.AddDate(0, 0, days), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid expiry %q (use YYYY-MM-DD, RFC 3339 or an interval like 90d)", s)
+}
+
+// Parse parses a review interval and an expiry as given to remember. Either
+// may be empty; an expiry must lie after now.
+func Parse(reviewIn, expires string, now time.Time) (days int, until *time.Time, err error) {
+	if strings.TrimSpace(reviewIn) != "" {
+		if days, err = ParseInterval(reviewIn); err != nil {
+			return 0, nil, err
+		}
+	}
+	if strings.TrimSpace(expires) != "" {
+		t, err := ParseExpiry(expires, now)
+		if err != nil {
+			return 0, nil, err
+		}
+		if !t.After(now) {
+			return 0, nil, fmt.Errorf("expiry %q is in the past", strings.TrimSpace(expires))
+		}
+		until = &t
+	}
+	return days, until, nil
+}
+
+// Store is the schedule file.
+type Store struct {
+	Path string
+}
+
+// Open returns the default store in ~/.ramorie/reviews.json.
+func Open() (*Store, error) {
+	path, err := statefile.Path("reviews.json")
+	if err != nil {
+		return nil, err
+	}
+	return &Store{Path: path}, nil
+}
+
+// Load returns every schedule by memory ID.
+func (s *Store) Load() (map[string]Schedule, error) {
+	out := map[string]Schedule{}
+	data, err := os.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		return out, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("corrupt review schedule %s: %w", s.Path, err)
+	}
+	return out, nil
+}
+
+// Put stores one schedule.
+func (s *Store) Put(sched Schedule) error {
+	all, err := s.Load()
+	if err != nil {
+		return err
+	}
+	all[sched.MemoryID] = sched
+	return s.write(all)
+}
+
+// Remove drops a memory's schedule.
+func (s *Store) Remove(memoryID string) error {
+	all, err := s.Load()
+	if err != nil {
+		return err
+	}
+	if _, ok := all[memoryID]; !ok {
+		return nil
+	}
+	delete(all, memoryID)
+	return s.write(all)
+}
+
+func (s *Store) write(all map[string]Schedule) error {
+	return statefile.WriteJSON(s.Path, all)
+}
+
+// Set stores a new schedule for memoryID in the default store. Nothing is
+// stored when there is neither an interval nor an expiry.
+func Set(memoryID string, intervalDays int, expires *time.Time) error {
+	if intervalDays <= 0 && expires == nil {
+		return nil
+	}
+	s, err := Open()
+	if err != nil {
+		return err
+	}
+	return s.Put(New(memoryID, intervalDays, expires, time.Now()))
+}
+
+// Expired returns the IDs of memories whose expiry has passed, from the
+// default store. Errors yield an empty set so hooks never fail on it.
+func Expired(now time.Time) map[string]bool {
+	out := map[string]bool{}
+	s, err := Open()
+	if err != nil {
+		return out
+	}
+	all, err := s.Load()
+	if err != nil {
+		return out
+	}
+	for id, sched := range all {
+		if sched.IsExpired(now) {
+			out[id] = true
+		}
+	}
+	return out
+}
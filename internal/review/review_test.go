@@ -0,0 +1,97 @@
+package review
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseInterval(t *testing.T) {
+	for in, want := range map[string]int{"90d": 90, "90": 90, "12w": 84, "6M": 180, "1y": 365} {
+		if got, err := ParseInterval(in); err != nil || got != want {
+			t.Errorf("ParseInterval(%q) = %d, %v; want %d", in, got, err, want)
+		}
+	}
+	for _, bad := range []string{"", "d", "-5d", "soon"} {
+		if _, err := ParseInterval(bad); err == nil {
+			t.Errorf("ParseInterval(%q) should fail", bad)
+		}
+	}
+}
+
+func TestParseExpiry(t *testing.T) {
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	got, err := ParseExpiry("2026-12-31", now)
+	if err != nil || got.Format(time.RFC3339) != "2026-12-31T23:59:59Z" {
+		t.Errorf("date = %v, %v", got, err)
+	}
+	if got, _ := ParseExpiry("30d", now); !got.Equal(now.AddDate(0, 0, 30)) {
+		t.Errorf("interval = %v", got)
+	}
+	if _, err := ParseExpiry("next tuesday", now); err == nil {
+		t.Error("nonsense expiry should fail")
+	}
+}
+
+func TestParse(t *testing.T) {
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	days, until, err := Parse("6m", "2026-03-01", now)
+	if err != nil || days != 180 || until == nil || until.Month() != time.March {
+		t.Errorf("Parse = %d, %v, %v", days, until, err)
+	}
+	if days, until, err := Parse("", "", now); err != nil || days != 0 || until != nil {
+		t.Errorf("empty Parse = %d, %v, %v", days, until, err)
+	}
+	if _, _, err := Parse("", "2025-12-31", now); err == nil {
+		t.Error("an expiry in the past should fail")
+	}
+}
+
+func TestScheduleGrowsOnConfirm(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := New("m1", 90, nil, now)
+	if s.IsDue(now) || !s.IsDue(now.AddDate(0, 0, 90)) {
+		t.Errorf("due = %v", s.Due)
+	}
+	later := now.AddDate(0, 0, 100)
+	s = s.Confirm(later)
+	if s.IntervalDays != 180 || !s.Due.Equal(later.AddDate(0, 0, 180)) || s.Reviews != 1 {
+		t.Errorf("after confirm = %+v", s)
+	}
+	if s = s.Edited(later); s.IntervalDays != 180 || s.Reviews != 2 {
+		t.Errorf("edit should keep the interval: %+v", s)
+	}
+	for i := 0; i < 5; i++ {
+		s = s.Confirm(later)
+	}
+	if s.IntervalDays != MaxIntervalDays {
+		t.Errorf("interval = %d, want capped at %d", s.IntervalDays, MaxIntervalDays)
+	}
+
+	expires := now.AddDate(0, 1, 0)
+	e := New("m2", 0, &expires, now)
+	if e.IsDue(now.AddDate(5, 0, 0)) || e.IsExpired(now) || !e.IsExpired(now.AddDate(0, 2, 0)) {
+		t.Errorf("expiry-only schedule = %+v", e)
+	}
+}
+
+func TestStoreRoundTrip(t *testing.T) {
+	s := &Store{Path: filepath.Join(t.TempDir(), "reviews.json")}
+	now := time.Now()
+	if err := s.Put(New("m1", 30, nil, now)); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Put(New("m2", 60, nil, now)); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Remove("m1"); err != nil {
+		t.Fatal(err)
+	}
+	all, err := s.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 1 || all["m2"].IntervalDays != 60 {
+		t.Errorf("store = %+v", all)
+	}
+}
@@ -0,0 +1,253 @@
+// Package searches stores named `ramorie find` queries.
+//
+// `find <term> --save deploy` records the term and every find option under
+// the name deploy; `find @deploy` runs it again. The TUI lists saved
+// searches in its sidebar and the MCP server exposes each one as the
+// resource ramorie://search/{name}.
+//
+// Searches live in ~/.ramorie/searches.json (mode 0600), keyed by name.
+package searches
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kutbudev/ramorie-cli/internal/api"
+	"github.com/kutbudev/ramorie-cli/internal/statefile"
+)
+
+// Search is a saved find query. Project is kept as the user gave it (name,
+// short ID or UUID) for display; ProjectID is the UUID it resolved to when
+// the search was saved, which is what runs.
+type Search struct {
+	Name              string    `json:"name"`
+	Term              string    `json:"term"`
+	Project           string    `json:"project,omitempty"`
+	ProjectID         string    `json:"project_id,omitempty"`
+	Types             []string  `json:"types,omitempty"`
+	Tags              []string  `json:"tags,omitempty"`
+	Limit             int       `json:"limit,omitempty"`
+	Budget            int       `json:"budget,omitempty"`
+	HyDE              string    `json:"hyde,omitempty"`
+	Rerank            string    `json:"rerank,omitempty"`
+	Intent            string    `json:"intent,omitempty"`
+	EntityHops        int       `json:"entity_hops,omitempty"`
+	IncludeSuperseded bool      `json:"include_superseded,omitempty"`
+	FastMode          bool      `json:"fast,omitempty"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// ValidName checks a search name. Names are referenced as @name on the
+// command line and in resource URIs, so they are limited to letters,
+// digits, dots, dashes and underscores.
+func ValidName(name string) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("invalid search name %q (use letters, digits, '.', '-' and '_')", name)
+	}
+	return nil
+}
+
+// FromOptions records find options under name. project is the project as
+// the user gave it; opts.Project must already be resolved to its UUID.
+func FromOptions(name, project string, opts api.FindMemoriesOptions) Search {
+	return Search{
+		Name:              name,
+		Term:              opts.Term,
+		Project:           project,
+		ProjectID:         opts.Project,
+		Types:             opts.Types,
+		Tags:              opts.Tags,
+		Limit:             opts.Limit,
+		Budget:            opts.BudgetTokens,
+		HyDE:              opts.HyDE,
+		Rerank:            opts.Rerank,
+		Intent:            opts.Intent,
+		EntityHops:        opts.EntityHops,
+		IncludeSuperseded: opts.IncludeSuperseded,
+		FastMode:          opts.FastMode,
+	}
+}
+
+// Options returns the find options of a saved search, scoped to the saved
+// project UUID.
+func (s Search) Options() api.FindMemoriesOptions {
+	return api.FindMemoriesOptions{
+		Term:              s.Term,
+		Project:           s.ProjectID,
+		Types:             append([]string{}, s.Types...),
+		Tags:              append([]string{}, s.Tags...),
+		Limit:             s.Limit,
+		BudgetTokens:      s.Budget,
+		HyDE:              s.HyDE,
+		Rerank:            s.Rerank,
+		Intent:            s.Intent,
+		EntityHops:        s.EntityHops,
+		IncludeSuperseded: s.IncludeSuperseded,
+		FastMode:          s.FastMode,
+	}
+}
+
+// Summary describes the options that differ from find's defaults, e.g.
+// "project api · types decision,pattern · fast".
+func (s Search) Summary() string {
+	var parts []string
+	if s.Project != "" {
+		parts = append(parts, "project "+s.Project)
+	}
+	if len(s.Types) > 0 {
+		parts = append(parts, "types "+strings.Join(s.Types, ","))
+	}
+	if len(s.Tags) > 0 {
+		parts = append(parts, "tags "+strings.Join(s.Tags, ","))
+	}
+	if s.Limit > 0 && s.Limit != 30 {
+		parts = append(parts, fmt.Sprintf("limit %d", s.Limit))
+	}
+	if s.Budget > 0 && s.Budget != 12000 {
+		parts = append(parts, fmt.Sprintf("budget %d", s.Budget))
+	}
+	if s.HyDE != "" && s.HyDE != "default" {
+		parts = append(parts, "hyde "+s.HyDE)
+	}
+	if s.Rerank != "" && s.Rerank != "default" {
+		parts = append(parts, "rerank "+s.Rerank)
+	}
+	if s.Intent != "" && s.Intent != "auto" {
+		parts = append(parts, "intent "+s.Intent)
+	}
+	if s.EntityHops > 0 {
+		parts = append(parts, fmt.Sprintf("entity-hops %d", s.EntityHops))
+	}
+	if s.IncludeSuperseded {
+		parts = append(parts, "include-superseded")
+	}
+	if s.FastMode {
+		parts = append(parts, "fast")
+	}
+	return strings.Join(parts, " · ")
+}
+
+// Store is the saved-search file.
+type Store struct {
+	Path string
+}
+
+// Open returns the default store in ~/.ramorie/searches.json.
+func Open() (*Store, error) {
+	path, err := statefile.Path("searches.json")
+	if err != nil {
+		return nil, err
+	}
+	return &Store{Path: path}, nil
+}
+
+// Load returns every saved search by name.
+func (s *Store) Load() (map[string]Search, error) {
+	out := map[string]Search{}
+	data, err := os.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		return out, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("corrupt saved searches %s: %w", s.Path, err)
+	}
+	return out, nil
+}
+
+// List returns the saved searches sorted by name.
+func (s *Store) List() ([]Search, error) {
+	all, err := s.Load()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Search, 0, len(all))
+	for _, search := range all {
+		out = append(out, search)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out, nil
+}
+
+// Get returns the search saved as name; a leading @ is ignored.
+func (s *Store) Get(name string) (Search, bool, error) {
+	all, err := s.Load()
+	if err != nil {
+		return Search{}, false, err
+	}
+	search, ok := all[strings.TrimPrefix(name, "@")]
+	return search, ok, nil
+}
+
+// Put stores a search, replacing one of the same name. The creation time
+// of a replaced search is kept.
+func (s *Store) Put(search Search) error {
+	if err := ValidName(search.Name); err != nil {
+		return err
+	}
+	all, err := s.Load()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	search.CreatedAt = now
+	if old, ok := all[search.Name]; ok && !old.CreatedAt.IsZero() {
+		search.CreatedAt = old.CreatedAt
+	}
+	search.UpdatedAt = now
+	all[search.Name] = search
+	return s.write(all)
+}
+
+// Remove deletes a saved search and reports whether it existed.
+func (s *Store) Remove(name string) (bool, error) {
+	all, err := s.Load()
+	if err != nil {
+		return false, err
+	}
+	name = strings.TrimPrefix(name, "@")
+	if _, ok := all[name]; !ok {
+		return false, nil
+	}
+	delete(all, name)
+	return true, s.write(all)
+}
+
+func (s *Store) write(all map[string]Search) error {
+	return statefile.WriteJSON(s.Path, all)
+}
+
+// All returns the saved searches from the default store, sorted by name.
+func All() ([]Search, error) {
+	s, err := Open()
+	if err != nil {
+		return nil, err
+	}
+	return s.List()
+}
+
+// Lookup returns the search saved as name in the default store.
+func Lookup(name string) (Search, error) {
+	s, err := Open()
+	if err != nil {
+		return Search{}, err
+	}
+	search, ok, err := s.Get(name)
+	if err != nil {
+		return Search{}, err
+	}
+	if !ok {
+		return Search{}, fmt.Errorf("no saved search @%s (see `ramorie searches list`)", strings.TrimPrefix(name, "@"))
+	}
+	return search, nil
+}
@@ -0,0 +1,79 @@
+package searches
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kutbudev/ramorie-cli/internal/api"
+)
+
+func TestValidName(t *testing.T) {
+	for _, ok := range []string{"deploy", "pg-decisions", "v1.2_notes"} {
+		if err := ValidName(ok); err != nil {
+			t.Errorf("ValidName(%q) = %v", ok, err)
+		}
+	}
+	for _, bad := range []string{"", "@deploy", "two words", "-x", "a/b"} {
+		if ValidName(bad) == nil {
+			t.Errorf("ValidName(%q) should fail", bad)
+		}
+	}
+}
+
+func TestOptionsRoundTrip(t *testing.T) {
+	opts := api.FindMemoriesOptions{
+		Term: "postgres decisions", Project: "5b0c7a52-8f3e-4e8e-9a55-1f2d6c1a7e90", Types: []string{"decision"},
+		Limit: 10, BudgetTokens: 4000, HyDE: "off", Rerank: "on", Intent: "why",
+		EntityHops: 2, IncludeSuperseded: true, FastMode: true,
+	}
+	got := FromOptions("pg", "api", opts).Options()
+	if !reflect.DeepEqual(got.Types, opts.Types) {
+		t.Fatalf("types = %v", got.Types)
+	}
+	got.Types, opts.Types = nil, nil
+	got.Tags, opts.Tags = nil, nil
+	if !reflect.DeepEqual(got, opts) {
+		t.Fatalf("Options() = %+v, want %+v", got, opts)
+	}
+	summary := FromOptions("pg", "api", opts).Summary()
+	for _, want := range []string{"project api", "limit 10", "hyde off", "intent why", "fast"} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("Summary() = %q, missing %q", summary, want)
+		}
+	}
+}
+
+func TestStore(t *testing.T) {
+	s := &Store{Path: filepath.Join(t.TempDir(), "searches.json")}
+	if err := s.Put(Search{Name: "deploy", Term: "deploy runbook"}); err != nil {
+		t.Fatal(err)
+	}
+	first, ok, err := s.Get("@deploy")
+	if err != nil || !ok || first.Term != "deploy runbook" || first.CreatedAt.IsZero() {
+		t.Fatalf("Get = %+v, %v, %v", first, ok, err)
+	}
+	if err := s.Put(Search{Name: "deploy", Term: "deploy runbook railway"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Put(Search{Name: "auth", Term: "oauth"}); err != nil {
+		t.Fatal(err)
+	}
+	all, err := s.List()
+	if err != nil || len(all) != 2 || all[0].Name != "auth" || all[1].Term != "deploy runbook railway" {
+		t.Fatalf("List = %+v, %v", all, err)
+	}
+	if !all[1].CreatedAt.Equal(first.CreatedAt) {
+		t.Error("replacing a search should keep its creation time")
+	}
+	if err := s.Put(Search{Name: "bad name"}); err == nil {
+		t.Error("invalid name should be refused")
+	}
+	if removed, err := s.Remove("deploy"); err != nil || !removed {
+		t.Fatalf("Remove = %v, %v", removed, err)
+	}
+	if removed, _ := s.Remove("deploy"); removed {
+		t.Error("second Remove should report nothing removed")
+	}
+}
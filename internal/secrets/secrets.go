@@ -0,0 +1,595 @@
+// Package secrets finds credentials in text before it is remembered.
+//
+// Agents paste tokens, .env lines and private keys into memories more often
+// than anyone would like. Every write path — `remember`, `task note`,
+// `import markdown`, `memory edit`, hygiene merges and the MCP remember,
+// auto_remember and task note tools — runs its content through Check first.
+// A finding comes from either a pattern rule (AWS keys, GitHub tokens, JWTs,
+// PEM private keys, …) or the high-entropy detector, which flags long random
+// looking tokens no rule knows about.
+//
+// What happens to a finding is its action:
+//
+//   - redact:  the secret is replaced by [REDACTED:<rule>] (the default)
+//   - encrypt: the content is saved encrypted, even where it would not be
+//   - block:   nothing is saved
+//   - off:     the rule is ignored
+//
+// Rules and actions are extensible from ~/.ramorie/secrets.yaml and
+// <repo>/.ramorie/secrets.yaml, the project file applied last:
+//
+//	action: redact              # default action for every rule
+//	actions:
+//	  private-key: block        # per-rule override
+//	rules:
+//	  - id: acme-token
+//	    description: ACME deploy token
+//	    pattern: 'acme_[a-z0-9]{32}'
+//	    action: block
+//	allow:                      # regexes for known false positives
+//	  - 'AKIA[0-9A-Z]{9}EXAMPLE'
+//	entropy:
+//	  enabled: true
+//	  min_length: 24
+//	  threshold: 4.0
+package secrets
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/kutbudev/ramorie-cli/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// Actions.
+const (
+	Redact  = "redact"
+	Encrypt = "encrypt"
+	Block   = "block"
+	Off     = "off"
+)
+
+// ConfigFile is the scanner config's file name in ~/.ramorie and
+// <repo>/.ramorie.
+const ConfigFile = "secrets.yaml"
+
+// EntropyRule is the rule ID of high-entropy findings.
+const EntropyRule = "high-entropy"
+
+// Rule is a pattern rule. When Group is set, only that capture group is the
+// secret (e.g. the value of KEY=value).
+type Rule struct {
+	ID          string `yaml:"id"`
+	Description string `yaml:"description"`
+	Pattern     string `yaml:"pattern"`
+	Group       int    `yaml:"group"`
+	Action      string `yaml:"action"`
+
+	re *regexp.Regexp
+}
+
+// builtinRules are the rules every scanner starts with.
+var builtinRules = []Rule{
+	{ID: "private-key", Description: "PEM private key", Pattern: `(?s)-----BEGIN[A-Z0-9 ]*PRIVATE KEY( BLOCK)?-----.*?(?:-----END[A-Z0-9 ]*PRIVATE KEY( BLOCK)?-----|\z)`},
+	{ID: "aws-access-key-id", Description: "AWS access key ID", Pattern: `\b(?:AKIA|ASIA|ABIA|ACCA)[0-9A-Z]{16}\b`},
+	{ID: "aws-secret-access-key", Description: "AWS secret access key", Pattern: `(?i)aws_?secret_?access_?key["']?\s*[:=]\s*["']?([A-Za-z0-9/+=]{40})\b`, Group: 1},
+	{ID: "github-token", Description: "GitHub token", Pattern: `\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{50,})\b`},
+	{ID: "gitlab-token", Description: "GitLab personal access token", Pattern: `\bglpat-[A-Za-z0-9_-]{20,}`},
+	{ID: "slack-token", Description: "Slack token", Pattern: `\bxox[abposr]-[A-Za-z0-9-]{10,}`},
+	{ID: "stripe-key", Description: "Stripe secret key", Pattern: `\b[sr]k_(?:live|test)_[A-Za-z0-9]{16,}\b`},
+	{ID: "google-api-key", Description: "Google API key", Pattern: `\bAIza[0-9A-Za-z_-]{35}\b`},
+	{ID: "llm-api-key", Description: "OpenAI / Anthropic API key", Pattern: `\bsk-(?:proj-|ant-[a-z0-9]+-)?[A-Za-z0-9_-]{32,}`},
+	{ID: "jwt", Description: "JSON Web Token", Pattern: `\beyJ[A-Za-z0-9_-]{8,}\.eyJ[A-Za-z0-9_-]{8,}\.[A-Za-z0-9_-]{8,}`},
+	{ID: "url-password", Description: "password in a connection URL", Pattern: `\b[a-zA-Z][a-zA-Z0-9+.-]*://[^\s:/@]+:([^\s@/]{3,})@`, Group: 1},
+	{ID: "env-secret", Description: "secret in a KEY=value assignment", Pattern: `(?im)^[ \t]*(?:export[ \t]+)?[A-Z0-9_]*(?:SECRET|TOKEN|PASSWORD|PASSWD|API_?KEY|PRIVATE_?KEY|ACCESS_?KEY|CREDENTIALS?)[A-Z0-9_]*[ \t]*[:=][ \t]*["']?([^\s"'#]{6,})`, Group: 1},
+}
+
+// Entropy configures the high-entropy detector.
+type Entropy struct {
+	Enabled   *bool   `yaml:"enabled"`
+	MinLength int     `yaml:"min_length"`
+	Threshold float64 `yaml:"threshold"` // Shannon bits per character
+}
+
+// Config is a scanner config file.
+type Config struct {
+	Action  string            `yaml:"action"`
+	Actions map[string]string `yaml:"actions"`
+	Rules   []Rule            `yaml:"rules"`
+	Allow   []string          `yaml:"allow"`
+	Entropy Entropy           `yaml:"entropy"`
+
+	// Source is the file the config was loaded from. Not part of the YAML.
+	Source string `yaml:"-"`
+}
+
+// Scanner finds secrets.
+type Scanner struct {
+	Action  string   // default action
+	Sources []string // config files applied, in order
+
+	rules         []Rule
+	allow         []*regexp.Regexp
+	entropyOn     bool
+	entropyAction string
+	minLength     int
+	threshold     float64
+}
+
+// New builds a scanner from the built-in rules and the given configs,
+// applied in order. A config rule with a built-in's ID replaces it.
+func New(configs ...Config) (*Scanner, error) {
+	s := &Scanner{Action: Redact, entropyOn: true, minLength: 24, threshold: 4.0}
+	rules := append([]Rule(nil), builtinRules...)
+	overrides := map[string]string{}
+	for _, cfg := range configs {
+		if cfg.Action != "" {
+			if !validAction(cfg.Action) {
+				return nil, fmt.Errorf("%s: unknown action %q (use block, redact, encrypt or off)", cfg.Source, cfg.Action)
+			}
+			s.Action = cfg.Action
+		}
+		for id, a := range cfg.Actions {
+			if !validAction(a) {
+				return nil, fmt.Errorf("%s: unknown action %q for %s", cfg.Source, a, id)
+			}
+			overrides[id] = a
+		}
+		for _, r := range cfg.Rules {
+			if r.ID == "" || r.Pattern == "" {
+				return nil, fmt.Errorf("%s: every rule needs an id and a pattern", cfg.Source)
+			}
+			if r.Action != "" && !validAction(r.Action) {
+				return nil, fmt.Errorf("%s: unknown action %q for %s", cfg.Source, r.Action, r.ID)
+			}
+			rules = replaceRule(rules, r)
+		}
+		for _, a := range cfg.Allow {
+			re, err := regexp.Compile(a)
+			if err != nil {
+				return nil, fmt.Errorf("%s: allow %q: %w", cfg.Source, a, err)
+			}
+			s.allow = append(s.allow, re)
+		}
+		if cfg.Entropy.Enabled != nil {
+			s.entropyOn = *cfg.Entropy.Enabled
+		}
+		if cfg.Entropy.MinLength > 0 {
+			s.minLength = cfg.Entropy.MinLength
+		}
+		if cfg.Entropy.Threshold > 0 {
+			s.threshold = cfg.Entropy.Threshold
+		}
+		if cfg.Source != "" {
+			s.Sources = append(s.Sources, cfg.Source)
+		}
+	}
+	s.entropyAction = overrides[EntropyRule]
+
+	for _, r := range rules {
+		re, err := regexp.Compile(r.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("rule %s: %w", r.ID, err)
+		}
+		if r.Group > re.NumSubexp() {
+			return nil, fmt.Errorf("rule %s: group %d does not exist", r.ID, r.Group)
+		}
+		r.re = re
+		if a, ok := overrides[r.ID]; ok {
+			r.Action = a
+		}
+		s.rules = append(s.rules, r)
+	}
+	return s, nil
+}
+
+func validAction(a string) bool {
+	switch a {
+	case Redact, Encrypt, Block, Off:
+		return true
+	}
+	return false
+}
+
+func replaceRule(rules []Rule, r Rule) []Rule {
+	for i := range rules {
+		if rules[i].ID == r.ID {
+			rules[i] = r
+			return rules
+		}
+	}
+	return append(rules, r)
+}
+
+// Finding is one secret in the text.
+type Finding struct {
+	Rule        string `json:"rule"`
+	Description string `json:"description"`
+	Action      string `json:"action"`
+	Start       int    `json:"start"`
+	End         int    `json:"end"`
+	// Preview is a masked form, safe to print: "ghp_…f3a".
+	Preview string `json:"preview"`
+}
+
+// Scan returns the secrets in text, in order and without overlaps. Findings
+// whose action is off are left out.
+func (s *Scanner) Scan(text string) []Finding {
+	var found []Finding
+	taken := func(start, end int) bool {
+		for _, f := range found {
+			if start < f.End && end > f.Start {
+				return true
+			}
+		}
+		return false
+	}
+	add := func(rule Rule, start, end int) {
+		secret := text[start:end]
+		if taken(start, end) || s.allowed(secret) || isPlaceholder(secret) {
+			return
+		}
+		action := rule.Action
+		if action == "" {
+			action = s.Action
+		}
+		if action == Off {
+			return
+		}
+		found = append(found, Finding{Rule: rule.ID, Description: rule.Description, Action: action,
+			Start: start, End: end, Preview: mask(secret)})
+	}
+	for _, r := range s.rules {
+		for _, m := range r.re.FindAllStringSubmatchIndex(text, -1) {
+			start, end := m[2*r.Group], m[2*r.Group+1]
+			if start >= 0 {
+				add(r, start, end)
+			}
+		}
+	}
+	if s.entropyOn {
+		entropyRule := Rule{ID: EntropyRule, Description: "high-entropy string", Action: s.entropyAction}
+		for _, m := range tokenPattern.FindAllStringIndex(text, -1) {
+			if s.looksRandom(text[m[0]:m[1]]) {
+				add(entropyRule, m[0], m[1])
+			}
+		}
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].Start < found[j].Start })
+	return found
+}
+
+func (s *Scanner) allowed(secret string) bool {
+	for _, re := range s.allow {
+		if re.MatchString(secret) {
+			return true
+		}
+	}
+	return false
+}
+
+// isPlaceholder reports values that only stand in for a secret: ${VAR},
+// <token>, xxxx, ****, changeme.
+func isPlaceholder(v string) bool {
+	v = strings.Trim(v, `"'`)
+	lower := strings.ToLower(v)
+	if strings.HasPrefix(v, "$") || strings.HasPrefix(v, "<") || strings.HasPrefix(v, "{{") {
+		return true
+	}
+	switch lower {
+	case "changeme", "password", "secret", "redacted", "your_token", "your-token", "your_api_key", "placeholder":
+		return true
+	}
+	if strings.HasPrefix(lower, "[redacted") {
+		return true
+	}
+	return strings.Trim(lower, "x*.-_") == ""
+}
+
+// tokenPattern finds candidate tokens for the entropy check.
+var tokenPattern = regexp.MustCompile(`[A-Za-z0-9+/_=-]{16,}`)
+
+var (
+	hexPattern  = regexp.MustCompile(`^[0-9a-fA-F-]+$`)
+	pathPattern = regexp.MustCompile(`^/?(?:[A-Za-z0-9_.-]+/)+[A-Za-z0-9_.-]*$`)
+)
+
+// looksRandom reports whether token is long, mixes upper case, lower case
+// and digits, and has high Shannon entropy. Hex strings (commit SHAs,
+// UUIDs, checksums) and paths are not flagged: memories are full of them.
+func (s *Scanner) looksRandom(token string) bool {
+	token = strings.TrimRight(token, "=")
+	if len(token) < s.minLength || hexPattern.MatchString(token) || pathPattern.MatchString(token) {
+		return false
+	}
+	var upper, lower, digit bool
+	for _, r := range token {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		}
+	}
+	return upper && lower && digit && ShannonEntropy(token) >= s.threshold
+}
+
+// ShannonEntropy returns the entropy of s in bits per character.
+func ShannonEntropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+	counts := map[rune]int{}
+	n := 0
+	for _, r := range s {
+		counts[r]++
+		n++
+	}
+	var h float64
+	for _, c := range counts {
+		p := float64(c) / float64(n)
+		h -= p * math.Log2(p)
+	}
+	return h
+}
+
+// mask keeps just enough of a secret to recognize it.
+func mask(secret string) string {
+	if i := strings.IndexByte(secret, '\n'); i >= 0 {
+		secret = secret[:i] // PEM blocks: the BEGIN line says enough
+		return secret + " …"
+	}
+	r := []rune(secret)
+	if len(r) <= 8 {
+		return strings.Repeat("*", len(r))
+	}
+	return string(r[:4]) + "…" + string(r[len(r)-3:])
+}
+
+// Result is the outcome of checking content.
+type Result struct {
+	Findings []Finding `json:"findings"`
+	// Content is the text to save: redact findings replaced, everything else
+	// as given.
+	Content string `json:"-"`
+	Blocked bool   `json:"blocked,omitempty"`
+	Encrypt bool   `json:"encrypt,omitempty"`
+}
+
+// Check scans content and applies the findings' actions.
+func (s *Scanner) Check(content string) Result {
+	r := Result{Findings: s.Scan(content)}
+	for _, f := range r.Findings {
+		switch f.Action {
+		case Block:
+			r.Blocked = true
+		case Encrypt:
+			r.Encrypt = true
+		}
+	}
+	r.Content = RedactFindings(content, r.Redacted())
+	return r
+}
+
+// RedactFindings replaces findings, which must come from scanning text, with
+// [REDACTED:<rule>].
+func RedactFindings(text string, findings []Finding) string {
+	if len(findings) == 0 {
+		return text
+	}
+	var b strings.Builder
+	last := 0
+	for _, f := range findings {
+		b.WriteString(text[last:f.Start])
+		b.WriteString("[REDACTED:" + f.Rule + "]")
+		last = f.End
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+// Redacted returns the findings that were redacted.
+func (r Result) Redacted() []Finding {
+	return r.with(Redact)
+}
+
+func (r Result) with(action string) []Finding {
+	var out []Finding
+	for _, f := range r.Findings {
+		if f.Action == action {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// Summary lists findings by rule: "github-token ×2, jwt".
+func Summary(findings []Finding) string {
+	counts := map[string]int{}
+	var order []string
+	for _, f := range findings {
+		if counts[f.Rule] == 0 {
+			order = append(order, f.Rule)
+		}
+		counts[f.Rule]++
+	}
+	parts := make([]string, 0, len(order))
+	for _, id := range order {
+		if counts[id] > 1 {
+			parts = append(parts, fmt.Sprintf("%s ×%d", id, counts[id]))
+		} else {
+			parts = append(parts, id)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// BlockedError is returned when content is refused.
+type BlockedError struct {
+	Findings []Finding
+}
+
+func (e *BlockedError) Error() string {
+	return fmt.Sprintf("refusing to save: content contains secrets (%s); remove them, or change the action in ~/.ramorie/%s",
+		Summary(blocking(e.Findings)), ConfigFile)
+}
+
+func blocking(findings []Finding) []Finding {
+	return Result{Findings: findings}.with(Block)
+}
+
+// Err returns a *BlockedError when the content is blocked.
+func (r Result) Err() error {
+	if r.Blocked {
+		return &BlockedError{Findings: r.Findings}
+	}
+	return nil
+}
+
+// ErrNeedsEncryption explains why content that must be encrypted could not
+// be saved.
+var ErrNeedsEncryption = errors.New("content contains secrets that must be encrypted, but encryption is unavailable here (vault locked or organization project) — unlock the vault with 'ramorie vault unlock' or remove the secrets")
+
+// ParseConfig decodes a config file.
+func ParseConfig(data []byte) (Config, error) {
+	var cfg Config
+	err := yaml.Unmarshal(data, &cfg)
+	return cfg, err
+}
+
+// Paths returns the config files in the order they apply: the user file,
+// then the project file.
+func Paths() (user, project string) {
+	return config.LayeredFiles(ConfigFile)
+}
+
+// PathsAt is Paths for the checkout containing dir.
+func PathsAt(dir string) (user, project string) {
+	return config.LayeredFilesAt(ConfigFile, dir)
+}
+
+// LoadFiles builds a scanner from the given config files, skipping ones
+// that do not exist.
+func LoadFiles(paths ...string) (*Scanner, error) {
+	var configs []Config
+	for _, p := range paths {
+		if p == "" {
+			continue
+		}
+		data, err := os.ReadFile(p)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		cfg, err := ParseConfig(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", p, err)
+		}
+		cfg.Source = p
+		configs = append(configs, cfg)
+	}
+	return New(configs...)
+}
+
+// Load builds the scanner from the user and project config files.
+func Load() (*Scanner, error) {
+	user, project := Paths()
+	return LoadFiles(user, project)
+}
+
+// cached is a loaded scanner, the config error it fell back from and the
+// modification times of the config files it was built from.
+type cached struct {
+	s      *Scanner
+	err    error
+	stamps [2]time.Time
+}
+
+var (
+	cacheMu sync.Mutex
+	cache   = map[[2]string]cached{}
+)
+
+// Default returns the scanner for the working directory; see ForDir.
+func Default() *Scanner {
+	return ForDir("")
+}
+
+// DefaultErr returns the config error Default fell back from, if any.
+func DefaultErr() error {
+	user, project := Paths()
+	return forFiles(user, project).err
+}
+
+// ForDir returns the scanner for the checkout containing dir (the working
+// directory when dir is ""), built from the user and that project's config
+// files. Scanners are cached per config pair and reloaded when either file
+// changes, so a long-lived process such as the MCP server follows edits. A
+// broken config falls back to the built-in rules — scanning never silently
+// stops.
+func ForDir(dir string) *Scanner {
+	user, project := PathsAt(dir)
+	return forFiles(user, project).s
+}
+
+// UserOnly returns the scanner built from the user config file alone, for
+// content whose project checkout is not known.
+func UserOnly() *Scanner {
+	user, _ := Paths()
+	return forFiles(user, "").s
+}
+
+// UserOnlyErr returns the config error UserOnly fell back from, if any.
+func UserOnlyErr() error {
+	user, _ := Paths()
+	return forFiles(user, "").err
+}
+
+func forFiles(user, project string) cached {
+	key := [2]string{user, project}
+	stamps := [2]time.Time{modTime(user), modTime(project)}
+
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+	if e, ok := cache[key]; ok && e.stamps == stamps {
+		return e
+	}
+	s, err := LoadFiles(user, project)
+	if err != nil {
+		s, _ = New()
+	}
+	e := cached{s: s, err: err, stamps: stamps}
+	cache[key] = e
+	return e
+}
+
+// modTime returns path's modification time, or the zero time when it is
+// unset or missing.
+func modTime(path string) time.Time {
+	if path == "" {
+		return time.Time{}
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
+
+// CheckContent checks content with the default scanner.
+func CheckContent(content string) Result {
+	return Default().Check(content)
+}
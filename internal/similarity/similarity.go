@@ -0,0 +1,258 @@
+// Package similarity scores how alike two memories are, for duplicate
+// prevention in remember/auto_remember and for memory hygiene.
+//
+// Text is tokenized Unicode-aware — letters and digits of any script count,
+// so Turkish, German or CJK memories no longer tokenize to nothing — and
+// code identifiers can be split into their parts (getUserProfile and
+// get_user_profile both yield get, user, profile). Engines score a pair in
+// [0, 1]:
+//
+//   - Words:    Jaccard over the word sets (the original behaviour).
+//   - Shingles: Jaccard over character k-shingles, which tolerates
+//     inflection, suffixes and typos that word sets miss.
+//   - Hybrid:   the better of both (the default).
+//
+// For many comparisons, Index hashes shingle sets into MinHash signatures
+// and uses locality-sensitive hashing to find candidate pairs before
+// scoring them exactly. The thresholds below are tuned on the labeled corpus
+// in testdata/corpus.json (see TestCorpusThresholds).
+package similarity
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Tuned thresholds for the Default engine.
+const (
+	// DuplicateThreshold marks near-duplicates: the same knowledge written
+	// again, reworded or with a typo fixed.
+	DuplicateThreshold = 0.50
+	// RelatedThreshold marks same-topic memories worth showing before saving.
+	RelatedThreshold = 0.35
+)
+
+// Engine scores the similarity of two texts in [0, 1].
+type Engine interface {
+	Name() string
+	Score(a, b string) float64
+}
+
+// Words is word-set Jaccard similarity.
+type Words struct {
+	// SplitIdentifiers splits camelCase/PascalCase words into their parts.
+	SplitIdentifiers bool
+}
+
+// Name implements Engine.
+func (Words) Name() string { return "words" }
+
+// Score implements Engine.
+func (w Words) Score(a, b string) float64 {
+	return Jaccard(Set(Tokens(a, w.SplitIdentifiers)), Set(Tokens(b, w.SplitIdentifiers)))
+}
+
+// Shingles is Jaccard similarity over character k-shingles.
+type Shingles struct {
+	K int // shingle length in runes; 0 means 3
+}
+
+// Name implements Engine.
+func (Shingles) Name() string { return "shingles" }
+
+// Score implements Engine.
+func (s Shingles) Score(a, b string) float64 {
+	return Jaccard(ShingleSet(a, s.K), ShingleSet(b, s.K))
+}
+
+// Hybrid takes the higher of word and shingle similarity: word overlap
+// catches reordered paraphrases, shingles catch inflected or misspelled
+// words.
+type Hybrid struct {
+	Words    Words
+	Shingles Shingles
+}
+
+// Name implements Engine.
+func (Hybrid) Name() string { return "hybrid" }
+
+// Score implements Engine.
+func (h Hybrid) Score(a, b string) float64 {
+	return max(h.Words.Score(a, b), h.Shingles.Score(a, b))
+}
+
+// features are the sets a built-in engine scores on. Index computes them
+// once per text instead of once per comparison.
+type features struct {
+	words, shingles map[string]struct{}
+}
+
+// preparer is implemented by the built-in engines.
+type preparer interface {
+	prepare(text string) features
+	scorePrepared(a, b features) float64
+}
+
+func (w Words) prepare(text string) features {
+	return features{words: Set(Tokens(text, w.SplitIdentifiers))}
+}
+
+func (Words) scorePrepared(a, b features) float64 { return Jaccard(a.words, b.words) }
+
+func (s Shingles) prepare(text string) features {
+	return features{shingles: ShingleSet(text, s.K)}
+}
+
+func (Shingles) scorePrepared(a, b features) float64 { return Jaccard(a.shingles, b.shingles) }
+
+func (h Hybrid) prepare(text string) features {
+	return features{words: h.Words.prepare(text).words, shingles: h.Shingles.prepare(text).shingles}
+}
+
+func (h Hybrid) scorePrepared(a, b features) float64 {
+	return max(h.Words.scorePrepared(a, b), h.Shingles.scorePrepared(a, b))
+}
+
+// Default is the engine used when callers do not pick one.
+var Default Engine = Hybrid{Words: Words{SplitIdentifiers: true}, Shingles: Shingles{K: 3}}
+
+// Engines lists the selectable engines by name.
+var Engines = map[string]Engine{
+	"words":    Words{SplitIdentifiers: true},
+	"shingles": Shingles{K: 3},
+	"hybrid":   Default,
+}
+
+// Lookup returns the named engine, or Default for "".
+func Lookup(name string) (Engine, bool) {
+	if name == "" {
+		return Default, true
+	}
+	e, ok := Engines[strings.ToLower(name)]
+	return e, ok
+}
+
+// Tokens splits text into lower-cased words. A word is a run of letters,
+// digits and combining marks in any script; everything else separates
+// words, including '_' and '-'. Han, Hiragana and Katakana characters are
+// words on their own since those scripts do not use spaces. Other
+// single-rune words are dropped. With splitIdentifiers, camelCase words
+// also split (parseHTTPResponse → parse, http, response); letters and
+// digits stay together so e2e, ipv6 or oauth2 remain one word.
+func Tokens(text string, splitIdentifiers bool) []string {
+	var out []string
+	var word []rune
+	flush := func() {
+		if len(word) == 0 {
+			return
+		}
+		parts := [][]rune{word}
+		if splitIdentifiers {
+			parts = splitIdentifier(word)
+		}
+		for _, p := range parts {
+			if len(p) > 1 {
+				out = append(out, fold(p))
+			}
+		}
+		word = word[:0]
+	}
+	for _, r := range text {
+		switch {
+		case isIdeograph(r):
+			flush()
+			out = append(out, string(unicode.ToLower(r)))
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r):
+			word = append(word, r)
+		default:
+			flush()
+		}
+	}
+	flush()
+	return out
+}
+
+// splitIdentifier splits a word at lower→upper and acronym→word
+// (HTTPServer → HTTP, Server) boundaries.
+func splitIdentifier(w []rune) [][]rune {
+	var parts [][]rune
+	start := 0
+	for i := 1; i < len(w); i++ {
+		prev, cur := w[i-1], w[i]
+		boundary := unicode.IsLower(prev) && unicode.IsUpper(cur) ||
+			unicode.IsUpper(prev) && unicode.IsUpper(cur) && i+1 < len(w) && unicode.IsLower(w[i+1])
+		if boundary {
+			parts = append(parts, w[start:i])
+			start = i
+		}
+	}
+	return append(parts, w[start:])
+}
+
+// fold lower-cases a word. The dotted capital İ lower-cases to i plus a
+// combining dot in Go; the dot is dropped so İstanbul matches istanbul.
+func fold(w []rune) string {
+	var b strings.Builder
+	for _, r := range w {
+		if r == '\u0307' {
+			continue
+		}
+		if r == 'İ' {
+			r = 'i'
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+func isIdeograph(r rune) bool {
+	return unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hiragana, r) || unicode.Is(unicode.Katakana, r)
+}
+
+// Set turns tokens into a set.
+func Set(tokens []string) map[string]struct{} {
+	s := make(map[string]struct{}, len(tokens))
+	for _, t := range tokens {
+		s[t] = struct{}{}
+	}
+	return s
+}
+
+// ShingleSet returns the character k-shingles of text after tokenizing it
+// (words joined by single spaces), so punctuation and case do not matter.
+// Text shorter than k is a single shingle.
+func ShingleSet(text string, k int) map[string]struct{} {
+	if k <= 0 {
+		k = 3
+	}
+	runes := []rune(strings.Join(Tokens(text, false), " "))
+	s := map[string]struct{}{}
+	if len(runes) == 0 {
+		return s
+	}
+	if len(runes) <= k {
+		s[string(runes)] = struct{}{}
+		return s
+	}
+	for i := 0; i+k <= len(runes); i++ {
+		s[string(runes[i:i+k])] = struct{}{}
+	}
+	return s
+}
+
+// Jaccard is |A ∩ B| / |A ∪ B|, and 0 when either set is empty.
+func Jaccard(a, b map[string]struct{}) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	inter := 0
+	for k := range a {
+		if _, ok := b[k]; ok {
+			inter++
+		}
+	}
+	return float64(inter) / float64(len(a)+len(b)-inter)
+}
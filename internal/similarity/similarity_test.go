@@ -0,0 +1,276 @@
+package similarity
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type corpusPair struct {
+	Lang string `json:"lang"`
+	Note string `json:"note"`
+	A    string `json:"a"`
+	B    string `json:"b"`
+	Dup  bool   `json:"dup"`
+}
+
+func loadCorpus(t testing.TB) []corpusPair {
+	t.Helper()
+	data, err := os.ReadFile("testdata/corpus.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var pairs []corpusPair
+	if err := json.Unmarshal(data, &pairs); err != nil {
+		t.Fatal(err)
+	}
+	return pairs
+}
+
+// evaluate returns precision and recall of "score >= threshold means
+// duplicate" over the corpus.
+func evaluate(e Engine, pairs []corpusPair, threshold float64) (precision, recall float64) {
+	var tp, fp, fn int
+	for _, p := range pairs {
+		hit := e.Score(p.A, p.B) >= threshold
+		switch {
+		case hit && p.Dup:
+			tp++
+		case hit:
+			fp++
+		case p.Dup:
+			fn++
+		}
+	}
+	if tp+fp > 0 {
+		precision = float64(tp) / float64(tp+fp)
+	}
+	if tp+fn > 0 {
+		recall = float64(tp) / float64(tp+fn)
+	}
+	return precision, recall
+}
+
+func f1(p, r float64) float64 {
+	if p+r == 0 {
+		return 0
+	}
+	return 2 * p * r / (p + r)
+}
+
+// bestF1 sweeps thresholds in steps of 0.05.
+func bestF1(e Engine, pairs []corpusPair) (threshold, score float64) {
+	for th := 0.05; th < 1; th += 0.05 {
+		if s := f1(evaluate(e, pairs, th)); s > score {
+			threshold, score = th, s
+		}
+	}
+	return threshold, score
+}
+
+// TestCorpusThresholds checks the tuned thresholds against the labeled
+// corpus. A duplicate warning must never fire on a non-duplicate, so its
+// precision is held at 1; the other bounds leave slack. When the engine
+// changes, rerun with -v to see the sweep and retune.
+func TestCorpusThresholds(t *testing.T) {
+	pairs := loadCorpus(t)
+
+	p, r := evaluate(Default, pairs, DuplicateThreshold)
+	t.Logf("DuplicateThreshold %.2f: precision %.2f recall %.2f", DuplicateThreshold, p, r)
+	if p < 1 || r < 0.85 {
+		t.Errorf("DuplicateThreshold %.2f: precision %.2f recall %.2f, want 1.00 / >= 0.85", DuplicateThreshold, p, r)
+	}
+	p, r = evaluate(Default, pairs, RelatedThreshold)
+	t.Logf("RelatedThreshold %.2f: precision %.2f recall %.2f", RelatedThreshold, p, r)
+	if p < 0.9 || r < 1 {
+		t.Errorf("RelatedThreshold %.2f: precision %.2f recall %.2f, want >= 0.90 / 1.00", RelatedThreshold, p, r)
+	}
+
+	for name, e := range map[string]Engine{"words": Words{}, "shingles": Shingles{K: 3}, "hybrid": Default} {
+		th, score := bestF1(e, pairs)
+		t.Logf("%-8s best F1 %.2f at %.2f", name, score, th)
+	}
+	_, plain := bestF1(Words{}, pairs)
+	if _, hybrid := bestF1(Default, pairs); hybrid <= plain {
+		t.Errorf("hybrid F1 %.2f should beat plain word Jaccard %.2f", hybrid, plain)
+	}
+}
+
+func TestCorpusPerLanguage(t *testing.T) {
+	// Every language must have its duplicates found at the related threshold.
+	for _, p := range loadCorpus(t) {
+		if p.Dup && Default.Score(p.A, p.B) < RelatedThreshold {
+			t.Errorf("%s/%s: %.2f below RelatedThreshold for %q vs %q", p.Lang, p.Note, Default.Score(p.A, p.B), p.A, p.B)
+		}
+	}
+}
+
+func TestTokens(t *testing.T) {
+	cases := []struct {
+		in    string
+		split bool
+		want  string
+	}{
+		{"Hello, World! This is a TEST.", false, "hello world this is test"},
+		{"Önbellek için Redis'i kullan", false, "önbellek için redis kullan"},
+		{"İstanbul ISTANBUL", false, "istanbul istanbul"},
+		{"Größe der Datenbankmigration", false, "größe der datenbankmigration"},
+		{"本番環境", false, "本 番 環 境"},
+		{"getUserProfile get_user_profile", false, "getuserprofile get user profile"},
+		{"getUserProfile parseHTTPResponse e2e oauth2", true, "get user profile parse http response e2e oauth2"},
+	}
+	for _, tc := range cases {
+		if got := strings.Join(Tokens(tc.in, tc.split), " "); got != tc.want {
+			t.Errorf("Tokens(%q, %v) = %q, want %q", tc.in, tc.split, got, tc.want)
+		}
+	}
+}
+
+func TestShingleSetAndJaccard(t *testing.T) {
+	if got := ShingleSet("Ab", 3); len(got) != 1 {
+		t.Errorf("short text should be one shingle: %v", got)
+	}
+	if got := ShingleSet("…", 3); len(got) != 0 {
+		t.Errorf("punctuation-only text has no shingles: %v", got)
+	}
+	a, b := Set([]string{"x", "y", "z"}), Set([]string{"y", "z", "w"})
+	if got := Jaccard(a, b); got != 0.5 {
+		t.Errorf("Jaccard = %v, want 0.5", got)
+	}
+	if Jaccard(a, nil) != 0 {
+		t.Error("empty set must score 0")
+	}
+}
+
+func TestLookup(t *testing.T) {
+	if e, ok := Lookup(""); !ok || e.Name() != "hybrid" {
+		t.Errorf("Lookup(\"\") = %v, %v", e, ok)
+	}
+	if e, ok := Lookup("Shingles"); !ok || e.Name() != "shingles" {
+		t.Errorf("Lookup(Shingles) = %v, %v", e, ok)
+	}
+	if _, ok := Lookup("bert"); ok {
+		t.Error("unknown engine should not resolve")
+	}
+}
+
+func TestMinHashEstimatesJaccard(t *testing.T) {
+	h := NewMinHasher(256)
+	for _, p := range loadCorpus(t) {
+		sa, sb := ShingleSet(p.A, 3), ShingleSet(p.B, 3)
+		exact := Jaccard(sa, sb)
+		est := h.Sign(sa).Similarity(h.Sign(sb))
+		if math.Abs(exact-est) > 0.15 {
+			t.Errorf("%s: estimate %.2f vs exact %.2f", p.Note, est, exact)
+		}
+	}
+	if NewMinHasher(4).Sign(Set([]string{"a"}))[0] != NewMinHasher(4).Sign(Set([]string{"a"}))[0] {
+		t.Error("signatures must be deterministic")
+	}
+}
+
+// syntheticMemories builds n memories of random words, where every tenth is a reworded copy of the one before it.
+func syntheticMemories(n int) (ids, texts []string) {
+	// A vocabulary of pronounceable nonsense words, about the size of a
+	// project's working vocabulary.
+	rng := rand.New(rand.NewSource(7))
+	syllables := strings.Fields("ka lo mi ne ru sa ti vo ze pa de gu hi ju be")
+	vocab := make([]string, 3000)
+	for i := range vocab {
+		var w strings.Builder
+		for j := 0; j < 2+rng.Intn(3); j++ {
+			w.WriteString(syllables[rng.Intn(len(syllables))])
+		}
+		vocab[i] = w.String()
+	}
+	for i := 0; i < n; i++ {
+		words := make([]string, 12)
+		for j := range words {
+			words[j] = vocab[rng.Intn(len(vocab))]
+		}
+		text := strings.Join(words, " ")
+		if i%10 == 9 {
+			prev := strings.Fields(texts[i-1])
+			prev[3], prev[8] = prev[8], prev[3]
+			text = strings.Join(prev, " ") + " confirmed"
+		}
+		ids = append(ids, fmt.Sprintf("m%04d", i))
+		texts = append(texts, text)
+	}
+	return ids, texts
+}
+
+func TestIndexMatchesBruteForce(t *testing.T) {
+	ids, texts := syntheticMemories(150)
+	ix := NewIndex(nil)
+	for i := range ids {
+		ix.Add(ids[i], texts[i])
+	}
+	if ix.Len() != 150 {
+		t.Fatalf("Len = %d", ix.Len())
+	}
+
+	var want []string
+	for i := range texts {
+		for j := i + 1; j < len(texts); j++ {
+			if Default.Score(texts[i], texts[j]) >= DuplicateThreshold {
+				want = append(want, ids[i]+"-"+ids[j])
+			}
+		}
+	}
+	var got []string
+	for _, p := range ix.Pairs(DuplicateThreshold) {
+		got = append(got, p.A+"-"+p.B)
+	}
+	slices.Sort(want)
+	slices.Sort(got)
+	if !slices.Equal(got, want) {
+		t.Errorf("LSH pairs differ from brute force:\n got  %v\n want %v", got, want)
+	}
+	if len(want) < 15 {
+		t.Errorf("expected the 15 planted duplicates, brute force found %d", len(want))
+	}
+
+	matches := ix.Query(texts[18], DuplicateThreshold)
+	if len(matches) < 2 || matches[0].ID != "m0018" {
+		t.Errorf("Query = %v, want m0018 first and its reworded copy", matches)
+	}
+}
+
+func BenchmarkHybridScore(b *testing.B) {
+	pairs := loadCorpus(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p := pairs[i%len(pairs)]
+		Default.Score(p.A, p.B)
+	}
+}
+
+func BenchmarkIndexPairs(b *testing.B) {
+	ids, texts := syntheticMemories(1000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ix := NewIndex(nil)
+		for j := range ids {
+			ix.Add(ids[j], texts[j])
+		}
+		ix.Pairs(DuplicateThreshold)
+	}
+}
+
+func BenchmarkBruteForcePairs(b *testing.B) {
+	_, texts := syntheticMemories(1000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for x := range texts {
+			for y := x + 1; y < len(texts); y++ {
+				Default.Score(texts[x], texts[y])
+			}
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// Package statefile writes the local state files the CLI keeps under
+// ~/.ramorie, such as the undo journal.
+//
+// Every file is replaced atomically — written to a temp file in the same
+// directory, then renamed over the old one — so a crash or a concurrent
+// reader never sees half a file. Files are mode 0600 and directories 0700,
+// since several of them hold memory content.
+package statefile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Lock timing: how long Lock waits for another process, and how old a lock
+// file must be before it is taken to be left behind by a crashed one.
+const (
+	lockWait  = 5 * time.Second
+	lockStale = 30 * time.Second
+)
+
+// Path returns ~/.ramorie joined with elem.
+func Path(elem ...string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(append([]string{home, ".ramorie"}, elem...)...), nil
+}
+
+// WriteFile atomically replaces path with data, creating its directory.
+func WriteFile(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// WriteJSON writes v as indented JSON with WriteFile.
+func WriteJSON(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return WriteFile(path, data)
+}
+
+// Lock serializes read-modify-write cycles on path across processes — the
+// MCP server, the CLI and git hooks all write some files — by creating
+// path.lock exclusively. It waits up to a few seconds for another holder and
+// breaks locks old enough to be stale. Call the returned func to release.
+func Lock(path string) (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+	lock := path + ".lock"
+	deadline := time.Now().Add(lockWait)
+	for {
+		f, err := os.OpenFile(lock, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			f.Close()
+			return func() { os.Remove(lock) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, err
+		}
+		if fi, err := os.Stat(lock); err == nil && time.Since(fi.ModTime()) > lockStale {
+			os.Remove(lock)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("%s is locked by another ramorie process (remove %s if none is running)", filepath.Base(path), lock)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
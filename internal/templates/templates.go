@@ -0,0 +1,304 @@
+// Package templates loads reusable task templates and expands their
+// variables. Templates are YAML files living in two places:
+//
+//   - ~/.ramorie/templates/*.yaml          — user-level, shared by every repo
+//   - <repo>/.ramorie/templates/*.yaml     — project-level, checked in
+//
+// A project-level template shadows a user-level one with the same name, so a
+// repo can specialise "release" without touching the operator's defaults.
+// Both `ramorie task create --template` and the MCP task tool expand through
+// this package so the two surfaces never drift.
+//
+// Example (release.yaml):
+//
+//	title: "Release {{version}}"
+//	description: |
+//	  Ship {{version}} to production.
+//	priority: H
+//	tags: [release]
+//	vars:
+//	  channel: stable
+//	subtasks:
+//	  - "Bump version to {{version}}"
+//	  - "Publish to {{channel}}"
+package templates
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Template is one parsed template file. Name defaults to the file's base name
+// (without extension) when the YAML leaves it blank.
+type Template struct {
+	Name        string            `yaml:"name"`
+	Title       string            `yaml:"title"`
+	Description string            `yaml:"description"`
+	Priority    string            `yaml:"priority"`
+	Tags        []string          `yaml:"tags"`
+	Subtasks    []string          `yaml:"subtasks"`
+	Vars        map[string]string `yaml:"vars"` // default values, overridable per call
+
+	// Source is the file the template was loaded from. Not part of the YAML.
+	Source string `yaml:"-"`
+}
+
+// Expanded is a template with every {{var}} placeholder substituted — ready
+// to be turned into a task plus its subtasks.
+type Expanded struct {
+	Title       string
+	Description string
+	Priority    string
+	Tags        []string
+	Subtasks    []string
+}
+
+// placeholderRe matches {{name}} and {{ name }}. Names are deliberately
+// restricted to identifier-ish characters so stray braces in prose survive.
+var placeholderRe = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_.-]+)\s*\}\}`)
+
+// UserDir returns ~/.ramorie/templates.
+func UserDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".ramorie", "templates"), nil
+}
+
+// RepoDir returns <git toplevel>/.ramorie/templates for the current working
+// directory, falling back to the cwd itself outside a git checkout. Returns ""
+// when neither can be determined.
+func RepoDir() string {
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	root := ""
+	if out, err := exec.CommandContext(ctx, "git", "rev-parse", "--show-toplevel").Output(); err == nil {
+		root = strings.TrimSpace(string(out))
+	}
+	if root == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return ""
+		}
+		root = cwd
+	}
+	return filepath.Join(root, ".ramorie", "templates")
+}
+
+// Dirs returns the search path in precedence order (lowest first): the user
+// directory, then the repo directory. Later entries shadow earlier ones.
+func Dirs() []string {
+	var dirs []string
+	if d, err := UserDir(); err == nil {
+		dirs = append(dirs, d)
+	}
+	if d := RepoDir(); d != "" && !containsPath(dirs, d) {
+		dirs = append(dirs, d)
+	}
+	return dirs
+}
+
+// List loads every template found in dirs. Missing directories are skipped
+// silently; a malformed file is an error so typos don't go unnoticed.
+// Results are sorted by name.
+func List(dirs []string) ([]Template, error) {
+	byName := map[string]Template{}
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		for _, e := range entries {
+			if e.IsDir() || !isYAML(e.Name()) {
+				continue
+			}
+			t, err := LoadFile(filepath.Join(dir, e.Name()))
+			if err != nil {
+				return nil, err
+			}
+			byName[strings.ToLower(t.Name)] = *t
+		}
+	}
+	out := make([]Template, 0, len(byName))
+	for _, t := range byName {
+		out = append(out, t)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out, nil
+}
+
+// Find returns the template called name (case-insensitive) from dirs, with
+// repo-level templates shadowing user-level ones.
+func Find(name string, dirs []string) (*Template, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("template name is required")
+	}
+	all, err := List(dirs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range all {
+		if strings.EqualFold(all[i].Name, name) {
+			return &all[i], nil
+		}
+	}
+	names := make([]string, 0, len(all))
+	for _, t := range all {
+		names = append(names, t.Name)
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("template %q not found — no templates in %s", name, strings.Join(dirs, ", "))
+	}
+	return nil, fmt.Errorf("template %q not found — available: %s", name, strings.Join(names, ", "))
+}
+
+// LoadFile parses a single template file.
+func LoadFile(path string) (*Template, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var t Template
+	if err := yaml.Unmarshal(data, &t); err != nil {
+		return nil, fmt.Errorf("parse template %s: %w", path, err)
+	}
+	if strings.TrimSpace(t.Name) == "" {
+		base := filepath.Base(path)
+		t.Name = strings.TrimSuffix(base, filepath.Ext(base))
+	}
+	if strings.TrimSpace(t.Title) == "" {
+		return nil, fmt.Errorf("template %s: title is required", path)
+	}
+	// A priority filled in from a variable is checked after expansion.
+	if !placeholderRe.MatchString(t.Priority) {
+		if err := checkPriority(t.Priority); err != nil {
+			return nil, fmt.Errorf("template %s: %w", path, err)
+		}
+	}
+	t.Source = path
+	return &t, nil
+}
+
+// ParseVars turns ["version=1.4.0", "owner=ada"] into a map. Keys are
+// trimmed; values keep their inner whitespace.
+func ParseVars(pairs []string) (map[string]string, error) {
+	out := make(map[string]string, len(pairs))
+	for _, p := range pairs {
+		k, v, ok := strings.Cut(p, "=")
+		k = strings.TrimSpace(k)
+		if !ok || k == "" {
+			return nil, fmt.Errorf("invalid variable %q — expected key=value", p)
+		}
+		out[k] = v
+	}
+	return out, nil
+}
+
+// Expand substitutes every placeholder using vars, falling back to the
+// template's own defaults. Any placeholder left without a value is reported
+// in a single error so the caller can fix them all in one go.
+func (t *Template) Expand(vars map[string]string) (*Expanded, error) {
+	merged := make(map[string]string, len(t.Vars)+len(vars))
+	for k, v := range t.Vars {
+		merged[k] = v
+	}
+	for k, v := range vars {
+		merged[k] = v
+	}
+
+	missing := map[string]bool{}
+	sub := func(s string) string {
+		return placeholderRe.ReplaceAllStringFunc(s, func(m string) string {
+			key := placeholderRe.FindStringSubmatch(m)[1]
+			if v, ok := merged[key]; ok {
+				return v
+			}
+			missing[key] = true
+			return m
+		})
+	}
+
+	exp := &Expanded{
+		Title:       strings.TrimSpace(sub(t.Title)),
+		Description: strings.TrimSpace(sub(t.Description)),
+		Priority:    strings.ToUpper(strings.TrimSpace(sub(t.Priority))),
+	}
+	for _, tag := range t.Tags {
+		if s := strings.TrimSpace(sub(tag)); s != "" {
+			exp.Tags = append(exp.Tags, s)
+		}
+	}
+	for _, st := range t.Subtasks {
+		if s := strings.TrimSpace(sub(st)); s != "" {
+			exp.Subtasks = append(exp.Subtasks, s)
+		}
+	}
+
+	if len(missing) > 0 {
+		keys := make([]string, 0, len(missing))
+		for k := range missing {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		return nil, fmt.Errorf("template %q: missing value for %s (pass --var %s=...)",
+			t.Name, strings.Join(keys, ", "), keys[0])
+	}
+	if err := checkPriority(exp.Priority); err != nil {
+		return nil, fmt.Errorf("template %q: %w", t.Name, err)
+	}
+	exp.Priority = shortPriority(exp.Priority)
+	return exp, nil
+}
+
+// checkPriority accepts an empty priority (the caller's default) or one of
+// the task priorities H, M, L and their long forms.
+func checkPriority(p string) error {
+	switch strings.ToUpper(strings.TrimSpace(p)) {
+	case "", "H", "M", "L", "HIGH", "MEDIUM", "LOW":
+		return nil
+	}
+	return fmt.Errorf("invalid priority %q (use H, M or L)", p)
+}
+
+// shortPriority maps HIGH, MEDIUM and LOW to the H, M and L the task API
+// stores.
+func shortPriority(p string) string {
+	switch p {
+	case "HIGH":
+		return "H"
+	case "MEDIUM":
+		return "M"
+	case "LOW":
+		return "L"
+	}
+	return p
+}
+
+func isYAML(name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	return ext == ".yaml" || ext == ".yml"
+}
+
+func containsPath(list []string, p string) bool {
+	for _, s := range list {
+		if filepath.Clean(s) == filepath.Clean(p) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,147 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func writeTemplate(t *testing.T, dir, name, body string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+const releaseYAML = `title: "Release {{version}}"
+description: |
+  Ship {{ version }} to {{channel}}.
+priority: h
+tags: [release, "v{{version}}"]
+vars:
+  channel: stable
+subtasks:
+  - "Bump version to {{version}}"
+  - "Publish to {{channel}}"
+`
+
+func TestFind_RepoShadowsUser(t *testing.T) {
+	root := t.TempDir()
+	user := filepath.Join(root, "user")
+	repo := filepath.Join(root, "repo")
+	writeTemplate(t, user, "release.yaml", releaseYAML)
+	writeTemplate(t, user, "bug.yml", "title: \"Bug: {{summary}}\"\n")
+	writeTemplate(t, repo, "release.yaml", "name: release\ntitle: \"Repo release {{version}}\"\n")
+	writeTemplate(t, repo, "notes.txt", "ignored")
+
+	all, err := List([]string{user, repo})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(all) != 2 || all[0].Name != "bug" || all[1].Name != "release" {
+		t.Fatalf("unexpected templates: %+v", all)
+	}
+
+	tpl, err := Find("Release", []string{user, repo})
+	if err != nil {
+		t.Fatalf("Find: %v", err)
+	}
+	if !strings.HasPrefix(tpl.Title, "Repo release") {
+		t.Fatalf("repo template should shadow user template, got %q", tpl.Title)
+	}
+	if tpl.Source != filepath.Join(repo, "release.yaml") {
+		t.Fatalf("source = %q", tpl.Source)
+	}
+}
+
+func TestFind_UnknownListsAvailable(t *testing.T) {
+	dir := t.TempDir()
+	writeTemplate(t, dir, "release.yaml", releaseYAML)
+	_, err := Find("incident", []string{dir, filepath.Join(dir, "missing")})
+	if err == nil || !strings.Contains(err.Error(), "available: release") {
+		t.Fatalf("expected not-found error listing templates, got %v", err)
+	}
+}
+
+func TestExpand_VarsAndDefaults(t *testing.T) {
+	dir := t.TempDir()
+	writeTemplate(t, dir, "release.yaml", releaseYAML)
+	tpl, err := LoadFile(filepath.Join(dir, "release.yaml"))
+	if err != nil {
+		t.Fatalf("LoadFile: %v", err)
+	}
+
+	exp, err := tpl.Expand(map[string]string{"version": "1.4.0"})
+	if err != nil {
+		t.Fatalf("Expand: %v", err)
+	}
+	if exp.Title != "Release 1.4.0" {
+		t.Errorf("title = %q", exp.Title)
+	}
+	if exp.Description != "Ship 1.4.0 to stable." {
+		t.Errorf("description = %q", exp.Description)
+	}
+	if exp.Priority != "H" {
+		t.Errorf("priority = %q", exp.Priority)
+	}
+	if want := []string{"release", "v1.4.0"}; !reflect.DeepEqual(exp.Tags, want) {
+		t.Errorf("tags = %v, want %v", exp.Tags, want)
+	}
+	if want := []string{"Bump version to 1.4.0", "Publish to stable"}; !reflect.DeepEqual(exp.Subtasks, want) {
+		t.Errorf("subtasks = %v, want %v", exp.Subtasks, want)
+	}
+
+	exp, err = tpl.Expand(map[string]string{"version": "2.0.0", "channel": "beta"})
+	if err != nil {
+		t.Fatalf("Expand override: %v", err)
+	}
+	if exp.Subtasks[1] != "Publish to beta" {
+		t.Errorf("explicit var should override default, got %q", exp.Subtasks[1])
+	}
+}
+
+func TestExpand_MissingVars(t *testing.T) {
+	tpl := &Template{Name: "release", Title: "Release {{version}} by {{owner}}"}
+	_, err := tpl.Expand(nil)
+	if err == nil {
+		t.Fatal("expected missing-variable error")
+	}
+	if !strings.Contains(err.Error(), "owner, version") {
+		t.Fatalf("error should list every missing var, got %v", err)
+	}
+}
+
+func TestPriorityIsValidated(t *testing.T) {
+	dir := t.TempDir()
+	writeTemplate(t, dir, "bad.yaml", "title: Bad\npriority: urgent\n")
+	if _, err := LoadFile(filepath.Join(dir, "bad.yaml")); err == nil || !strings.Contains(err.Error(), "invalid priority") {
+		t.Fatalf("LoadFile should reject priority urgent, got %v", err)
+	}
+
+	tpl := &Template{Name: "p", Title: "T", Priority: "{{prio}}"}
+	if _, err := tpl.Expand(map[string]string{"prio": "P9"}); err == nil {
+		t.Fatal("Expand should reject an expanded priority outside H/M/L")
+	}
+	if exp, err := tpl.Expand(map[string]string{"prio": "low"}); err != nil || exp.Priority != "L" {
+		t.Fatalf("Expand(low) = %+v, %v", exp, err)
+	}
+}
+
+func TestParseVars(t *testing.T) {
+	got, err := ParseVars([]string{"version=1.4.0", " owner =Ada Lovelace", "empty="})
+	if err != nil {
+		t.Fatalf("ParseVars: %v", err)
+	}
+	want := map[string]string{"version": "1.4.0", "owner": "Ada Lovelace", "empty": ""}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v want %v", got, want)
+	}
+	if _, err := ParseVars([]string{"novalue"}); err == nil {
+		t.Fatal("expected error for pair without '='")
+	}
+}